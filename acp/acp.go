@@ -0,0 +1,121 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+/*
+Package acp implements document level access control.
+
+Documents created by a request made with an identity are owned by that identity, and may only be
+read, updated and deleted by requests made with the same identity. Documents created anonymously
+have no owner and remain accessible to everyone.
+
+Other nodes only merge the changes made to an owned document if the blocks holding them are signed
+by the key of the owner. The blocks are signed by the key carried by the request context (see
+NewContextWithKey), or otherwise by the signing key of the node, which must then be the key of the
+owner.
+*/
+package acp
+
+import (
+	"context"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+type contextKey int
+
+const (
+	identityContextKey contextKey = iota
+	identityKeyContextKey
+	skipAccessControlContextKey
+)
+
+// NewContext returns a copy of the given context carrying the given identity.
+func NewContext(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityContextKey, identity)
+}
+
+// NewContextWithKey returns a copy of the given context carrying the identity of the holder of the
+// given private key, along with the key so that the blocks written on behalf of the identity can
+// be signed by it.
+func NewContextWithKey(ctx context.Context, key crypto.PrivKey) (context.Context, error) {
+	identity, err := NewIdentity(key.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(NewContext(ctx, identity), identityKeyContextKey, key), nil
+}
+
+// KeyFromContext returns the private key of the identity carried by the given context.
+//
+// Nil is returned if the context carries no identity, or if it was given without its key.
+func KeyFromContext(ctx context.Context) crypto.PrivKey {
+	key, _ := ctx.Value(identityKeyContextKey).(crypto.PrivKey)
+	return key
+}
+
+// FromContext returns the identity carried by the given context.
+//
+// An empty string is returned if the context carries no identity.
+func FromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(identityContextKey).(string)
+	return identity
+}
+
+// WithoutAccessControl returns a copy of the given context for which access checks are skipped.
+//
+// It should only be used by internal processes, such as index maintenance, that must see every
+// document regardless of who owns it.
+func WithoutAccessControl(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipAccessControlContextKey, true)
+}
+
+// CanAccess returns true if a request made with the given context may access a document owned
+// by the given identity.
+func CanAccess(ctx context.Context, owner string) bool {
+	if owner == "" {
+		return true
+	}
+	if skip, _ := ctx.Value(skipAccessControlContextKey).(bool); skip {
+		return true
+	}
+	return owner == FromContext(ctx)
+}
+
+// OwnerKey returns the key under which the owner of the document with the given key is stored.
+func OwnerKey(docKey core.DataStoreKey) core.DataStoreKey {
+	return docKey.WithValueFlag().WithFieldId(core.DATASTORE_DOC_OWNER_FIELD_ID)
+}
+
+// GetOwner returns the identity owning the document with the given key.
+//
+// An empty string is returned if the document has no owner, or does not exist.
+func GetOwner(ctx context.Context, store datastore.DSReaderWriter, docKey core.DataStoreKey) (string, error) {
+	ownerKey := OwnerKey(docKey)
+	for _, key := range []core.DataStoreKey{ownerKey, ownerKey.WithDeletedFlag()} {
+		owner, err := store.Get(ctx, key.ToDS())
+		if err == nil {
+			return string(owner), nil
+		}
+		if !errors.Is(err, ds.ErrNotFound) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// SetOwner records the given identity as the owner of the document with the given key.
+func SetOwner(ctx context.Context, store datastore.DSReaderWriter, docKey core.DataStoreKey, owner string) error {
+	return store.Put(ctx, OwnerKey(docKey).ToDS(), []byte(owner))
+}
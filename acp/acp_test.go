@@ -0,0 +1,91 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKey(t *testing.T) crypto.PrivKey {
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	require.NoError(t, err)
+	return key
+}
+
+func TestVerifyTokenReturnsIdentityOfKey(t *testing.T) {
+	key := newTestKey(t)
+
+	token, err := NewToken(key, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	identity, err := VerifyToken(token)
+	require.NoError(t, err)
+
+	expected, err := NewIdentity(key.GetPublic())
+	require.NoError(t, err)
+	require.Equal(t, expected, identity)
+}
+
+func TestVerifyTokenWithExpiredTokenReturnsError(t *testing.T) {
+	token, err := NewToken(newTestKey(t), time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	_, err = VerifyToken(token)
+	require.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestVerifyTokenWithTamperedExpiryReturnsError(t *testing.T) {
+	token, err := NewToken(newTestKey(t), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	parts := strings.Split(token, tokenSeparator)
+	parts[1] = "99999999999"
+
+	_, err = VerifyToken(strings.Join(parts, tokenSeparator))
+	require.ErrorIs(t, err, ErrInvalidTokenSignature)
+}
+
+func TestVerifyTokenWithMalformedTokenReturnsError(t *testing.T) {
+	_, err := VerifyToken("not-a-token")
+	require.ErrorIs(t, err, ErrMalformedToken)
+}
+
+func TestCanAccess(t *testing.T) {
+	ctx := context.Background()
+	ownerCtx := NewContext(ctx, "owner")
+	otherCtx := NewContext(ctx, "other")
+
+	require.True(t, CanAccess(ctx, ""))
+	require.True(t, CanAccess(otherCtx, ""))
+	require.True(t, CanAccess(ownerCtx, "owner"))
+	require.False(t, CanAccess(ctx, "owner"))
+	require.False(t, CanAccess(otherCtx, "owner"))
+	require.True(t, CanAccess(WithoutAccessControl(otherCtx), "owner"))
+}
+
+func TestNewContextWithKeyCarriesIdentityAndKey(t *testing.T) {
+	key := newTestKey(t)
+
+	ctx, err := NewContextWithKey(context.Background(), key)
+	require.NoError(t, err)
+
+	expected, err := NewIdentity(key.GetPublic())
+	require.NoError(t, err)
+	require.Equal(t, expected, FromContext(ctx))
+	require.Equal(t, key, KeyFromContext(ctx))
+	require.Nil(t, KeyFromContext(NewContext(context.Background(), expected)))
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import (
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	errInvalidToken string = "invalid identity token"
+)
+
+// Errors returnable from this package.
+//
+// This list is incomplete and undefined errors may also be returned.
+// Errors returned from this package may be tested against these errors with errors.Is.
+var (
+	ErrInvalidToken          = errors.New(errInvalidToken)
+	ErrMalformedToken        = errors.New("identity token must consist of three dot separated parts")
+	ErrTokenExpired          = errors.New("identity token has expired")
+	ErrInvalidTokenSignature = errors.New("identity token signature is invalid")
+)
+
+// NewErrInvalidToken returns a new error indicating that an identity token could not be parsed.
+func NewErrInvalidToken(inner error) error {
+	return errors.Wrap(errInvalidToken, inner)
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const tokenSeparator = "."
+
+// NewIdentity returns the identity of the holder of the given public key.
+func NewIdentity(key crypto.PubKey) (string, error) {
+	id, err := peer.IDFromPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// NewToken returns a token, valid until the given expiry time, proving that the bearer holds
+// the given private key.
+//
+// The token is made of the public key, the expiry time and a signature of both, all separated by dots.
+func NewToken(key crypto.PrivKey, expiry time.Time) (string, error) {
+	pubKey, err := crypto.MarshalPublicKey(key.GetPublic())
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(pubKey) + tokenSeparator + strconv.FormatInt(expiry.Unix(), 10)
+	sig, err := key.Sign([]byte(payload))
+	if err != nil {
+		return "", err
+	}
+
+	return payload + tokenSeparator + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// VerifyToken returns the identity proven by the given token.
+//
+// An error is returned if the token is malformed, its signature is invalid, or it has expired.
+func VerifyToken(token string) (string, error) {
+	parts := strings.Split(token, tokenSeparator)
+	if len(parts) != 3 {
+		return "", ErrMalformedToken
+	}
+
+	pubKeyBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", NewErrInvalidToken(err)
+	}
+	pubKey, err := crypto.UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return "", NewErrInvalidToken(err)
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", NewErrInvalidToken(err)
+	}
+	if time.Now().Unix() > expiry {
+		return "", ErrTokenExpired
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", NewErrInvalidToken(err)
+	}
+	valid, err := pubKey.Verify([]byte(parts[0]+tokenSeparator+parts[1]), sig)
+	if err != nil {
+		return "", NewErrInvalidToken(err)
+	}
+	if !valid {
+		return "", ErrInvalidTokenSignature
+	}
+
+	return NewIdentity(pubKey)
+}
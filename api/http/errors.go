@@ -27,15 +27,18 @@ var env = os.Getenv("DEFRA_ENV")
 // This list is incomplete. Undefined errors may also be returned.
 // Errors returned from this package may be tested against these errors with errors.Is.
 var (
-	ErrNoListener           = errors.New("cannot serve with no listener")
-	ErrSchema               = errors.New("base must start with the http or https scheme")
-	ErrDatabaseNotAvailable = errors.New("no database available")
-	ErrFormNotSupported     = errors.New("content type application/x-www-form-urlencoded not yet supported")
-	ErrBodyEmpty            = errors.New("body cannot be empty")
-	ErrMissingGQLRequest    = errors.New("missing GraphQL request")
-	ErrPeerIdUnavailable    = errors.New("no peer ID available. P2P might be disabled")
-	ErrStreamingUnsupported = errors.New("streaming unsupported")
-	ErrNoEmail              = errors.New("email address must be specified for tls with autocert")
+	ErrNoListener            = errors.New("cannot serve with no listener")
+	ErrSchema                = errors.New("base must start with the http or https scheme")
+	ErrDatabaseNotAvailable  = errors.New("no database available")
+	ErrFormNotSupported      = errors.New("content type application/x-www-form-urlencoded not yet supported")
+	ErrBodyEmpty             = errors.New("body cannot be empty")
+	ErrMissingGQLRequest     = errors.New("missing GraphQL request")
+	ErrPeerIdUnavailable     = errors.New("no peer ID available. P2P might be disabled")
+	ErrStreamingUnsupported  = errors.New("streaming unsupported")
+	ErrNoEmail               = errors.New("email address must be specified for tls with autocert")
+	ErrMissingBackupFilepath = errors.New("backup file path must be specified")
+	ErrInvalidAuthorization  = errors.New("authorization header must hold a bearer token")
+	ErrMissingDiffVersions   = errors.New("from and to versions must be specified")
 )
 
 // ErrorResponse is the GQL top level object holding error items for the response payload.
@@ -25,6 +25,7 @@ import (
 	"github.com/multiformats/go-multihash"
 	"github.com/pkg/errors"
 
+	"github.com/sourcenetwork/defradb/client"
 	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/events"
 )
@@ -78,12 +79,24 @@ func dumpHandler(rw http.ResponseWriter, req *http.Request) {
 }
 
 type gqlRequest struct {
-	Request string `json:"query"`
+	Request       string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
 }
 
 func execGQLHandler(rw http.ResponseWriter, req *http.Request) {
 	request := req.URL.Query().Get("query")
-	if request == "" {
+	var variables map[string]any
+	operationName := req.URL.Query().Get("operationName")
+	if request != "" {
+		if rawVariables := req.URL.Query().Get("variables"); rawVariables != "" {
+			err := json.Unmarshal([]byte(rawVariables), &variables)
+			if err != nil {
+				handleErr(req.Context(), rw, errors.Wrap(err, "unmarshal error"), http.StatusBadRequest)
+				return
+			}
+		}
+	} else {
 		// extract the media type from the content-type header
 		contentType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
 		// mime.ParseMediaType will return an error (mime: no media type)
@@ -106,6 +119,8 @@ func execGQLHandler(rw http.ResponseWriter, req *http.Request) {
 			}
 
 			request = gqlReq.Request
+			variables = gqlReq.Variables
+			operationName = gqlReq.OperationName
 
 		case contentTypeFormURLEncoded:
 			handleErr(
@@ -144,7 +159,12 @@ func execGQLHandler(rw http.ResponseWriter, req *http.Request) {
 		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
 		return
 	}
-	result := db.ExecRequest(req.Context(), request)
+	result := db.ExecRequest(
+		req.Context(),
+		request,
+		client.WithVariables(variables),
+		client.WithOperationName(operationName),
+	)
 
 	if result.Pub != nil {
 		subscriptionHandler(result.Pub, rw, req)
@@ -208,6 +228,203 @@ func patchSchemaHandler(rw http.ResponseWriter, req *http.Request) {
 	)
 }
 
+func setMigrationHandler(rw http.ResponseWriter, req *http.Request) {
+	cfg := client.LensConfig{}
+	err := getJSON(req, &cfg)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = db.SetMigration(req.Context(), cfg)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse("result", "success"),
+		http.StatusOK,
+	)
+}
+
+func exportHandler(rw http.ResponseWriter, req *http.Request) {
+	config := &client.BackupConfig{}
+	err := getJSON(req, config)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	if config.Filepath == "" {
+		handleErr(req.Context(), rw, ErrMissingBackupFilepath, http.StatusBadRequest)
+		return
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = db.BasicExport(req.Context(), config)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse("result", "success"),
+		http.StatusOK,
+	)
+}
+
+type importRequest struct {
+	Filepath string `json:"filepath"`
+}
+
+func importHandler(rw http.ResponseWriter, req *http.Request) {
+	importReq := importRequest{}
+	err := getJSON(req, &importReq)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	if importReq.Filepath == "" {
+		handleErr(req.Context(), rw, ErrMissingBackupFilepath, http.StatusBadRequest)
+		return
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = db.BasicImport(req.Context(), importReq.Filepath)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse("result", "success"),
+		http.StatusOK,
+	)
+}
+
+func diffHandler(rw http.ResponseWriter, req *http.Request) {
+	fromStr := req.URL.Query().Get("from")
+	toStr := req.URL.Query().Get("to")
+	if fromStr == "" || toStr == "" {
+		handleErr(req.Context(), rw, ErrMissingDiffVersions, http.StatusBadRequest)
+		return
+	}
+
+	key, err := client.NewDocKeyFromString(chi.URLParam(req, "dockey"))
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+	from, err := cid.Decode(fromStr)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+	to, err := cid.Decode(toStr)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	col, err := db.GetCollectionByName(req.Context(), chi.URLParam(req, "collection"))
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	diff, err := col.Diff(req.Context(), key, from, to)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse("diff", diff),
+		http.StatusOK,
+	)
+}
+
+func setHistoryPolicyHandler(rw http.ResponseWriter, req *http.Request) {
+	policy := client.HistoryPolicy{}
+	err := getJSON(req, &policy)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = db.SetHistoryPolicy(req.Context(), policy)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse("result", "success"),
+		http.StatusOK,
+	)
+}
+
+func pruneHistoryHandler(rw http.ResponseWriter, req *http.Request) {
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = db.PruneHistory(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse("result", "success"),
+		http.StatusOK,
+	)
+}
+
 func getBlockHandler(rw http.ResponseWriter, req *http.Request) {
 	cidStr := chi.URLParam(req, "cid")
 
@@ -25,9 +25,11 @@ import (
 	badger "github.com/dgraph-io/badger/v3"
 	"github.com/ipfs/go-cid"
 	dshelp "github.com/ipfs/go-ipfs-ds-help"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client"
 	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v3"
 	"github.com/sourcenetwork/defradb/db"
@@ -347,6 +349,43 @@ func TestExecGQLHandlerContentTypeJSON(t *testing.T) {
 	assert.Contains(t, users[0].Key, "bae-")
 }
 
+func TestExecGQLHandlerContentTypeJSONWithVariables(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	// load schema
+	testLoadSchema(t, ctx, defra)
+
+	// add document
+	stmt := `
+{
+	"query": "mutation Create($data: String) {create_user(data: $data) {_key}}",
+	"variables": {
+		"data": "{\"age\": 31, \"verified\": true, \"points\": 90, \"name\": \"Bob\"}"
+	},
+	"operationName": "Create"
+}`
+
+	buf := bytes.NewBuffer([]byte(stmt))
+	users := []testUser{}
+	resp := DataResponse{
+		Data: &users,
+	}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "POST",
+		Path:           GraphQLPath,
+		Body:           buf,
+		Headers:        map[string]string{"Content-Type": contentTypeJSON},
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	assert.Contains(t, users[0].Key, "bae-")
+}
+
 func TestExecGQLHandlerContentTypeJSONWithCharset(t *testing.T) {
 	ctx := context.Background()
 	defra := testNewInMemoryDB(t, ctx)
@@ -902,6 +941,270 @@ func TestPeerIDHandlerWithNoPeerIDInContext(t *testing.T) {
 	assert.Equal(t, "no peer ID available. P2P might be disabled", errResponse.Errors[0].Message)
 }
 
+func TestExportHandlerWithoutFilepath(t *testing.T) {
+	t.Cleanup(CleanupEnv)
+	env = "dev"
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "POST",
+		Path:           ExportPath,
+		Body:           bytes.NewBuffer([]byte(`{"format": "json"}`)),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Contains(t, errResponse.Errors[0].Extensions.Stack, "backup file path must be specified")
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "Bad Request", errResponse.Errors[0].Extensions.HTTPError)
+	assert.Equal(t, "backup file path must be specified", errResponse.Errors[0].Message)
+}
+
+func TestImportHandlerWithoutFilepath(t *testing.T) {
+	t.Cleanup(CleanupEnv)
+	env = "dev"
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "POST",
+		Path:           ImportPath,
+		Body:           bytes.NewBuffer([]byte(`{}`)),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Contains(t, errResponse.Errors[0].Extensions.Stack, "backup file path must be specified")
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "Bad Request", errResponse.Errors[0].Extensions.HTTPError)
+	assert.Equal(t, "backup file path must be specified", errResponse.Errors[0].Message)
+}
+
+func TestDiffHandlerWithoutVersions(t *testing.T) {
+	t.Cleanup(CleanupEnv)
+	env = "dev"
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "GET",
+		Path:           DiffPath + "/user/bae-52b9170d-b77a-5887-b877-cbdbb99b009f",
+		Body:           nil,
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Contains(t, errResponse.Errors[0].Extensions.Stack, "from and to versions must be specified")
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "Bad Request", errResponse.Errors[0].Extensions.HTTPError)
+	assert.Equal(t, "from and to versions must be specified", errResponse.Errors[0].Message)
+}
+
+func TestDiffHandlerWithNoError(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	testLoadSchema(t, ctx, defra)
+
+	col, err := defra.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := testGetDocVersion(t, defra, doc.Key().String())
+
+	err = doc.Set("age", 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Save(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := testGetDocVersion(t, defra, doc.Key().String())
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "GET",
+		Path:           fmt.Sprintf("%s/user/%s?from=%s&to=%s", DiffPath, doc.Key().String(), from, to),
+		Body:           nil,
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	assert.Equal(
+		t,
+		map[string]any{
+			"diff": []any{
+				map[string]any{
+					"fieldName": "age",
+					"oldValue":  float64(31),
+					"newValue":  float64(32),
+					"cid":       to,
+					"height":    float64(2),
+				},
+			},
+		},
+		resp.Data,
+	)
+}
+
+func TestExportAndImportHandlerWithNoError(t *testing.T) {
+	ctx := context.Background()
+	source := testNewInMemoryDB(t, ctx)
+	defer source.Close(ctx)
+	testLoadSchema(t, ctx, source)
+
+	stmt := `
+mutation {
+	create_user(data: "{\"age\": 31, \"verified\": true, \"points\": 90, \"name\": \"Bob\"}") {
+		_key
+	}
+}`
+	users := []testUser{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             source,
+		Method:         "POST",
+		Path:           GraphQLPath,
+		Body:           bytes.NewBuffer([]byte(stmt)),
+		ExpectedStatus: 200,
+		ResponseData:   &DataResponse{Data: &users},
+	})
+
+	filepath := t.TempDir() + "/backup.json"
+	exportReq := fmt.Sprintf(`{"filepath": %q}`, filepath)
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             source,
+		Method:         "POST",
+		Path:           ExportPath,
+		Body:           bytes.NewBuffer([]byte(exportReq)),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+	assert.Equal(t, map[string]any{"result": "success"}, resp.Data)
+
+	target := testNewInMemoryDB(t, ctx)
+	defer target.Close(ctx)
+	testLoadSchema(t, ctx, target)
+
+	resp = DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             target,
+		Method:         "POST",
+		Path:           ImportPath,
+		Body:           bytes.NewBuffer([]byte(exportReq)),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+	assert.Equal(t, map[string]any{"result": "success"}, resp.Data)
+
+	col, err := target.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := client.NewDocKeyFromString(users[0].Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := col.Get(ctx, key, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := doc.Get("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Bob", name)
+}
+
+func TestRequestWithInvalidAuthorizationHeader(t *testing.T) {
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "GET",
+		Path:           PingPath,
+		Body:           nil,
+		Headers:        map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+		ExpectedStatus: 401,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Equal(t, http.StatusUnauthorized, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "authorization header must hold a bearer token", errResponse.Errors[0].Message)
+}
+
+func TestRequestWithExpiredIdentityToken(t *testing.T) {
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := acp.NewToken(key, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "GET",
+		Path:           PingPath,
+		Body:           nil,
+		Headers:        map[string]string{"Authorization": "Bearer " + token},
+		ExpectedStatus: 401,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Equal(t, "identity token has expired", errResponse.Errors[0].Message)
+}
+
+func TestRequestWithValidIdentityToken(t *testing.T) {
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := acp.NewToken(key, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "GET",
+		Path:           PingPath,
+		Body:           nil,
+		Headers:        map[string]string{"Authorization": "Bearer " + token},
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	data, ok := resp.Data.(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, "pong", data["response"])
+}
+
 func testRequest(opt testOptions) {
 	req, err := http.NewRequest(opt.Method, opt.Path, opt.Body)
 	if err != nil {
@@ -975,6 +1278,34 @@ func testNewInMemoryDB(t *testing.T, ctx context.Context) client.DB {
 	return defra
 }
 
+// testGetDocVersion returns the CID of the current version of the user document with the given key.
+func testGetDocVersion(t *testing.T, db client.DB, key string) string {
+	stmt := `
+query {
+	user (dockey: "%s") {
+		_version {
+			cid
+		}
+	}
+}`
+
+	users := []testUser{}
+	resp := DataResponse{
+		Data: &users,
+	}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             db,
+		Method:         "POST",
+		Path:           GraphQLPath,
+		Body:           bytes.NewBuffer([]byte(fmt.Sprintf(stmt, key))),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	return users[0].Versions[0].CID
+}
+
 func testLoadSchema(t *testing.T, ctx context.Context, db client.DB) {
 	stmt := `
 type user {
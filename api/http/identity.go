@@ -0,0 +1,47 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sourcenetwork/defradb/acp"
+)
+
+const bearerPrefix = "Bearer "
+
+// identityMiddleware attaches the identity proven by the bearer token of the request, if any,
+// to the request context so that access to documents may be restricted to their owner.
+//
+// Requests without an Authorization header are handled anonymously.
+func identityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		authorization := req.Header.Get("Authorization")
+		if authorization == "" {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		if !strings.HasPrefix(authorization, bearerPrefix) {
+			handleErr(req.Context(), rw, ErrInvalidAuthorization, http.StatusUnauthorized)
+			return
+		}
+
+		identity, err := acp.VerifyToken(strings.TrimPrefix(authorization, bearerPrefix))
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(rw, req.WithContext(acp.NewContext(req.Context(), identity)))
+	})
+}
@@ -25,14 +25,20 @@ const (
 	Version          string = "v0"
 	versionedAPIPath string = "/api/" + Version
 
-	RootPath        string = versionedAPIPath + ""
-	PingPath        string = versionedAPIPath + "/ping"
-	DumpPath        string = versionedAPIPath + "/debug/dump"
-	BlocksPath      string = versionedAPIPath + "/blocks"
-	GraphQLPath     string = versionedAPIPath + "/graphql"
-	SchemaLoadPath  string = versionedAPIPath + "/schema/load"
-	SchemaPatchPath string = versionedAPIPath + "/schema/patch"
-	PeerIDPath      string = versionedAPIPath + "/peerid"
+	RootPath            string = versionedAPIPath + ""
+	PingPath            string = versionedAPIPath + "/ping"
+	DumpPath            string = versionedAPIPath + "/debug/dump"
+	BlocksPath          string = versionedAPIPath + "/blocks"
+	GraphQLPath         string = versionedAPIPath + "/graphql"
+	SchemaLoadPath      string = versionedAPIPath + "/schema/load"
+	SchemaPatchPath     string = versionedAPIPath + "/schema/patch"
+	SchemaMigrationPath string = versionedAPIPath + "/schema/migration"
+	PeerIDPath          string = versionedAPIPath + "/peerid"
+	ExportPath          string = versionedAPIPath + "/backup/export"
+	ImportPath          string = versionedAPIPath + "/backup/import"
+	DiffPath            string = versionedAPIPath + "/diff"
+	HistoryPolicyPath   string = versionedAPIPath + "/history/policy"
+	HistoryPrunePath    string = versionedAPIPath + "/history/prune"
 )
 
 func setRoutes(h *handler) *handler {
@@ -43,7 +49,7 @@ func setRoutes(h *handler) *handler {
 		h.Use(cors.Handler(cors.Options{
 			AllowedOrigins: h.options.allowedOrigins,
 			AllowedMethods: []string{"GET", "POST", "OPTIONS"},
-			AllowedHeaders: []string{"Content-Type"},
+			AllowedHeaders: []string{"Content-Type", "Authorization"},
 			MaxAge:         300,
 		}))
 	}
@@ -51,6 +57,9 @@ func setRoutes(h *handler) *handler {
 	// setup logger middleware
 	h.Use(loggerMiddleware)
 
+	// setup identity middleware
+	h.Use(identityMiddleware)
+
 	// define routes
 	h.Get(RootPath, h.handle(rootHandler))
 	h.Get(PingPath, h.handle(pingHandler))
@@ -60,7 +69,13 @@ func setRoutes(h *handler) *handler {
 	h.Post(GraphQLPath, h.handle(execGQLHandler))
 	h.Post(SchemaLoadPath, h.handle(loadSchemaHandler))
 	h.Post(SchemaPatchPath, h.handle(patchSchemaHandler))
+	h.Post(SchemaMigrationPath, h.handle(setMigrationHandler))
 	h.Get(PeerIDPath, h.handle(peerIDHandler))
+	h.Post(ExportPath, h.handle(exportHandler))
+	h.Post(ImportPath, h.handle(importHandler))
+	h.Get(DiffPath+"/{collection}/{dockey}", h.handle(diffHandler))
+	h.Post(HistoryPolicyPath, h.handle(setHistoryPolicyHandler))
+	h.Post(HistoryPrunePath, h.handle(pruneHistoryHandler))
 
 	return h
 }
@@ -0,0 +1,81 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+var (
+	exportCollections []string
+	exportFormat      string
+	exportPretty      bool
+	exportHistory     bool
+)
+
+var backupExportCmd = &cobra.Command{
+	Use:   "export [-c, --collections] [-f, --format] [-p, --pretty] [--history] <output_path>",
+	Short: "Export the database to a file",
+	Long: `Export the database to a file. If a file exists at the <output_path> location, it will be overwritten.
+
+The file is written by the running node, relative paths are resolved against the current directory.
+
+If the --collections flag is provided, only the data for the given collections will be exported.
+Otherwise, all collections in the database will be exported.
+
+If the --history flag is provided, the full history of each document is exported, allowing the
+documents to be restored with the same commits.
+
+Example: export data for the 'Users' collection:
+  defradb client backup export --collections Users user_data.json
+
+Example: export all data as newline delimited JSON:
+  defradb client backup export --format ndjson data.ndjson`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return errors.New("must specify one argument: output_path")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputPath, err := filepath.Abs(args[0])
+		if err != nil {
+			return errors.Wrap("failed to resolve output path", err)
+		}
+
+		config := client.BackupConfig{
+			Filepath:       outputPath,
+			Format:         exportFormat,
+			Pretty:         exportPretty,
+			Collections:    exportCollections,
+			IncludeHistory: exportHistory,
+		}
+		return sendJSONRequest(cmd, httpapi.ExportPath, config)
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupExportCmd)
+	backupExportCmd.Flags().StringSliceVarP(
+		&exportCollections, "collections", "c", []string{}, "List of collections to export",
+	)
+	backupExportCmd.Flags().StringVarP(
+		&exportFormat, "format", "f", client.BackupFormatJSON, "Define the output format. Options are [json, ndjson]",
+	)
+	backupExportCmd.Flags().BoolVarP(&exportPretty, "pretty", "p", false, "Set the output JSON to be pretty printed")
+	backupExportCmd.Flags().BoolVar(&exportHistory, "history", false, "Include the full history of each document")
+}
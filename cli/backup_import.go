@@ -0,0 +1,49 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+var backupImportCmd = &cobra.Command{
+	Use:   "import <input_path>",
+	Short: "Import a JSON data file to the database",
+	Long: `Import a JSON or NDJSON data file to the database.
+
+The schema of the collections within the file must be added to the database before importing it.
+
+Example: import data to the database:
+  defradb client backup import user_data.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return errors.New("must specify one argument: input_path")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputPath, err := filepath.Abs(args[0])
+		if err != nil {
+			return errors.Wrap("failed to resolve input path", err)
+		}
+
+		return sendJSONRequest(cmd, httpapi.ImportPath, map[string]string{"filepath": inputPath})
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupImportCmd)
+}
@@ -18,9 +18,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
+	"net/http"
 	"os"
 	"strings"
 
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
 	"github.com/sourcenetwork/defradb/config"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/logging"
@@ -106,3 +111,64 @@ func hasGraphQLErrors(buf []byte) (bool, error) {
 		return false, nil
 	}
 }
+
+// sendJSONRequest posts the given request body, encoded as JSON, to the given endpoint of the
+// running node and reports the result.
+func sendJSONRequest(cmd *cobra.Command, path string, body any) error {
+	endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), path)
+	if err != nil {
+		return NewErrFailedToJoinEndpoint(err)
+	}
+
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.Post(endpoint.String(), "application/json", bytes.NewBuffer(buf))
+	if err != nil {
+		return NewErrFailedToSendRequest(err)
+	}
+
+	//nolint:errcheck
+	defer res.Body.Close()
+	response, err := io.ReadAll(res.Body)
+	if err != nil {
+		return NewErrFailedToReadResponseBody(err)
+	}
+
+	stdout, err := os.Stdout.Stat()
+	if err != nil {
+		return NewErrFailedToStatStdOut(err)
+	}
+	if isFileInfoPipe(stdout) {
+		cmd.Println(string(response))
+		return nil
+	}
+
+	graphlErr, err := hasGraphQLErrors(response)
+	if err != nil {
+		return NewErrFailedToHandleGQLErrors(err)
+	}
+	if graphlErr {
+		indentedResult, err := indentJSON(response)
+		if err != nil {
+			return NewErrFailedToPrettyPrintResponse(err)
+		}
+		log.FeedbackError(cmd.Context(), indentedResult)
+		return nil
+	}
+
+	type resultResponse struct {
+		Data struct {
+			Result string `json:"result"`
+		} `json:"data"`
+	}
+	r := resultResponse{}
+	err = json.Unmarshal(response, &r)
+	if err != nil {
+		return NewErrFailedToUnmarshalResponse(err)
+	}
+	log.FeedbackInfo(cmd.Context(), r.Data.Result)
+	return nil
+}
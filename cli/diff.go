@@ -0,0 +1,107 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+)
+
+var (
+	diffFrom string
+	diffTo   string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <collection> <dockey> --from <cid> --to <cid>",
+	Short: "Show the changes made to a document between two versions",
+	Long: `Show the changes made to a document between two versions.
+
+The versions are given as the CIDs of composite commits of the document, which may be obtained
+from the _version field or the commits query. The from version must precede the to version.
+
+For each field changed between the two versions, its old value, its new value, and the CID and
+height of the latest commit that changed it are returned.
+
+Example: show the changes made to a user document:
+  defradb client diff Users bae-52b9170d-b77a-5887-b877-cbdbb99b009f --from bafybeib... --to bafybeic...`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if len(args) != 2 {
+			return NewErrMissingArgs(2, len(args))
+		}
+		if diffFrom == "" {
+			return NewErrMissingArg("from")
+		}
+		if diffTo == "" {
+			return NewErrMissingArg("to")
+		}
+
+		endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.DiffPath, args[0], args[1])
+		if err != nil {
+			return NewErrFailedToJoinEndpoint(err)
+		}
+		endpoint.RawQuery = url.Values{
+			"from": []string{diffFrom},
+			"to":   []string{diffTo},
+		}.Encode()
+
+		res, err := http.Get(endpoint.String())
+		if err != nil {
+			return NewErrFailedToSendRequest(err)
+		}
+
+		defer func() {
+			if e := res.Body.Close(); e != nil {
+				err = NewErrFailedToReadResponseBody(err)
+			}
+		}()
+
+		response, err := io.ReadAll(res.Body)
+		if err != nil {
+			return NewErrFailedToReadResponseBody(err)
+		}
+
+		stdout, err := os.Stdout.Stat()
+		if err != nil {
+			return NewErrFailedToStatStdOut(err)
+		}
+		if isFileInfoPipe(stdout) {
+			cmd.Println(string(response))
+		} else {
+			graphlErr, err := hasGraphQLErrors(response)
+			if err != nil {
+				return NewErrFailedToHandleGQLErrors(err)
+			}
+			indentedResult, err := indentJSON(response)
+			if err != nil {
+				return NewErrFailedToPrettyPrintResponse(err)
+			}
+			if graphlErr {
+				log.FeedbackError(cmd.Context(), indentedResult)
+			} else {
+				log.FeedbackInfo(cmd.Context(), indentedResult)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	clientCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffFrom, "from", "", "CID of the version to show the changes from")
+	diffCmd.Flags().StringVar(&diffTo, "to", "", "CID of the version to show the changes up to")
+}
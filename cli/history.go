@@ -0,0 +1,28 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Interact with the document history of a running DefraDB instance",
+	Long: `Manage the commit history kept for the documents of a DefraDB node.
+
+The history of the documents of a collection may be pruned according to the history policy
+set for that collection, removing the commits that are no longer required.`,
+}
+
+func init() {
+	clientCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var historyPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Interact with the history policies of a running DefraDB instance",
+	Long: `Manage the history policies of a DefraDB node.
+
+A history policy defines which commits of the documents of a collection are kept when their
+history is pruned.`,
+}
+
+func init() {
+	historyCmd.AddCommand(historyPolicyCmd)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+var (
+	historyKeepHeights  uint64
+	historyKeepDuration time.Duration
+)
+
+var historyPolicySetCmd = &cobra.Command{
+	Use:   "set [--keep-heights] [--keep-duration] <collection>",
+	Short: "Set the history policy of a collection",
+	Long: `Set the history policy of a collection, replacing any existing policy.
+
+When the history is pruned, the commits of the last --keep-heights heights, and the commits made
+within the last --keep-duration, of each document of the collection are kept. The older commits
+are squashed into a snapshot of the document and removed. Setting neither flag removes the
+policy of the collection, its history will then no longer be pruned.
+
+Peers that have not synced a document up to the height its history has been pruned to can no
+longer sync it from this node, and documents with pruned history can not be exported with
+their history.
+
+Example: keep the last 10 versions of each document of the 'Users' collection:
+  defradb client history policy set --keep-heights 10 Users
+
+Example: keep the versions of the last 30 days:
+  defradb client history policy set --keep-duration 720h Users`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return errors.New("must specify one argument: collection")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy := client.HistoryPolicy{
+			CollectionName: args[0],
+			KeepHeights:    historyKeepHeights,
+			KeepDuration:   historyKeepDuration,
+		}
+		return sendJSONRequest(cmd, httpapi.HistoryPolicyPath, policy)
+	},
+}
+
+func init() {
+	historyPolicyCmd.AddCommand(historyPolicySetCmd)
+	historyPolicySetCmd.Flags().Uint64Var(
+		&historyKeepHeights, "keep-heights", 0, "Number of latest heights of history to keep",
+	)
+	historyPolicySetCmd.Flags().DurationVar(
+		&historyKeepDuration, "keep-duration", 0, "Duration of latest history to keep",
+	)
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+)
+
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune the document history according to the history policies",
+	Long: `Prune the history of the documents of each collection that has a history policy.
+
+The commits that are not kept by the policy are squashed into a snapshot of each document and
+removed, along with the blocks they reference.
+
+Example:
+  defradb client history prune`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return sendJSONRequest(cmd, httpapi.HistoryPrunePath, struct{}{})
+	},
+}
+
+func init() {
+	historyCmd.AddCommand(historyPruneCmd)
+}
@@ -12,27 +12,38 @@ package cli
 
 import (
 	"context"
+	"strings"
 
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/logging"
 	netclient "github.com/sourcenetwork/defradb/net/api/client"
 )
 
 var (
-	fullRep bool
-	col     []string
+	fullRep     bool
+	col         []string
+	allowFields []string
+	denyFields  []string
 )
 
 var setReplicatorCmd = &cobra.Command{
-	Use:   "set [-f, --full | -c, --collection] <peer>",
+	Use:   "set [-f, --full | -c, --collection] [--allow-field] [--deny-field] <peer>",
 	Short: "Set a P2P replicator",
 	Long: `Use this command if you wish to add a new target replicator
-for the p2p data sync system or add schemas to an existing one`,
+for the p2p data sync system or add schemas to an existing one.
+
+The fields of a collection replicated to the target may be restricted with the --allow-field
+and --deny-field flags, given as <collection>.<field>. If any field of a collection is allowed,
+only the allowed fields of that collection are replicated. Denied fields are never replicated.
+
+Example: replicate the 'Users' collection without the 'email' field:
+  defradb client rpc replicator set -c Users --deny-field Users.email <peer>`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
 			return errors.New("must specify one argument: peer")
@@ -65,6 +76,11 @@ for the p2p data sync system or add schemas to an existing one`,
 			)
 		}
 
+		fieldFilters, err := parseReplicatorFieldFilters(allowFields, denyFields)
+		if err != nil {
+			return err
+		}
+
 		cred := insecure.NewCredentials()
 		client, err := netclient.NewClient(cfg.Net.RPCAddress, grpc.WithTransportCredentials(cred))
 		if err != nil {
@@ -79,7 +95,7 @@ for the p2p data sync system or add schemas to an existing one`,
 		ctx, cancel := context.WithTimeout(cmd.Context(), rpcTimeoutDuration)
 		defer cancel()
 
-		pid, err := client.SetReplicator(ctx, peerAddr, col...)
+		pid, err := client.SetReplicator(ctx, peerAddr, fieldFilters, col...)
 		if err != nil {
 			return errors.Wrap("failed to add replicator, request failed", err)
 		}
@@ -94,4 +110,33 @@ func init() {
 	setReplicatorCmd.Flags().StringArrayVarP(&col, "collection", "c",
 		[]string{}, "Define the collection for the replicator")
 	setReplicatorCmd.MarkFlagsMutuallyExclusive("full", "collection")
+	setReplicatorCmd.Flags().StringArrayVar(&allowFields, "allow-field",
+		[]string{}, "Define a field that is replicated, as <collection>.<field>")
+	setReplicatorCmd.Flags().StringArrayVar(&denyFields, "deny-field",
+		[]string{}, "Define a field that is not replicated, as <collection>.<field>")
+}
+
+// parseReplicatorFieldFilters returns the field filters, keyed by collection name,
+// described by the given allowed and denied fields.
+func parseReplicatorFieldFilters(allowFields []string, denyFields []string) (map[string]client.FieldFilter, error) {
+	fieldFilters := map[string]client.FieldFilter{}
+	for _, allowField := range allowFields {
+		collection, field, found := strings.Cut(allowField, ".")
+		if !found {
+			return nil, errors.New("allowed fields must be given as <collection>.<field>")
+		}
+		filter := fieldFilters[collection]
+		filter.Allow = append(filter.Allow, field)
+		fieldFilters[collection] = filter
+	}
+	for _, denyField := range denyFields {
+		collection, field, found := strings.Cut(denyField, ".")
+		if !found {
+			return nil, errors.New("denied fields must be given as <collection>.<field>")
+		}
+		filter := fieldFilters[collection]
+		filter.Deny = append(filter.Deny, field)
+		fieldFilters[collection] = filter
+	}
+	return fieldFilters, nil
 }
@@ -11,6 +11,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,6 +24,11 @@ import (
 	"github.com/sourcenetwork/defradb/errors"
 )
 
+var (
+	requestVariables     string
+	requestOperationName string
+)
+
 var requestCmd = &cobra.Command{
 	Use:   "query [query request]",
 	Short: "Send a DefraDB GraphQL query request",
@@ -34,6 +40,11 @@ defradb client query 'query { ... }'
 Or it can be sent via stdin by using the '-' special syntax. Example command:
 cat request.graphql | defradb client query -
 
+Variables referenced by the request can be given as a JSON object, and the operation to execute
+can be selected by name. Example command:
+defradb client query --variables '{"name": "Bob"}' --operation-name UsersByName \
+	'query UsersByName($name: String) { User(filter: {name: {_eq: $name}}) { _key } }'
+
 A GraphQL client such as GraphiQL (https://github.com/graphql/graphiql) can be used to interact
 with the database more conveniently.
 
@@ -90,6 +101,15 @@ To learn more about the DefraDB GraphQL Query Language, refer to https://docs.so
 
 		p := url.Values{}
 		p.Add("query", request)
+		if requestVariables != "" {
+			if !json.Valid([]byte(requestVariables)) {
+				return errors.New("variables must be a valid JSON object")
+			}
+			p.Add("variables", requestVariables)
+		}
+		if requestOperationName != "" {
+			p.Add("operationName", requestOperationName)
+		}
 		endpoint.RawQuery = p.Encode()
 
 		res, err := http.Get(endpoint.String())
@@ -136,4 +156,8 @@ To learn more about the DefraDB GraphQL Query Language, refer to https://docs.so
 
 func init() {
 	clientCmd.AddCommand(requestCmd)
+	requestCmd.Flags().StringVar(&requestVariables, "variables", "",
+		"JSON object containing the values of the variables referenced by the request")
+	requestCmd.Flags().StringVar(&requestOperationName, "operation-name", "",
+		"Name of the operation within the request to execute")
 }
@@ -0,0 +1,27 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var schemaMigrationCmd = &cobra.Command{
+	Use:   "migration",
+	Short: "Interact with the schema migration system of a running DefraDB instance",
+	Long: `Make changes to the schema migrations of a DefraDB node.
+
+Schema migrations transform documents written at one schema version so that they may be read at another.`,
+}
+
+func init() {
+	schemaCmd.AddCommand(schemaMigrationCmd)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+var lensFile string
+
+var schemaMigrationSetCmd = &cobra.Command{
+	Use:   "set [src] [dst] [lens]",
+	Short: "Set a schema migration within DefraDB",
+	Long: `Set a migration between two schema versions within the local DefraDB node.
+
+The lens is a JSON array of operations applied, in order, to documents written at the source
+schema version. Supported operations are set, default, copy, rename and remove.
+
+Example: set from an argument string:
+  defradb client schema migration set bae123 bae456 '[{"op": "default", "field": "points", "value": 0}]'
+
+Example: set from file:
+  defradb client schema migration set bae123 bae456 -f lens.json
+
+Learn more about the DefraDB GraphQL Schema Language on https://docs.source.network.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.RangeArgs(2, 3)(cmd, args); err != nil {
+			return errors.New("must specify src and dst schema versions, as well as a lens")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var lensJSON []byte
+		if lensFile != "" {
+			buf, err := os.ReadFile(lensFile)
+			if err != nil {
+				return NewFailedToReadFile(err)
+			}
+			lensJSON = buf
+		} else if len(args) == 3 {
+			lensJSON = []byte(args[2])
+		} else {
+			return ErrEmptyFile
+		}
+
+		var lens []client.LensOperation
+		err := json.Unmarshal(lensJSON, &lens)
+		if err != nil {
+			return errors.Wrap("invalid lens", err)
+		}
+
+		config := client.LensConfig{
+			SourceSchemaVersionID:      args[0],
+			DestinationSchemaVersionID: args[1],
+			Lens:                       lens,
+		}
+		return sendJSONRequest(cmd, httpapi.SchemaMigrationPath, config)
+	},
+}
+
+func init() {
+	schemaMigrationCmd.AddCommand(schemaMigrationSetCmd)
+	schemaMigrationSetCmd.Flags().StringVarP(&lensFile, "file", "f", "", "Lens configuration file")
+}
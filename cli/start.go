@@ -100,6 +100,15 @@ func init() {
 		log.FeedbackFatalE(context.Background(), "Could not bind datastore.maxtxnretries", err)
 	}
 
+	startCmd.Flags().String(
+		"history-prune-interval", cfg.Datastore.HistoryPruneInterval,
+		"Specify the interval at which the history of collections with a history policy is pruned (0s to disable)",
+	)
+	err = cfg.BindFlag("datastore.historypruneinterval", startCmd.Flags().Lookup("history-prune-interval"))
+	if err != nil {
+		log.FeedbackFatalE(context.Background(), "Could not bind datastore.historypruneinterval", err)
+	}
+
 	startCmd.Flags().String(
 		"store", cfg.Datastore.Store,
 		"Specify the datastore to use (supported: badger, memory)",
@@ -145,6 +154,33 @@ func init() {
 		log.FeedbackFatalE(context.Background(), "Could not bind net.p2pdisabled", err)
 	}
 
+	startCmd.Flags().Bool(
+		"sign-blocks", cfg.Net.SignBlocks,
+		"Sign the blocks created by the node using its private key",
+	)
+	err = cfg.BindFlag("net.signblocks", startCmd.Flags().Lookup("sign-blocks"))
+	if err != nil {
+		log.FeedbackFatalE(context.Background(), "Could not bind net.signblocks", err)
+	}
+
+	startCmd.Flags().Bool(
+		"require-signed-blocks", cfg.Net.RequireSignedBlocks,
+		"Reject unsigned blocks received from peers",
+	)
+	err = cfg.BindFlag("net.requiresignedblocks", startCmd.Flags().Lookup("require-signed-blocks"))
+	if err != nil {
+		log.FeedbackFatalE(context.Background(), "Could not bind net.requiresignedblocks", err)
+	}
+
+	startCmd.Flags().String(
+		"trusted-signers", cfg.Net.TrustedSigners,
+		"List of identities allowed to sign the blocks received from peers",
+	)
+	err = cfg.BindFlag("net.trustedsigners", startCmd.Flags().Lookup("trusted-signers"))
+	if err != nil {
+		log.FeedbackFatalE(context.Background(), "Could not bind net.trustedsigners", err)
+	}
+
 	startCmd.Flags().Bool(
 		"tls", cfg.API.TLS,
 		"Enable serving the API over https",
@@ -237,6 +273,22 @@ func start(ctx context.Context) (*defraInstance, error) {
 		db.WithMaxRetries(cfg.Datastore.MaxTxnRetries),
 	}
 
+	historyPruneInterval, err := cfg.Datastore.HistoryPruneIntervalDuration()
+	if err != nil {
+		return nil, err
+	}
+	if historyPruneInterval > 0 {
+		options = append(options, db.WithHistoryPruneInterval(historyPruneInterval))
+	}
+
+	if cfg.Net.SignBlocks {
+		signingKey, err := node.GetHostKey(cfg.Datastore.Badger.Path)
+		if err != nil {
+			return nil, errors.Wrap("failed to load signing key", err)
+		}
+		options = append(options, db.WithSigningKey(signingKey))
+	}
+
 	db, err := db.NewDB(ctx, rootstore, options...)
 	if err != nil {
 		return nil, errors.Wrap("failed to create database", err)
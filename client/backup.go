@@ -0,0 +1,46 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+const (
+	// BackupFormatJSON writes the backup as a single JSON object mapping each
+	// collection name to the list of its documents.
+	BackupFormatJSON = "json"
+	// BackupFormatNDJSON writes the backup as newline delimited JSON, each line
+	// holding a single document keyed by the name of its collection.
+	BackupFormatNDJSON = "ndjson"
+)
+
+// BackupConfig holds the parameters of a database export.
+type BackupConfig struct {
+	// Filepath is the path of the file the backup is written to.
+	Filepath string `json:"filepath"`
+
+	// Format is the format of the backup file, either [BackupFormatJSON] or [BackupFormatNDJSON].
+	//
+	// Defaults to [BackupFormatJSON] if empty.
+	Format string `json:"format"`
+
+	// Pretty indents the JSON output. It is ignored by the NDJSON format.
+	Pretty bool `json:"pretty"`
+
+	// Collections is the list of names of the collections to export.
+	//
+	// All collections are exported if empty.
+	Collections []string `json:"collections"`
+
+	// IncludeHistory adds the full Merkle DAG history of each document to the backup,
+	// allowing it to be restored with the same commit CIDs.
+	//
+	// Deleted documents are only exported if the history is included. The export will fail if
+	// the history of any of the documents has been pruned.
+	IncludeHistory bool `json:"includeHistory"`
+}
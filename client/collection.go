@@ -13,6 +13,8 @@ package client
 import (
 	"context"
 
+	"github.com/ipfs/go-cid"
+
 	"github.com/sourcenetwork/defradb/datastore"
 )
 
@@ -94,6 +96,13 @@ type Collection interface {
 	//
 	// Returns an ErrDocumentNotFound if a document is not found for any given DocKey.
 	UpdateWithKeys(context.Context, []DocKey, string) (*UpdateResult, error)
+	// Upsert updates the documents matching the given filter, or creates the given document
+	// if no documents match it, within a single transaction.
+	//
+	// The filter and updater follow the same rules as those given to UpdateWithFilter. The given
+	// document is only created if no documents match the filter, it does not need to match the
+	// filter itself.
+	Upsert(ctx context.Context, filter any, doc *Document, updater string) (*UpsertResult, error)
 
 	// DeleteWith deletes a target document.
 	//
@@ -130,12 +139,44 @@ type Collection interface {
 	// Returns an ErrDocumentNotFound if a document matching the given DocKey is not found.
 	Get(ctx context.Context, key DocKey, showDeleted bool) (*Document, error)
 
+	// Diff returns the changes made to the fields of the document with the given DocKey between
+	// the two given versions (composite commit CIDs) of the document.
+	//
+	// The from version must be an ancestor of the to version. Fields whose value is the same in
+	// both versions are not included. Changes are ordered by field name.
+	Diff(ctx context.Context, key DocKey, from cid.Cid, to cid.Cid) ([]FieldDiff, error)
+
+	// Revert restores the document with the given DocKey to its state at the given version
+	// (composite commit CID) of the document.
+	//
+	// The restored state is written as a new commit, the history of the document is left intact.
+	// Counter fields that were not set at the given version are left as they are.
+	//
+	// Returns an ErrDocumentNotFound if a document matching the given DocKey is not found.
+	Revert(ctx context.Context, key DocKey, version cid.Cid) error
+
 	// WithTxn returns a new instance of the collection, with a transaction
 	// handle instead of a raw DB handle.
 	WithTxn(datastore.Txn) Collection
 
 	// GetAllDocKeys returns all the document keys that exist in the collection.
 	GetAllDocKeys(ctx context.Context) (<-chan DocKeysResult, error)
+
+	// CreateIndex creates a new secondary index on the collection and indexes all
+	// existing documents.
+	//
+	// If the given description has no name, one will be generated. The ID will always be
+	// assigned by the database. Returns the description of the created index.
+	//
+	// Returns an error if an index with the same name already exists, or if any of the fields
+	// do not exist or cannot be indexed.
+	CreateIndex(context.Context, IndexDescription) (IndexDescription, error)
+	// DropIndex removes the secondary index with the given name along with all of its entries.
+	//
+	// Returns an error if no index with the given name exists.
+	DropIndex(ctx context.Context, indexName string) error
+	// GetIndexes returns the descriptions of all the secondary indexes on the collection.
+	GetIndexes(ctx context.Context) ([]IndexDescription, error)
 }
 
 // DocKeysResult wraps the result of an attempt at a DocKey retrieval operation.
@@ -154,6 +195,28 @@ type UpdateResult struct {
 	DocKeys []string
 }
 
+// UpsertResult wraps the result of an upsert call.
+type UpsertResult struct {
+	// Created is true if no documents matched the filter and the given document was created.
+	Created bool
+	// DocKeys contains the DocKeys of all the documents updated or created by the upsert call.
+	DocKeys []string
+}
+
+// FieldDiff describes the change made to a single field between two versions of a document.
+type FieldDiff struct {
+	// FieldName is the name of the changed field.
+	FieldName string `json:"fieldName"`
+	// OldValue is the value of the field at the older version, nil if it was not set.
+	OldValue any `json:"oldValue"`
+	// NewValue is the value of the field at the newer version, nil if it is not set.
+	NewValue any `json:"newValue"`
+	// Cid is the CID of the latest commit between the two versions that changed the field.
+	Cid string `json:"cid"`
+	// Height is the height of that commit within the document's history.
+	Height uint64 `json:"height"`
+}
+
 // DeleteResult wraps the result of an delete call.
 type DeleteResult struct {
 	// Count contains the number of documents deleted by the delete call.
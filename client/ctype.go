@@ -22,4 +22,5 @@ const (
 	LWW_REGISTER
 	OBJECT
 	COMPOSITE
+	PN_COUNTER
 )
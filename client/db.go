@@ -106,6 +106,28 @@ type Store interface {
 	// [FieldKindStringToEnumMapping].
 	PatchSchema(context.Context, string) error
 
+	// SetMigration registers the given schema migration, replacing any existing migration
+	// between the same source and destination schema versions.
+	//
+	// Documents last written at the source schema version, including those received from peers
+	// running that version, will be transformed by the migration when read at the destination
+	// version. Migrations are chained when no direct migration between two versions exists.
+	SetMigration(context.Context, LensConfig) error
+
+	// SetHistoryPolicy sets the policy determining how much of the commit history of the
+	// documents of the given collection is kept when the history is pruned, replacing any
+	// existing policy of the collection.
+	//
+	// A policy with neither limit set removes the policy of the collection.
+	SetHistoryPolicy(context.Context, HistoryPolicy) error
+
+	// PruneHistory prunes the commit history of the documents of each collection that has
+	// a [HistoryPolicy], deleting the commits not kept by the policy.
+	//
+	// The state of each document preceding the kept commits is squashed into a snapshot,
+	// from which past versions of the document are reconstructed.
+	PruneHistory(context.Context) error
+
 	// GetCollectionByName attempts to retrieve a collection matching the given name.
 	//
 	// If no matching collection is found an error will be returned.
@@ -126,7 +148,62 @@ type Store interface {
 	GetAllCollections(context.Context) ([]Collection, error)
 
 	// ExecRequest executes the given GQL request against the [Store].
-	ExecRequest(context.Context, string) *RequestResult
+	//
+	// Request variables and the name of the operation to execute may be provided
+	// via [WithVariables] and [WithOperationName].
+	ExecRequest(ctx context.Context, request string, opts ...RequestOption) *RequestResult
+
+	// BasicExport writes the documents of the [Store] to the file described by the given config.
+	//
+	// Document keys are preserved, allowing the backup to be restored via [BasicImport].
+	BasicExport(ctx context.Context, config *BackupConfig) error
+
+	// BasicImport imports the documents held by the given backup file into the [Store].
+	//
+	// The schema of the collections within the backup must be added before importing it, and
+	// none of the documents may already exist. Nothing will be imported if any document fails
+	// to import.
+	BasicImport(ctx context.Context, filepath string) error
+}
+
+// RequestOptions contains the optional parameters of a GQL request.
+type RequestOptions struct {
+	// Variables contains the values of the variables declared by the request operation.
+	//
+	// Values should be of the types produced by unmarshalling JSON into an `any`.
+	Variables map[string]any
+
+	// OperationName is the name of the operation to execute.
+	//
+	// If empty, all the operations within the request will be executed.
+	OperationName string
+}
+
+// RequestOption is a function that sets a [RequestOptions] value.
+type RequestOption func(*RequestOptions)
+
+// WithVariables sets the values of the variables declared by the request operation.
+func WithVariables(variables map[string]any) RequestOption {
+	return func(opts *RequestOptions) {
+		opts.Variables = variables
+	}
+}
+
+// WithOperationName sets the name of the operation within the request to execute.
+func WithOperationName(operationName string) RequestOption {
+	return func(opts *RequestOptions) {
+		opts.OperationName = operationName
+	}
+}
+
+// NewRequestOptions returns the request options resulting from applying the given
+// option functions.
+func NewRequestOptions(opts ...RequestOption) RequestOptions {
+	options := RequestOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
 }
 
 // GQLResult represents the immediate results of a GQL request.
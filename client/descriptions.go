@@ -12,6 +12,7 @@ package client
 
 import (
 	"fmt"
+	"strings"
 )
 
 // CollectionDescription describes a Collection and all its associated metadata.
@@ -29,6 +30,12 @@ type CollectionDescription struct {
 
 	// Schema contains the data type information that this Collection uses.
 	Schema SchemaDescription
+
+	// Indexes contains the secondary indexes that this Collection has.
+	//
+	// They are local to the node hosting the DefraDB instance and are excluded from
+	// the (global) schema.
+	Indexes []IndexDescription
 }
 
 // IDString returns the collection ID as a string.
@@ -48,6 +55,21 @@ func (col CollectionDescription) GetField(name string) (FieldDescription, bool)
 	return FieldDescription{}, false
 }
 
+// GetEmbeddedFields returns the fields holding the values of the embedded object field
+// of the given name.
+func (col CollectionDescription) GetEmbeddedFields(name string) []FieldDescription {
+	fields := []FieldDescription{}
+	if !col.Schema.IsEmpty() {
+		prefix := name + EmbeddedFieldSeparator
+		for _, field := range col.Schema.Fields {
+			if strings.HasPrefix(field.Name, prefix) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
 // GetRelation returns the field that supports the relation of the given name.
 func (col CollectionDescription) GetRelation(name string) (FieldDescription, bool) {
 	if !col.Schema.IsEmpty() {
@@ -85,6 +107,54 @@ type SchemaDescription struct {
 	//
 	// Currently new fields may be added after initial declaration, but they cannot be removed.
 	Fields []FieldDescription
+
+	// UniqueConstraints contains the sets of fields whose values must be unique across
+	// all the documents of this Schema.
+	//
+	// Constraints are enforced locally at write time. Documents received from peers are
+	// always merged so that all peers converge, any violations this may cause are reported
+	// but not rejected.
+	//
+	// They cannot currently be modified after initial declaration. The field is omitted from
+	// the serialized schema when empty so that it does not affect the ids of schemas without
+	// constraints.
+	UniqueConstraints []UniqueConstraintDescription `json:",omitempty"`
+
+	// Enums contains the enum types of the enum fields within this Schema.
+	//
+	// New values may be added to an enum after initial declaration, but existing values cannot
+	// be removed. The field is omitted from the serialized schema when empty so that it does
+	// not affect the ids of schemas without enums.
+	Enums []EnumDescription `json:",omitempty"`
+}
+
+// UniqueConstraintDescription describes a set of fields whose (combined) values must be
+// unique across all the documents of a schema.
+//
+// Documents with a nil value for any of the fields are not constrained.
+type UniqueConstraintDescription struct {
+	// Fields contains the names of the constrained fields.
+	Fields []string
+}
+
+// EnumDescription describes an enum type and the values that fields of that type may hold.
+type EnumDescription struct {
+	// Name is the name of the enum type.
+	Name string
+
+	// Values contains the values that fields of this enum type may hold, in the order
+	// in which they were declared.
+	Values []string
+}
+
+// HasValue returns true if the given value is one of the values of this enum.
+func (ed EnumDescription) HasValue(value string) bool {
+	for _, enumValue := range ed.Values {
+		if enumValue == value {
+			return true
+		}
+	}
+	return false
 }
 
 // IsEmpty returns true if the SchemaDescription is empty and uninitialized
@@ -92,6 +162,37 @@ func (sd SchemaDescription) IsEmpty() bool {
 	return len(sd.Fields) == 0
 }
 
+// GetEnum returns the enum type of the given name.
+func (sd SchemaDescription) GetEnum(name string) (EnumDescription, bool) {
+	for _, enum := range sd.Enums {
+		if enum.Name == name {
+			return enum, true
+		}
+	}
+	return EnumDescription{}, false
+}
+
+// ValidateEnumValue returns an error if the given value of the given enum field is not one of
+// the values of the field's enum type.
+//
+// Nil values are always valid, as are values of fields that are not enum fields.
+func (sd SchemaDescription) ValidateEnumValue(field FieldDescription, value any) error {
+	if field.Kind != FieldKind_ENUM || value == nil {
+		return nil
+	}
+
+	enum, ok := sd.GetEnum(field.Schema)
+	if !ok {
+		return NewErrEnumNotFound(field.Schema)
+	}
+
+	enumValue, ok := value.(string)
+	if !ok || !enum.HasValue(enumValue) {
+		return NewErrInvalidEnumValue(field.Name, value)
+	}
+	return nil
+}
+
 // GetFieldKey returns the field ID for the given field name.
 func (sd SchemaDescription) GetFieldKey(fieldName string) uint32 {
 	for _, field := range sd.Fields {
@@ -120,9 +221,15 @@ const (
 	FieldKind_DATETIME     FieldKind = 10
 	FieldKind_STRING       FieldKind = 11
 	FieldKind_STRING_ARRAY FieldKind = 12
-	_                      FieldKind = 13 // safe to repurpose (was never used)
-	_                      FieldKind = 14 // safe to repurpose (was never used)
-	_                      FieldKind = 15 // safe to repurpose (was never used)
+
+	// Arbitrary JSON value (object, array, or scalar)
+	FieldKind_JSON FieldKind = 13
+
+	// Binary data, represented externally as a hex encoded string
+	FieldKind_BLOB FieldKind = 14
+
+	// Embedded object, stored within the host document
+	FieldKind_EMBEDDED_OBJECT FieldKind = 15
 
 	// Embedded object, but accessed via foreign keys
 	FieldKind_FOREIGN_OBJECT FieldKind = 16
@@ -134,8 +241,15 @@ const (
 	FieldKind_NILLABLE_INT_ARRAY    FieldKind = 19
 	FieldKind_NILLABLE_FLOAT_ARRAY  FieldKind = 20
 	FieldKind_NILLABLE_STRING_ARRAY FieldKind = 21
+
+	// One of the values of the enum type named by the field's Schema
+	FieldKind_ENUM FieldKind = 22
 )
 
+// EmbeddedFieldSeparator separates the name of an embedded object field from the names
+// of the fields within it.
+const EmbeddedFieldSeparator = "."
+
 // FieldKindStringToEnumMapping maps string representations of [FieldKind] values to
 // their enum values.
 //
@@ -158,6 +272,8 @@ var FieldKindStringToEnumMapping = map[string]FieldKind{
 	"String":     FieldKind_STRING,
 	"[String]":   FieldKind_NILLABLE_STRING_ARRAY,
 	"[String!]":  FieldKind_STRING_ARRAY,
+	"JSON":       FieldKind_JSON,
+	"Blob":       FieldKind_BLOB,
 }
 
 // RelationType describes the type of relation between two types.
@@ -226,6 +342,14 @@ func (f FieldDescription) IsObject() bool {
 		(f.Kind == FieldKind_FOREIGN_OBJECT_ARRAY)
 }
 
+// IsEmbeddedObject returns true if this field is an embedded object type.
+//
+// The values of embedded objects are held by the fields of the host schema named
+// after the path to them, e.g. `address.city`.
+func (f FieldDescription) IsEmbeddedObject() bool {
+	return f.Kind == FieldKind_EMBEDDED_OBJECT
+}
+
 // IsObjectArray returns true if this field is an object array type.
 func (f FieldDescription) IsObjectArray() bool {
 	return (f.Kind == FieldKind_FOREIGN_OBJECT_ARRAY)
@@ -420,6 +420,40 @@ func (doc *Document) Clean() {
 	}
 }
 
+// ValidateEnumValues returns an error if the value of any of the enum fields of the given collection
+// held by this document, including those of embedded objects, is not a value of the field's enum type.
+func (doc *Document) ValidateEnumValues(desc CollectionDescription) error {
+	return doc.validateEnumValues(desc, "")
+}
+
+func (doc *Document) validateEnumValues(desc CollectionDescription, prefix string) error {
+	for name, field := range doc.Fields() {
+		value, err := doc.GetValueWithField(field)
+		if err != nil {
+			return err
+		}
+
+		if value.IsDocument() {
+			subDoc := value.Value().(*Document)
+			err := subDoc.validateEnumValues(desc, prefix+name+EmbeddedFieldSeparator)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		fieldDesc, ok := desc.GetField(prefix + name)
+		if !ok {
+			continue
+		}
+		err = desc.Schema.ValidateEnumValue(fieldDesc, value.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // converts the document into a map[string]any
 // including any sub documents
 func (doc *Document) toMap() (map[string]any, error) {
@@ -439,6 +473,7 @@ func (doc *Document) toMap() (map[string]any, error) {
 				return nil, err
 			}
 			docMap[k] = subDocMap
+			continue
 		}
 
 		docMap[k] = value.Value()
@@ -464,6 +499,7 @@ func (doc *Document) toMapWithKey() (map[string]any, error) {
 				return nil, err
 			}
 			docMap[k] = subDocMap
+			continue
 		}
 
 		docMap[k] = value.Value()
@@ -473,6 +509,52 @@ func (doc *Document) toMapWithKey() (map[string]any, error) {
 	return docMap, nil
 }
 
+// ToJSONValue returns the given document value in its plain JSON form, converting
+// any sub-document into a map.
+//
+// Whole numbers are returned as int64, matching the parsing of top level document values.
+func ToJSONValue(value Value) (any, error) {
+	if !value.IsDocument() {
+		return NormalizeJSONValue(value.Value()), nil
+	}
+
+	subDoc, ok := value.Value().(*Document)
+	if !ok {
+		return nil, NewErrUnexpectedType[*Document]("value", value.Value())
+	}
+	subDocMap, err := subDoc.toMap()
+	if err != nil {
+		return nil, err
+	}
+	return NormalizeJSONValue(subDocMap), nil
+}
+
+// NormalizeJSONValue returns the given unmarshalled JSON value with all whole numbers
+// converted to int64.
+func NormalizeJSONValue(value any) any {
+	switch v := value.(type) {
+	case float64:
+		if float64(int64(v)) == v {
+			return int64(v)
+		}
+		return v
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = NormalizeJSONValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = NormalizeJSONValue(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
 // DocumentStatus represent the state of the document in the DAG store.
 // It can either be `Active“ or `Deleted`.
 type DocumentStatus uint8
@@ -23,6 +23,10 @@ const (
 	errParsingFailed         string = "failed to parse argument"
 	errUninitializeProperty  string = "invalid state, required property is uninitialized"
 	errMaxTxnRetries         string = "reached maximum transaction reties"
+	errInvalidEnumValue      string = "the given value is not a value of the field's enum type"
+	errEnumNotFound          string = "no enum type with the given name exists"
+	errAsOfWithCid           string = "asOf and asOfHeight cannot be combined with cid"
+	errAsOfWithAsOfHeight    string = "asOf cannot be combined with asOfHeight"
 )
 
 // Errors returnable from this package.
@@ -45,6 +49,10 @@ var (
 	ErrMalformedDocKey       = errors.New("malformed DocKey, missing either version or cid")
 	ErrInvalidDocKeyVersion  = errors.New("invalid DocKey version")
 	ErrMaxTxnRetries         = errors.New(errMaxTxnRetries)
+	ErrInvalidEnumValue      = errors.New(errInvalidEnumValue)
+	ErrEnumNotFound          = errors.New(errEnumNotFound)
+	ErrAsOfWithCid           = errors.New(errAsOfWithCid)
+	ErrAsOfWithAsOfHeight    = errors.New(errAsOfWithAsOfHeight)
 )
 
 // NewErrFieldNotExist returns an error indicating that the given field does not exist.
@@ -105,3 +113,18 @@ func NewErrUninitializeProperty(host string, propertyName string) error {
 func NewErrMaxTxnRetries(inner error) error {
 	return errors.Wrap(errMaxTxnRetries, inner)
 }
+
+// NewErrInvalidEnumValue returns an error indicating that the given value is not one of the
+// values of the given field's enum type.
+func NewErrInvalidEnumValue(fieldName string, value any) error {
+	return errors.New(
+		errInvalidEnumValue,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrEnumNotFound returns an error indicating that no enum type of the given name exists.
+func NewErrEnumNotFound(name string) error {
+	return errors.New(errEnumNotFound, errors.NewKV("Name", name))
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import "time"
+
+// HistoryPolicy describes how much of the commit history of the documents of a collection is
+// kept when the history is pruned.
+//
+// The commits of a document that are kept by either limit are kept. The state of the document
+// preceding the kept commits is squashed into a snapshot, the pruned commits can then no longer
+// be queried, diffed or reverted to, and peers whose copy of the document is older than the
+// snapshot can no longer sync it from this node.
+type HistoryPolicy struct {
+	// CollectionName is the name of the collection the policy applies to.
+	CollectionName string `json:"collectionName"`
+
+	// KeepHeights is the number of most recent commit heights of each document that are kept.
+	//
+	// History is not pruned by height if zero.
+	KeepHeights uint64 `json:"keepHeights"`
+
+	// KeepDuration is the duration for which commits are kept after being merged locally.
+	//
+	// History is not pruned by age if zero.
+	KeepDuration time.Duration `json:"keepDuration"`
+}
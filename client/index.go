@@ -0,0 +1,56 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+// IndexDirection is the direction in which the values of an indexed field are stored.
+type IndexDirection string
+
+const (
+	// Ascending is the value to use for an ascending index field.
+	Ascending IndexDirection = "ASC"
+	// Descending is the value to use for a descending index field.
+	Descending IndexDirection = "DESC"
+)
+
+// IndexedFieldDescription describes how a field is being indexed.
+type IndexedFieldDescription struct {
+	// Name contains the name of the field.
+	Name string
+	// Direction contains the direction in which the field values are stored.
+	Direction IndexDirection
+}
+
+// IndexDescription describes a secondary index of a collection.
+//
+// Indexes are local to the node hosting the DefraDB instance and are not part
+// of the (global) schema.
+type IndexDescription struct {
+	// Name contains the name of the index.
+	//
+	// It is unique within the collection. If left empty on creation, a name will
+	// be generated from the collection and field names.
+	Name string
+
+	// ID is the local identifier of this index.
+	//
+	// It is assigned on creation and is immutable.
+	ID uint32
+
+	// Fields contains the fields that are being indexed, in the order in which
+	// they are stored within the index keys.
+	Fields []IndexedFieldDescription
+
+	// Unique is true if this index backs a unique constraint of the schema.
+	//
+	// Unique indexes are created and dropped alongside the constraint they back,
+	// they cannot be managed directly.
+	Unique bool
+}
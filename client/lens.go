@@ -0,0 +1,54 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+const (
+	// LensOpSet sets the field to the given value.
+	LensOpSet = "set"
+	// LensOpDefault sets the field to the given value if it has no value.
+	LensOpDefault = "default"
+	// LensOpCopy copies the value of the from field to the field.
+	LensOpCopy = "copy"
+	// LensOpRename moves the value of the from field to the field, clearing the from field.
+	LensOpRename = "rename"
+	// LensOpRemove clears the value of the field.
+	LensOpRemove = "remove"
+)
+
+// LensConfig describes a schema migration, transforming the documents written at the source
+// schema version so that they may be read at the destination schema version.
+type LensConfig struct {
+	// SourceSchemaVersionID is the schema version the migration transforms documents from.
+	SourceSchemaVersionID string `json:"sourceSchemaVersionID"`
+
+	// DestinationSchemaVersionID is the schema version the migration transforms documents to.
+	DestinationSchemaVersionID string `json:"destinationSchemaVersionID"`
+
+	// Lens is the list of operations applied, in order, to transform the documents.
+	Lens []LensOperation `json:"lens"`
+}
+
+// LensOperation is a single declarative transform of a document.
+type LensOperation struct {
+	// Op is the kind of the operation, one of the LensOp constants.
+	Op string `json:"op"`
+
+	// Field is the name of the field written by the operation.
+	Field string `json:"field"`
+
+	// From is the name of the field read by [LensOpCopy] and [LensOpRename] operations.
+	From string `json:"from,omitempty"`
+
+	// Value is the value written by [LensOpSet] and [LensOpDefault] operations.
+	//
+	// Values should be of the types produced by unmarshalling JSON into an `any`.
+	Value any `json:"value,omitempty"`
+}
@@ -10,10 +10,65 @@
 
 package client
 
-import "github.com/libp2p/go-libp2p/core/peer"
+import (
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
 
 // Replicator is a peer that a set of local collections are replicated to.
 type Replicator struct {
 	Info    peer.AddrInfo
 	Schemas []string
+	// FieldFilters restrict the fields of the collections that are replicated, keyed by the
+	// schema ID of the collection. All the fields of collections without a filter are replicated.
+	FieldFilters map[string]FieldFilter `json:",omitempty"`
+}
+
+// FieldFilter restricts the fields of a collection that are replicated to a replicator.
+//
+// Documents of a filtered collection are replicated as partial documents: only the blocks of the
+// permitted fields are sent to the replicator, the composite blocks of the documents, which hold
+// the values of all the updated fields, are not.
+type FieldFilter struct {
+	// Allow holds the names of the only fields that are replicated. All fields are replicated
+	// if it is empty.
+	Allow []string `json:",omitempty"`
+	// Deny holds the names of the fields that are never replicated.
+	Deny []string `json:",omitempty"`
+}
+
+// IsEmpty returns true if the filter permits all fields.
+func (f FieldFilter) IsEmpty() bool {
+	return len(f.Allow) == 0 && len(f.Deny) == 0
+}
+
+// Permits returns true if the field of the given name may be replicated.
+//
+// The fields holding the values of an embedded object field are permitted if the embedded
+// object field is.
+func (f FieldFilter) Permits(fieldName string) bool {
+	names := []string{fieldName}
+	if parentName, _, isEmbedded := strings.Cut(fieldName, EmbeddedFieldSeparator); isEmbedded {
+		names = append(names, parentName)
+	}
+
+	for _, name := range names {
+		for _, denied := range f.Deny {
+			if name == denied {
+				return false
+			}
+		}
+	}
+	if len(f.Allow) == 0 {
+		return true
+	}
+	for _, name := range names {
+		for _, allowed := range f.Allow {
+			if name == allowed {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,39 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldFilterPermits(t *testing.T) {
+	filter := FieldFilter{
+		Allow: []string{"name", "address"},
+		Deny:  []string{"address.street"},
+	}
+
+	assert.True(t, filter.Permits("name"))
+	assert.True(t, filter.Permits("address.city"))
+	assert.False(t, filter.Permits("address.street"))
+	assert.False(t, filter.Permits("email"))
+}
+
+func TestFieldFilterWithOnlyDeniedFieldsPermitsOtherFields(t *testing.T) {
+	filter := FieldFilter{
+		Deny: []string{"email", "address"},
+	}
+
+	assert.True(t, filter.Permits("name"))
+	assert.False(t, filter.Permits("email"))
+	assert.False(t, filter.Permits("address.city"))
+}
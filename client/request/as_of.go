@@ -0,0 +1,31 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package request
+
+import (
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+)
+
+// AsOf describes a point in the history of the documents of a collection, at which their
+// state is to be returned.
+//
+// Only one of Time and Height may be set.
+type AsOf struct {
+	// Time, if set, requests the state of each document as of its latest commit merged into
+	// the local store at or before the given time.
+	Time immutable.Option[time.Time]
+
+	// Height, if set, requests the state of each document as of its latest commit of a height
+	// no greater than the given height. The commit creating a document has a height of 1.
+	Height immutable.Option[uint64]
+}
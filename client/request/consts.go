@@ -16,6 +16,7 @@ const (
 	TypeNameFieldName = "__typename"
 
 	Cid         = "cid"
+	Create      = "create"
 	Data        = "data"
 	DocKey      = "dockey"
 	DocKeys     = "dockeys"
@@ -23,6 +24,7 @@ const (
 	Id          = "id"
 	Ids         = "ids"
 	ShowDeleted = "showDeleted"
+	Update      = "update"
 
 	FilterClause  = "filter"
 	GroupByClause = "groupBy"
@@ -30,14 +32,32 @@ const (
 	OffsetClause  = "offset"
 	OrderClause   = "order"
 	DepthClause   = "depth"
-
-	AverageFieldName = "_avg"
-	CountFieldName   = "_count"
-	KeyFieldName     = "_key"
-	GroupFieldName   = "_group"
-	DeletedFieldName = "_deleted"
-	SumFieldName     = "_sum"
-	VersionFieldName = "_version"
+	FirstClause   = "first"
+	LastClause    = "last"
+	AfterClause   = "after"
+	BeforeClause  = "before"
+
+	AsOfClause       = "asOf"
+	AsOfHeightClause = "asOfHeight"
+
+	AverageFieldName  = "_avg"
+	CountFieldName    = "_count"
+	KeyFieldName      = "_key"
+	GroupFieldName    = "_group"
+	DeletedFieldName  = "_deleted"
+	SumFieldName      = "_sum"
+	MinFieldName      = "_min"
+	MaxFieldName      = "_max"
+	VersionFieldName  = "_version"
+	CursorFieldName   = "_cursor"
+	PageInfoFieldName = "_pageInfo"
+
+	// Counter update operations, e.g. `{"likes": {"_inc": 1}}`
+	IncrementOpName = "_inc"
+	DecrementOpName = "_dec"
+
+	// Order argument property determining the placement of nil values, e.g. `{"age": ASC, "_nulls": LAST}`
+	OrderNullsName = "_nulls"
 
 	ExplainLabel = "explain"
 
@@ -52,12 +72,22 @@ const (
 	CollectionIDFieldName    = "collectionID"
 	SchemaVersionIDFieldName = "schemaVersionId"
 	DeltaFieldName           = "delta"
+	SignerFieldName          = "signer"
+
+	PageInfoTypeName         = "PageInfo"
+	HasNextPageFieldName     = "hasNextPage"
+	HasPreviousPageFieldName = "hasPreviousPage"
+	StartCursorFieldName     = "startCursor"
+	EndCursorFieldName       = "endCursor"
 
 	LinksNameFieldName = "name"
 	LinksCidFieldName  = "cid"
 
 	ASC  = OrderDirection("ASC")
 	DESC = OrderDirection("DESC")
+
+	NullsFirst = NullsPlacement("FIRST")
+	NullsLast  = NullsPlacement("LAST")
 )
 
 var (
@@ -66,6 +96,11 @@ var (
 		string(DESC): DESC,
 	}
 
+	NameToNullsPlacement = map[string]NullsPlacement{
+		string(NullsFirst): NullsFirst,
+		string(NullsLast):  NullsLast,
+	}
+
 	ReservedFields = map[string]bool{
 		TypeNameFieldName: true,
 		VersionFieldName:  true,
@@ -73,14 +108,20 @@ var (
 		CountFieldName:    true,
 		SumFieldName:      true,
 		AverageFieldName:  true,
+		MinFieldName:      true,
+		MaxFieldName:      true,
 		KeyFieldName:      true,
 		DeletedFieldName:  true,
+		CursorFieldName:   true,
+		PageInfoFieldName: true,
 	}
 
 	Aggregates = map[string]struct{}{
 		CountFieldName:   {},
 		SumFieldName:     {},
 		AverageFieldName: {},
+		MinFieldName:     {},
+		MaxFieldName:     {},
 	}
 
 	CommitQueries = map[string]struct{}{
@@ -95,10 +136,18 @@ var (
 		CollectionIDFieldName,
 		SchemaVersionIDFieldName,
 		DeltaFieldName,
+		SignerFieldName,
 	}
 
 	LinksFields = []string{
 		LinksNameFieldName,
 		LinksCidFieldName,
 	}
+
+	PageInfoFields = []string{
+		HasNextPageFieldName,
+		HasPreviousPageFieldName,
+		StartCursorFieldName,
+		EndCursorFieldName,
+	}
 )
@@ -19,6 +19,8 @@ const (
 	CreateObjects
 	UpdateObjects
 	DeleteObjects
+	UpsertObjects
+	RevertObjects
 )
 
 // ObjectMutation is a field on the `mutation` operation of a graphql request. It includes
@@ -35,6 +37,14 @@ type ObjectMutation struct {
 	Filter immutable.Option[Filter]
 	Data   string
 
+	// UpdateData is the patch applied by an upsert to the documents matching the Filter.
+	//
+	// If no documents match, the document declared by Data will be created instead.
+	UpdateData string
+
+	// Version is the CID of the version a revert restores the document identified by IDs to.
+	Version string
+
 	Fields []Selection
 }
 
@@ -13,6 +13,10 @@ package request
 type (
 	OrderDirection string
 
+	// NullsPlacement determines whether documents without a value to order by are
+	// placed before or after all other documents.
+	NullsPlacement string
+
 	OrderCondition struct {
 		// field may be a compound field statement
 		// since the order statement allows ordering on
@@ -23,6 +27,11 @@ type (
 		// and the direction would be "DESC"
 		Fields    []string
 		Direction OrderDirection
+
+		// Nulls explicitly places nil values first or last, regardless of Direction.
+		//
+		// If empty, nil values come first when ascending and last when descending.
+		Nulls NullsPlacement
 	}
 
 	OrderBy struct {
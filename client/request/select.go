@@ -33,6 +33,9 @@ type Select struct {
 	DocKeys immutable.Option[[]string]
 	CID     immutable.Option[string]
 
+	// AsOf, if set, requests the state of the selected documents at a point in their history.
+	AsOf immutable.Option[AsOf]
+
 	// Root is the top level type of parsed request
 	Root SelectionType
 
@@ -42,6 +45,13 @@ type Select struct {
 	GroupBy immutable.Option[GroupBy]
 	Filter  immutable.Option[Filter]
 
+	// First, Last, After and Before describe the Relay style cursor pagination of the
+	// selected documents, and may not be combined with Limit and Offset.
+	First  immutable.Option[uint64]
+	Last   immutable.Option[uint64]
+	After  immutable.Option[string]
+	Before immutable.Option[string]
+
 	Fields []Selection
 
 	ShowDeleted bool
@@ -69,10 +79,24 @@ func (s *Select) validateShallow() []error {
 	result := []error{}
 
 	result = append(result, s.validateGroupBy()...)
+	result = append(result, s.validateAsOf()...)
 
 	return result
 }
 
+func (s *Select) validateAsOf() []error {
+	if !s.AsOf.HasValue() {
+		return []error{}
+	}
+	if s.CID.HasValue() {
+		return []error{client.ErrAsOfWithCid}
+	}
+	if s.AsOf.Value().Time.HasValue() && s.AsOf.Value().Height.HasValue() {
+		return []error{client.ErrAsOfWithAsOfHeight}
+	}
+	return []error{}
+}
+
 func (s *Select) validateGroupBy() []error {
 	result := []error{}
 
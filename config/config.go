@@ -237,6 +237,9 @@ type DatastoreConfig struct {
 	Memory        MemoryConfig
 	Badger        BadgerConfig
 	MaxTxnRetries int
+	// The interval at which the history of collections with a history policy is pruned,
+	// a zero interval disables the automatic pruning.
+	HistoryPruneInterval string
 }
 
 // BadgerConfig configures Badger's on-disk / filesystem mode.
@@ -261,7 +264,8 @@ func defaultDatastoreConfig() *DatastoreConfig {
 			ValueLogFileSize: 1 * GiB,
 			Options:          &opts,
 		},
-		MaxTxnRetries: 5,
+		MaxTxnRetries:        5,
+		HistoryPruneInterval: "0s",
 	}
 }
 
@@ -271,9 +275,22 @@ func (dbcfg DatastoreConfig) validate() error {
 	default:
 		return NewErrInvalidDatastoreType(dbcfg.Store)
 	}
+	_, err := time.ParseDuration(dbcfg.HistoryPruneInterval)
+	if err != nil {
+		return NewErrInvalidHistoryPruneInterval(err, dbcfg.HistoryPruneInterval)
+	}
 	return nil
 }
 
+// HistoryPruneIntervalDuration gives the history prune interval as a time.Duration.
+func (dbcfg *DatastoreConfig) HistoryPruneIntervalDuration() (time.Duration, error) {
+	d, err := time.ParseDuration(dbcfg.HistoryPruneInterval)
+	if err != nil {
+		return d, NewErrInvalidHistoryPruneInterval(err, dbcfg.HistoryPruneInterval)
+	}
+	return d, nil
+}
+
 // APIConfig configures the API endpoints.
 type APIConfig struct {
 	Address     string
@@ -347,6 +364,9 @@ type NetConfig struct {
 	RPCMaxConnectionIdle string
 	RPCTimeout           string
 	TCPAddress           string
+	SignBlocks           bool
+	RequireSignedBlocks  bool
+	TrustedSigners       string
 }
 
 func defaultNetConfig() *NetConfig {
@@ -360,6 +380,9 @@ func defaultNetConfig() *NetConfig {
 		RPCMaxConnectionIdle: "5m",
 		RPCTimeout:           "10s",
 		TCPAddress:           "/ip4/0.0.0.0/tcp/9161",
+		SignBlocks:           false,
+		RequireSignedBlocks:  false,
+		TrustedSigners:       "",
 	}
 }
 
@@ -425,6 +448,10 @@ func (cfg *Config) NodeConfig() node.NodeOpt {
 		}
 		opt.EnableRelay = cfg.Net.RelayEnabled
 		opt.EnablePubSub = cfg.Net.PubSubEnabled
+		opt.SignaturePolicy.RequireSignature = cfg.Net.RequireSignedBlocks
+		if len(cfg.Net.TrustedSigners) > 0 {
+			opt.SignaturePolicy.TrustedSigners = strings.Split(cfg.Net.TrustedSigners, ",")
+		}
 		opt.DataPath = cfg.Datastore.Badger.Path
 		opt.ConnManager, err = node.NewConnManager(100, 400, time.Second*20)
 		if err != nil {
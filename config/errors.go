@@ -49,6 +49,7 @@ const (
 	errInvalidDatastorePath        string = "invalid datastore path"
 	errMissingPortNumber           string = "missing port number"
 	errNoPortWithDomain            string = "cannot provide port with domain name"
+	errInvalidHistoryPruneInterval string = "invalid history prune interval"
 )
 
 var (
@@ -62,6 +63,7 @@ var (
 	ErrFailedToValidateConfig      = errors.New(errFailedToValidateConfig)
 	ErrInvalidRPCTimeout           = errors.New(errInvalidRPCTimeout)
 	ErrInvalidRPCMaxConnectionIdle = errors.New(errInvalidRPCMaxConnectionIdle)
+	ErrInvalidHistoryPruneInterval = errors.New(errInvalidHistoryPruneInterval)
 	ErrInvalidP2PAddress           = errors.New(errInvalidP2PAddress)
 	ErrInvalidRPCAddress           = errors.New(errInvalidRPCAddress)
 	ErrInvalidBootstrapPeers       = errors.New(errInvalidBootstrapPeers)
@@ -128,6 +130,10 @@ func NewErrInvalidRPCMaxConnectionIdle(inner error, timeout string) error {
 	return errors.Wrap(errInvalidRPCMaxConnectionIdle, inner, errors.NewKV("timeout", timeout))
 }
 
+func NewErrInvalidHistoryPruneInterval(inner error, interval string) error {
+	return errors.Wrap(errInvalidHistoryPruneInterval, inner, errors.NewKV("interval", interval))
+}
+
 func NewErrInvalidP2PAddress(inner error, address string) error {
 	return errors.Wrap(errInvalidP2PAddress, inner, errors.NewKV("address", address))
 }
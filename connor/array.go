@@ -0,0 +1,69 @@
+package connor
+
+import (
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// arrayItems returns the items of the given inline array.
+//
+// A nil value is treated as an empty array, and items without a value are returned as nil.
+func arrayItems(data any) ([]any, error) {
+	switch arr := data.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		return arr, nil
+	case []bool:
+		return toAnySlice(arr), nil
+	case []immutable.Option[bool]:
+		return optionsToAnySlice(arr), nil
+	case []int64:
+		return toAnySlice(arr), nil
+	case []immutable.Option[int64]:
+		return optionsToAnySlice(arr), nil
+	case []float64:
+		return toAnySlice(arr), nil
+	case []immutable.Option[float64]:
+		return optionsToAnySlice(arr), nil
+	case []string:
+		return toAnySlice(arr), nil
+	case []immutable.Option[string]:
+		return optionsToAnySlice(arr), nil
+	default:
+		return nil, client.NewErrUnhandledType("data", data)
+	}
+}
+
+func toAnySlice[T any](source []T) []any {
+	result := make([]any, len(source))
+	for i, item := range source {
+		result[i] = item
+	}
+	return result
+}
+
+func optionsToAnySlice[T any](source []immutable.Option[T]) []any {
+	result := make([]any, len(source))
+	for i, item := range source {
+		if item.HasValue() {
+			result[i] = item.Value()
+		}
+	}
+	return result
+}
+
+// containsItem returns true if any of the given items is equal to the given value.
+func containsItem(items []any, value any) (bool, error) {
+	for _, item := range items {
+		m, err := eq(value, item)
+		if err != nil {
+			return false, err
+		}
+		if m {
+			return true, nil
+		}
+	}
+	return false, nil
+}
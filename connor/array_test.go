@@ -0,0 +1,69 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayItemOperators(t *testing.T) {
+	data := []int64{1, 5, 10}
+	greaterThanFour := map[FilterKey]any{
+		testOperatorKey("_gt"): int64(4),
+	}
+
+	result, err := anyOp(greaterThanFour, data)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = all(greaterThanFour, data)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = none(greaterThanFour, data)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// operators on a nil array behave as if it were empty
+	result, err = anyOp(greaterThanFour, nil)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = all(greaterThanFour, nil)
+	require.NoError(t, err)
+	require.True(t, result)
+}
+
+func TestArrayValueOperators(t *testing.T) {
+	data := []immutable.Option[string]{
+		immutable.Some("urgent"),
+		immutable.None[string](),
+		immutable.Some("bug"),
+	}
+
+	result, err := contains([]any{"bug", "urgent"}, data)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = contains([]any{"bug", "feature"}, data)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = contains([]any{nil}, data)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = overlaps([]any{"feature", "bug"}, data)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = overlaps([]any{"feature"}, data)
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestArrayOperatorsWithNonArrayReturnsError(t *testing.T) {
+	_, err := overlaps([]any{"bug"}, "bug")
+	require.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package connor
+
+import "github.com/sourcenetwork/defradb/client"
+
+// contains is an operator which matches if an inline array
+// contains all of the given values.
+func contains(condition, data any) (bool, error) {
+	switch cn := condition.(type) {
+	case []any:
+		items, err := arrayItems(data)
+		if err != nil {
+			return false, err
+		}
+
+		for _, value := range cn {
+			m, err := containsItem(items, value)
+			if err != nil {
+				return false, err
+			}
+			if !m {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+}
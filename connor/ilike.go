@@ -0,0 +1,31 @@
+package connor
+
+import (
+	"strings"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// ilike is an operator which performs case insensitive
+// string equality tests.
+func ilike(condition, data any) (bool, error) {
+	switch arr := data.(type) {
+	case immutable.Option[string]:
+		if !arr.HasValue() {
+			return condition == nil, nil
+		}
+		data = arr.Value()
+	}
+
+	switch cn := condition.(type) {
+	case string:
+		if d, ok := data.(string); ok {
+			return like(strings.ToLower(cn), strings.ToLower(d))
+		}
+		return false, nil
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+}
@@ -12,3 +12,34 @@ type FilterKey interface {
 	// Equal returns true if other is equal, otherwise returns false.
 	Equal(other FilterKey) bool
 }
+
+// ObjectProperty is a FilterKey that represents a property of an object held within
+// a document value, such as a JSON value.
+//
+// It allows paths within such values to be filtered upon.
+// E.g. `{metadata: {author: {name: {_eq: "John"}}}}`
+type ObjectProperty struct {
+	// The name of the target property within its parent object.
+	Name string
+}
+
+var _ FilterKey = (*ObjectProperty)(nil)
+
+func (k *ObjectProperty) GetProp(data any) any {
+	object, ok := data.(map[string]any)
+	if !ok {
+		return nil
+	}
+	return object[k.Name]
+}
+
+func (k *ObjectProperty) GetOperatorOrDefault(defaultOp string) string {
+	return defaultOp
+}
+
+func (k *ObjectProperty) Equal(other FilterKey) bool {
+	if otherKey, isOk := other.(*ObjectProperty); isOk && *k == *otherKey {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectPropertyPaths(t *testing.T) {
+	data := map[string]any{
+		"tree": "maple",
+		"age":  uint64(250),
+		"location": map[string]any{
+			"city": "Toronto",
+		},
+	}
+
+	condition := map[FilterKey]any{
+		&ObjectProperty{Name: "location"}: map[FilterKey]any{
+			&ObjectProperty{Name: "city"}: map[FilterKey]any{
+				testOperatorKey("_eq"): "Toronto",
+			},
+		},
+		&ObjectProperty{Name: "age"}: map[FilterKey]any{
+			testOperatorKey("_gt"): int64(200),
+		},
+	}
+
+	result, err := Match(condition, data)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = Match(condition, map[string]any{"tree": "oak"})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// paths into values that are not objects match nil
+	result, err = Match(map[FilterKey]any{&ObjectProperty{Name: "tree"}: nil}, "maple")
+	require.NoError(t, err)
+	require.True(t, result)
+}
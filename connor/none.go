@@ -0,0 +1,12 @@
+package connor
+
+// none is an operator which matches if none of the items
+// of an inline array match the given conditions.
+func none(condition, data any) (bool, error) {
+	m, err := anyOp(condition, data)
+	if err != nil {
+		return false, err
+	}
+
+	return !m, nil
+}
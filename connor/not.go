@@ -0,0 +1,12 @@
+package connor
+
+// not is an operator which negates the result of
+// the given sub-conditions.
+func not(condition, data any) (bool, error) {
+	m, err := eq(condition, data)
+	if err != nil {
+		return false, err
+	}
+
+	return !m, nil
+}
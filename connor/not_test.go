@@ -0,0 +1,40 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testOperatorKey string
+
+func (k testOperatorKey) GetProp(data any) any {
+	return data
+}
+
+func (k testOperatorKey) GetOperatorOrDefault(defaultOp string) string {
+	return string(k)
+}
+
+func (k testOperatorKey) Equal(other FilterKey) bool {
+	return k == other
+}
+
+func TestNot(t *testing.T) {
+	condition := map[FilterKey]any{
+		testOperatorKey("_gt"): int64(10),
+	}
+
+	result, err := not(condition, int64(5))
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = not(condition, int64(15))
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// operators may be nested within `_not`
+	result, err = not(map[FilterKey]any{testOperatorKey("_not"): condition}, int64(15))
+	require.NoError(t, err)
+	require.True(t, result)
+}
@@ -0,0 +1,29 @@
+package connor
+
+import "github.com/sourcenetwork/defradb/client"
+
+// overlaps is an operator which matches if an inline array
+// contains any of the given values.
+func overlaps(condition, data any) (bool, error) {
+	switch cn := condition.(type) {
+	case []any:
+		items, err := arrayItems(data)
+		if err != nil {
+			return false, err
+		}
+
+		for _, value := range cn {
+			m, err := containsItem(items, value)
+			if err != nil {
+				return false, err
+			}
+			if m {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+}
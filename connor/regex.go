@@ -0,0 +1,52 @@
+package connor
+
+import (
+	"regexp"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// CompileRegex compiles the given `_regex` condition.
+//
+// Filters should hold the compiled expression as their `_regex` condition so that
+// it is not recompiled for every document that is matched against the filter.
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, NewErrInvalidRegex(pattern, err)
+	}
+	return re, nil
+}
+
+// regex is an operator which tests whether the data contains
+// a match of the regular expression given as condition.
+func regex(condition, data any) (bool, error) {
+	switch arr := data.(type) {
+	case immutable.Option[string]:
+		if !arr.HasValue() {
+			return condition == nil, nil
+		}
+		data = arr.Value()
+	}
+
+	var re *regexp.Regexp
+	switch cn := condition.(type) {
+	case *regexp.Regexp:
+		re = cn
+	case string:
+		var err error
+		re, err = CompileRegex(cn)
+		if err != nil {
+			return false, err
+		}
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+
+	if d, ok := data.(string); ok {
+		return re.MatchString(d), nil
+	}
+	return false, nil
+}
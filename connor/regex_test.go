@@ -0,0 +1,54 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegex(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	// partial match
+	result, err := regex("glue", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// anchored match
+	result, err = regex("^Source.*web[0-9]$", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// no match
+	result, err = regex("^glue", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// nil value
+	result, err = regex("glue", immutable.None[string]())
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// invalid expression
+	_, err = regex("(glue", testString)
+	require.ErrorIs(t, err, ErrInvalidRegex)
+}
+
+func TestRegexWithCompiledCondition(t *testing.T) {
+	re, err := CompileRegex("^Source.*web[0-9]$")
+	require.NoError(t, err)
+
+	result, err := regex(re, "Source is the glue of web3")
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = regex(re, "Source is the glue")
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestCompileRegexWithInvalidExpression(t *testing.T) {
+	_, err := CompileRegex("(glue")
+	require.ErrorIs(t, err, ErrInvalidRegex)
+}
@@ -15,9 +15,12 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
+	dshelp "github.com/ipfs/go-ipfs-ds-help"
 	ipld "github.com/ipfs/go-ipld-format"
 	dag "github.com/ipfs/go-merkledag"
 	"github.com/ugorji/go/codec"
@@ -32,6 +35,7 @@ import (
 var (
 	_ core.ReplicatedData = (*CompositeDAG)(nil)
 	_ core.CompositeDelta = (*CompositeDAGDelta)(nil)
+	_ core.SignedDelta    = (*CompositeDAGDelta)(nil)
 )
 
 // CompositeDAGDelta represents a delta-state update made of sub-MerkleCRDTs.
@@ -47,6 +51,8 @@ type CompositeDAGDelta struct {
 	// Status represents the status of the document. By default it is `Active`.
 	// Alternatively, if can be set to `Deleted`.
 	Status client.DocumentStatus
+	// Signature is the signature of the block holding this delta, nil if the block is unsigned.
+	Signature *core.Signature
 }
 
 // GetPriority gets the current priority for this delta.
@@ -59,6 +65,16 @@ func (delta *CompositeDAGDelta) SetPriority(prio uint64) {
 	delta.Priority = prio
 }
 
+// GetSignature returns the signature of the block holding this delta.
+func (delta *CompositeDAGDelta) GetSignature() *core.Signature {
+	return delta.Signature
+}
+
+// SetSignature sets the signature of the block holding this delta.
+func (delta *CompositeDAGDelta) SetSignature(signature *core.Signature) {
+	delta.Signature = signature
+}
+
 // Marshal will serialize this delta to a byte array.
 func (delta *CompositeDAGDelta) Marshal() ([]byte, error) {
 	h := &codec.CborHandle{}
@@ -70,7 +86,8 @@ func (delta *CompositeDAGDelta) Marshal() ([]byte, error) {
 		Data            []byte
 		DocKey          []byte
 		Status          uint8
-	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.Status.UInt8()})
+		Signature       *core.Signature `codec:",omitempty"`
+	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.Status.UInt8(), delta.Signature})
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +155,13 @@ func (c CompositeDAG) Set(patch []byte, links []core.DAGLink) *CompositeDAGDelta
 // It ensures that the object marker exists for the given key.
 // If it doesn't, it adds it to the store.
 func (c CompositeDAG) Merge(ctx context.Context, delta core.Delta, id string) error {
-	if dagDelta, ok := delta.(*CompositeDAGDelta); ok && dagDelta.Status.IsDeleted() {
+	dagDelta, isDAGDelta := delta.(*CompositeDAGDelta)
+	err := c.setCommitTime(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if isDAGDelta && dagDelta.Status.IsDeleted() {
 		err := c.store.Put(ctx, c.key.ToPrimaryDataStoreKey().ToDS(), []byte{base.DeletedObjectMarker})
 		if err != nil {
 			return err
@@ -153,12 +176,99 @@ func (c CompositeDAG) Merge(ctx context.Context, delta core.Delta, id string) er
 	}
 	if !exists {
 		// write object marker
-		return c.store.Put(ctx, c.key.ToPrimaryDataStoreKey().ToDS(), []byte{base.ObjectMarker})
+		err = c.store.Put(ctx, c.key.ToPrimaryDataStoreKey().ToDS(), []byte{base.ObjectMarker})
+		if err != nil {
+			return err
+		}
 	}
 
+	if isDAGDelta {
+		return c.setSchemaVersionID(ctx, dagDelta)
+	}
 	return nil
 }
 
+// setCommitTime records the local time at which the commit of the given id was merged, allowing
+// the state of the document at a point in time to be found.
+//
+// Commits merged more than once keep the time at which they were first merged.
+func (c CompositeDAG) setCommitTime(ctx context.Context, id string) error {
+	key := compositeCommitTimeKey(c.key, id)
+	exists, err := c.store.Has(ctx, key.ToDS())
+	if err != nil || exists {
+		return err
+	}
+	return c.store.Put(ctx, key.ToDS(), []byte(time.Now().UTC().Format(time.RFC3339Nano)))
+}
+
+// GetCommitTime returns the local time at which the composite commit of the given CID was merged
+// into the document of the given composite key.
+//
+// Returns false if no time has been recorded for the commit.
+func GetCommitTime(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	key core.DataStoreKey,
+	c cid.Cid,
+) (time.Time, bool, error) {
+	id := dshelp.MultihashToDsKey(c.Hash()).String()
+	buf, err := store.Get(ctx, compositeCommitTimeKey(key, id).ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, err
+	}
+	commitTime, err := time.Parse(time.RFC3339Nano, string(buf))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return commitTime, true, nil
+}
+
+// DeleteCommitTime deletes the time recorded for the composite commit of the given CID of the
+// document of the given composite key.
+func DeleteCommitTime(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	key core.DataStoreKey,
+	c cid.Cid,
+) error {
+	id := dshelp.MultihashToDsKey(c.Hash()).String()
+	return store.Delete(ctx, compositeCommitTimeKey(key, id).ToDS())
+}
+
+func compositeCommitTimeKey(key core.DataStoreKey, id string) core.DataStoreKey {
+	return key.WithCommitTimeFlag().WithFieldId(strings.TrimPrefix(id, "/"))
+}
+
+// setSchemaVersionID records the schema version the document was last written at, allowing
+// documents written at other versions to be migrated when read.
+//
+// Deltas merged after deltas of a higher priority, for example when syncing with a peer, do not
+// overwrite it.
+func (c CompositeDAG) setSchemaVersionID(ctx context.Context, delta *CompositeDAGDelta) error {
+	if delta.SchemaVersionID == "" {
+		return nil
+	}
+
+	priorities := newBaseCRDT(c.store, c.key)
+	curPrio, err := priorities.getPriority(ctx, c.key)
+	if err != nil {
+		return NewErrFailedToGetPriority(err)
+	}
+	if delta.Priority < curPrio {
+		return nil
+	}
+
+	versionKey := c.key.WithValueFlag().WithFieldId(core.DATASTORE_DOC_VERSION_FIELD_ID)
+	err = c.store.Put(ctx, versionKey.ToDS(), []byte(delta.SchemaVersionID))
+	if err != nil {
+		return err
+	}
+	return priorities.setPriority(ctx, c.key, delta.Priority)
+}
+
 func (c CompositeDAG) deleteWithPrefix(ctx context.Context, key core.DataStoreKey) error {
 	val, err := c.store.Get(ctx, key.ToDS())
 	if err != nil && !errors.Is(err, ds.ErrNotFound) {
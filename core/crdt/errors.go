@@ -17,6 +17,7 @@ import (
 const (
 	errFailedToGetPriority string = "failed to get priority"
 	errFailedToStoreValue  string = "failed to store value"
+	errInvalidCounterValue string = "counter values must be either an Int or a Float"
 )
 
 // Errors returnable from this package.
@@ -26,6 +27,7 @@ const (
 var (
 	ErrFailedToGetPriority = errors.New(errFailedToGetPriority)
 	ErrFailedToStoreValue  = errors.New(errFailedToStoreValue)
+	ErrInvalidCounterValue = errors.New(errInvalidCounterValue)
 	ErrEncodingPriority    = errors.New("error encoding priority")
 	ErrDecodingPriority    = errors.New("error decoding priority")
 	// ErrMismatchedMergeType - Tying to merge two ReplicatedData of different types
@@ -41,3 +43,9 @@ func NewErrFailedToGetPriority(inner error) error {
 func NewErrFailedToStoreValue(inner error) error {
 	return errors.Wrap(errFailedToStoreValue, inner)
 }
+
+// NewErrInvalidCounterValue returns an error indicating that the given value cannot be
+// stored in, or added to, a counter.
+func NewErrInvalidCounterValue(value any) error {
+	return errors.New(errInvalidCounterValue, errors.NewKV("Value", value))
+}
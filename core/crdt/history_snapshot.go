@@ -0,0 +1,167 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dag "github.com/ipfs/go-merkledag"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// HistorySnapshot holds the state of a document at a composite commit, the history preceding
+// which has been pruned.
+type HistorySnapshot struct {
+	// Commit is the CID of the composite commit the state is held at.
+	Commit cid.Cid
+
+	// Height is the height of the commit.
+	Height uint64
+
+	// Entries are the datastore entries holding the state of the document at the commit,
+	// keyed by their datastore key.
+	Entries map[string][]byte
+}
+
+// PrunedHeight returns the height of the latest commit that may have been pruned from the
+// history of the document.
+func (s HistorySnapshot) PrunedHeight() uint64 {
+	if s.Height == 0 {
+		return 0
+	}
+	return s.Height - 1
+}
+
+// SetHistorySnapshot writes the given snapshot to the blockstore as the snapshot of the history
+// of the document of the given key, replacing any existing snapshot of the document.
+func SetHistorySnapshot(
+	ctx context.Context,
+	store datastore.MultiStore,
+	key core.DataStoreKey,
+	snapshot HistorySnapshot,
+) error {
+	existingCid, hasExisting, err := getHistorySnapshotCid(ctx, store, key)
+	if err != nil {
+		return err
+	}
+
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err = enc.Encode(struct {
+		Commit  []byte
+		Height  uint64
+		Entries map[string][]byte
+	}{snapshot.Commit.Bytes(), snapshot.Height, snapshot.Entries})
+	if err != nil {
+		return err
+	}
+
+	nd := dag.NodeWithData(buf.Bytes())
+	err = nd.SetCidBuilder(cid.V1Builder{
+		Codec:    cid.DagProtobuf,
+		MhType:   mh.SHA2_256,
+		MhLength: -1,
+	})
+	if err != nil {
+		return err
+	}
+	err = store.DAGstore().Put(ctx, nd)
+	if err != nil {
+		return err
+	}
+
+	err = store.Datastore().Put(ctx, historySnapshotKey(key).ToDS(), nd.Cid().Bytes())
+	if err != nil {
+		return err
+	}
+
+	if hasExisting && existingCid != nd.Cid() {
+		return store.DAGstore().DeleteBlock(ctx, existingCid)
+	}
+	return nil
+}
+
+// GetHistorySnapshot returns the snapshot of the history of the document of the given key.
+//
+// Returns false if the history of the document has not been pruned.
+func GetHistorySnapshot(
+	ctx context.Context,
+	store datastore.MultiStore,
+	key core.DataStoreKey,
+) (HistorySnapshot, bool, error) {
+	snapshotCid, hasSnapshot, err := getHistorySnapshotCid(ctx, store, key)
+	if err != nil || !hasSnapshot {
+		return HistorySnapshot{}, false, err
+	}
+
+	block, err := store.DAGstore().Get(ctx, snapshotCid)
+	if err != nil {
+		return HistorySnapshot{}, false, err
+	}
+	nd, err := dag.DecodeProtobuf(block.RawData())
+	if err != nil {
+		return HistorySnapshot{}, false, err
+	}
+
+	var data struct {
+		Commit  []byte
+		Height  uint64
+		Entries map[string][]byte
+	}
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(nd.Data(), h)
+	err = dec.Decode(&data)
+	if err != nil {
+		return HistorySnapshot{}, false, err
+	}
+
+	commit, err := cid.Cast(data.Commit)
+	if err != nil {
+		return HistorySnapshot{}, false, err
+	}
+	return HistorySnapshot{
+		Commit:  commit,
+		Height:  data.Height,
+		Entries: data.Entries,
+	}, true, nil
+}
+
+func getHistorySnapshotCid(
+	ctx context.Context,
+	store datastore.MultiStore,
+	key core.DataStoreKey,
+) (cid.Cid, bool, error) {
+	buf, err := store.Datastore().Get(ctx, historySnapshotKey(key).ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return cid.Undef, false, nil
+		}
+		return cid.Undef, false, err
+	}
+	snapshotCid, err := cid.Cast(buf)
+	if err != nil {
+		return cid.Undef, false, err
+	}
+	return snapshotCid, true, nil
+}
+
+func historySnapshotKey(key core.DataStoreKey) core.DataStoreKey {
+	return key.WithHistorySnapshotFlag().WithFieldId("")
+}
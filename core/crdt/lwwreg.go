@@ -32,6 +32,7 @@ var (
 	// ensure types implements core interfaces
 	_ core.ReplicatedData = (*LWWRegister)(nil)
 	_ core.Delta          = (*LWWRegDelta)(nil)
+	_ core.SignedDelta    = (*LWWRegDelta)(nil)
 )
 
 // LWWRegDelta is a single delta operation for an LWWRegister
@@ -41,6 +42,8 @@ type LWWRegDelta struct {
 	Priority        uint64
 	Data            []byte
 	DocKey          []byte
+	// Signature is the signature of the block holding this delta, nil if the block is unsigned.
+	Signature *core.Signature
 }
 
 // GetPriority gets the current priority for this delta.
@@ -53,6 +56,16 @@ func (delta *LWWRegDelta) SetPriority(prio uint64) {
 	delta.Priority = prio
 }
 
+// GetSignature returns the signature of the block holding this delta.
+func (delta *LWWRegDelta) GetSignature() *core.Signature {
+	return delta.Signature
+}
+
+// SetSignature sets the signature of the block holding this delta.
+func (delta *LWWRegDelta) SetSignature(signature *core.Signature) {
+	delta.Signature = signature
+}
+
 // Marshal encodes the delta using CBOR.
 // for now le'ts do cbor (quick to implement)
 func (delta *LWWRegDelta) Marshal() ([]byte, error) {
@@ -64,7 +77,8 @@ func (delta *LWWRegDelta) Marshal() ([]byte, error) {
 		Priority        uint64
 		Data            []byte
 		DocKey          []byte
-	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey})
+		Signature       *core.Signature `codec:",omitempty"`
+	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.Signature})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,340 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+
+	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	dag "github.com/ipfs/go-merkledag"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// pnCounterNonceLength is the number of random bytes added to each counter delta.
+const pnCounterNonceLength = 8
+
+var (
+	// ensure types implements core interfaces
+	_ core.ReplicatedData = (*PNCounter)(nil)
+	_ core.Delta          = (*PNCounterDelta)(nil)
+	_ core.SignedDelta    = (*PNCounterDelta)(nil)
+)
+
+// PNCounterDelta is a single increment (or decrement, if negative) of a PNCounter.
+type PNCounterDelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	// Nonce ensures that two identical increments made concurrently on different
+	// replicas result in different blocks, and are thus both applied.
+	Nonce []byte
+	// Data is the CBOR encoded increment.
+	Data   []byte
+	DocKey []byte
+	// Signature is the signature of the block holding this delta, nil if the block is unsigned.
+	Signature *core.Signature
+}
+
+// GetPriority gets the current priority for this delta.
+func (delta *PNCounterDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *PNCounterDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// GetSignature returns the signature of the block holding this delta.
+func (delta *PNCounterDelta) GetSignature() *core.Signature {
+	return delta.Signature
+}
+
+// SetSignature sets the signature of the block holding this delta.
+func (delta *PNCounterDelta) SetSignature(signature *core.Signature) {
+	delta.Signature = signature
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *PNCounterDelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Nonce           []byte
+		Data            []byte
+		DocKey          []byte
+		Signature       *core.Signature `codec:",omitempty"`
+	}{delta.SchemaVersionID, delta.Priority, delta.Nonce, delta.Data, delta.DocKey, delta.Signature})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *PNCounterDelta) Value() any {
+	return delta.Data
+}
+
+// PNCounter, Positive-Negative Counter, is a numeric CRDT type that converges by
+// summing all of the increments and decrements applied to it, in any order.
+//
+// Int and Float values are supported.
+type PNCounter struct {
+	baseCRDT
+
+	// schemaVersionKey is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at time of commit.
+	schemaVersionKey core.CollectionSchemaVersionKey
+}
+
+// NewPNCounter returns a new instance of the PNCounter with the given ID.
+func NewPNCounter(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+) PNCounter {
+	return PNCounter{
+		baseCRDT:         newBaseCRDT(store, key),
+		schemaVersionKey: schemaVersionKey,
+	}
+}
+
+// Value gets the current counter value.
+func (counter PNCounter) Value(ctx context.Context) ([]byte, error) {
+	valueK := counter.key.WithValueFlag()
+	buf, err := counter.store.Get(ctx, valueK.ToDS())
+	if err != nil {
+		return nil, err
+	}
+	// ignore the first byte (CRDT Type marker) from the returned value
+	buf = buf[1:]
+	return buf, nil
+}
+
+// Increment generates a new delta that adds the given CBOR encoded value to the counter.
+//
+// Negative values decrement the counter.
+func (counter PNCounter) Increment(value []byte) (*PNCounterDelta, error) {
+	_, err := decodeCounterValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, pnCounterNonceLength)
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PNCounterDelta{
+		Nonce:           nonce,
+		Data:            value,
+		DocKey:          []byte(counter.key.DocKey),
+		SchemaVersionID: counter.schemaVersionKey.SchemaVersionId,
+	}, nil
+}
+
+// Set generates a new delta that moves the counter from its current local value to
+// the given CBOR encoded value.
+//
+// Concurrent calls on different replicas are merged as increments, not overwrites.
+func (counter PNCounter) Set(ctx context.Context, value []byte) (*PNCounterDelta, error) {
+	newValue, err := decodeCounterValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := counter.valueKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := counter.getValue(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	increment, err := cbor.Marshal(subtractCounterValues(newValue, current))
+	if err != nil {
+		return nil, err
+	}
+	return counter.Increment(increment)
+}
+
+func (counter PNCounter) ID() string {
+	return counter.key.ToString()
+}
+
+// Merge implements ReplicatedData interface.
+//
+// Merge adds the increment carried by the delta to the current value, which makes
+// it commutative.
+func (counter PNCounter) Merge(ctx context.Context, delta core.Delta, id string) error {
+	d, ok := delta.(*PNCounterDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	increment, err := decodeCounterValue(d.Data)
+	if err != nil {
+		return err
+	}
+
+	key, err := counter.valueKey(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := counter.getValue(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	val, err := cbor.Marshal(addCounterValues(current, increment))
+	if err != nil {
+		return err
+	}
+
+	// prepend the value byte array with a single byte indicator for the CRDT Type.
+	buf := append([]byte{byte(client.PN_COUNTER)}, val...)
+	err = counter.store.Put(ctx, key.ToDS(), buf)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+
+	curPrio, err := counter.getPriority(ctx, counter.key)
+	if err != nil {
+		return NewErrFailedToGetPriority(err)
+	}
+	if d.GetPriority() <= curPrio {
+		return nil
+	}
+	return counter.setPriority(ctx, counter.key, d.GetPriority())
+}
+
+// valueKey returns the key that the counter value is stored under, taking into account
+// whether the document has been deleted.
+func (counter PNCounter) valueKey(ctx context.Context) (core.DataStoreKey, error) {
+	key := counter.key.WithValueFlag()
+	marker, err := counter.store.Get(ctx, counter.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return core.DataStoreKey{}, err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		key = key.WithDeletedFlag()
+	}
+	return key, nil
+}
+
+// getValue returns the decoded value stored at the given key, or nil if there is none.
+func (counter PNCounter) getValue(ctx context.Context, key core.DataStoreKey) (any, error) {
+	buf, err := counter.store.Get(ctx, key.ToDS())
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) <= 1 {
+		return nil, nil
+	}
+	return decodeCounterValue(buf[1:])
+}
+
+// DeltaDecode is a typed helper to extract
+// a PNCounterDelta from a ipld.Node
+func (counter PNCounter) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &PNCounterDelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// decodeCounterValue decodes the given CBOR encoded number into either an int64 or a float64.
+func decodeCounterValue(buf []byte) (any, error) {
+	var val any
+	err := cbor.Unmarshal(buf, &val)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typedVal := val.(type) {
+	case uint64:
+		return int64(typedVal), nil
+	case int64:
+		return typedVal, nil
+	case float64:
+		return typedVal, nil
+	default:
+		return nil, NewErrInvalidCounterValue(val)
+	}
+}
+
+// addCounterValues returns the sum of the two given values, a nil value is treated as zero.
+//
+// The result is an int64 if both values are integers, otherwise it is a float64.
+func addCounterValues(a any, b any) any {
+	aInt, aIsInt := a.(int64)
+	bInt, bIsInt := b.(int64)
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case aIsInt && bIsInt:
+		return aInt + bInt
+	default:
+		return counterValueToFloat(a) + counterValueToFloat(b)
+	}
+}
+
+// subtractCounterValues returns the value of a minus b, a nil value is treated as zero.
+func subtractCounterValues(a any, b any) any {
+	switch typedB := b.(type) {
+	case int64:
+		return addCounterValues(a, -typedB)
+	case float64:
+		return addCounterValues(a, -typedB)
+	default:
+		return a
+	}
+}
+
+func counterValueToFloat(val any) float64 {
+	switch typedVal := val.(type) {
+	case int64:
+		return float64(typedVal)
+	case float64:
+		return typedVal
+	default:
+		return 0
+	}
+}
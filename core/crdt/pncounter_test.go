@@ -0,0 +1,141 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupPNCounter() PNCounter {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewPNCounter(store, core.CollectionSchemaVersionKey{}, key)
+}
+
+func mustEncodeCounterValue(t *testing.T, val any) []byte {
+	buf, err := cbor.Marshal(val)
+	require.NoError(t, err)
+	return buf
+}
+
+func requireCounterValue(ctx context.Context, t *testing.T, counter PNCounter, expected any) {
+	buf, err := counter.Value(ctx)
+	require.NoError(t, err)
+
+	val, err := decodeCounterValue(buf)
+	require.NoError(t, err)
+	require.Equal(t, expected, val)
+}
+
+func TestPNCounterIncrementMerge(t *testing.T) {
+	ctx := context.Background()
+	counter := setupPNCounter()
+
+	for _, increment := range []int64{5, -2, 10} {
+		delta, err := counter.Increment(mustEncodeCounterValue(t, increment))
+		require.NoError(t, err)
+
+		err = counter.Merge(ctx, delta, "test")
+		require.NoError(t, err)
+	}
+
+	requireCounterValue(ctx, t, counter, int64(13))
+}
+
+func TestPNCounterMergeIsCommutative(t *testing.T) {
+	ctx := context.Background()
+	source := setupPNCounter()
+
+	deltas := []*PNCounterDelta{}
+	for _, increment := range []int64{1, 1, -3, 7} {
+		delta, err := source.Increment(mustEncodeCounterValue(t, increment))
+		require.NoError(t, err)
+		deltas = append(deltas, delta)
+	}
+
+	forward := setupPNCounter()
+	backward := setupPNCounter()
+	for i := range deltas {
+		err := forward.Merge(ctx, deltas[i], "test")
+		require.NoError(t, err)
+
+		err = backward.Merge(ctx, deltas[len(deltas)-1-i], "test")
+		require.NoError(t, err)
+	}
+
+	requireCounterValue(ctx, t, forward, int64(6))
+	requireCounterValue(ctx, t, backward, int64(6))
+}
+
+func TestPNCounterIdenticalIncrementsProduceDistinctDeltas(t *testing.T) {
+	counter := setupPNCounter()
+
+	delta1, err := counter.Increment(mustEncodeCounterValue(t, int64(1)))
+	require.NoError(t, err)
+	delta2, err := counter.Increment(mustEncodeCounterValue(t, int64(1)))
+	require.NoError(t, err)
+
+	marshalled1, err := delta1.Marshal()
+	require.NoError(t, err)
+	marshalled2, err := delta2.Marshal()
+	require.NoError(t, err)
+
+	require.NotEqual(t, marshalled1, marshalled2)
+}
+
+func TestPNCounterSetPublishesDifference(t *testing.T) {
+	ctx := context.Background()
+	counter := setupPNCounter()
+
+	delta, err := counter.Increment(mustEncodeCounterValue(t, int64(3)))
+	require.NoError(t, err)
+	err = counter.Merge(ctx, delta, "test")
+	require.NoError(t, err)
+
+	delta, err = counter.Set(ctx, mustEncodeCounterValue(t, int64(10)))
+	require.NoError(t, err)
+
+	increment, err := decodeCounterValue(delta.Data)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), increment)
+
+	err = counter.Merge(ctx, delta, "test")
+	require.NoError(t, err)
+	requireCounterValue(ctx, t, counter, int64(10))
+}
+
+func TestPNCounterFloatIncrementMerge(t *testing.T) {
+	ctx := context.Background()
+	counter := setupPNCounter()
+
+	for _, increment := range []float64{1.5, -0.25} {
+		delta, err := counter.Increment(mustEncodeCounterValue(t, increment))
+		require.NoError(t, err)
+
+		err = counter.Merge(ctx, delta, "test")
+		require.NoError(t, err)
+	}
+
+	requireCounterValue(ctx, t, counter, float64(1.25))
+}
+
+func TestPNCounterIncrementWithNonNumberReturnsError(t *testing.T) {
+	counter := setupPNCounter()
+
+	_, err := counter.Increment(mustEncodeCounterValue(t, "one"))
+	require.ErrorIs(t, err, ErrInvalidCounterValue)
+}
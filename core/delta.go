@@ -29,6 +29,21 @@ type CompositeDelta interface {
 	Links() []DAGLink
 }
 
+// SignedDelta represents a delta that may be signed by the node that created it.
+type SignedDelta interface {
+	Delta
+	GetSignature() *Signature
+	SetSignature(*Signature)
+}
+
+// Signature is the signature of a block, proving the identity of the node that created it.
+type Signature struct {
+	// PublicKey is the marshalled public key of the signer.
+	PublicKey []byte
+	// Value is the signature of the block payload, excluding the signature itself.
+	Value []byte
+}
+
 type NetDelta interface {
 	Delta
 	GetSchemaID() string
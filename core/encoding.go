@@ -0,0 +1,174 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"time"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// The leading byte of an encoded index value, it ensures that values of different
+// types never compare equal and that nil values always sort first.
+const (
+	indexValueNilTag    byte = 0x00
+	indexValueBoolTag   byte = 0x01
+	indexValueNumberTag byte = 0x02
+	indexValueStringTag byte = 0x03
+	indexValueTimeTag   byte = 0x04
+)
+
+const (
+	indexValueEscape        byte = 0x00
+	indexValueEscapedNull   byte = 0xff
+	indexValueStringEndMark byte = 0x01
+	indexValueSignBitMask64      = uint64(1) << 63
+)
+
+// EncodeIndexFieldValue encodes the given value of a field of the given kind into a string
+// whose lexicographic ordering matches the ordering of the original values in the given direction.
+//
+// Ints and floats share a single (float64) representation so that they may be compared
+// against each other. Values that cannot be represented exactly, such as very large ints,
+// may share an encoding with their neighbours, callers must therefore treat a match as a
+// candidate only.
+//
+// The result is hex encoded so that it may be safely used as a key segment.
+func EncodeIndexFieldValue(
+	kind client.FieldKind,
+	value any,
+	direction client.IndexDirection,
+) (string, error) {
+	buf, err := encodeIndexFieldValue(kind, value)
+	if err != nil {
+		return "", err
+	}
+
+	if direction == client.Descending {
+		for i := range buf {
+			buf[i] = ^buf[i]
+		}
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+func encodeIndexFieldValue(kind client.FieldKind, value any) ([]byte, error) {
+	if value == nil {
+		return []byte{indexValueNilTag}, nil
+	}
+
+	switch kind {
+	case client.FieldKind_BOOL:
+		if v, ok := value.(bool); ok {
+			if v {
+				return []byte{indexValueBoolTag, 1}, nil
+			}
+			return []byte{indexValueBoolTag, 0}, nil
+		}
+
+	case client.FieldKind_INT, client.FieldKind_FLOAT:
+		if v, ok := toFloat64(value); ok {
+			return encodeIndexFloat(v), nil
+		}
+
+	case client.FieldKind_STRING, client.FieldKind_DocKey:
+		if v, ok := value.(string); ok {
+			return encodeIndexString(v), nil
+		}
+
+	case client.FieldKind_DATETIME:
+		switch v := value.(type) {
+		case time.Time:
+			return encodeIndexTime(v), nil
+		case string:
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				// Values that are not valid date times are still indexed, they
+				// can only be found by exact matches.
+				return encodeIndexString(v), nil
+			}
+			return encodeIndexTime(t), nil
+		}
+
+	default:
+		return nil, NewErrUnsupportedIndexFieldKind(kind)
+	}
+
+	return nil, client.NewErrUnhandledType("value", value)
+}
+
+func encodeIndexFloat(v float64) []byte {
+	if v == 0 {
+		// Make sure that negative zero is encoded the same as positive zero.
+		v = 0
+	}
+
+	bits := math.Float64bits(v)
+	if bits&indexValueSignBitMask64 == 0 {
+		bits ^= indexValueSignBitMask64
+	} else {
+		bits = ^bits
+	}
+
+	buf := make([]byte, 9)
+	buf[0] = indexValueNumberTag
+	binary.BigEndian.PutUint64(buf[1:], bits)
+	return buf
+}
+
+func encodeIndexString(v string) []byte {
+	buf := make([]byte, 0, len(v)+3)
+	buf = append(buf, indexValueStringTag)
+	for i := 0; i < len(v); i++ {
+		buf = append(buf, v[i])
+		if v[i] == indexValueEscape {
+			buf = append(buf, indexValueEscapedNull)
+		}
+	}
+	// The end mark makes sure that a string sorts before all strings that it is a prefix of,
+	// regardless of the direction of the index.
+	return append(buf, indexValueEscape, indexValueStringEndMark)
+}
+
+func encodeIndexTime(v time.Time) []byte {
+	buf := make([]byte, 13)
+	buf[0] = indexValueTimeTag
+	binary.BigEndian.PutUint64(buf[1:], uint64(v.Unix())^indexValueSignBitMask64)
+	binary.BigEndian.PutUint32(buf[9:], uint32(v.Nanosecond()))
+	return buf
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestEncodeIndexFieldValue_PreservesNumberOrder(t *testing.T) {
+	values := []any{math.Inf(-1), int64(-100), -1.5, int64(-1), 0, 0.25, int64(1), 3.5, int64(100), math.Inf(1)}
+
+	assertEncodingPreservesOrder(t, client.FieldKind_FLOAT, values)
+}
+
+func TestEncodeIndexFieldValue_PreservesStringOrder(t *testing.T) {
+	values := []any{"", "a", "a\x00", "a\x00b", "ab", "b", "ba"}
+
+	assertEncodingPreservesOrder(t, client.FieldKind_STRING, values)
+}
+
+func TestEncodeIndexFieldValue_PreservesTimeOrder(t *testing.T) {
+	values := []any{
+		"1969-12-31T23:59:59Z",
+		"2017-07-23T03:46:56.647Z",
+		time.Date(2017, 7, 23, 3, 46, 57, 0, time.UTC),
+		"2021-01-01T00:00:00Z",
+	}
+
+	assertEncodingPreservesOrder(t, client.FieldKind_DATETIME, values)
+}
+
+func TestEncodeIndexFieldValue_NilSortsFirst(t *testing.T) {
+	values := []any{nil, false, true}
+
+	assertEncodingPreservesOrder(t, client.FieldKind_BOOL, values)
+}
+
+func TestEncodeIndexFieldValue_IntAndFloatEncodeTheSame(t *testing.T) {
+	intValue, err := EncodeIndexFieldValue(client.FieldKind_INT, int64(5), client.Ascending)
+	require.NoError(t, err)
+
+	floatValue, err := EncodeIndexFieldValue(client.FieldKind_INT, 5.0, client.Ascending)
+	require.NoError(t, err)
+
+	assert.Equal(t, intValue, floatValue)
+}
+
+func TestEncodeIndexFieldValue_WithDescendingDirection_ReversesOrder(t *testing.T) {
+	values := []any{int64(-1), int64(0), int64(1)}
+
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		var err error
+		encoded[i], err = EncodeIndexFieldValue(client.FieldKind_INT, value, client.Descending)
+		require.NoError(t, err)
+	}
+
+	assert.True(t, encoded[0] > encoded[1])
+	assert.True(t, encoded[1] > encoded[2])
+}
+
+func TestEncodeIndexFieldValue_WithUnsupportedKind_ReturnsError(t *testing.T) {
+	_, err := EncodeIndexFieldValue(client.FieldKind_FOREIGN_OBJECT, "value", client.Ascending)
+
+	assert.ErrorIs(t, err, ErrUnsupportedIndexFieldKind)
+}
+
+func TestEncodeIndexFieldValue_WithMismatchingValueType_ReturnsError(t *testing.T) {
+	_, err := EncodeIndexFieldValue(client.FieldKind_INT, "value", client.Ascending)
+
+	assert.Error(t, err)
+}
+
+func assertEncodingPreservesOrder(t *testing.T, kind client.FieldKind, values []any) {
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		var err error
+		encoded[i], err = EncodeIndexFieldValue(kind, value, client.Ascending)
+		require.NoError(t, err)
+	}
+
+	assert.True(t, sort.StringsAreSorted(encoded))
+	for i := 1; i < len(encoded); i++ {
+		assert.NotEqual(t, encoded[i-1], encoded[i])
+	}
+}
@@ -11,20 +11,37 @@
 package core
 
 import (
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/errors"
 )
 
 const (
-	errFailedToGetFieldIdOfKey string = "failed to get FieldID of Key"
+	errFailedToGetFieldIdOfKey   string = "failed to get FieldID of Key"
+	errUnsupportedIndexFieldKind string = "field kind is not supported by indexes"
+	errInvalidIndexDataStoreKey  string = "invalid index datastore key"
 )
 
 var (
-	ErrFailedToGetFieldIdOfKey = errors.New(errFailedToGetFieldIdOfKey)
-	ErrEmptyKey                = errors.New("received empty key string")
-	ErrInvalidKey              = errors.New("invalid key string")
+	ErrFailedToGetFieldIdOfKey   = errors.New(errFailedToGetFieldIdOfKey)
+	ErrEmptyKey                  = errors.New("received empty key string")
+	ErrInvalidKey                = errors.New("invalid key string")
+	ErrUnsupportedIndexFieldKind = errors.New(errUnsupportedIndexFieldKind)
+	ErrInvalidIndexDataStoreKey  = errors.New(errInvalidIndexDataStoreKey)
 )
 
 // NewErrFailedToGetFieldIdOfKey returns the error indicating failure to get FieldID of Key.
 func NewErrFailedToGetFieldIdOfKey(inner error) error {
 	return errors.Wrap(errFailedToGetFieldIdOfKey, inner)
 }
+
+// NewErrUnsupportedIndexFieldKind returns the error indicating that values of the given field kind
+// cannot be encoded into an index key.
+func NewErrUnsupportedIndexFieldKind(kind client.FieldKind) error {
+	return errors.New(errUnsupportedIndexFieldKind, errors.NewKV("Kind", kind))
+}
+
+// NewErrInvalidIndexDataStoreKey returns the error indicating that the given key is not a valid
+// index datastore key.
+func NewErrInvalidIndexDataStoreKey(key string) error {
+	return errors.New(errInvalidIndexDataStoreKey, errors.NewKV("Key", key))
+}
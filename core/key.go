@@ -38,12 +38,19 @@ const (
 	PriorityKey = InstanceType("p")
 	// DeletedKey is a type that represents a deleted document.
 	DeletedKey = InstanceType("d")
+	// CommitTimeKey is a type that represents the local time at which a commit was merged.
+	CommitTimeKey = InstanceType("t")
+	// HistorySnapshotKey is a type that represents the snapshot of a document's pruned history.
+	HistorySnapshotKey = InstanceType("s")
 )
 
 const (
 	COLLECTION                = "/collection/names"
 	COLLECTION_SCHEMA         = "/collection/schema"
 	COLLECTION_SCHEMA_VERSION = "/collection/version"
+	COLLECTION_INDEX          = "/collection/index"
+	COLLECTION_HISTORY        = "/collection/history"
+	SCHEMA_MIGRATION          = "/schema/migration"
 	SEQ                       = "/seq"
 	PRIMARY_KEY               = "/pk"
 	REPLICATOR                = "/replicator/id"
@@ -106,6 +113,46 @@ type CollectionSchemaVersionKey struct {
 
 var _ Key = (*CollectionSchemaVersionKey)(nil)
 
+// CollectionIndexKey points to the description of a secondary index
+// of the collection of the given name.
+type CollectionIndexKey struct {
+	CollectionName string
+	IndexName      string
+}
+
+var _ Key = (*CollectionIndexKey)(nil)
+
+// SchemaVersionMigrationKey points to the migration transforming documents from the
+// source schema version to the destination schema version.
+type SchemaVersionMigrationKey struct {
+	SourceSchemaVersionID      string
+	DestinationSchemaVersionID string
+}
+
+var _ Key = (*SchemaVersionMigrationKey)(nil)
+
+// CollectionHistoryPolicyKey points to the history policy of the collection of the given name.
+type CollectionHistoryPolicyKey struct {
+	CollectionName string
+}
+
+var _ Key = (*CollectionHistoryPolicyKey)(nil)
+
+// IndexDataStoreKey is the key of a secondary index entry in the datastore.
+//
+// It is stored alongside the document values of the collection, but does not
+// clash with them as index IDs are numeric and instance types are not.
+type IndexDataStoreKey struct {
+	CollectionID uint32
+	IndexID      uint32
+	// FieldValues contains the encoded values of the indexed fields, see
+	// [EncodeIndexFieldValue].
+	FieldValues []string
+	DocKey      string
+}
+
+var _ Key = (*IndexDataStoreKey)(nil)
+
 type P2PCollectionKey struct {
 	CollectionID string
 }
@@ -210,6 +257,43 @@ func NewCollectionSchemaVersionKey(schemaVersionId string) CollectionSchemaVersi
 	return CollectionSchemaVersionKey{SchemaVersionId: schemaVersionId}
 }
 
+// NewCollectionIndexKey returns a key pointing to the description of the index of the given
+// name, on the collection of the given name.
+//
+// If the index name is empty the key will point to all the indexes of the collection.
+func NewCollectionIndexKey(collectionName, indexName string) CollectionIndexKey {
+	return CollectionIndexKey{CollectionName: collectionName, IndexName: indexName}
+}
+
+// NewIndexDataStoreKey creates a new IndexDataStoreKey from a string as best as it can,
+// splitting the input using '/' as a field deliminator.  It assumes that the input
+// string is in the following format:
+//
+// /[CollectionID]/[IndexID]/[FieldValue](/[FieldValue]...)/[DocKey]
+func NewIndexDataStoreKey(key string) (IndexDataStoreKey, error) {
+	indexKey := IndexDataStoreKey{}
+	elements := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	if len(elements) < 4 {
+		return indexKey, NewErrInvalidIndexDataStoreKey(key)
+	}
+
+	colID, err := strconv.ParseUint(elements[0], 10, 32)
+	if err != nil {
+		return indexKey, NewErrInvalidIndexDataStoreKey(key)
+	}
+	indexID, err := strconv.ParseUint(elements[1], 10, 32)
+	if err != nil {
+		return indexKey, NewErrInvalidIndexDataStoreKey(key)
+	}
+
+	indexKey.CollectionID = uint32(colID)
+	indexKey.IndexID = uint32(indexID)
+	indexKey.FieldValues = elements[2 : len(elements)-1]
+	indexKey.DocKey = elements[len(elements)-1]
+
+	return indexKey, nil
+}
+
 func NewSequenceKey(name string) SequenceKey {
 	return SequenceKey{SequenceName: name}
 }
@@ -232,6 +316,18 @@ func (k DataStoreKey) WithDeletedFlag() DataStoreKey {
 	return newKey
 }
 
+func (k DataStoreKey) WithCommitTimeFlag() DataStoreKey {
+	newKey := k
+	newKey.InstanceType = CommitTimeKey
+	return newKey
+}
+
+func (k DataStoreKey) WithHistorySnapshotFlag() DataStoreKey {
+	newKey := k
+	newKey.InstanceType = HistorySnapshotKey
+	return newKey
+}
+
 func (k DataStoreKey) WithDocKey(docKey string) DataStoreKey {
 	newKey := k
 	newKey.DocKey = docKey
@@ -419,6 +515,120 @@ func (k SequenceKey) ToDS() ds.Key {
 	return ds.NewKey(k.ToString())
 }
 
+func (k CollectionIndexKey) ToString() string {
+	result := COLLECTION_INDEX
+
+	if k.CollectionName != "" {
+		result = result + "/" + k.CollectionName
+		if k.IndexName != "" {
+			result = result + "/" + k.IndexName
+		}
+	}
+
+	return result
+}
+
+func (k CollectionIndexKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k CollectionIndexKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+// NewSchemaVersionMigrationKey returns a key pointing to the migration from the given source
+// schema version to the given destination schema version.
+//
+// If the source version is empty the key will point to all the migrations.
+func NewSchemaVersionMigrationKey(sourceSchemaVersionID, destinationSchemaVersionID string) SchemaVersionMigrationKey {
+	return SchemaVersionMigrationKey{
+		SourceSchemaVersionID:      sourceSchemaVersionID,
+		DestinationSchemaVersionID: destinationSchemaVersionID,
+	}
+}
+
+func (k SchemaVersionMigrationKey) ToString() string {
+	result := SCHEMA_MIGRATION
+
+	if k.SourceSchemaVersionID != "" {
+		result = result + "/" + k.SourceSchemaVersionID
+		if k.DestinationSchemaVersionID != "" {
+			result = result + "/" + k.DestinationSchemaVersionID
+		}
+	}
+
+	return result
+}
+
+func (k SchemaVersionMigrationKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k SchemaVersionMigrationKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+// NewCollectionHistoryPolicyKey returns a key pointing to the history policy of the collection
+// of the given name.
+//
+// If the name is empty the key will point to the policies of all the collections.
+func NewCollectionHistoryPolicyKey(collectionName string) CollectionHistoryPolicyKey {
+	return CollectionHistoryPolicyKey{CollectionName: collectionName}
+}
+
+func (k CollectionHistoryPolicyKey) ToString() string {
+	result := COLLECTION_HISTORY
+
+	if k.CollectionName != "" {
+		result = result + "/" + k.CollectionName
+	}
+
+	return result
+}
+
+func (k CollectionHistoryPolicyKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k CollectionHistoryPolicyKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+// ToString returns the string representation of the key, omitting any trailing
+// empty properties.
+func (k IndexDataStoreKey) ToString() string {
+	if k.CollectionID == 0 {
+		return ""
+	}
+	result := "/" + strconv.FormatUint(uint64(k.CollectionID), 10)
+
+	if k.IndexID == 0 {
+		return result
+	}
+	result = result + "/" + strconv.FormatUint(uint64(k.IndexID), 10)
+
+	for _, value := range k.FieldValues {
+		if value == "" {
+			return result
+		}
+		result = result + "/" + value
+	}
+
+	if k.DocKey != "" {
+		result = result + "/" + k.DocKey
+	}
+
+	return result
+}
+
+func (k IndexDataStoreKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k IndexDataStoreKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
 // New
 func NewP2PCollectionKey(collectionID string) P2PCollectionKey {
 	return P2PCollectionKey{CollectionID: collectionID}
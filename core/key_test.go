@@ -107,3 +107,45 @@ func TestNewDataStoreKey_GivenAStringWithExtraSuffix(t *testing.T) {
 
 	assert.ErrorIs(t, ErrInvalidKey, err)
 }
+
+func TestNewIndexDataStoreKey_ReturnsKey_GivenValidString(t *testing.T) {
+	inputString := "/1/2/a1/b2/docKey"
+
+	result, err := NewIndexDataStoreKey(inputString)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(
+		t,
+		IndexDataStoreKey{
+			CollectionID: 1,
+			IndexID:      2,
+			FieldValues:  []string{"a1", "b2"},
+			DocKey:       "docKey",
+		},
+		result,
+	)
+	assert.Equal(t, inputString, result.ToString())
+}
+
+func TestNewIndexDataStoreKey_ReturnsError_GivenStringWithMissingElements(t *testing.T) {
+	_, err := NewIndexDataStoreKey("/1/2/docKey")
+
+	assert.ErrorIs(t, err, ErrInvalidIndexDataStoreKey)
+}
+
+func TestNewIndexDataStoreKey_ReturnsError_GivenNonNumericIDs(t *testing.T) {
+	_, err := NewIndexDataStoreKey("/col/2/a1/docKey")
+
+	assert.ErrorIs(t, err, ErrInvalidIndexDataStoreKey)
+}
+
+func TestIndexDataStoreKeyToString_OmitsEmptyTrailingParts(t *testing.T) {
+	key := IndexDataStoreKey{
+		CollectionID: 1,
+		IndexID:      2,
+	}
+
+	assert.Equal(t, "/1/2", key.ToString())
+}
@@ -42,10 +42,12 @@ type Parser interface {
 	IsIntrospection(*ast.Document) bool
 
 	// Executes the given introspection request.
-	ExecuteIntrospection(request string) *client.RequestResult
+	ExecuteIntrospection(request string, options client.RequestOptions) *client.RequestResult
 
 	// Parses the given request, returning a strongly typed model of that request.
-	Parse(*ast.Document) (*request.Request, []error)
+	//
+	// Any variables referenced by the request are resolved using the given options.
+	Parse(ast *ast.Document, options client.RequestOptions) (*request.Request, []error)
 
 	// NewFilterFromString creates a new filter from a string.
 	NewFilterFromString(collectionType string, body string) (immutable.Option[request.Filter], error)
@@ -13,4 +13,12 @@ package core
 const (
 	COMPOSITE_NAMESPACE = "C"
 	HEAD                = "_head"
+
+	// DATASTORE_DOC_VERSION_FIELD_ID is the field ID under which the schema version a document
+	// was last written at is stored alongside the document values.
+	DATASTORE_DOC_VERSION_FIELD_ID = "v"
+
+	// DATASTORE_DOC_OWNER_FIELD_ID is the field ID under which the identity owning a document
+	// is stored alongside the document values.
+	DATASTORE_DOC_OWNER_FIELD_ID = "o"
 )
@@ -0,0 +1,53 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// setDocOwner records the given identity, if any, as the owner of the given document.
+func (c *collection) setDocOwner(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+	owner string,
+) error {
+	if owner == "" {
+		return nil
+	}
+	return acp.SetOwner(ctx, txn.Datastore(), key.ToDataStoreKey(), owner)
+}
+
+// getDocOwner returns the identity owning the given document, or an empty string if it has no owner.
+func (c *collection) getDocOwner(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+) (string, error) {
+	return acp.GetOwner(ctx, txn.Datastore(), key.ToDataStoreKey())
+}
+
+// checkDocAccess returns an error if the identity of the request may not modify the given document.
+func (c *collection) checkDocAccess(ctx context.Context, txn datastore.Txn, key core.PrimaryDataStoreKey) error {
+	owner, err := c.getDocOwner(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+	if !acp.CanAccess(ctx, owner) {
+		return NewErrDocumentAccessDenied(key.DocKey)
+	}
+	return nil
+}
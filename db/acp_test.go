@@ -0,0 +1,111 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+func TestACPOwnedDocIsOnlyReadableByOwner(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	ownerCtx := acp.NewContext(ctx, "owner")
+
+	doc := createTestDoc(t, ownerCtx, col, `{"Name": "John"}`)
+
+	_, err = col.Get(ownerCtx, doc.Key(), false)
+	require.NoError(t, err)
+
+	_, err = col.Get(acp.NewContext(ctx, "other"), doc.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+}
+
+func TestACPUnownedDocIsReadableByAll(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John"}`)
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+
+	_, err = col.Get(acp.NewContext(ctx, "other"), doc.Key(), false)
+	require.NoError(t, err)
+}
+
+func TestACPOwnedDocCanOnlyBeUpdatedByOwner(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	ownerCtx := acp.NewContext(ctx, "owner")
+	otherCtx := acp.NewContext(ctx, "other")
+
+	doc := createTestDoc(t, ownerCtx, col, `{"Name": "John"}`)
+
+	err = doc.Set("Name", "Fred")
+	require.NoError(t, err)
+	err = col.Update(otherCtx, doc)
+	require.ErrorIs(t, err, ErrDocumentAccessDenied)
+
+	// The filter is given pre-parsed as the collection is not part of the GQL schema.
+	filter := immutable.Some(request.Filter{
+		Conditions: map[string]any{"Name": map[string]any{"_eq": "John"}},
+	})
+	result, err := col.UpdateWithFilter(otherCtx, filter, `{"Name": "Fred"}`)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), result.Count)
+
+	err = col.Update(ownerCtx, doc)
+	require.NoError(t, err)
+
+	updated, err := col.Get(ownerCtx, doc.Key(), false)
+	require.NoError(t, err)
+	name, err := updated.Get("Name")
+	require.NoError(t, err)
+	require.Equal(t, "Fred", name)
+}
+
+func TestACPOwnedDocCanOnlyBeDeletedByOwner(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	ownerCtx := acp.NewContext(ctx, "owner")
+
+	doc := createTestDoc(t, ownerCtx, col, `{"Name": "John"}`)
+
+	_, err = col.Delete(acp.NewContext(ctx, "other"), doc.Key())
+	require.ErrorIs(t, err, ErrDocumentAccessDenied)
+
+	deleted, err := col.Delete(ownerCtx, doc.Key())
+	require.NoError(t, err)
+	require.True(t, deleted)
+}
@@ -0,0 +1,603 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-libipfs/blocks"
+	dag "github.com/ipfs/go-merkledag"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+const (
+	// backupKeyHeads is the document property holding the composite heads of an
+	// exported document history.
+	backupKeyHeads = "_heads"
+	// backupKeyBlocks is the document property holding the blocks of an exported
+	// document history, keyed by CID.
+	backupKeyBlocks = "_blocks"
+	// backupKeyOwner is the document property holding the identity owning an exported
+	// document, it is omitted if the document has no owner.
+	backupKeyOwner = "_owner"
+)
+
+// basicExport writes the documents of the requested collections to the file described
+// by the given config.
+func (db *db) basicExport(ctx context.Context, txn datastore.Txn, config *client.BackupConfig) (err error) {
+	format := config.Format
+	if format == "" {
+		format = client.BackupFormatJSON
+	}
+	if format != client.BackupFormatJSON && format != client.BackupFormatNDJSON {
+		return NewErrInvalidBackupFormat(format)
+	}
+
+	cols, err := db.getBackupCollections(ctx, txn, config.Collections)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(config.Filepath)
+	if err != nil {
+		return NewErrFailedToCreateBackupFile(err)
+	}
+	defer func() {
+		closeErr := f.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			// do not leave a partial backup behind
+			_ = os.Remove(config.Filepath)
+		}
+	}()
+
+	w := &backupWriter{
+		w:      bufio.NewWriter(f),
+		ndjson: format == client.BackupFormatNDJSON,
+		pretty: config.Pretty,
+	}
+
+	w.begin()
+	for _, col := range cols {
+		w.beginCollection(col.Name())
+
+		keysCh, err := col.getAllDocKeysChan(ctx, txn)
+		if err != nil {
+			return err
+		}
+		for res := range keysCh {
+			if res.Err != nil {
+				return res.Err
+			}
+			docMap, err := col.exportDoc(ctx, txn, res.Key, config.IncludeHistory)
+			if err != nil {
+				return err
+			}
+			if docMap == nil {
+				continue
+			}
+			err = w.writeDoc(docMap)
+			if err != nil {
+				return err
+			}
+		}
+
+		w.endCollection()
+	}
+	w.end()
+
+	return w.w.Flush()
+}
+
+// getBackupCollections returns the collections with the given names, or all the collections
+// of the database if no names are given.
+//
+// The collections are bound to the given transaction, so that iterating over their documents
+// does not discard it.
+func (db *db) getBackupCollections(ctx context.Context, txn datastore.Txn, names []string) ([]*collection, error) {
+	var cols []client.Collection
+	if len(names) == 0 {
+		var err error
+		cols, err = db.getAllCollections(ctx, txn)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, name := range names {
+			col, err := db.getCollectionByName(ctx, txn, name)
+			if err != nil {
+				return nil, NewErrFailedToGetCollection(name, err)
+			}
+			cols = append(cols, col)
+		}
+	}
+
+	result := make([]*collection, len(cols))
+	for i, col := range cols {
+		result[i] = col.WithTxn(txn).(*collection)
+	}
+	return result, nil
+}
+
+// exportDoc returns the backup representation of the document with the given key.
+//
+// Returns nil if the document is deleted and the history is not exported. The history of
+// documents that has been pruned cannot be exported.
+func (c *collection) exportDoc(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	includeHistory bool,
+) (map[string]any, error) {
+	primaryKey := c.getPrimaryKeyFromDocKey(key)
+	_, isDeleted, err := c.exists(ctx, txn, primaryKey)
+	if err != nil {
+		return nil, err
+	}
+	if isDeleted && !includeHistory {
+		return nil, nil
+	}
+
+	docMap := map[string]any{"_key": key.String()}
+	doc, err := c.get(ctx, txn, primaryKey, true)
+	if err != nil {
+		return nil, err
+	}
+	if doc != nil {
+		docMap, err = doc.ToMap()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	owner, err := c.getDocOwner(ctx, txn, primaryKey)
+	if err != nil {
+		return nil, err
+	}
+	if owner != "" {
+		docMap[backupKeyOwner] = owner
+	}
+
+	if !includeHistory {
+		return docMap, nil
+	}
+
+	_, isPruned, err := corecrdt.GetHistorySnapshot(ctx, txn, primaryKey.ToDataStoreKey())
+	if err != nil {
+		return nil, err
+	}
+	if isPruned {
+		return nil, NewErrCannotExportPrunedHistory(key.String())
+	}
+
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		primaryKey.ToDataStoreKey().WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey(),
+	)
+	heads, _, err := headset.List(ctx)
+	if err != nil {
+		return nil, NewErrFailedToGetHeads(err)
+	}
+
+	headStrings := make([]string, len(heads))
+	for i, head := range heads {
+		headStrings[i] = head.String()
+	}
+
+	blockMap := map[string][]byte{}
+	toVisit := heads
+	for len(toVisit) > 0 {
+		blockCid := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		if _, visited := blockMap[blockCid.String()]; visited {
+			continue
+		}
+
+		block, err := txn.DAGstore().Get(ctx, blockCid)
+		if err != nil {
+			return nil, err
+		}
+		blockMap[blockCid.String()] = block.RawData()
+
+		nd, err := dag.DecodeProtobuf(block.RawData())
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range nd.Links() {
+			toVisit = append(toVisit, link.Cid)
+		}
+	}
+
+	docMap[backupKeyHeads] = headStrings
+	docMap[backupKeyBlocks] = blockMap
+	return docMap, nil
+}
+
+// basicImport imports the documents held by the given backup file.
+//
+// Both the JSON and NDJSON formats are read as a stream of JSON objects mapping collection
+// names to either a list of documents or a single document.
+func (db *db) basicImport(ctx context.Context, txn datastore.Txn, filepath string) (err error) {
+	f, err := os.Open(filepath)
+	if err != nil {
+		return NewErrFailedToOpenBackupFile(err)
+	}
+	defer func() {
+		closeErr := f.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	cols := map[string]*collection{}
+	d := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var record map[string]json.RawMessage
+		err := d.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return NewErrInvalidBackup(err)
+		}
+
+		for name, raw := range record {
+			col, ok := cols[name]
+			if !ok {
+				c, err := db.getCollectionByName(ctx, txn, name)
+				if err != nil {
+					return NewErrFailedToGetCollection(name, err)
+				}
+				col = c.WithTxn(txn).(*collection)
+				cols[name] = col
+			}
+
+			var docMaps []map[string]any
+			if len(raw) > 0 && raw[0] == '[' {
+				err = json.Unmarshal(raw, &docMaps)
+			} else {
+				docMaps = make([]map[string]any, 1)
+				err = json.Unmarshal(raw, &docMaps[0])
+			}
+			if err != nil {
+				return NewErrInvalidBackup(err)
+			}
+
+			for _, docMap := range docMaps {
+				err = col.importDoc(ctx, txn, docMap)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// importDoc creates the given backed up document, replaying its history if it was exported.
+//
+// The document is restored with the owner it was exported with, only the owner may import
+// an owned document.
+func (c *collection) importDoc(ctx context.Context, txn datastore.Txn, docMap map[string]any) error {
+	owner, err := popBackupOwner(docMap)
+	if err != nil {
+		return err
+	}
+
+	heads, nodes, err := popBackupHistory(docMap)
+	if err != nil {
+		return err
+	}
+
+	doc, err := client.NewDocFromMap(docMap)
+	if err != nil {
+		return err
+	}
+
+	if !acp.CanAccess(ctx, owner) {
+		return NewErrDocumentAccessDenied(doc.Key().String())
+	}
+
+	if len(heads) == 0 {
+		return c.createWithOwner(ctx, txn, doc, owner)
+	}
+
+	primaryKey := c.getPrimaryKeyFromDocKey(doc.Key())
+	exists, _, err := c.exists(ctx, txn, primaryKey)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrDocumentAlreadyExists
+	}
+
+	err = c.setDocOwner(ctx, txn, primaryKey, owner)
+	if err != nil {
+		return err
+	}
+
+	visited := map[cid.Cid]struct{}{}
+	for _, head := range heads {
+		err = c.importBlock(ctx, txn, primaryKey, "", head, nodes, visited)
+		if err != nil {
+			return err
+		}
+	}
+
+	newDoc, err := c.getDocForIndexing(ctx, txn, primaryKey)
+	if err != nil {
+		return err
+	}
+	return c.updateIndexedDoc(ctx, txn, nil, newDoc)
+}
+
+// importBlock merges the given block of a backed up document history into the document,
+// after having merged all the blocks it links to.
+//
+// Blocks are merged from oldest to newest so that the heads of each CRDT are the same as
+// those of the exported document once all the blocks have been processed.
+func (c *collection) importBlock(
+	ctx context.Context,
+	txn datastore.Txn,
+	primaryKey core.PrimaryDataStoreKey,
+	field string,
+	blockCid cid.Cid,
+	nodes map[cid.Cid]ipld.Node,
+	visited map[cid.Cid]struct{},
+) error {
+	if _, ok := visited[blockCid]; ok {
+		return nil
+	}
+	visited[blockCid] = struct{}{}
+
+	nd, ok := nodes[blockCid]
+	if !ok {
+		return NewErrMissingBackupBlock(blockCid)
+	}
+
+	for _, link := range nd.Links() {
+		linkField := field
+		if link.Name != core.HEAD {
+			linkField = link.Name
+		}
+		err := c.importBlock(ctx, txn, primaryKey, linkField, link.Cid, nodes, visited)
+		if err != nil {
+			return err
+		}
+	}
+
+	key := primaryKey.ToDataStoreKey().WithFieldId(core.COMPOSITE_NAMESPACE)
+	ctype := client.COMPOSITE
+	if field != "" {
+		fieldKey, fieldExists := c.tryGetFieldKey(primaryKey, field)
+		if !fieldExists {
+			return client.NewErrFieldNotExist(field)
+		}
+		fieldDescription, _ := c.desc.GetField(field)
+		key = fieldKey
+		ctype = fieldDescription.Typ
+	}
+
+	merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
+		txn,
+		core.NewCollectionSchemaVersionKey(c.Schema().VersionID),
+		events.EmptyUpdateChannel,
+		ctype,
+		key,
+	)
+	if err != nil {
+		return err
+	}
+
+	delta, err := merkleCRDT.DeltaDecode(nd)
+	if err != nil {
+		return err
+	}
+
+	err = txn.DAGstore().Put(ctx, nd)
+	if err != nil {
+		return err
+	}
+
+	// all the linked blocks have already been processed, so no nodes need to be fetched
+	ng := &clock.CrdtNodeGetter{DeltaExtractor: merkleCRDT.DeltaDecode}
+	_, err = merkleCRDT.Clock().ProcessNode(ctx, ng, blockCid, delta.GetPriority(), delta, nd)
+	return err
+}
+
+// popBackupOwner removes the exported owner from the given document map, returning it.
+//
+// An empty string is returned if the document was exported without an owner.
+func popBackupOwner(docMap map[string]any) (string, error) {
+	rawOwner, hasOwner := docMap[backupKeyOwner]
+	delete(docMap, backupKeyOwner)
+	if !hasOwner {
+		return "", nil
+	}
+
+	owner, ok := rawOwner.(string)
+	if !ok {
+		return "", client.NewErrUnexpectedType[string](backupKeyOwner, rawOwner)
+	}
+	return owner, nil
+}
+
+// popBackupHistory removes the exported history from the given document map, returning
+// the composite heads and the decoded blocks of the document.
+func popBackupHistory(docMap map[string]any) ([]cid.Cid, map[cid.Cid]ipld.Node, error) {
+	rawHeads, hasHeads := docMap[backupKeyHeads]
+	rawBlocks, hasBlocks := docMap[backupKeyBlocks]
+	delete(docMap, backupKeyHeads)
+	delete(docMap, backupKeyBlocks)
+	if !hasHeads && !hasBlocks {
+		return nil, nil, nil
+	}
+
+	headList, ok := rawHeads.([]any)
+	if !ok {
+		return nil, nil, client.NewErrUnexpectedType[[]any](backupKeyHeads, rawHeads)
+	}
+	heads := make([]cid.Cid, len(headList))
+	for i, rawHead := range headList {
+		headString, ok := rawHead.(string)
+		if !ok {
+			return nil, nil, client.NewErrUnexpectedType[string](backupKeyHeads, rawHead)
+		}
+		head, err := cid.Decode(headString)
+		if err != nil {
+			return nil, nil, NewErrInvalidBackup(err)
+		}
+		heads[i] = head
+	}
+
+	blockMap, ok := rawBlocks.(map[string]any)
+	if !ok {
+		return nil, nil, client.NewErrUnexpectedType[map[string]any](backupKeyBlocks, rawBlocks)
+	}
+	nodes := make(map[cid.Cid]ipld.Node, len(blockMap))
+	for cidString, rawData := range blockMap {
+		dataString, ok := rawData.(string)
+		if !ok {
+			return nil, nil, client.NewErrUnexpectedType[string](backupKeyBlocks, rawData)
+		}
+		blockCid, err := cid.Decode(cidString)
+		if err != nil {
+			return nil, nil, NewErrInvalidBackup(err)
+		}
+		data, err := base64.StdEncoding.DecodeString(dataString)
+		if err != nil {
+			return nil, nil, NewErrInvalidBackup(err)
+		}
+		block, err := blocks.NewBlockWithCid(data, blockCid)
+		if err != nil {
+			return nil, nil, NewErrInvalidBackup(err)
+		}
+		nd, err := dag.DecodeProtobufBlock(block)
+		if err != nil {
+			return nil, nil, NewErrInvalidBackup(err)
+		}
+		nodes[blockCid] = nd
+	}
+
+	return heads, nodes, nil
+}
+
+// backupWriter writes documents to a backup file in either the JSON or NDJSON format.
+//
+// Write errors are buffered by the underlying writer and returned when it is flushed.
+type backupWriter struct {
+	w      *bufio.Writer
+	ndjson bool
+	pretty bool
+
+	collection      string
+	collectionCount int
+	docCount        int
+}
+
+func (w *backupWriter) begin() {
+	if !w.ndjson {
+		_, _ = w.w.WriteString("{")
+	}
+}
+
+func (w *backupWriter) beginCollection(name string) {
+	w.collection = name
+	w.docCount = 0
+	if w.ndjson {
+		return
+	}
+
+	if w.collectionCount > 0 {
+		_, _ = w.w.WriteString(",")
+	}
+	if w.pretty {
+		_, _ = w.w.WriteString("\n\t")
+	}
+	// marshalling a string cannot fail
+	nameJSON, _ := json.Marshal(name)
+	_, _ = w.w.Write(nameJSON)
+	if w.pretty {
+		_, _ = w.w.WriteString(": [")
+	} else {
+		_, _ = w.w.WriteString(":[")
+	}
+	w.collectionCount++
+}
+
+func (w *backupWriter) writeDoc(docMap map[string]any) error {
+	if w.ndjson {
+		line, err := json.Marshal(map[string]any{w.collection: docMap})
+		if err != nil {
+			return err
+		}
+		_, _ = w.w.Write(line)
+		_, _ = w.w.WriteString("\n")
+		return nil
+	}
+
+	var docJSON []byte
+	var err error
+	if w.pretty {
+		docJSON, err = json.MarshalIndent(docMap, "\t\t", "\t")
+	} else {
+		docJSON, err = json.Marshal(docMap)
+	}
+	if err != nil {
+		return err
+	}
+
+	if w.docCount > 0 {
+		_, _ = w.w.WriteString(",")
+	}
+	if w.pretty {
+		_, _ = w.w.WriteString("\n\t\t")
+	}
+	_, _ = w.w.Write(docJSON)
+	w.docCount++
+	return nil
+}
+
+func (w *backupWriter) endCollection() {
+	if w.ndjson {
+		return
+	}
+	if w.pretty && w.docCount > 0 {
+		_, _ = w.w.WriteString("\n\t")
+	}
+	_, _ = w.w.WriteString("]")
+}
+
+func (w *backupWriter) end() {
+	if w.ndjson {
+		return
+	}
+	if w.pretty && w.collectionCount > 0 {
+		_, _ = w.w.WriteString("\n")
+	}
+	_, _ = w.w.WriteString("}\n")
+}
@@ -0,0 +1,315 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func requireBackupTestDoc(
+	t *testing.T,
+	ctx context.Context,
+	col client.Collection,
+	key client.DocKey,
+	expected map[string]any,
+) {
+	doc, err := col.Get(ctx, key, false)
+	require.NoError(t, err)
+
+	for field, value := range expected {
+		actual, err := doc.Get(field)
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+	}
+}
+
+func TestBasicExportImport_JSON_PreservesUpdatedDocKeys(t *testing.T) {
+	ctx := context.Background()
+	source, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, source)
+	require.NoError(t, err)
+
+	john := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 30}`)
+	fred := createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 25}`)
+	saveTestDoc(t, ctx, col, john, map[string]any{"Age": 31})
+
+	filepath := t.TempDir() + "/backup.json"
+	err = source.BasicExport(ctx, &client.BackupConfig{Filepath: filepath, Pretty: true})
+	require.NoError(t, err)
+
+	target, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	targetCol, err := newTestCollectionWithSchema(t, ctx, target)
+	require.NoError(t, err)
+	err = target.BasicImport(ctx, filepath)
+	require.NoError(t, err)
+
+	requireBackupTestDoc(t, ctx, targetCol, john.Key(), map[string]any{"Name": "John", "Age": uint64(31)})
+	requireBackupTestDoc(t, ctx, targetCol, fred.Key(), map[string]any{"Name": "Fred", "Age": uint64(25)})
+}
+
+func TestBasicExportImport_NDJSON_WritesOneDocPerLine(t *testing.T) {
+	ctx := context.Background()
+	source, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, source)
+	require.NoError(t, err)
+
+	john := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 30}`)
+	fred := createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 25}`)
+
+	filepath := t.TempDir() + "/backup.ndjson"
+	err = source.BasicExport(ctx, &client.BackupConfig{
+		Filepath: filepath,
+		Format:   client.BackupFormatNDJSON,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+
+	target, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	targetCol, err := newTestCollectionWithSchema(t, ctx, target)
+	require.NoError(t, err)
+	err = target.BasicImport(ctx, filepath)
+	require.NoError(t, err)
+
+	requireBackupTestDoc(t, ctx, targetCol, john.Key(), map[string]any{"Name": "John", "Age": uint64(30)})
+	requireBackupTestDoc(t, ctx, targetCol, fred.Key(), map[string]any{"Name": "Fred", "Age": uint64(25)})
+}
+
+func TestBasicExportImport_WithMultipleCollections_RestoresAllCollections(t *testing.T) {
+	ctx := context.Background()
+	source, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, source)
+	require.NoError(t, err)
+	err = source.AddSchema(ctx, `type Book { title: String }`)
+	require.NoError(t, err)
+	books, err := source.GetCollectionByName(ctx, "Book")
+	require.NoError(t, err)
+
+	john := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 30}`)
+	fred := createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 25}`)
+	dune := createTestDoc(t, ctx, books, `{"title": "Dune"}`)
+	emma := createTestDoc(t, ctx, books, `{"title": "Emma"}`)
+
+	for _, includeHistory := range []bool{false, true} {
+		filepath := t.TempDir() + "/backup.json"
+		err = source.BasicExport(ctx, &client.BackupConfig{Filepath: filepath, IncludeHistory: includeHistory})
+		require.NoError(t, err)
+
+		target, err := newMemoryDB(ctx)
+		require.NoError(t, err)
+		targetCol, err := newTestCollectionWithSchema(t, ctx, target)
+		require.NoError(t, err)
+		err = target.AddSchema(ctx, `type Book { title: String }`)
+		require.NoError(t, err)
+		targetBooks, err := target.GetCollectionByName(ctx, "Book")
+		require.NoError(t, err)
+
+		err = target.BasicImport(ctx, filepath)
+		require.NoError(t, err)
+
+		requireBackupTestDoc(t, ctx, targetCol, john.Key(), map[string]any{"Name": "John", "Age": uint64(30)})
+		requireBackupTestDoc(t, ctx, targetCol, fred.Key(), map[string]any{"Name": "Fred", "Age": uint64(25)})
+		requireBackupTestDoc(t, ctx, targetBooks, dune.Key(), map[string]any{"title": "Dune"})
+		requireBackupTestDoc(t, ctx, targetBooks, emma.Key(), map[string]any{"title": "Emma"})
+	}
+}
+
+func TestBasicExport_WithCollections_OnlyExportsGivenCollections(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	err = db.AddSchema(ctx, `type Book { title: String }`)
+	require.NoError(t, err)
+	books, err := db.GetCollectionByName(ctx, "Book")
+	require.NoError(t, err)
+
+	createTestDoc(t, ctx, col, `{"Name": "John", "Age": 30}`)
+	createTestDoc(t, ctx, books, `{"title": "Dune"}`)
+
+	filepath := t.TempDir() + "/backup.json"
+	err = db.BasicExport(ctx, &client.BackupConfig{
+		Filepath:    filepath,
+		Collections: []string{"Book"},
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "Dune")
+	require.NotContains(t, string(data), "John")
+}
+
+func TestBasicExport_WithInvalidFormat_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+
+	filepath := t.TempDir() + "/backup.xml"
+	err = db.BasicExport(ctx, &client.BackupConfig{Filepath: filepath, Format: "xml"})
+	require.ErrorIs(t, err, ErrInvalidBackupFormat)
+
+	_, err = os.Stat(filepath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestBasicImport_WithExistingDoc_ReturnsErrorAndImportsNothing(t *testing.T) {
+	ctx := context.Background()
+	source, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, source)
+	require.NoError(t, err)
+
+	createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 25}`)
+	john := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 30}`)
+
+	filepath := t.TempDir() + "/backup.json"
+	err = source.BasicExport(ctx, &client.BackupConfig{Filepath: filepath})
+	require.NoError(t, err)
+
+	target, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	targetCol, err := newTestCollectionWithSchema(t, ctx, target)
+	require.NoError(t, err)
+	fred := createTestDoc(t, ctx, targetCol, `{"Name": "Fred", "Age": 25}`)
+
+	err = target.BasicImport(ctx, filepath)
+	require.ErrorIs(t, err, ErrDocumentAlreadyExists)
+
+	_, err = targetCol.Get(ctx, john.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+	requireBackupTestDoc(t, ctx, targetCol, fred.Key(), map[string]any{"Name": "Fred", "Age": uint64(25)})
+}
+
+func TestBasicExportImport_WithHistory_PreservesCommits(t *testing.T) {
+	ctx := context.Background()
+	source, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, source)
+	require.NoError(t, err)
+
+	john := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 30}`)
+	fred := createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 25}`)
+
+	saveTestDoc(t, ctx, col, john, map[string]any{"Age": 31})
+	_, err = col.Delete(ctx, fred.Key())
+	require.NoError(t, err)
+
+	sourcePath := t.TempDir() + "/source.json"
+	err = source.BasicExport(ctx, &client.BackupConfig{Filepath: sourcePath, IncludeHistory: true})
+	require.NoError(t, err)
+
+	target, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	targetCol, err := newTestCollectionWithSchema(t, ctx, target)
+	require.NoError(t, err)
+	err = target.BasicImport(ctx, sourcePath)
+	require.NoError(t, err)
+
+	requireBackupTestDoc(t, ctx, targetCol, john.Key(), map[string]any{"Name": "John", "Age": uint64(31)})
+
+	_, err = targetCol.Get(ctx, fred.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+	// re-exporting the imported database must produce the same heads and blocks
+	targetPath := t.TempDir() + "/target.json"
+	err = target.BasicExport(ctx, &client.BackupConfig{Filepath: targetPath, IncludeHistory: true})
+	require.NoError(t, err)
+
+	sourceData, err := os.ReadFile(sourcePath)
+	require.NoError(t, err)
+	targetData, err := os.ReadFile(targetPath)
+	require.NoError(t, err)
+	require.Equal(t, string(sourceData), string(targetData))
+}
+
+func TestBasicExportImport_WithOwnedDoc_RestoresOwner(t *testing.T) {
+	ctx := context.Background()
+	source, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, source)
+	require.NoError(t, err)
+	ownerCtx := acp.NewContext(ctx, "owner")
+
+	john := createTestDoc(t, ownerCtx, col, `{"Name": "John", "Age": 30}`)
+	fred := createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 25}`)
+
+	for _, includeHistory := range []bool{false, true} {
+		filepath := t.TempDir() + "/backup.json"
+		err = source.BasicExport(ownerCtx, &client.BackupConfig{Filepath: filepath, IncludeHistory: includeHistory})
+		require.NoError(t, err)
+
+		target, err := newMemoryDB(ctx)
+		require.NoError(t, err)
+		targetCol, err := newTestCollectionWithSchema(t, ctx, target)
+		require.NoError(t, err)
+		err = target.BasicImport(ownerCtx, filepath)
+		require.NoError(t, err)
+
+		requireBackupTestDoc(t, ownerCtx, targetCol, john.Key(), map[string]any{"Name": "John", "Age": uint64(30)})
+		_, err = targetCol.Get(acp.NewContext(ctx, "other"), john.Key(), false)
+		require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+		// The unowned document must not be claimed by the importer.
+		requireBackupTestDoc(
+			t,
+			acp.NewContext(ctx, "other"),
+			targetCol,
+			fred.Key(),
+			map[string]any{"Name": "Fred", "Age": uint64(25)},
+		)
+	}
+}
+
+func TestBasicImport_WithDocOwnedByOtherIdentity_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	source, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, source)
+	require.NoError(t, err)
+	ownerCtx := acp.NewContext(ctx, "owner")
+
+	john := createTestDoc(t, ownerCtx, col, `{"Name": "John", "Age": 30}`)
+
+	filepath := t.TempDir() + "/backup.json"
+	err = source.BasicExport(ownerCtx, &client.BackupConfig{Filepath: filepath})
+	require.NoError(t, err)
+
+	for _, importCtx := range []context.Context{ctx, acp.NewContext(ctx, "other")} {
+		target, err := newMemoryDB(ctx)
+		require.NoError(t, err)
+		targetCol, err := newTestCollectionWithSchema(t, ctx, target)
+		require.NoError(t, err)
+
+		err = target.BasicImport(importCtx, filepath)
+		require.ErrorIs(t, err, ErrDocumentAccessDenied)
+
+		_, err = targetCol.Get(ownerCtx, john.Key(), false)
+		require.ErrorIs(t, err, client.ErrDocumentNotFound)
+	}
+}
@@ -41,7 +41,7 @@ func MakePrimaryIndexKeyForCRDT(
 	switch ctype {
 	case client.COMPOSITE:
 		return MakeCollectionKey(c).WithInstanceInfo(key).WithFieldId(core.COMPOSITE_NAMESPACE), nil
-	case client.LWW_REGISTER:
+	case client.LWW_REGISTER, client.PN_COUNTER:
 		fieldKey := getFieldKey(c, key, fieldName)
 		return MakeCollectionKey(c).WithInstanceInfo(fieldKey), nil
 	}
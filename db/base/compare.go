@@ -22,8 +22,9 @@ import (
 // returns 0 if a == b
 // returns 1 if a > b.
 //
-// The only possible values for a and b is a concrete field type
-// and they are always the same type as each other.
+// Nil values are less than any other value. Numbers may be of differing
+// types, for example an int aggregate result may be compared to an int64,
+// otherwise values of differing types are considered equal.
 // @todo: Handle list/slice/array fields
 func Compare(a, b any) int {
 	if a == nil || b == nil {
@@ -32,24 +33,25 @@ func Compare(a, b any) int {
 
 	switch v := a.(type) {
 	case bool:
-		return compareBool(v, b.(bool))
-	case int:
-		return compareInt(int64(v), b.(int64))
-	case int64:
-		return compareInt(v, b.(int64))
-	case uint64:
-		return compareUint(v, b.(uint64))
-	case float64:
-		return compareFloat(v, b.(float64))
+		if bValue, ok := b.(bool); ok {
+			return compareBool(v, bValue)
+		}
+	case int, int64, uint64, float64:
+		return compareNumber(a, b)
 	case time.Time:
-		return compareTime(v, b.(time.Time))
+		if bValue, ok := b.(time.Time); ok {
+			return compareTime(v, bValue)
+		}
 	case string:
-		return compareString(v, b.(string))
+		if bValue, ok := b.(string); ok {
+			return compareString(v, bValue)
+		}
 	case []byte:
-		return compareBytes(v, b.([]byte))
-	default:
-		return 0
+		if bValue, ok := b.([]byte); ok {
+			return compareBytes(v, bValue)
+		}
 	}
+	return 0
 }
 
 func compareNil(a, b any) int {
@@ -69,6 +71,74 @@ func compareBool(a, b bool) int {
 	}
 	return -1
 }
+
+// compareNumber compares two numbers of possibly differing types.
+//
+// Integers are compared without converting them to floats, so that large
+// int64 and uint64 values keep their precision.
+func compareNumber(a, b any) int {
+	_, aIsFloat := a.(float64)
+	_, bIsFloat := b.(float64)
+	if aIsFloat || bIsFloat {
+		aFloat, aIsNumber := toFloat64(a)
+		bFloat, bIsNumber := toFloat64(b)
+		if !aIsNumber || !bIsNumber {
+			return 0
+		}
+		return compareFloat(aFloat, bFloat)
+	}
+
+	aUint, aIsUint := a.(uint64)
+	bUint, bIsUint := b.(uint64)
+	aInt, aIsInt := toInt64(a)
+	bInt, bIsInt := toInt64(b)
+
+	switch {
+	case aIsUint && bIsUint:
+		return compareUint(aUint, bUint)
+	case aIsUint && bIsInt:
+		if bInt < 0 {
+			return 1
+		}
+		return compareUint(aUint, uint64(bInt))
+	case aIsInt && bIsUint:
+		if aInt < 0 {
+			return -1
+		}
+		return compareUint(uint64(aInt), bUint)
+	case aIsInt && bIsInt:
+		return compareInt(aInt, bInt)
+	default:
+		return 0
+	}
+}
+
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
 func compareInt(a, b int64) int {
 	if a == b {
 		return 0
@@ -10,9 +10,49 @@
 
 package base
 
+import (
+	"encoding/hex"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
 type DataEncoding uint32
 
 const (
 	// Indicates that the data is encoded using the CBOR encoding for values.
 	DataEncoding_VALUE_CBOR DataEncoding = 0
 )
+
+// jsonDecMode decodes CBOR maps into maps with string keys, as is required
+// by JSON.
+var jsonDecMode, _ = cbor.DecOptions{
+	DefaultMapType: reflect.TypeOf(map[string]any(nil)),
+}.DecMode()
+
+// DecodeJSONValue decodes the given CBOR encoded value of a JSON field.
+//
+// Unlike the default decoding any maps within the value are decoded with string
+// keys, allowing the value to be marshalled back into JSON.
+func DecodeJSONValue(buf []byte) (any, error) {
+	var val any
+	err := jsonDecMode.Unmarshal(buf, &val)
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// DecodeBlobValue decodes the given CBOR encoded value of a Blob field into its
+// hex encoded string representation.
+func DecodeBlobValue(buf []byte) (any, error) {
+	var val []byte
+	err := cbor.Unmarshal(buf, &val)
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, nil
+	}
+	return hex.EncodeToString(val), nil
+}
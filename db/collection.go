@@ -13,9 +13,11 @@ package db
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/ipfs/go-cid"
@@ -25,6 +27,7 @@ import (
 	mh "github.com/multiformats/go-multihash"
 	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
@@ -90,6 +93,10 @@ func (db *db) newCollection(desc client.CollectionDescription) (*collection, err
 			field.Typ == client.NONE_CRDT {
 			return nil, client.NewErrUninitializeProperty("Collection.Schema", "CRDT type")
 		}
+		err := validateFieldCRDTType(field)
+		if err != nil {
+			return nil, err
+		}
 		desc.Schema.Fields[i].ID = client.FieldID(i)
 	}
 
@@ -126,6 +133,12 @@ func (db *db) createCollection(
 		return nil, err
 	}
 	desc.ID = uint32(colID)
+
+	// Indexes are persisted separately from the collection description, they are
+	// created once the collection itself has been saved.
+	indexes := desc.Indexes
+	desc.Indexes = nil
+
 	col, err := db.newCollection(desc)
 	if err != nil {
 		return nil, err
@@ -180,6 +193,23 @@ func (db *db) createCollection(
 		return nil, err
 	}
 
+	for _, constraint := range desc.Schema.UniqueConstraints {
+		_, err = col.createIndex(ctx, txn, newUniqueIndexDescription(constraint))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, index := range indexes {
+		if index.Unique {
+			return nil, NewErrCannotManageUniqueIndex(index.Name)
+		}
+		_, err = col.createIndex(ctx, txn, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	log.Debug(
 		ctx,
 		"Created collection",
@@ -239,6 +269,9 @@ func (db *db) updateCollection(
 	schemaVersionID := cid.String()
 	desc.Schema.VersionID = schemaVersionID
 
+	// Indexes are persisted separately and cannot be modified via updates to the collection.
+	desc.Indexes = nil
+
 	buf, err := json.Marshal(desc)
 	if err != nil {
 		return nil, err
@@ -310,6 +343,20 @@ func (db *db) validateUpdateCollection(
 		return false, ErrCannotSetVersionID
 	}
 
+	if !areIndexesEqual(proposedDesc.Indexes, existingDesc.Indexes) {
+		return false, NewErrCannotModifyIndexesWithPatch(proposedDesc.Name)
+	}
+
+	if !areUniqueConstraintsEqual(proposedDesc.Schema.UniqueConstraints, existingDesc.Schema.UniqueConstraints) {
+		return false, NewErrCannotModifyUniqueConstraints(proposedDesc.Name)
+	}
+
+	enumsHaveChanged, err := validateUpdateEnums(proposedDesc.Schema.Enums, existingDesc.Schema.Enums)
+	if err != nil {
+		return false, err
+	}
+	hasChanged = hasChanged || enumsHaveChanged
+
 	existingFieldsByID := map[client.FieldID]client.FieldDescription{}
 	existingFieldIndexesByName := map[string]int{}
 	for i, field := range existingDesc.Schema.Fields {
@@ -339,6 +386,11 @@ func (db *db) validateUpdateCollection(
 			return false, NewErrCannotAddRelationalField(proposedField.Name, proposedField.Kind)
 		}
 
+		if !fieldAlreadyExists && (proposedField.IsEmbeddedObject() ||
+			strings.Contains(proposedField.Name, client.EmbeddedFieldSeparator)) {
+			return false, NewErrCannotAddEmbeddedField(proposedField.Name)
+		}
+
 		if _, isDuplicate := newFieldNames[proposedField.Name]; isDuplicate {
 			return false, NewErrDuplicateField(proposedField.Name)
 		}
@@ -352,8 +404,15 @@ func (db *db) validateUpdateCollection(
 			return false, NewErrCannotMoveField(proposedField.Name, proposedIndex, existingIndex)
 		}
 
-		if proposedField.Typ != client.NONE_CRDT && proposedField.Typ != client.LWW_REGISTER {
-			return false, NewErrInvalidCRDTType(proposedField.Name, proposedField.Typ)
+		err = validateFieldCRDTType(proposedField)
+		if err != nil {
+			return false, err
+		}
+
+		if proposedField.Kind == client.FieldKind_ENUM {
+			if _, ok := proposedDesc.Schema.GetEnum(proposedField.Schema); !ok {
+				return false, client.NewErrEnumNotFound(proposedField.Schema)
+			}
 		}
 
 		newFieldNames[proposedField.Name] = struct{}{}
@@ -369,6 +428,60 @@ func (db *db) validateUpdateCollection(
 	return hasChanged, nil
 }
 
+// validateUpdateEnums returns an error if the proposed enums remove any of the values of the
+// existing enums. Values may only be appended to existing enums.
+//
+// Returns true if any enum or enum value has been added.
+func validateUpdateEnums(proposedEnums, existingEnums []client.EnumDescription) (bool, error) {
+	proposedEnumsByName := make(map[string]client.EnumDescription, len(proposedEnums))
+	for _, enum := range proposedEnums {
+		values := map[string]struct{}{}
+		for _, value := range enum.Values {
+			if _, isDuplicate := values[value]; isDuplicate {
+				return false, NewErrDuplicateEnumValue(enum.Name, value)
+			}
+			values[value] = struct{}{}
+		}
+		proposedEnumsByName[enum.Name] = enum
+	}
+
+	hasChanged := len(proposedEnums) != len(existingEnums)
+	for _, existingEnum := range existingEnums {
+		proposedEnum := proposedEnumsByName[existingEnum.Name]
+		for i, value := range existingEnum.Values {
+			if i >= len(proposedEnum.Values) || proposedEnum.Values[i] != value {
+				return false, NewErrCannotRemoveEnumValue(existingEnum.Name, value)
+			}
+		}
+		hasChanged = hasChanged || len(proposedEnum.Values) != len(existingEnum.Values)
+	}
+
+	return hasChanged, nil
+}
+
+// validateFieldCRDTType returns an error if the given field's CRDT type is not supported,
+// or is not supported for the field's kind.
+func validateFieldCRDTType(field client.FieldDescription) error {
+	if field.IsEmbeddedObject() != (field.Typ == client.OBJECT) {
+		// Embedded objects are always, and exclusively, of the object CRDT type.
+		return NewErrInvalidCRDTType(field.Name, field.Typ)
+	}
+
+	switch field.Typ {
+	case client.NONE_CRDT, client.LWW_REGISTER, client.OBJECT:
+		return nil
+
+	case client.PN_COUNTER:
+		if field.Kind != client.FieldKind_INT && field.Kind != client.FieldKind_FLOAT {
+			return NewErrInvalidCRDTTypeForKind(field.Name, field.Kind)
+		}
+		return nil
+
+	default:
+		return NewErrInvalidCRDTType(field.Name, field.Typ)
+	}
+}
+
 // getCollectionByVersionId returns the [*collection] at the given [schemaVersionId] version.
 //
 // Will return an error if the given key is empty, or not found.
@@ -393,6 +506,11 @@ func (db *db) getCollectionByVersionID(
 		return nil, err
 	}
 
+	desc.Indexes, err = db.getCollectionIndexes(ctx, txn, desc.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	return &collection{
 		db:       db,
 		desc:     desc,
@@ -642,10 +760,31 @@ func (c *collection) getKeysFromDoc(
 }
 
 func (c *collection) create(ctx context.Context, txn datastore.Txn, doc *client.Document) error {
-	dockey, primaryKey, err := c.getKeysFromDoc(doc)
+	_, _, err := c.getKeysFromDoc(doc)
 	if err != nil {
 		return err
 	}
+	return c.createWithKey(ctx, txn, doc)
+}
+
+// createWithKey creates the given document under its current key, without verifying that the
+// key matches the document contents.
+//
+// This allows restoring documents whose key was derived from an earlier version of their contents.
+func (c *collection) createWithKey(ctx context.Context, txn datastore.Txn, doc *client.Document) error {
+	return c.createWithOwner(ctx, txn, doc, acp.FromContext(ctx))
+}
+
+// createWithOwner creates the given document under its current key on behalf of the given
+// owner, the document has no owner if the given owner is empty.
+func (c *collection) createWithOwner(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+	owner string,
+) error {
+	dockey := doc.Key()
+	primaryKey := c.getPrimaryKeyFromDocKey(dockey)
 
 	// check if doc already exists
 	exists, isDeleted, err := c.exists(ctx, txn, primaryKey)
@@ -668,6 +807,11 @@ func (c *collection) create(ctx context.Context, txn datastore.Txn, doc *client.
 		}
 	}
 
+	err = c.setDocOwner(ctx, txn, primaryKey, owner)
+	if err != nil {
+		return err
+	}
+
 	// write data to DB via MerkleClock/CRDT
 	_, err = c.save(ctx, txn, doc, true)
 	if err != nil {
@@ -770,6 +914,25 @@ func (c *collection) save(
 	//	=> 		instantiate MerkleCRDT objects
 	//	=> 		Set/Publish new CRDT values
 	primaryKey := c.getPrimaryKeyFromDocKey(doc.Key())
+
+	var oldDoc *client.Document
+	if !isCreate {
+		err := c.checkDocAccess(ctx, txn, primaryKey)
+		if err != nil {
+			return cid.Undef, err
+		}
+
+		oldDoc, err = c.getDocForIndexing(ctx, txn, primaryKey)
+		if err != nil {
+			return cid.Undef, err
+		}
+	}
+
+	err := doc.ValidateEnumValues(c.desc)
+	if err != nil {
+		return cid.Undef, err
+	}
+
 	links := make([]core.DAGLink, 0)
 	docProperties := make(map[string]any)
 	for k, v := range doc.Fields() {
@@ -779,14 +942,34 @@ func (c *collection) save(
 		}
 
 		if val.IsDirty() {
-			fieldKey, fieldExists := c.tryGetFieldKey(primaryKey, k)
-			if !fieldExists {
-				return cid.Undef, client.NewErrFieldNotExist(k)
-			}
-
 			fieldDescription, valid := c.desc.GetField(k)
 			if !valid {
-				return cid.Undef, client.NewErrFieldNotExist(k)
+				relationFieldDescription, isManyToManyLinks := c.getManyToManyField(k)
+				if !isManyToManyLinks {
+					return cid.Undef, client.NewErrFieldNotExist(k)
+				}
+
+				linkedKeys, err := toManyToManyLinks(relationFieldDescription, val.Value())
+				if err != nil {
+					return cid.Undef, err
+				}
+
+				err = c.setManyToManyLinks(ctx, txn, relationFieldDescription, primaryKey.DocKey, linkedKeys)
+				if err != nil {
+					return cid.Undef, err
+				}
+
+				// The links are stored in the link collection of the relation, and not on the document.
+				continue
+			}
+
+			if fieldDescription.IsEmbeddedObject() {
+				embeddedLinks, err := c.saveEmbeddedValue(ctx, txn, primaryKey, fieldDescription, val, docProperties)
+				if err != nil {
+					return cid.Undef, err
+				}
+				links = append(links, embeddedLinks...)
+				continue
 			}
 
 			relationFieldDescription, isSecondaryRelationID := c.isSecondaryIDField(fieldDescription)
@@ -803,23 +986,22 @@ func (c *collection) save(
 				continue
 			}
 
-			node, _, err := c.saveDocValue(ctx, txn, fieldKey, val)
+			link, err := c.saveFieldValue(ctx, txn, primaryKey, fieldDescription, val, docProperties)
 			if err != nil {
 				return cid.Undef, err
 			}
-			if val.IsDelete() {
-				docProperties[k] = nil
-			} else {
-				docProperties[k] = val.Value()
-			}
-
-			link := core.DAGLink{
-				Name: k,
-				Cid:  node.Cid(),
-			}
 			links = append(links, link)
 		}
 	}
+
+	if !isCreate {
+		migratedLinks, err := c.saveMigratedFields(ctx, txn, primaryKey, docProperties)
+		if err != nil {
+			return cid.Undef, err
+		}
+		links = append(links, migratedLinks...)
+	}
+
 	// Update CompositeDAG
 	em, err := cbor.CanonicalEncOptions().EncMode()
 	if err != nil {
@@ -843,16 +1025,36 @@ func (c *collection) save(
 		return cid.Undef, err
 	}
 
+	newDoc, err := c.getDocForIndexing(ctx, txn, primaryKey)
+	if err != nil {
+		return cid.Undef, err
+	}
+	err = c.updateIndexedDoc(ctx, txn, oldDoc, newDoc)
+	if err != nil {
+		return cid.Undef, err
+	}
+
 	if c.db.events.Updates.HasValue() {
+		owner, err := c.getDocOwner(ctx, txn, primaryKey)
+		if err != nil {
+			return cid.Undef, err
+		}
+		prunedHeight, err := c.getDocPrunedHeight(ctx, txn, primaryKey)
+		if err != nil {
+			return cid.Undef, err
+		}
+
 		txn.OnSuccess(
 			func() {
 				c.db.events.Updates.Value().Publish(
 					events.Update{
-						DocKey:   doc.Key().String(),
-						Cid:      headNode.Cid(),
-						SchemaID: c.schemaID,
-						Block:    headNode,
-						Priority: priority,
+						DocKey:       doc.Key().String(),
+						Cid:          headNode.Cid(),
+						SchemaID:     c.schemaID,
+						Block:        headNode,
+						Priority:     priority,
+						Owner:        owner,
+						PrunedHeight: prunedHeight,
 					},
 				)
 			},
@@ -931,6 +1133,159 @@ func (c *collection) exists(
 	return true, false, nil
 }
 
+// saveFieldValue saves the given value of the given field, returning the link to the
+// saved value.
+//
+// The saved value is added to the given properties.
+func (c *collection) saveFieldValue(
+	ctx context.Context,
+	txn datastore.Txn,
+	primaryKey core.PrimaryDataStoreKey,
+	field client.FieldDescription,
+	val client.Value,
+	properties map[string]any,
+) (core.DAGLink, error) {
+	fieldKey, fieldExists := c.tryGetFieldKey(primaryKey, field.Name)
+	if !fieldExists {
+		return core.DAGLink{}, client.NewErrFieldNotExist(field.Name)
+	}
+
+	if field.Typ == client.PN_COUNTER {
+		if val.IsDelete() {
+			return core.DAGLink{}, ErrCannotDeleteCounter
+		}
+		// Document values are always parsed as LWW registers as the document has no
+		// knowledge of the schema, counters must be saved as such.
+		val = client.NewCBORValue(client.PN_COUNTER, val.Value())
+	}
+
+	val, err := toFieldKindValue(field, val)
+	if err != nil {
+		return core.DAGLink{}, err
+	}
+
+	node, _, err := c.saveDocValue(ctx, txn, fieldKey, val)
+	if err != nil {
+		return core.DAGLink{}, err
+	}
+	if val.IsDelete() {
+		properties[field.Name] = nil
+	} else {
+		properties[field.Name] = val.Value()
+	}
+
+	return core.DAGLink{
+		Name: field.Name,
+		Cid:  node.Cid(),
+	}, nil
+}
+
+// saveEmbeddedValue saves the given value of the given embedded object field to the fields
+// holding the values within it, returning the links to the saved values.
+//
+// Deleting the embedded object deletes all of the values within it. The saved values are
+// added to the given properties.
+func (c *collection) saveEmbeddedValue(
+	ctx context.Context,
+	txn datastore.Txn,
+	primaryKey core.PrimaryDataStoreKey,
+	field client.FieldDescription,
+	val client.Value,
+	properties map[string]any,
+) ([]core.DAGLink, error) {
+	links := []core.DAGLink{}
+
+	if val.IsDelete() {
+		for _, embeddedField := range c.desc.GetEmbeddedFields(field.Name) {
+			if embeddedField.Typ == client.PN_COUNTER {
+				// Counters cannot be deleted, and are left as they are.
+				continue
+			}
+			embeddedVal := client.NewCBORValue(embeddedField.Typ, nil)
+			embeddedVal.Delete()
+
+			link, err := c.saveFieldValue(ctx, txn, primaryKey, embeddedField, embeddedVal, properties)
+			if err != nil {
+				return nil, err
+			}
+			links = append(links, link)
+		}
+		return links, nil
+	}
+
+	embeddedDoc, isDoc := val.Value().(*client.Document)
+	if !isDoc {
+		return nil, NewErrInvalidEmbeddedValue(field.Name, val.Value())
+	}
+
+	for name, f := range embeddedDoc.Fields() {
+		embeddedVal, err := embeddedDoc.GetValueWithField(f)
+		if err != nil {
+			return nil, err
+		}
+		if !embeddedVal.IsDirty() {
+			continue
+		}
+
+		embeddedFieldName := field.Name + client.EmbeddedFieldSeparator + name
+		embeddedField, valid := c.desc.GetField(embeddedFieldName)
+		if !valid {
+			return nil, client.NewErrFieldNotExist(embeddedFieldName)
+		}
+
+		link, err := c.saveFieldValue(ctx, txn, primaryKey, embeddedField, embeddedVal, properties)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// toFieldKindValue converts the given document value into the form in which values
+// of the given field's kind are persisted.
+//
+// Documents have no knowledge of the schema, JSON objects will have been parsed as
+// sub-documents and blobs as strings.
+func toFieldKindValue(field client.FieldDescription, val client.Value) (client.Value, error) {
+	if val.IsDelete() {
+		return val, nil
+	}
+
+	switch field.Kind {
+	case client.FieldKind_JSON:
+		jsonVal, err := client.ToJSONValue(val)
+		if err != nil {
+			return nil, err
+		}
+		return client.NewCBORValue(field.Typ, jsonVal), nil
+
+	case client.FieldKind_BLOB:
+		blobVal, err := toBlobValue(field.Name, val.Value())
+		if err != nil {
+			return nil, err
+		}
+		return client.NewCBORValue(field.Typ, blobVal), nil
+
+	default:
+		return val, nil
+	}
+}
+
+// toBlobValue decodes the given hex encoded blob value.
+func toBlobValue(name string, value any) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, NewErrInvalidBlobValue(name, value)
+	}
+	blob, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, NewErrInvalidBlobValue(name, value)
+	}
+	return blob, nil
+}
+
 func (c *collection) saveDocValue(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -954,17 +1309,62 @@ func (c *collection) saveDocValue(
 			}
 		}
 		return c.saveValueToMerkleCRDT(ctx, txn, key, client.LWW_REGISTER, bytes)
+	case client.PN_COUNTER:
+		wval, ok := val.(client.WriteableValue)
+		if !ok {
+			return nil, 0, client.ErrValueTypeMismatch
+		}
+		if val.IsDelete() {
+			return nil, 0, ErrCannotDeleteCounter
+		}
+		bytes, err := wval.Bytes()
+		if err != nil {
+			return nil, 0, err
+		}
+		return c.saveValueToMerkleCRDT(ctx, txn, key, client.PN_COUNTER, bytes)
 	default:
 		return nil, 0, ErrUnknownCRDT
 	}
 }
 
+// incrementDocValue adds the given value to the counter stored at the given field key.
+//
+// Negative values decrement the counter.
+func (c *collection) incrementDocValue(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.DataStoreKey,
+	increment any,
+) (ipld.Node, error) {
+	ctx = c.db.signingContext(ctx)
+	merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
+		txn,
+		core.NewCollectionSchemaVersionKey(c.Schema().VersionID),
+		c.db.events.Updates,
+		client.PN_COUNTER,
+		key,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := client.NewCBORValue(client.PN_COUNTER, increment).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	counter := merkleCRDT.(*crdt.MerklePNCounter)
+	node, _, err := counter.Increment(ctx, bytes)
+	return node, err
+}
+
 func (c *collection) saveValueToMerkleCRDT(
 	ctx context.Context,
 	txn datastore.Txn,
 	key core.DataStoreKey,
 	ctype client.CType,
 	args ...any) (ipld.Node, uint64, error) {
+	ctx = c.db.signingContext(ctx)
 	switch ctype {
 	case client.LWW_REGISTER:
 		merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
@@ -990,6 +1390,27 @@ func (c *collection) saveValueToMerkleCRDT(
 		}
 		lwwreg := merkleCRDT.(*crdt.MerkleLWWRegister)
 		return lwwreg.Set(ctx, bytes)
+	case client.PN_COUNTER:
+		merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
+			txn,
+			core.NewCollectionSchemaVersionKey(c.Schema().VersionID),
+			c.db.events.Updates,
+			ctype,
+			key,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(args) != 1 {
+			return nil, 0, ErrUnknownCRDTArgument
+		}
+		bytes, ok := args[0].([]byte)
+		if !ok {
+			return nil, 0, ErrUnknownCRDTArgument
+		}
+		counter := merkleCRDT.(*crdt.MerklePNCounter)
+		return counter.Set(ctx, bytes)
 	case client.COMPOSITE:
 		key = key.WithFieldId(core.COMPOSITE_NAMESPACE)
 		merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
@@ -1099,9 +1520,10 @@ func (c *collection) tryGetFieldKey(key core.PrimaryDataStoreKey, fieldName stri
 func (c *collection) tryGetSchemaFieldID(fieldName string) (uint32, bool) {
 	for _, field := range c.desc.Schema.Fields {
 		if field.Name == fieldName {
-			if field.IsObject() || field.IsObjectArray() {
+			if field.IsObject() || field.IsObjectArray() || field.IsEmbeddedObject() {
 				// We do not wish to match navigational properties, only
-				// fields directly on the collection.
+				// fields directly on the collection.  The values of embedded
+				// objects are held by their own fields.
 				return uint32(0), false
 			}
 			return uint32(field.ID), true
@@ -236,6 +236,16 @@ func (c *collection) applyDelete(
 		return ErrDocumentDeleted
 	}
 
+	err = c.checkDocAccess(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+
+	oldDoc, err := c.getDocForIndexing(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+
 	dsKey := key.ToDataStoreKey()
 
 	headset := clock.NewHeadSet(
@@ -268,16 +278,37 @@ func (c *collection) applyDelete(
 		return err
 	}
 
+	err = c.updateIndexedDoc(ctx, txn, oldDoc, nil)
+	if err != nil {
+		return err
+	}
+
+	err = c.deleteManyToManyLinks(ctx, txn, key.DocKey)
+	if err != nil {
+		return err
+	}
+
 	if c.db.events.Updates.HasValue() {
+		owner, err := c.getDocOwner(ctx, txn, key)
+		if err != nil {
+			return err
+		}
+		prunedHeight, err := c.getDocPrunedHeight(ctx, txn, key)
+		if err != nil {
+			return err
+		}
+
 		txn.OnSuccess(
 			func() {
 				c.db.events.Updates.Value().Publish(
 					events.Update{
-						DocKey:   key.DocKey,
-						Cid:      headNode.Cid(),
-						SchemaID: c.schemaID,
-						Block:    headNode,
-						Priority: priority,
+						DocKey:       key.DocKey,
+						Cid:          headNode.Cid(),
+						SchemaID:     c.schemaID,
+						Block:        headNode,
+						Priority:     priority,
+						Owner:        owner,
+						PrunedHeight: prunedHeight,
 					},
 				)
 			},
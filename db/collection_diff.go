@@ -0,0 +1,268 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	dag "github.com/ipfs/go-merkledag"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// compositeCommit holds the parts of a composite block of a document's history needed to
+// compare versions of the document.
+type compositeCommit struct {
+	height  uint64
+	parents []cid.Cid
+	fields  []string
+}
+
+// Diff returns the changes made to the fields of the document with the given DocKey between
+// the two given versions of the document.
+func (c *collection) Diff(
+	ctx context.Context,
+	key client.DocKey,
+	from cid.Cid,
+	to cid.Cid,
+) ([]client.FieldDiff, error) {
+	txn, err := c.getTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	diff, err := c.diff(ctx, txn, key, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return diff, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) diff(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	from cid.Cid,
+	to cid.Cid,
+) ([]client.FieldDiff, error) {
+	history, err := c.getCompositeHistory(ctx, txn, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, client.ErrDocumentNotFound
+	}
+	for _, version := range []cid.Cid{from, to} {
+		if _, ok := history[version]; !ok {
+			return nil, NewErrVersionNotFound(key.String(), version)
+		}
+	}
+
+	fromAncestors := getAncestors(history, from)
+	toAncestors := getAncestors(history, to)
+	if _, ok := toAncestors[from]; !ok {
+		return nil, NewErrDiffFromNotAncestor(from, to)
+	}
+
+	// Find the latest commit made after the from version that changed each field.
+	latestChanges := map[string]cid.Cid{}
+	for commitCid := range toAncestors {
+		if _, ok := fromAncestors[commitCid]; ok {
+			continue
+		}
+		commit := history[commitCid]
+		for _, field := range commit.fields {
+			latest, ok := latestChanges[field]
+			if !ok || commit.height > history[latest].height ||
+				(commit.height == history[latest].height && commitCid.String() > latest.String()) {
+				latestChanges[field] = commitCid
+			}
+		}
+	}
+
+	oldDoc, err := c.getVersion(ctx, txn, key, from)
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := c.getVersion(ctx, txn, key, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := []client.FieldDiff{}
+	for field, commitCid := range latestChanges {
+		oldValue, err := getDocFieldValue(oldDoc, field)
+		if err != nil {
+			return nil, err
+		}
+		newValue, err := getDocFieldValue(newDoc, field)
+		if err != nil {
+			return nil, err
+		}
+		// Fields may have been changed and then changed back again.
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		diff = append(diff, client.FieldDiff{
+			FieldName: field,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+			Cid:       commitCid.String(),
+			Height:    history[commitCid].height,
+		})
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].FieldName < diff[j].FieldName
+	})
+	return diff, nil
+}
+
+// getCompositeHistory returns all the composite commits of the document with the given key,
+// walking back through the document's DAG from its current heads.
+//
+// If the history of the document has been pruned, the walk stops at the commit of the
+// document's history snapshot, which is returned without parents.
+func (c *collection) getCompositeHistory(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+) (map[cid.Cid]compositeCommit, error) {
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.DataStoreKeyFromDocKey(key).WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey(),
+	)
+	toVisit, _, err := headset.List(ctx)
+	if err != nil {
+		return nil, NewErrFailedToGetHeads(err)
+	}
+
+	snapshot, hasSnapshot, err := corecrdt.GetHistorySnapshot(ctx, txn, base.MakeDocKey(c.desc, key.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	history := map[cid.Cid]compositeCommit{}
+	for len(toVisit) > 0 {
+		commitCid := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		if _, visited := history[commitCid]; visited {
+			continue
+		}
+
+		block, err := txn.DAGstore().Get(ctx, commitCid)
+		if err != nil {
+			return nil, err
+		}
+		nd, err := dag.DecodeProtobuf(block.RawData())
+		if err != nil {
+			return nil, err
+		}
+		delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+		if err != nil {
+			return nil, err
+		}
+
+		commit := compositeCommit{
+			height: delta.GetPriority(),
+		}
+		isSnapshotCommit := hasSnapshot && commitCid == snapshot.Commit
+		for _, link := range nd.Links() {
+			if link.Name == core.HEAD {
+				if isSnapshotCommit {
+					continue
+				}
+				commit.parents = append(commit.parents, link.Cid)
+				toVisit = append(toVisit, link.Cid)
+			} else {
+				commit.fields = append(commit.fields, link.Name)
+			}
+		}
+		history[commitCid] = commit
+	}
+
+	return history, nil
+}
+
+// getAncestors returns the given version along with all the versions preceding it.
+func getAncestors(history map[cid.Cid]compositeCommit, version cid.Cid) map[cid.Cid]struct{} {
+	ancestors := map[cid.Cid]struct{}{}
+	toVisit := []cid.Cid{version}
+	for len(toVisit) > 0 {
+		commitCid := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		if _, visited := ancestors[commitCid]; visited {
+			continue
+		}
+		ancestors[commitCid] = struct{}{}
+		toVisit = append(toVisit, history[commitCid].parents...)
+	}
+	return ancestors
+}
+
+// getVersion returns the state of the document with the given key at the given version.
+func (c *collection) getVersion(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	version cid.Cid,
+) (*client.Document, error) {
+	vf := new(fetcher.VersionedFetcher)
+	err := vf.Init(&c.desc, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vf.Start(ctx, txn, fetcher.NewVersionedSpan(core.DataStoreKeyFromDocKey(key), version))
+	if err != nil {
+		_ = vf.Close()
+		return nil, err
+	}
+
+	doc, err := vf.FetchNextDecoded(ctx)
+	if err != nil {
+		_ = vf.Close()
+		return nil, err
+	}
+	if err := vf.Close(); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		// The document was either deleted at this version, or may not be accessed.
+		return nil, client.ErrDocumentNotFound
+	}
+	return doc, nil
+}
+
+// getDocFieldValue returns the value of the given field of the given document, nil if the
+// field is not set.
+func getDocFieldValue(doc *client.Document, field string) (any, error) {
+	value, err := doc.Get(field)
+	if err != nil {
+		if errors.Is(err, client.ErrFieldNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return value, nil
+}
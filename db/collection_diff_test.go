@@ -0,0 +1,109 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestDiffReturnsChangedFields(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 21, "Weight": 1.5}`)
+	v1 := getTestDocHead(t, ctx, db, doc.Key())
+
+	saveTestDoc(t, ctx, col, doc, map[string]any{"Age": 22, "Weight": 2.5})
+	v2 := getTestDocHead(t, ctx, db, doc.Key())
+
+	saveTestDoc(t, ctx, col, doc, map[string]any{"Name": "Johnny", "Weight": 1.5})
+	v3 := getTestDocHead(t, ctx, db, doc.Key())
+
+	diff, err := col.Diff(ctx, doc.Key(), v1, v3)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]client.FieldDiff{
+			{
+				FieldName: "Age",
+				OldValue:  uint64(21),
+				NewValue:  uint64(22),
+				Cid:       v2.String(),
+				Height:    2,
+			},
+			{
+				FieldName: "Name",
+				OldValue:  "John",
+				NewValue:  "Johnny",
+				Cid:       v3.String(),
+				Height:    3,
+			},
+		},
+		diff,
+	)
+}
+
+func TestDiffWithSameVersionReturnsNoChanges(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 21}`)
+	v1 := getTestDocHead(t, ctx, db, doc.Key())
+
+	diff, err := col.Diff(ctx, doc.Key(), v1, v1)
+	require.NoError(t, err)
+	require.Empty(t, diff)
+}
+
+func TestDiffWithFromAfterToReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 21}`)
+	v1 := getTestDocHead(t, ctx, db, doc.Key())
+
+	saveTestDoc(t, ctx, col, doc, map[string]any{"Age": 22})
+	v2 := getTestDocHead(t, ctx, db, doc.Key())
+
+	_, err = col.Diff(ctx, doc.Key(), v2, v1)
+	require.ErrorIs(t, err, ErrDiffFromNotAncestor)
+}
+
+func TestDiffWithVersionOfOtherDocReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 21}`)
+	v1 := getTestDocHead(t, ctx, db, doc.Key())
+
+	otherDoc := createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 30}`)
+	otherVersion := getTestDocHead(t, ctx, db, otherDoc.Key())
+
+	_, err = col.Diff(ctx, doc.Key(), v1, otherVersion)
+	require.ErrorIs(t, err, ErrVersionNotFound)
+}
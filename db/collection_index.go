@@ -0,0 +1,794 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/events"
+)
+
+// indexNameRegexp restricts index names to characters that are safe to use
+// within a key segment.
+var indexNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// uniqueIndexNameSuffix is appended to the generated names of the indexes backing
+// unique constraints.
+const uniqueIndexNameSuffix = "UNIQUE"
+
+// CreateIndex creates a new secondary index on the collection and indexes all
+// existing documents.
+//
+// If the given description has no name, one will be generated. The ID will always be
+// assigned by the database. Returns the description of the created index.
+func (c *collection) CreateIndex(
+	ctx context.Context,
+	desc client.IndexDescription,
+) (client.IndexDescription, error) {
+	if desc.Unique {
+		return client.IndexDescription{}, NewErrCannotManageUniqueIndex(desc.Name)
+	}
+
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	index, err := c.createIndex(ctx, txn, desc)
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+
+	return index, c.commitImplicitTxn(ctx, txn)
+}
+
+// DropIndex removes the secondary index with the given name along with all of its entries.
+func (c *collection) DropIndex(ctx context.Context, indexName string) error {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	err = c.dropIndex(ctx, txn, indexName)
+	if err != nil {
+		return err
+	}
+
+	return c.commitImplicitTxn(ctx, txn)
+}
+
+// GetIndexes returns the descriptions of all the secondary indexes on the collection.
+func (c *collection) GetIndexes(ctx context.Context) ([]client.IndexDescription, error) {
+	txn, err := c.getTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	indexes, err := c.db.getCollectionIndexes(ctx, txn, c.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return indexes, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) createIndex(
+	ctx context.Context,
+	txn datastore.Txn,
+	desc client.IndexDescription,
+) (client.IndexDescription, error) {
+	if desc.ID != 0 {
+		return client.IndexDescription{}, NewErrCannotSetIndexID(desc.Name, desc.ID)
+	}
+
+	desc, err := c.validateIndexDescription(desc)
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+
+	existingIndexes, err := c.db.getCollectionIndexes(ctx, txn, c.Name())
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+
+	if desc.Name == "" {
+		desc.Name = generateIndexName(c.Name(), desc, existingIndexes)
+	} else {
+		for _, index := range existingIndexes {
+			if index.Name == desc.Name {
+				return client.IndexDescription{}, NewErrIndexWithNameAlreadyExists(desc.Name)
+			}
+		}
+	}
+
+	indexSeq, err := c.db.getSequence(ctx, txn, fmt.Sprintf("%s/%d", core.COLLECTION_INDEX, c.ID()))
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+	indexID, err := indexSeq.next(ctx, txn)
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+	desc.ID = uint32(indexID)
+
+	buf, err := json.Marshal(desc)
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+
+	indexKey := core.NewCollectionIndexKey(c.Name(), desc.Name)
+	err = txn.Systemstore().Put(ctx, indexKey.ToDS(), buf)
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+
+	err = c.indexExistingDocs(ctx, txn, desc)
+	if err != nil {
+		return client.IndexDescription{}, err
+	}
+
+	// The slice is copied so that any other instances of this collection are left untouched.
+	indexes := make([]client.IndexDescription, len(c.desc.Indexes), len(c.desc.Indexes)+1)
+	copy(indexes, c.desc.Indexes)
+	c.desc.Indexes = append(indexes, desc)
+
+	return desc, nil
+}
+
+func (c *collection) dropIndex(ctx context.Context, txn datastore.Txn, indexName string) error {
+	indexKey := core.NewCollectionIndexKey(c.Name(), indexName)
+	buf, err := txn.Systemstore().Get(ctx, indexKey.ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return NewErrIndexWithNameDoesNotExist(indexName)
+		}
+		return err
+	}
+
+	var desc client.IndexDescription
+	err = json.Unmarshal(buf, &desc)
+	if err != nil {
+		return err
+	}
+
+	if desc.Unique {
+		return NewErrCannotManageUniqueIndex(indexName)
+	}
+
+	prefix := core.IndexDataStoreKey{
+		CollectionID: c.ID(),
+		IndexID:      desc.ID,
+	}
+	entryKeys, err := getKeysWithPrefix(ctx, txn.Datastore(), prefix.ToString())
+	if err != nil {
+		return err
+	}
+
+	for _, entryKey := range entryKeys {
+		err = txn.Datastore().Delete(ctx, entryKey)
+		if err != nil {
+			return NewErrFailedToDeleteIndexedField(indexName, err)
+		}
+	}
+
+	err = txn.Systemstore().Delete(ctx, indexKey.ToDS())
+	if err != nil {
+		return err
+	}
+
+	indexes := make([]client.IndexDescription, 0, len(c.desc.Indexes))
+	for _, index := range c.desc.Indexes {
+		if index.Name != indexName {
+			indexes = append(indexes, index)
+		}
+	}
+	c.desc.Indexes = indexes
+
+	return nil
+}
+
+// getCollectionIndexes returns the descriptions of all the indexes of the collection
+// of the given name.
+func (db *db) getCollectionIndexes(
+	ctx context.Context,
+	txn datastore.Txn,
+	collectionName string,
+) ([]client.IndexDescription, error) {
+	prefix := core.NewCollectionIndexKey(collectionName, "")
+	q, err := txn.Systemstore().Query(ctx, query.Query{
+		Prefix: prefix.ToString(),
+		Orders: []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := q.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close collection index query", err)
+		}
+	}()
+
+	var indexes []client.IndexDescription
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		var index client.IndexDescription
+		err = json.Unmarshal(res.Value, &index)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// getKeysWithPrefix returns all the keys within the given store that start with the given prefix.
+//
+// The keys are read upfront so that the caller is free to modify the store whilst
+// iterating through them.
+func getKeysWithPrefix(ctx context.Context, store datastore.DSReaderWriter, prefix string) ([]ds.Key, error) {
+	q, err := store.Query(ctx, query.Query{
+		Prefix:   prefix,
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := q.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close key prefix query", err)
+		}
+	}()
+
+	keys := []ds.Key{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		keys = append(keys, ds.NewKey(res.Key))
+	}
+
+	return keys, nil
+}
+
+// areIndexesEqual returns true if both sets of index descriptions are the same.
+func areIndexesEqual(a, b []client.IndexDescription) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name ||
+			a[i].ID != b[i].ID ||
+			a[i].Unique != b[i].Unique ||
+			len(a[i].Fields) != len(b[i].Fields) {
+			return false
+		}
+		for j := range a[i].Fields {
+			if a[i].Fields[j] != b[i].Fields[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validateIndexDescription validates the given index against the collection,
+// returning a copy with any defaults applied.
+func (c *collection) validateIndexDescription(desc client.IndexDescription) (client.IndexDescription, error) {
+	if desc.Name != "" && !indexNameRegexp.MatchString(desc.Name) {
+		return client.IndexDescription{}, NewErrInvalidIndexName(desc.Name)
+	}
+
+	if len(desc.Fields) == 0 {
+		return client.IndexDescription{}, ErrIndexMissingFields
+	}
+
+	fields := make([]client.IndexedFieldDescription, len(desc.Fields))
+	fieldNames := map[string]struct{}{}
+	for i, field := range desc.Fields {
+		if field.Name == "" {
+			return client.IndexDescription{}, ErrIndexFieldMissingName
+		}
+
+		if _, isDuplicate := fieldNames[field.Name]; isDuplicate {
+			return client.IndexDescription{}, NewErrDuplicateIndexField(field.Name)
+		}
+		fieldNames[field.Name] = struct{}{}
+
+		switch field.Direction {
+		case "":
+			field.Direction = client.Ascending
+		case client.Ascending, client.Descending:
+		default:
+			return client.IndexDescription{}, NewErrInvalidIndexFieldDirection(field.Name, field.Direction)
+		}
+
+		fieldDesc, exists := c.desc.GetField(field.Name)
+		if !exists {
+			return client.IndexDescription{}, NewErrNonExistingFieldForIndex(field.Name)
+		}
+		if !isIndexableField(fieldDesc) {
+			return client.IndexDescription{}, NewErrUnsupportedIndexFieldKind(field.Name, fieldDesc.Kind)
+		}
+
+		fields[i] = field
+	}
+	desc.Fields = fields
+
+	return desc, nil
+}
+
+// isIndexableField returns true if values of the given field may be stored within an index.
+func isIndexableField(field client.FieldDescription) bool {
+	if field.Name == request.KeyFieldName {
+		return false
+	}
+
+	switch field.Kind {
+	case client.FieldKind_DocKey,
+		client.FieldKind_BOOL,
+		client.FieldKind_INT,
+		client.FieldKind_FLOAT,
+		client.FieldKind_DATETIME,
+		client.FieldKind_STRING:
+		return true
+	default:
+		return false
+	}
+}
+
+// generateIndexName returns a name for the given index that does not clash with any
+// of the existing indexes.
+func generateIndexName(
+	collectionName string,
+	desc client.IndexDescription,
+	existingIndexes []client.IndexDescription,
+) string {
+	var sb strings.Builder
+	sb.WriteString(collectionName)
+	for _, field := range desc.Fields {
+		sb.WriteString("_")
+		sb.WriteString(field.Name)
+	}
+	sb.WriteString("_")
+	if desc.Unique {
+		sb.WriteString(uniqueIndexNameSuffix)
+	} else {
+		sb.WriteString(string(desc.Fields[0].Direction))
+	}
+	baseName := sb.String()
+
+	existingNames := make(map[string]struct{}, len(existingIndexes))
+	for _, index := range existingIndexes {
+		existingNames[index.Name] = struct{}{}
+	}
+
+	name := baseName
+	for i := 2; ; i++ {
+		if _, exists := existingNames[name]; !exists {
+			return name
+		}
+		name = fmt.Sprintf("%s_%d", baseName, i)
+	}
+}
+
+// indexExistingDocs adds entries for all the existing documents of the collection
+// to the given index.
+func (c *collection) indexExistingDocs(
+	ctx context.Context,
+	txn datastore.Txn,
+	index client.IndexDescription,
+) error {
+	// All documents must be indexed, regardless of who owns them.
+	ctx = acp.WithoutAccessControl(ctx)
+
+	df := new(fetcher.DocumentFetcher)
+	err := df.Init(&c.desc, nil, false, false)
+	if err != nil {
+		_ = df.Close()
+		return err
+	}
+
+	err = df.Start(ctx, txn, core.Spans{})
+	if err != nil {
+		_ = df.Close()
+		return err
+	}
+
+	for {
+		doc, err := df.FetchNextDecoded(ctx)
+		if err != nil {
+			_ = df.Close()
+			return err
+		}
+		if doc == nil {
+			break
+		}
+
+		key, err := c.getIndexDataStoreKey(index, doc)
+		if err != nil {
+			_ = df.Close()
+			return err
+		}
+
+		err = txn.Datastore().Put(ctx, key.ToDS(), []byte{})
+		if err != nil {
+			_ = df.Close()
+			return NewErrFailedToStoreIndexedField(index.Name, err)
+		}
+	}
+
+	return df.Close()
+}
+
+// getDocForIndexing returns the current state of the document with the given key,
+// if the collection has any indexes to maintain.
+//
+// Returns nil if the collection has no indexes or if the document does not exist.
+func (c *collection) getDocForIndexing(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+) (*client.Document, error) {
+	if len(c.desc.Indexes) == 0 {
+		return nil, nil
+	}
+	// Index entries must reflect the document regardless of who owns it.
+	return c.get(acp.WithoutAccessControl(ctx), txn, key, false)
+}
+
+// updateIndexedDoc replaces the index entries of the old state of a document with
+// the entries of its new state.
+//
+// The old document should be nil if the document is being created, and the new
+// document should be nil if it is being deleted.
+//
+// Will return an ErrUniqueConstraintViolation error if the new state of the document
+// violates any of the unique constraints of the collection.
+func (c *collection) updateIndexedDoc(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	return c.updateIndexes(ctx, txn, oldDoc, newDoc, true)
+}
+
+// SyncIndexedDoc brings the indexes of the collection up to date with the current state of
+// the document with the given key, oldDoc being the state of the document before it was
+// modified outside of the collection (for example by changes received from a peer).
+//
+// oldDoc should be nil if the document did not previously exist.
+//
+// Changes received from peers cannot be rejected without preventing the peers from converging,
+// any unique constraint violations caused by them are therefore logged instead of returned.
+func (c *collection) SyncIndexedDoc(ctx context.Context, oldDoc *client.Document, key client.DocKey) error {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	newDoc, err := c.getDocForIndexing(ctx, txn, c.getPrimaryKeyFromDocKey(key))
+	if err != nil {
+		return err
+	}
+
+	err = c.updateIndexes(ctx, txn, oldDoc, newDoc, false)
+	if err != nil {
+		return err
+	}
+
+	return c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) updateIndexes(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+	enforceUnique bool,
+) error {
+	for _, index := range c.desc.Indexes {
+		var oldKey, newKey core.IndexDataStoreKey
+		var err error
+		if oldDoc != nil {
+			oldKey, err = c.getIndexDataStoreKey(index, oldDoc)
+			if err != nil {
+				return err
+			}
+		}
+		if newDoc != nil {
+			newKey, err = c.getIndexDataStoreKey(index, newDoc)
+			if err != nil {
+				return err
+			}
+		}
+
+		if oldDoc != nil && newDoc != nil && oldKey.ToString() == newKey.ToString() {
+			continue
+		}
+
+		if oldDoc != nil {
+			err = c.deleteIndexEntry(ctx, txn, index, oldKey)
+			if err != nil {
+				return err
+			}
+		}
+		if newDoc != nil {
+			err = c.storeIndexEntry(ctx, txn, index, newDoc, newKey, enforceUnique)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// storeIndexEntry stores the entry of the given document within the given index.
+//
+// The entries of unique indexes omit the document key from their key, and hold it as their
+// value instead, so that any transactions writing the same field values conflict on commit.
+// Documents with a nil value for any of the indexed fields keep the regular layout, as do
+// documents received from a peer that violate the constraint so that the entry of the other
+// document is kept.
+//
+// Will return an ErrUniqueConstraintViolation error if the document violates the unique index
+// and enforceUnique is true, otherwise the violation is published as an IndexConflict event.
+func (c *collection) storeIndexEntry(
+	ctx context.Context,
+	txn datastore.Txn,
+	index client.IndexDescription,
+	doc *client.Document,
+	key core.IndexDataStoreKey,
+	enforceUnique bool,
+) error {
+	value := []byte{}
+	if index.Unique {
+		fieldNames, hasNil, err := getUniqueFieldNames(index, doc)
+		if err != nil {
+			return err
+		}
+		if !hasNil {
+			conflictingDocKey, err := c.getUniqueConflict(ctx, txn, key)
+			if err != nil {
+				return err
+			}
+			switch {
+			case conflictingDocKey == "":
+				value = []byte(key.DocKey)
+				key.DocKey = ""
+			case enforceUnique:
+				return NewErrUniqueConstraintViolation(fieldNames, key.DocKey, conflictingDocKey)
+			default:
+				c.publishIndexConflict(txn, index, fieldNames, key.DocKey, conflictingDocKey)
+			}
+		}
+	}
+
+	err := txn.Datastore().Put(ctx, key.ToDS(), value)
+	if err != nil {
+		return NewErrFailedToStoreIndexedField(index.Name, err)
+	}
+	return nil
+}
+
+// deleteIndexEntry deletes the entry of the given document from the given index, in whichever
+// layout it has been stored.
+func (c *collection) deleteIndexEntry(
+	ctx context.Context,
+	txn datastore.Txn,
+	index client.IndexDescription,
+	key core.IndexDataStoreKey,
+) error {
+	if index.Unique {
+		uniqueKey := key
+		uniqueKey.DocKey = ""
+		value, err := txn.Datastore().Get(ctx, uniqueKey.ToDS())
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		if err == nil && string(value) == key.DocKey {
+			key = uniqueKey
+		}
+	}
+
+	err := txn.Datastore().Delete(ctx, key.ToDS())
+	if err != nil {
+		return NewErrFailedToDeleteIndexedField(index.Name, err)
+	}
+	return nil
+}
+
+// getUniqueFieldNames returns the names of the fields of the given unique index, and whether
+// the given document has a nil value for any of them.
+//
+// Documents with a nil value for any of the indexed fields are never in violation.
+func getUniqueFieldNames(index client.IndexDescription, doc *client.Document) ([]string, bool, error) {
+	fieldNames := make([]string, len(index.Fields))
+	for i, field := range index.Fields {
+		value, err := getIndexedFieldValue(doc, field.Name)
+		if err != nil {
+			return nil, false, err
+		}
+		if value == nil {
+			return nil, true, nil
+		}
+		fieldNames[i] = field.Name
+	}
+	return fieldNames, false, nil
+}
+
+// getUniqueConflict returns the key of a document other than the one of the given entry that
+// already has an entry with the same field values, or an empty string if there is none.
+//
+// The unique entry is read within the given transaction, so a concurrent transaction storing
+// the same field values will conflict with it on commit.
+func (c *collection) getUniqueConflict(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.IndexDataStoreKey,
+) (string, error) {
+	uniqueKey := key
+	uniqueKey.DocKey = ""
+	value, err := txn.Datastore().Get(ctx, uniqueKey.ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return "", err
+	}
+	if err == nil && string(value) != key.DocKey {
+		return string(value), nil
+	}
+
+	// Documents indexed despite a violation keep their key within the key of their entry.
+	existingKeys, err := getKeysWithPrefix(ctx, txn.Datastore(), uniqueKey.ToString())
+	if err != nil {
+		return "", err
+	}
+	for _, existingKey := range existingKeys {
+		if existingKey.Name() != key.DocKey {
+			return existingKey.Name(), nil
+		}
+	}
+
+	return "", nil
+}
+
+// publishIndexConflict publishes an IndexConflict event once the given transaction has been
+// committed, if index conflict events are enabled.
+func (c *collection) publishIndexConflict(
+	txn datastore.Txn,
+	index client.IndexDescription,
+	fieldNames []string,
+	docKey string,
+	conflictingDocKey string,
+) {
+	if !c.db.events.IndexConflicts.HasValue() {
+		return
+	}
+	txn.OnSuccess(
+		func() {
+			c.db.events.IndexConflicts.Value().Publish(
+				events.IndexConflict{
+					CollectionName:    c.Name(),
+					IndexName:         index.Name,
+					Fields:            fieldNames,
+					DocKey:            docKey,
+					ConflictingDocKey: conflictingDocKey,
+				},
+			)
+		},
+	)
+}
+
+// getIndexDataStoreKey returns the key of the entry of the given document within the given index.
+func (c *collection) getIndexDataStoreKey(
+	index client.IndexDescription,
+	doc *client.Document,
+) (core.IndexDataStoreKey, error) {
+	key := core.IndexDataStoreKey{
+		CollectionID: c.ID(),
+		IndexID:      index.ID,
+		FieldValues:  make([]string, len(index.Fields)),
+		DocKey:       doc.Key().String(),
+	}
+
+	for i, field := range index.Fields {
+		fieldDesc, exists := c.desc.GetField(field.Name)
+		if !exists {
+			return core.IndexDataStoreKey{}, NewErrNonExistingFieldForIndex(field.Name)
+		}
+
+		value, err := getIndexedFieldValue(doc, field.Name)
+		if err != nil {
+			return core.IndexDataStoreKey{}, err
+		}
+
+		key.FieldValues[i], err = core.EncodeIndexFieldValue(fieldDesc.Kind, value, field.Direction)
+		if err != nil {
+			return core.IndexDataStoreKey{}, err
+		}
+	}
+
+	return key, nil
+}
+
+// newUniqueIndexDescription returns the description of the index backing the given
+// unique constraint.
+func newUniqueIndexDescription(constraint client.UniqueConstraintDescription) client.IndexDescription {
+	fields := make([]client.IndexedFieldDescription, len(constraint.Fields))
+	for i, fieldName := range constraint.Fields {
+		fields[i] = client.IndexedFieldDescription{
+			Name:      fieldName,
+			Direction: client.Ascending,
+		}
+	}
+	return client.IndexDescription{
+		Fields: fields,
+		Unique: true,
+	}
+}
+
+// areUniqueConstraintsEqual returns true if both sets of unique constraints are the same.
+func areUniqueConstraintsEqual(a, b []client.UniqueConstraintDescription) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i].Fields) != len(b[i].Fields) {
+			return false
+		}
+		for j := range a[i].Fields {
+			if a[i].Fields[j] != b[i].Fields[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getIndexedFieldValue returns the value of the given field of the given document, as it
+// is to be indexed.
+//
+// Fields that are not set on the document are indexed as nil.
+func getIndexedFieldValue(doc *client.Document, fieldName string) (any, error) {
+	docValue, err := doc.GetValue(fieldName)
+	if err != nil {
+		if errors.Is(err, client.ErrFieldNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if docValue.IsDelete() {
+		return nil, nil
+	}
+	return docValue.Value(), nil
+}
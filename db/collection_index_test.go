@@ -0,0 +1,465 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v3"
+	"github.com/sourcenetwork/defradb/events"
+)
+
+func TestCreateIndex_WithoutName_GeneratesName(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	desc, err := col.CreateIndex(ctx, client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{{Name: "Name"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "users_Name_ASC", desc.Name)
+	assert.Equal(t, uint32(1), desc.ID)
+	assert.Equal(t, client.Ascending, desc.Fields[0].Direction)
+}
+
+func TestCreateIndex_WithExistingName_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	index := client.IndexDescription{
+		Name:   "UsersByName",
+		Fields: []client.IndexedFieldDescription{{Name: "Name"}},
+	}
+	_, err = col.CreateIndex(ctx, index)
+	require.NoError(t, err)
+
+	_, err = col.CreateIndex(ctx, index)
+	assert.ErrorIs(t, err, NewErrIndexWithNameAlreadyExists("UsersByName"))
+}
+
+func TestCreateIndex_WithInvalidDescription_ReturnsError(t *testing.T) {
+	testCases := []struct {
+		description   string
+		index         client.IndexDescription
+		expectedError error
+	}{
+		{
+			description:   "no fields",
+			index:         client.IndexDescription{},
+			expectedError: ErrIndexMissingFields,
+		},
+		{
+			description: "field without name",
+			index: client.IndexDescription{
+				Fields: []client.IndexedFieldDescription{{}},
+			},
+			expectedError: ErrIndexFieldMissingName,
+		},
+		{
+			description: "invalid name",
+			index: client.IndexDescription{
+				Name:   "users by name",
+				Fields: []client.IndexedFieldDescription{{Name: "Name"}},
+			},
+			expectedError: NewErrInvalidIndexName("users by name"),
+		},
+		{
+			description: "ID set",
+			index: client.IndexDescription{
+				ID:     2,
+				Fields: []client.IndexedFieldDescription{{Name: "Name"}},
+			},
+			expectedError: NewErrCannotSetIndexID("", 2),
+		},
+		{
+			description: "non-existing field",
+			index: client.IndexDescription{
+				Fields: []client.IndexedFieldDescription{{Name: "Email"}},
+			},
+			expectedError: NewErrNonExistingFieldForIndex("Email"),
+		},
+		{
+			description: "duplicate field",
+			index: client.IndexDescription{
+				Fields: []client.IndexedFieldDescription{{Name: "Name"}, {Name: "Name"}},
+			},
+			expectedError: NewErrDuplicateIndexField("Name"),
+		},
+		{
+			description: "invalid direction",
+			index: client.IndexDescription{
+				Fields: []client.IndexedFieldDescription{{Name: "Name", Direction: "UP"}},
+			},
+			expectedError: NewErrInvalidIndexFieldDirection("Name", "UP"),
+		},
+	}
+
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	for _, testCase := range testCases {
+		_, err := col.CreateIndex(ctx, testCase.index)
+		assert.ErrorIs(t, err, testCase.expectedError, testCase.description)
+	}
+}
+
+func TestCreateIndex_WithExistingDocs_IndexesDocs(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21, "Weight": 154.1}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	index, err := col.CreateIndex(ctx, client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{{Name: "Age"}},
+	})
+	require.NoError(t, err)
+
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+
+	keys, err := getKeysWithPrefix(ctx, txn.Datastore(), "/1/"+fmt.Sprint(index.ID))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, doc.Key().String(), keys[0].Name())
+}
+
+func TestDropIndex_ShouldRemoveIndex(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	_, err = col.CreateIndex(ctx, client.IndexDescription{
+		Name:   "UsersByName",
+		Fields: []client.IndexedFieldDescription{{Name: "Name"}},
+	})
+	require.NoError(t, err)
+
+	err = col.DropIndex(ctx, "UsersByName")
+	require.NoError(t, err)
+
+	indexes, err := col.GetIndexes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, indexes)
+}
+
+func TestDropIndex_WithNonExistingIndex_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	err = col.DropIndex(ctx, "UsersByName")
+	assert.ErrorIs(t, err, NewErrIndexWithNameDoesNotExist("UsersByName"))
+}
+
+func TestGetIndexes_ShouldReturnIndexesFromNewCollectionInstance(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	index, err := col.CreateIndex(ctx, client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{{Name: "Age", Direction: client.Descending}},
+	})
+	require.NoError(t, err)
+
+	col, err = db.GetCollectionByName(ctx, "users")
+	require.NoError(t, err)
+
+	indexes, err := col.GetIndexes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.IndexDescription{index}, indexes)
+	assert.Equal(t, []client.IndexDescription{index}, col.Description().Indexes)
+}
+
+func newTestCollectionWithUniqueName(
+	t *testing.T,
+	ctx context.Context,
+	db *implicitTxnDB,
+) client.Collection {
+	desc := client.CollectionDescription{
+		Name: "users",
+		Schema: client.SchemaDescription{
+			Fields: []client.FieldDescription{
+				{
+					Name: "_key",
+					Kind: client.FieldKind_DocKey,
+				},
+				{
+					Name: "Name",
+					Kind: client.FieldKind_STRING,
+					Typ:  client.LWW_REGISTER,
+				},
+				{
+					Name: "Age",
+					Kind: client.FieldKind_INT,
+					Typ:  client.LWW_REGISTER,
+				},
+			},
+			UniqueConstraints: []client.UniqueConstraintDescription{
+				{
+					Fields: []string{"Name"},
+				},
+			},
+		},
+	}
+
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+
+	col, err := db.createCollection(ctx, txn, desc)
+	require.NoError(t, err)
+
+	err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	return col
+}
+
+func TestCreateCollection_WithUniqueConstraint_CreatesUniqueIndex(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	indexes, err := col.GetIndexes(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]client.IndexDescription{
+			{
+				Name:   "users_Name_UNIQUE",
+				ID:     1,
+				Fields: []client.IndexedFieldDescription{{Name: "Name", Direction: client.Ascending}},
+				Unique: true,
+			},
+		},
+		indexes,
+	)
+}
+
+func TestCreateIndex_WithUnique_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	_, err = col.CreateIndex(ctx, client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{{Name: "Name"}},
+		Unique: true,
+	})
+	assert.ErrorIs(t, err, ErrCannotManageUniqueIndex)
+}
+
+func TestDropIndex_WithUniqueIndex_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	err = col.DropIndex(ctx, "users_Name_UNIQUE")
+	assert.ErrorIs(t, err, ErrCannotManageUniqueIndex)
+}
+
+func TestCreate_WithDuplicateUniqueValue_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc1)
+	require.NoError(t, err)
+
+	doc2, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 30}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc2)
+	assert.ErrorIs(t, err, ErrUniqueConstraintViolation)
+
+	exists, err := col.Exists(ctx, doc2.Key())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestCreate_WithNilUniqueValues_Succeeds(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"Age": 21}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc1)
+	require.NoError(t, err)
+
+	doc2, err := client.NewDocFromJSON([]byte(`{"Age": 30}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc2)
+	assert.NoError(t, err)
+}
+
+func TestUpdate_WithDuplicateUniqueValue_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc1)
+	require.NoError(t, err)
+
+	doc2, err := client.NewDocFromJSON([]byte(`{"Name": "Fred", "Age": 30}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc2)
+	require.NoError(t, err)
+
+	_, err = col.UpdateWithKey(ctx, doc2.Key(), `{"Name": "John"}`)
+	assert.ErrorIs(t, err, ErrUniqueConstraintViolation)
+
+	// Updating the other fields of a document must not be blocked by its own entry.
+	_, err = col.UpdateWithKey(ctx, doc1.Key(), `{"Age": 22}`)
+	assert.NoError(t, err)
+}
+
+func TestCreate_WithConcurrentDuplicateUniqueValue_ConflictsOnCommit(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	txn1, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	defer txn1.Discard(ctx)
+	txn2, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	defer txn2.Discard(ctx)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	err = col.WithTxn(txn1).Create(ctx, doc1)
+	require.NoError(t, err)
+
+	// Neither transaction can see the entry of the other one.
+	doc2, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 30}`))
+	require.NoError(t, err)
+	err = col.WithTxn(txn2).Create(ctx, doc2)
+	require.NoError(t, err)
+
+	err = txn1.Commit(ctx)
+	require.NoError(t, err)
+
+	err = txn2.Commit(ctx)
+	assert.ErrorIs(t, err, badgerds.ErrTxnConflict)
+
+	exists, err := col.Exists(ctx, doc2.Key())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestUpdate_WithUniqueValueOfDeletedDoc_Succeeds(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc1)
+	require.NoError(t, err)
+
+	doc2, err := client.NewDocFromJSON([]byte(`{"Name": "Fred", "Age": 30}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc2)
+	require.NoError(t, err)
+
+	_, err = col.Delete(ctx, doc1.Key())
+	require.NoError(t, err)
+
+	_, err = col.UpdateWithKey(ctx, doc2.Key(), `{"Name": "John"}`)
+	assert.NoError(t, err)
+}
+
+func TestSyncIndexedDoc_WithUniqueConflict_IndexesDoc(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx, WithUpdateEvents())
+	require.NoError(t, err)
+	col := newTestCollectionWithUniqueName(t, ctx, db)
+
+	conflicts, err := db.Events().IndexConflicts.Value().Subscribe()
+	require.NoError(t, err)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc1)
+	require.NoError(t, err)
+
+	// Simulate a document received from a peer, bypassing the index maintenance.
+	unindexedCol := *col.(*collection)
+	unindexedCol.desc.Indexes = nil
+	doc2, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 30}`))
+	require.NoError(t, err)
+	err = unindexedCol.Create(ctx, doc2)
+	require.NoError(t, err)
+
+	err = col.(*collection).SyncIndexedDoc(ctx, nil, doc2.Key())
+	require.NoError(t, err)
+
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+
+	keys, err := getKeysWithPrefix(ctx, txn.Datastore(), "/1/1")
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	assert.Equal(
+		t,
+		events.IndexConflict{
+			CollectionName:    "users",
+			IndexName:         "users_Name_UNIQUE",
+			Fields:            []string{"Name"},
+			DocKey:            doc2.Key().String(),
+			ConflictingDocKey: doc1.Key().String(),
+		},
+		<-conflicts,
+	)
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/request/graphql/schema"
+)
+
+// manyToManyLink is a document of the link collection of a many-to-many relation.
+type manyToManyLink struct {
+	// key is the dockey of the link document.
+	key string
+	// linkedKey is the dockey of the document linked to.
+	linkedKey string
+}
+
+// getManyToManyField returns the many-to-many relation field whose links are set through the
+// document field of the given name, which is the name of the relation field suffixed with `_id`.
+//
+// The links are not stored on the document, but as documents of the link collection of the relation.
+func (c *collection) getManyToManyField(name string) (client.FieldDescription, bool) {
+	if !strings.HasSuffix(name, "_id") {
+		return client.FieldDescription{}, false
+	}
+
+	field, valid := c.desc.GetField(strings.TrimSuffix(name, "_id"))
+	return field, valid && field.Kind == client.FieldKind_FOREIGN_OBJECT_ARRAY &&
+		schema.IsManyToMany(field.RelationType)
+}
+
+// toManyToManyLinks converts the given document value, set for the given many-to-many relation
+// field, into the list of dockeys to link to.
+func toManyToManyLinks(field client.FieldDescription, value any) ([]string, error) {
+	if value == nil {
+		return []string{}, nil
+	}
+
+	values, ok := value.([]any)
+	if !ok {
+		return nil, NewErrInvalidManyToManyLinks(field.Name, value)
+	}
+
+	linkedKeys := make([]string, len(values))
+	for i, v := range values {
+		linkedKey, ok := v.(string)
+		if !ok {
+			return nil, NewErrInvalidManyToManyLinks(field.Name, value)
+		}
+		linkedKeys[i] = linkedKey
+	}
+	return linkedKeys, nil
+}
+
+// setManyToManyLinks replaces the links of the document of the given key through the given
+// many-to-many relation field with links to the documents of the given keys.
+//
+// Returns an error if any of the documents to link to does not exist.
+func (c *collection) setManyToManyLinks(
+	ctx context.Context,
+	txn datastore.Txn,
+	field client.FieldDescription,
+	docKey string,
+	linkedKeys []string,
+) error {
+	linkCol, err := c.getLinkCollection(ctx, txn, field)
+	if err != nil {
+		return err
+	}
+	linkedCol, err := c.db.getCollectionByName(ctx, txn, field.Schema)
+	if err != nil {
+		return err
+	}
+	linkedCol = linkedCol.WithTxn(txn)
+
+	wanted := make(map[string]struct{}, len(linkedKeys))
+	for _, linkedKey := range linkedKeys {
+		key, err := client.NewDocKeyFromString(linkedKey)
+		if err != nil {
+			return err
+		}
+		exists, err := linkedCol.Exists(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return NewErrLinkedDocNotFound(field.Name, linkedKey)
+		}
+		wanted[linkedKey] = struct{}{}
+	}
+
+	linkFieldName := schema.ManyToManyLinkFieldName(c.desc.Schema.Name)
+	linkedFieldName := schema.ManyToManyLinkFieldName(field.Schema)
+
+	links, err := linkCol.getManyToManyLinks(ctx, txn, linkFieldName, linkedFieldName, docKey)
+	if err != nil {
+		return err
+	}
+
+	linked := make(map[string]struct{}, len(links))
+	for _, link := range links {
+		if _, isWanted := wanted[link.linkedKey]; isWanted {
+			linked[link.linkedKey] = struct{}{}
+			continue
+		}
+		err = linkCol.applyDelete(ctx, txn, linkCol.getPrimaryKey(link.key))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, linkedKey := range linkedKeys {
+		if _, isLinked := linked[linkedKey]; isLinked {
+			continue
+		}
+		err = linkCol.createLink(ctx, txn, map[string]any{
+			linkFieldName:   docKey,
+			linkedFieldName: linkedKey,
+		})
+		if err != nil {
+			return err
+		}
+		linked[linkedKey] = struct{}{}
+	}
+
+	return nil
+}
+
+// deleteManyToManyLinks deletes all the links of the document of the given key through the
+// many-to-many relation fields of this collection.
+func (c *collection) deleteManyToManyLinks(ctx context.Context, txn datastore.Txn, docKey string) error {
+	for _, field := range c.desc.Schema.Fields {
+		if field.Kind != client.FieldKind_FOREIGN_OBJECT_ARRAY || !schema.IsManyToMany(field.RelationType) {
+			continue
+		}
+
+		linkCol, err := c.getLinkCollection(ctx, txn, field)
+		if err != nil {
+			return err
+		}
+
+		links, err := linkCol.getManyToManyLinks(
+			ctx,
+			txn,
+			schema.ManyToManyLinkFieldName(c.desc.Schema.Name),
+			schema.ManyToManyLinkFieldName(field.Schema),
+			docKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, link := range links {
+			err = linkCol.applyDelete(ctx, txn, linkCol.getPrimaryKey(link.key))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getLinkCollection returns the link collection of the given many-to-many relation field.
+func (c *collection) getLinkCollection(
+	ctx context.Context,
+	txn datastore.Txn,
+	field client.FieldDescription,
+) (*collection, error) {
+	linkCol, err := c.db.getCollectionByName(ctx, txn, field.RelationName)
+	if err != nil {
+		return nil, err
+	}
+	return linkCol.(*collection), nil
+}
+
+// getManyToManyLinks returns the documents of this link collection linking the document of the
+// given key, held by the given link field, to other documents.
+func (c *collection) getManyToManyLinks(
+	ctx context.Context,
+	txn datastore.Txn,
+	linkFieldName string,
+	linkedFieldName string,
+	docKey string,
+) ([]manyToManyLink, error) {
+	// The link fields are indexed, the selection plan will only read the links of the document.
+	selectionPlan, err := c.makeSelectionPlan(ctx, txn, fmt.Sprintf(`{%s: {_eq: "%s"}}`, linkFieldName, docKey))
+	if err != nil {
+		return nil, err
+	}
+	if err := selectionPlan.Start(); err != nil {
+		return nil, err
+	}
+
+	// If the plan isn't properly closed at any exit point log the error.
+	defer func() {
+		if err := selectionPlan.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close the selection plan, after getting links", err)
+		}
+	}()
+
+	docMap := selectionPlan.DocumentMap()
+	links := []manyToManyLink{}
+	for {
+		next, err := selectionPlan.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !next {
+			break
+		}
+
+		doc := docMap.ToMap(selectionPlan.Value())
+		key, _ := doc[request.KeyFieldName].(string)
+		linkedKey, _ := doc[linkedFieldName].(string)
+		links = append(links, manyToManyLink{
+			key:       key,
+			linkedKey: linkedKey,
+		})
+	}
+
+	return links, nil
+}
+
+// createLink creates a document holding the given values in this link collection.
+//
+// Link documents are keyed by their contents so that the same link created on different nodes
+// converges to a single document. As deleted documents cannot be recreated, a link that has
+// previously been removed is recreated under a key derived from that of the deleted document.
+func (c *collection) createLink(ctx context.Context, txn datastore.Txn, values map[string]any) error {
+	doc, err := client.NewDocFromMap(values)
+	if err != nil {
+		return err
+	}
+
+	key := doc.Key()
+	for {
+		exists, isDeleted, err := c.exists(ctx, txn, c.getPrimaryKeyFromDocKey(key))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			break
+		}
+		if !isDeleted {
+			return nil
+		}
+		key, err = nextLinkKey(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	linkDoc := client.NewDocWithKey(key)
+	for name, value := range values {
+		err = linkDoc.Set(name, value)
+		if err != nil {
+			return err
+		}
+	}
+	return c.createWithKey(ctx, txn, linkDoc)
+}
+
+// nextLinkKey returns the key under which a link previously held by the deleted document of the
+// given key is recreated.
+func nextLinkKey(key client.DocKey) (client.DocKey, error) {
+	pref := cid.Prefix{
+		Version:  1,
+		Codec:    cid.Raw,
+		MhType:   mh.SHA2_256,
+		MhLength: -1, // default length
+	}
+	c, err := pref.Sum([]byte(key.String()))
+	if err != nil {
+		return client.DocKey{}, err
+	}
+	return client.NewDocKeyV0(c), nil
+}
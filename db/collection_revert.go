@@ -0,0 +1,120 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// Revert restores the document with the given DocKey to its state at the given version.
+//
+// The state at the given version is reconstructed from the document's history, and the fields
+// that differ from the current state are written as a new commit. As the history is never
+// rewritten, peers receiving the new commit will converge on the reverted state.
+func (c *collection) Revert(ctx context.Context, key client.DocKey, version cid.Cid) error {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	err = c.revert(ctx, txn, key, version)
+	if err != nil {
+		return err
+	}
+	return c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) revert(ctx context.Context, txn datastore.Txn, key client.DocKey, version cid.Cid) error {
+	primaryKey := c.getPrimaryKeyFromDocKey(key)
+	exists, isDeleted, err := c.exists(ctx, txn, primaryKey)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return client.ErrDocumentNotFound
+	}
+	if isDeleted {
+		return ErrDocumentDeleted
+	}
+
+	history, err := c.getCompositeHistory(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+	if _, ok := history[version]; !ok {
+		return NewErrVersionNotFound(key.String(), version)
+	}
+
+	currentDoc, err := c.get(ctx, txn, primaryKey, false)
+	if err != nil {
+		return err
+	}
+	versionDoc, err := c.getVersion(ctx, txn, key, version)
+	if err != nil {
+		return err
+	}
+
+	fieldTypes := map[string]client.CType{}
+	for _, doc := range []*client.Document{currentDoc, versionDoc} {
+		for field, fieldValue := range doc.Fields() {
+			fieldTypes[field] = fieldValue.Type()
+		}
+	}
+
+	// Only the fields that differ from the current state are written to the new commit.
+	revertedDoc := client.NewDocWithKey(key)
+	hasChanged := false
+	for field, ctype := range fieldTypes {
+		versionValue, err := getDocFieldValue(versionDoc, field)
+		if err != nil {
+			return err
+		}
+		currentValue, err := getDocFieldValue(currentDoc, field)
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(versionValue, currentValue) {
+			continue
+		}
+
+		if versionValue == nil {
+			if ctype == client.PN_COUNTER {
+				// Counters cannot be deleted, and are left as they are.
+				continue
+			}
+			err = revertedDoc.SetAs(field, nil, ctype)
+			if err != nil {
+				return err
+			}
+			err = revertedDoc.Delete(field)
+		} else {
+			err = revertedDoc.SetAs(field, versionValue, ctype)
+		}
+		if err != nil {
+			return err
+		}
+		hasChanged = true
+	}
+
+	if !hasChanged {
+		return nil
+	}
+
+	_, err = c.save(ctx, txn, revertedDoc, false)
+	return err
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevertRestoresFieldValuesAsNewVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 21}`)
+	v1 := getTestDocHead(t, ctx, db, doc.Key())
+
+	saveTestDoc(t, ctx, col, doc, map[string]any{"Name": "Johnny", "Age": 22})
+	v2 := getTestDocHead(t, ctx, db, doc.Key())
+
+	err = col.Revert(ctx, doc.Key(), v1)
+	require.NoError(t, err)
+	v3 := getTestDocHead(t, ctx, db, doc.Key())
+	require.NotEqual(t, v1, v3)
+
+	revertedDoc, err := col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	name, err := revertedDoc.Get("Name")
+	require.NoError(t, err)
+	require.Equal(t, "John", name)
+	age, err := revertedDoc.Get("Age")
+	require.NoError(t, err)
+	require.Equal(t, uint64(21), age)
+
+	// The reverted state is appended to the history rather than replacing it.
+	diff, err := col.Diff(ctx, doc.Key(), v2, v3)
+	require.NoError(t, err)
+	require.Len(t, diff, 2)
+	for _, fieldDiff := range diff {
+		require.Equal(t, uint64(3), fieldDiff.Height)
+	}
+}
+
+func TestRevertDeletesFieldsNotSetAtVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John"}`)
+	v1 := getTestDocHead(t, ctx, db, doc.Key())
+
+	saveTestDoc(t, ctx, col, doc, map[string]any{"Age": 22})
+
+	err = col.Revert(ctx, doc.Key(), v1)
+	require.NoError(t, err)
+
+	revertedDoc, err := col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	age, err := getDocFieldValue(revertedDoc, "Age")
+	require.NoError(t, err)
+	require.Nil(t, age)
+}
+
+func TestRevertToCurrentVersionDoesNotCreateNewVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 21}`)
+	v1 := getTestDocHead(t, ctx, db, doc.Key())
+
+	err = col.Revert(ctx, doc.Key(), v1)
+	require.NoError(t, err)
+	require.Equal(t, v1, getTestDocHead(t, ctx, db, doc.Key()))
+}
+
+func TestRevertWithVersionOfOtherDocReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 21}`)
+
+	otherDoc := createTestDoc(t, ctx, col, `{"Name": "Fred", "Age": 40}`)
+	otherVersion := getTestDocHead(t, ctx, db, otherDoc.Key())
+
+	err = col.Revert(ctx, doc.Key(), otherVersion)
+	require.ErrorIs(t, err, ErrVersionNotFound)
+}
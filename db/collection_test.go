@@ -15,10 +15,13 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/merkle/clock"
 )
 
 func newTestCollectionWithSchema(
@@ -64,6 +67,42 @@ func newTestCollectionWithSchema(
 	return col, txn.Commit(ctx)
 }
 
+func createTestDoc(t *testing.T, ctx context.Context, col client.Collection, docJSON string) *client.Document {
+	doc, err := client.NewDocFromJSON([]byte(docJSON))
+	require.NoError(t, err)
+
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+	return doc
+}
+
+func saveTestDoc(
+	t *testing.T,
+	ctx context.Context,
+	col client.Collection,
+	doc *client.Document,
+	values map[string]any,
+) {
+	for field, value := range values {
+		err := doc.Set(field, value)
+		require.NoError(t, err)
+	}
+	err := col.Save(ctx, doc)
+	require.NoError(t, err)
+}
+
+// getTestDocHead returns the cid of the single composite head of the document with the given key.
+func getTestDocHead(t *testing.T, ctx context.Context, db *implicitTxnDB, key client.DocKey) cid.Cid {
+	headset := clock.NewHeadSet(
+		db.multistore.Headstore(),
+		core.DataStoreKeyFromDocKey(key).WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey(),
+	)
+	heads, _, err := headset.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, heads, 1)
+	return heads[0]
+}
+
 func TestNewCollection_ReturnsError_GivenNoSchema(t *testing.T) {
 	ctx := context.Background()
 	db, err := newMemoryDB(ctx)
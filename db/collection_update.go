@@ -12,10 +12,12 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	cbor "github.com/fxamacker/cbor/v2"
+	ipld "github.com/ipfs/go-ipld-format"
 	"github.com/sourcenetwork/immutable"
 	"github.com/valyala/fastjson"
 
@@ -285,6 +287,17 @@ func (c *collection) applyMerge(
 		return ErrDocMissingKey
 	}
 	key := c.getPrimaryKey(keyStr)
+
+	err := c.checkDocAccess(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+
+	oldDoc, err := c.getDocForIndexing(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+
 	links := make([]core.DAGLink, 0)
 
 	mergeMap := make(map[string]*fastjson.Value)
@@ -295,13 +308,60 @@ func (c *collection) applyMerge(
 	mergeCBOR := make(map[string]any)
 
 	for mfield, mval := range mergeMap {
-		if mval.Type() == fastjson.TypeObject {
-			return ErrInvalidMergeValueType
+		fd, valid := c.desc.GetField(mfield)
+
+		if valid && fd.IsEmbeddedObject() {
+			embeddedLinks, err := c.applyEmbeddedMerge(ctx, txn, key, fd, mval, mergeCBOR)
+			if err != nil {
+				return err
+			}
+			links = append(links, embeddedLinks...)
+			continue
+		}
+
+		if mval.Type() == fastjson.TypeObject && (!valid || fd.Kind != client.FieldKind_JSON) {
+			// Other than as JSON values, object values are only permitted as increment/decrement
+			// operations on counters.
+			if !valid || fd.Typ != client.PN_COUNTER {
+				return ErrInvalidMergeValueType
+			}
+
+			fieldKey, fieldExists := c.tryGetFieldKey(key, mfield)
+			if !fieldExists {
+				return client.NewErrFieldNotExist(mfield)
+			}
+
+			node, op, err := c.applyCounterOperation(ctx, txn, fieldKey, fd, mval.GetObject())
+			if err != nil {
+				return err
+			}
+			mergeCBOR[mfield] = op
+
+			links = append(links, core.DAGLink{
+				Name: mfield,
+				Cid:  node.Cid(),
+			})
+			continue
 		}
 
-		fd, valid := c.desc.GetField(mfield)
 		if !valid {
-			return client.NewErrFieldNotExist(mfield)
+			relationFieldDescription, isManyToManyLinks := c.getManyToManyField(mfield)
+			if !isManyToManyLinks {
+				return client.NewErrFieldNotExist(mfield)
+			}
+
+			linkedKeys, err := getArray(mval, getString)
+			if err != nil {
+				return err
+			}
+
+			err = c.setManyToManyLinks(ctx, txn, relationFieldDescription, keyStr, linkedKeys)
+			if err != nil {
+				return err
+			}
+
+			// The links are stored in the link collection of the relation, and not on the document.
+			continue
 		}
 
 		relationFieldDescription, isSecondaryRelationID := c.isSecondaryIDField(fd)
@@ -321,28 +381,18 @@ func (c *collection) applyMerge(
 			continue
 		}
 
-		cborVal, err := validateFieldSchema(mval, fd)
-		if err != nil {
-			return err
-		}
-		mergeCBOR[mfield] = cborVal
-
-		val := client.NewCBORValue(fd.Typ, cborVal)
-		fieldKey, fieldExists := c.tryGetFieldKey(key, mfield)
-		if !fieldExists {
-			return client.NewErrFieldNotExist(mfield)
-		}
-
-		c, _, err := c.saveDocValue(ctx, txn, fieldKey, val)
+		link, err := c.applyFieldMerge(ctx, txn, key, fd, mval, mergeCBOR)
 		if err != nil {
 			return err
 		}
+		links = append(links, link)
+	}
 
-		links = append(links, core.DAGLink{
-			Name: mfield,
-			Cid:  c.Cid(),
-		})
+	migratedLinks, err := c.saveMigratedFields(ctx, txn, key, mergeCBOR)
+	if err != nil {
+		return err
 	}
+	links = append(links, migratedLinks...)
 
 	// Update CompositeDAG
 	em, err := cbor.CanonicalEncOptions().EncMode()
@@ -367,16 +417,36 @@ func (c *collection) applyMerge(
 		return err
 	}
 
+	newDoc, err := c.getDocForIndexing(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+	err = c.updateIndexedDoc(ctx, txn, oldDoc, newDoc)
+	if err != nil {
+		return err
+	}
+
 	if c.db.events.Updates.HasValue() {
+		owner, err := c.getDocOwner(ctx, txn, key)
+		if err != nil {
+			return err
+		}
+		prunedHeight, err := c.getDocPrunedHeight(ctx, txn, key)
+		if err != nil {
+			return err
+		}
+
 		txn.OnSuccess(
 			func() {
 				c.db.events.Updates.Value().Publish(
 					events.Update{
-						DocKey:   keyStr,
-						Cid:      headNode.Cid(),
-						SchemaID: c.schemaID,
-						Block:    headNode,
-						Priority: priority,
+						DocKey:       keyStr,
+						Cid:          headNode.Cid(),
+						SchemaID:     c.schemaID,
+						Block:        headNode,
+						Priority:     priority,
+						Owner:        owner,
+						PrunedHeight: prunedHeight,
 					},
 				)
 			},
@@ -386,6 +456,136 @@ func (c *collection) applyMerge(
 	return nil
 }
 
+// applyCounterOperation applies the given `_inc` or `_dec` operation to the counter
+// field stored at the given key.
+//
+// It returns the new field block, and the operation as it should be recorded in the
+// composite delta.
+func (c *collection) applyCounterOperation(
+	ctx context.Context,
+	txn datastore.Txn,
+	fieldKey core.DataStoreKey,
+	fd client.FieldDescription,
+	operation *fastjson.Object,
+) (ipld.Node, map[string]any, error) {
+	if operation.Len() != 1 {
+		return nil, nil, NewErrInvalidCounterOperation(fd.Name)
+	}
+
+	var opName string
+	var opValue *fastjson.Value
+	operation.Visit(func(k []byte, v *fastjson.Value) {
+		opName = string(k)
+		opValue = v
+	})
+
+	value, err := validateFieldSchema(opValue, fd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var increment any
+	switch opName {
+	case request.IncrementOpName:
+		increment = value
+	case request.DecrementOpName:
+		switch typedValue := value.(type) {
+		case int64:
+			increment = -typedValue
+		case float64:
+			increment = -typedValue
+		}
+	default:
+		return nil, nil, NewErrInvalidCounterOperation(fd.Name)
+	}
+
+	node, err := c.incrementDocValue(ctx, txn, fieldKey, increment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return node, map[string]any{opName: value}, nil
+}
+
+// applyFieldMerge saves the given merge value of the given field, returning the link to the
+// saved value.
+//
+// The saved value is added to the given merge properties.
+func (c *collection) applyFieldMerge(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+	fd client.FieldDescription,
+	mval *fastjson.Value,
+	mergeCBOR map[string]any,
+) (core.DAGLink, error) {
+	cborVal, err := validateFieldSchema(mval, fd)
+	if err != nil {
+		return core.DAGLink{}, err
+	}
+	err = c.desc.Schema.ValidateEnumValue(fd, cborVal)
+	if err != nil {
+		return core.DAGLink{}, err
+	}
+	mergeCBOR[fd.Name] = cborVal
+
+	val := client.NewCBORValue(fd.Typ, cborVal)
+	fieldKey, fieldExists := c.tryGetFieldKey(key, fd.Name)
+	if !fieldExists {
+		return core.DAGLink{}, client.NewErrFieldNotExist(fd.Name)
+	}
+
+	node, _, err := c.saveDocValue(ctx, txn, fieldKey, val)
+	if err != nil {
+		return core.DAGLink{}, err
+	}
+
+	return core.DAGLink{
+		Name: fd.Name,
+		Cid:  node.Cid(),
+	}, nil
+}
+
+// applyEmbeddedMerge saves the given merge value of the given embedded object field to the
+// fields holding the values within it, returning the links to the saved values.
+//
+// Only the values present in the merge object are modified.
+func (c *collection) applyEmbeddedMerge(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+	fd client.FieldDescription,
+	mval *fastjson.Value,
+	mergeCBOR map[string]any,
+) ([]core.DAGLink, error) {
+	if mval.Type() != fastjson.TypeObject {
+		return nil, NewErrInvalidEmbeddedValue(fd.Name, mval.String())
+	}
+
+	links := []core.DAGLink{}
+	var err error
+	mval.GetObject().Visit(func(k []byte, v *fastjson.Value) {
+		if err != nil {
+			return
+		}
+
+		embeddedFieldName := fd.Name + client.EmbeddedFieldSeparator + string(k)
+		embeddedField, valid := c.desc.GetField(embeddedFieldName)
+		if !valid {
+			err = client.NewErrFieldNotExist(embeddedFieldName)
+			return
+		}
+
+		var link core.DAGLink
+		link, err = c.applyFieldMerge(ctx, txn, key, embeddedField, v, mergeCBOR)
+		links = append(links, link)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
 // isSecondaryIDField returns true if the given field description represents a secondary relation field ID.
 func (c *collection) isSecondaryIDField(fieldDesc client.FieldDescription) (client.FieldDescription, bool) {
 	if fieldDesc.RelationType != client.Relation_Type_INTERNAL_ID {
@@ -440,7 +640,7 @@ func (c *collection) patchPrimaryDoc(
 // the typed value again as an interface.
 func validateFieldSchema(val *fastjson.Value, field client.FieldDescription) (any, error) {
 	switch field.Kind {
-	case client.FieldKind_DocKey, client.FieldKind_STRING:
+	case client.FieldKind_DocKey, client.FieldKind_STRING, client.FieldKind_ENUM:
 		return getString(val)
 
 	case client.FieldKind_STRING_ARRAY:
@@ -484,6 +684,16 @@ func validateFieldSchema(val *fastjson.Value, field client.FieldDescription) (an
 	case client.FieldKind_NILLABLE_INT_ARRAY:
 		return getNillableArray(val, getInt64)
 
+	case client.FieldKind_JSON:
+		return getJSON(val)
+
+	case client.FieldKind_BLOB:
+		str, err := getString(val)
+		if err != nil {
+			return nil, err
+		}
+		return toBlobValue(field.Name, str)
+
 	case client.FieldKind_FOREIGN_OBJECT, client.FieldKind_FOREIGN_OBJECT_ARRAY:
 		return nil, ErrMergeSubTypeNotSupported
 	}
@@ -508,6 +718,15 @@ func getInt64(v *fastjson.Value) (int64, error) {
 	return v.Int64()
 }
 
+func getJSON(v *fastjson.Value) (any, error) {
+	var value any
+	err := json.Unmarshal(v.MarshalTo(nil), &value)
+	if err != nil {
+		return nil, err
+	}
+	return client.NormalizeJSONValue(value), nil
+}
+
 func getArray[T any](
 	val *fastjson.Value,
 	typeGetter func(*fastjson.Value) (T, error),
@@ -617,7 +836,7 @@ func (c *collection) makeSelectLocal(filter immutable.Option[request.Filter]) (*
 	}
 
 	for _, fd := range c.Schema().Fields {
-		if fd.IsObject() {
+		if fd.IsObject() || fd.IsEmbeddedObject() || strings.Contains(fd.Name, client.EmbeddedFieldSeparator) {
 			continue
 		}
 		slct.Fields = append(slct.Fields, &request.Field{
@@ -0,0 +1,76 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// Upsert updates the documents matching the given filter, or creates the given document
+// if no documents match it.
+//
+// The filter and updater follow the same rules as those given to UpdateWithFilter. As the
+// documents are matched and written within the same transaction, concurrent upserts of the
+// same documents will result in a transaction conflict instead of duplicate documents.
+func (c *collection) Upsert(
+	ctx context.Context,
+	filter any,
+	doc *client.Document,
+	updater string,
+) (*client.UpsertResult, error) {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	res, err := c.upsert(ctx, txn, filter, doc, updater)
+	if err != nil {
+		return nil, err
+	}
+	return res, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) upsert(
+	ctx context.Context,
+	txn datastore.Txn,
+	filter any,
+	doc *client.Document,
+	updater string,
+) (*client.UpsertResult, error) {
+	if doc == nil {
+		return nil, ErrUpsertWithoutDocument
+	}
+
+	updateResult, err := c.updateWithFilter(ctx, txn, filter, updater)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateResult.Count > 0 {
+		return &client.UpsertResult{
+			DocKeys: updateResult.DocKeys,
+		}, nil
+	}
+
+	err = c.create(ctx, txn, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.UpsertResult{
+		Created: true,
+		DocKeys: []string{doc.Key().String()},
+	}, nil
+}
@@ -17,18 +17,22 @@ package db
 import (
 	"context"
 	"sync"
+	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/logging"
+	"github.com/sourcenetwork/defradb/merkle/clock"
 	"github.com/sourcenetwork/defradb/merkle/crdt"
 	"github.com/sourcenetwork/defradb/request/graphql"
 )
@@ -63,6 +67,17 @@ type db struct {
 	// The maximum number of retries per transaction.
 	maxTxnRetries immutable.Option[int]
 
+	// The key used to sign the blocks created by this database, nil if blocks are not signed.
+	signingKey crypto.PrivKey
+
+	// The interval at which the history of collections with a history policy is pruned,
+	// zero if the history is only pruned on request.
+	historyPruneInterval time.Duration
+
+	// closing is closed when the database is closed, stopping its background routines.
+	closing    chan struct{}
+	background sync.WaitGroup
+
 	// The options used to init the database
 	options any
 }
@@ -72,11 +87,12 @@ type Option func(*db)
 
 const updateEventBufferSize = 100
 
-// WithUpdateEvents enables the update events channel.
+// WithUpdateEvents enables the update and index conflict events channels.
 func WithUpdateEvents() Option {
 	return func(db *db) {
 		db.events = events.Events{
-			Updates: immutable.Some(events.New[events.Update](0, updateEventBufferSize)),
+			Updates:        immutable.Some(events.New[events.Update](0, updateEventBufferSize)),
+			IndexConflicts: immutable.Some(events.New[events.IndexConflict](0, updateEventBufferSize)),
 		}
 	}
 }
@@ -88,6 +104,21 @@ func WithMaxRetries(num int) Option {
 	}
 }
 
+// WithSigningKey enables the signing of the blocks created by the database using the given key.
+func WithSigningKey(key crypto.PrivKey) Option {
+	return func(db *db) {
+		db.signingKey = key
+	}
+}
+
+// WithHistoryPruneInterval enables the pruning of the history of the collections that have a
+// history policy at the given interval.
+func WithHistoryPruneInterval(interval time.Duration) Option {
+	return func(db *db) {
+		db.historyPruneInterval = interval
+	}
+}
+
 // NewDB creates a new instance of the DB using the given options.
 func NewDB(ctx context.Context, rootstore datastore.RootStore, options ...Option) (client.DB, error) {
 	return newDB(ctx, rootstore, options...)
@@ -112,6 +143,7 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 
 		parser:  parser,
 		options: options,
+		closing: make(chan struct{}),
 	}
 
 	// apply options
@@ -127,9 +159,26 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 		return nil, err
 	}
 
+	if db.historyPruneInterval > 0 {
+		db.background.Add(1)
+		go db.pruneHistoryPeriodically(context.Background(), db.historyPruneInterval)
+	}
+
 	return &implicitTxnDB{db}, nil
 }
 
+// signingContext returns a context within which the blocks created are signed using the
+// key of the identity of the request if known, or otherwise the signing key of the database, if any.
+func (db *db) signingContext(ctx context.Context) context.Context {
+	if key := acp.KeyFromContext(ctx); key != nil {
+		return clock.WithSigner(ctx, key)
+	}
+	if db.signingKey == nil {
+		return ctx
+	}
+	return clock.WithSigner(ctx, db.signingKey)
+}
+
 // NewTxn creates a new transaction.
 func (db *db) NewTxn(ctx context.Context, readonly bool) (datastore.Txn, error) {
 	return datastore.NewTxnFrom(ctx, db.rootstore, readonly)
@@ -233,9 +282,15 @@ func (db *db) PrintDump(ctx context.Context) error {
 // This is the place for any last minute cleanup or releasing of resources (i.e.: Badger instance).
 func (db *db) Close(ctx context.Context) {
 	log.Info(ctx, "Closing DefraDB process...")
+	close(db.closing)
+	db.background.Wait()
+
 	if db.events.Updates.HasValue() {
 		db.events.Updates.Value().Close()
 	}
+	if db.events.IndexConflicts.HasValue() {
+		db.events.IndexConflicts.Value().Close()
+	}
 
 	err := db.rootstore.Close()
 	if err != nil {
@@ -16,8 +16,11 @@ import (
 
 	badger "github.com/dgraph-io/badger/v3"
 	dag "github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
 	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
@@ -25,13 +28,13 @@ import (
 	"github.com/sourcenetwork/defradb/merkle/clock"
 )
 
-func newMemoryDB(ctx context.Context) (*implicitTxnDB, error) {
+func newMemoryDB(ctx context.Context, options ...Option) (*implicitTxnDB, error) {
 	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
 	rootstore, err := badgerds.NewDatastore("", &opts)
 	if err != nil {
 		return nil, err
 	}
-	return newDB(ctx, rootstore)
+	return newDB(ctx, rootstore, options...)
 }
 
 func TestNewDB(t *testing.T) {
@@ -414,3 +417,72 @@ func TestDBSchemaSaveSimpleDocument(t *testing.T) {
 	err = db.PrintDump(ctx)
 	assert.Nil(t, err)
 }
+
+func TestDBWithSigningKeySignsBlocks(t *testing.T) {
+	ctx := context.Background()
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	identity, err := acp.NewIdentity(key.GetPublic())
+	require.NoError(t, err)
+
+	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
+	rootstore, err := badgerds.NewDatastore("", &opts)
+	require.NoError(t, err)
+	db, err := newDB(ctx, rootstore, WithSigningKey(key))
+	require.NoError(t, err)
+	defer db.Close(ctx)
+
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	result := db.ExecRequest(ctx, `query { commits { signer } }`)
+	require.Empty(t, result.GQL.Errors)
+
+	commits, ok := result.GQL.Data.([]map[string]any)
+	require.True(t, ok)
+	require.NotEmpty(t, commits)
+	for _, commit := range commits {
+		require.Equal(t, identity, commit["signer"])
+	}
+}
+
+func TestDBWithIdentityKeySignsBlocksWithIdentityKey(t *testing.T) {
+	ctx := context.Background()
+	nodeKey, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	ownerKey, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	ownerCtx, err := acp.NewContextWithKey(ctx, ownerKey)
+	require.NoError(t, err)
+
+	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
+	rootstore, err := badgerds.NewDatastore("", &opts)
+	require.NoError(t, err)
+	db, err := newDB(ctx, rootstore, WithSigningKey(nodeKey))
+	require.NoError(t, err)
+	defer db.Close(ctx)
+
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	err = col.Create(ownerCtx, doc)
+	require.NoError(t, err)
+
+	result := db.ExecRequest(ownerCtx, `query { commits { signer } }`)
+	require.Empty(t, result.GQL.Errors)
+
+	commits, ok := result.GQL.Data.([]map[string]any)
+	require.True(t, ok)
+	require.NotEmpty(t, commits)
+	for _, commit := range commits {
+		// Blocks of owned documents must be signed by the owner for other nodes to accept them.
+		require.Equal(t, acp.FromContext(ownerCtx), commit["signer"])
+	}
+}
@@ -11,6 +11,10 @@
 package db
 
 import (
+	"time"
+
+	"github.com/ipfs/go-cid"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/errors"
 )
@@ -32,9 +36,44 @@ const (
 	errDuplicateField                string = "duplicate field"
 	errCannotMutateField             string = "mutating an existing field is not supported"
 	errCannotMoveField               string = "moving fields is not currently supported"
-	errInvalidCRDTType               string = "only default or LWW (last writer wins) CRDT types are supported"
+	errInvalidCRDTType               string = "only default, LWW or PN counter CRDT types are supported"
 	errCannotDeleteField             string = "deleting an existing field is not supported"
+	errInvalidCRDTTypeForKind        string = "PN counter CRDT type is only supported on Int and Float fields"
+	errInvalidCounterOperation       string = "counter operations must contain exactly one of _inc or _dec"
 	errFieldKindNotFound             string = "no type found for given name"
+	errIndexMissingFields            string = "index must contain at least one field"
+	errIndexFieldMissingName         string = "index field must have a name"
+	errInvalidIndexFieldDirection    string = "invalid index field direction"
+	errInvalidIndexName              string = "index name may only contain letters, digits and underscores"
+	errCannotSetIndexID              string = "explicitly setting an index ID value is not supported"
+	errNonExistingFieldForIndex      string = "creating an index on a non-existing field"
+	errUnsupportedIndexFieldKind     string = "indexing fields of the given kind is not supported"
+	errDuplicateIndexField           string = "a field can only appear once within an index"
+	errIndexWithNameAlreadyExists    string = "an index with the given name already exists"
+	errIndexWithNameDoesNotExist     string = "an index with the given name does not exist"
+	errCannotModifyIndexesWithPatch  string = "modifying indexes via schema patch is not supported"
+	errFailedToStoreIndexedField     string = "failed to store indexed field"
+	errFailedToDeleteIndexedField    string = "failed to delete indexed field"
+	errCannotManageUniqueIndex       string = "unique indexes are managed through the schema"
+	errCannotModifyUniqueConstraints string = "modifying unique constraints is not supported"
+	errUniqueConstraintViolation     string = "a document with the given unique field values already exists"
+	errInvalidBackupFormat           string = "invalid backup format"
+	errFailedToCreateBackupFile      string = "failed to create backup file"
+	errFailedToOpenBackupFile        string = "failed to open backup file"
+	errInvalidBackup                 string = "invalid backup file"
+	errMissingBackupBlock            string = "backup is missing a block of the document history"
+	errDocumentAccessDenied          string = "the document is owned by another identity"
+	errInvalidBlobValue              string = "blob values must be hex encoded strings"
+	errCannotAddEmbeddedField        string = "the adding of new embedded object fields is not yet supported"
+	errInvalidEmbeddedValue          string = "embedded object values must be objects"
+	errCannotRemoveEnumValue         string = "enum values may not be removed or reordered"
+	errDuplicateEnumValue            string = "duplicate enum value"
+	errVersionNotFound               string = "the version is not part of the history of the document"
+	errDiffFromNotAncestor           string = "the from version must be an ancestor of the to version"
+	errCannotExportPrunedHistory     string = "the history of the document has been pruned and cannot be exported"
+	errNegativeHistoryDuration       string = "the duration for which history is kept may not be negative"
+	errInvalidManyToManyLinks        string = "many-to-many links must be given as a list of dockeys"
+	errLinkedDocNotFound             string = "the document to link to does not exist"
 )
 
 var (
@@ -52,36 +91,75 @@ var (
 	ErrInvalidMergeValueType   = errors.New(
 		"the type of value in the merge patch doesn't match the schema",
 	)
-	ErrMissingDocFieldToUpdate  = errors.New("missing document field to update")
-	ErrDocMissingKey            = errors.New("document is missing key")
-	ErrMergeSubTypeNotSupported = errors.New("merge doesn't support sub types yet")
-	ErrInvalidFilter            = errors.New("invalid filter")
-	ErrInvalidOpPath            = errors.New("invalid patch op path")
-	ErrDocumentAlreadyExists    = errors.New("a document with the given dockey already exists")
-	ErrDocumentDeleted          = errors.New("a document with the given dockey has been deleted")
-	ErrUnknownCRDTArgument      = errors.New("invalid CRDT arguments")
-	ErrUnknownCRDT              = errors.New("unknown crdt")
-	ErrSchemaFirstFieldDocKey   = errors.New("collection schema first field must be a DocKey")
-	ErrCollectionAlreadyExists  = errors.New("collection already exists")
-	ErrCollectionNameEmpty      = errors.New("collection name can't be empty")
-	ErrSchemaIdEmpty            = errors.New("schema ID can't be empty")
-	ErrSchemaVersionIdEmpty     = errors.New("schema version ID can't be empty")
-	ErrKeyEmpty                 = errors.New("key cannot be empty")
-	ErrAddingP2PCollection      = errors.New(errAddingP2PCollection)
-	ErrRemovingP2PCollection    = errors.New(errRemovingP2PCollection)
-	ErrAddCollectionWithPatch   = errors.New(errAddCollectionWithPatch)
-	ErrCollectionIDDoesntMatch  = errors.New(errCollectionIDDoesntMatch)
-	ErrSchemaIDDoesntMatch      = errors.New(errSchemaIDDoesntMatch)
-	ErrCannotModifySchemaName   = errors.New(errCannotModifySchemaName)
-	ErrCannotSetVersionID       = errors.New(errCannotSetVersionID)
-	ErrCannotSetFieldID         = errors.New(errCannotSetFieldID)
-	ErrCannotAddRelationalField = errors.New(errCannotAddRelationalField)
-	ErrDuplicateField           = errors.New(errDuplicateField)
-	ErrCannotMutateField        = errors.New(errCannotMutateField)
-	ErrCannotMoveField          = errors.New(errCannotMoveField)
-	ErrInvalidCRDTType          = errors.New(errInvalidCRDTType)
-	ErrCannotDeleteField        = errors.New(errCannotDeleteField)
-	ErrFieldKindNotFound        = errors.New(errFieldKindNotFound)
+	ErrMissingDocFieldToUpdate       = errors.New("missing document field to update")
+	ErrDocMissingKey                 = errors.New("document is missing key")
+	ErrMergeSubTypeNotSupported      = errors.New("merge doesn't support sub types yet")
+	ErrInvalidFilter                 = errors.New("invalid filter")
+	ErrUpsertWithoutDocument         = errors.New("upsert requires a document to create")
+	ErrInvalidOpPath                 = errors.New("invalid patch op path")
+	ErrDocumentAlreadyExists         = errors.New("a document with the given dockey already exists")
+	ErrDocumentDeleted               = errors.New("a document with the given dockey has been deleted")
+	ErrUnknownCRDTArgument           = errors.New("invalid CRDT arguments")
+	ErrUnknownCRDT                   = errors.New("unknown crdt")
+	ErrCannotDeleteCounter           = errors.New("PN counter fields cannot be set to null")
+	ErrSchemaFirstFieldDocKey        = errors.New("collection schema first field must be a DocKey")
+	ErrCollectionAlreadyExists       = errors.New("collection already exists")
+	ErrCollectionNameEmpty           = errors.New("collection name can't be empty")
+	ErrSchemaIdEmpty                 = errors.New("schema ID can't be empty")
+	ErrSchemaVersionIdEmpty          = errors.New("schema version ID can't be empty")
+	ErrKeyEmpty                      = errors.New("key cannot be empty")
+	ErrAddingP2PCollection           = errors.New(errAddingP2PCollection)
+	ErrRemovingP2PCollection         = errors.New(errRemovingP2PCollection)
+	ErrAddCollectionWithPatch        = errors.New(errAddCollectionWithPatch)
+	ErrCollectionIDDoesntMatch       = errors.New(errCollectionIDDoesntMatch)
+	ErrSchemaIDDoesntMatch           = errors.New(errSchemaIDDoesntMatch)
+	ErrCannotModifySchemaName        = errors.New(errCannotModifySchemaName)
+	ErrCannotSetVersionID            = errors.New(errCannotSetVersionID)
+	ErrCannotSetFieldID              = errors.New(errCannotSetFieldID)
+	ErrCannotAddRelationalField      = errors.New(errCannotAddRelationalField)
+	ErrDuplicateField                = errors.New(errDuplicateField)
+	ErrCannotMutateField             = errors.New(errCannotMutateField)
+	ErrCannotMoveField               = errors.New(errCannotMoveField)
+	ErrInvalidCRDTType               = errors.New(errInvalidCRDTType)
+	ErrCannotDeleteField             = errors.New(errCannotDeleteField)
+	ErrInvalidCRDTTypeForKind        = errors.New(errInvalidCRDTTypeForKind)
+	ErrInvalidCounterOperation       = errors.New(errInvalidCounterOperation)
+	ErrFieldKindNotFound             = errors.New(errFieldKindNotFound)
+	ErrIndexMissingFields            = errors.New(errIndexMissingFields)
+	ErrIndexFieldMissingName         = errors.New(errIndexFieldMissingName)
+	ErrInvalidIndexFieldDirection    = errors.New(errInvalidIndexFieldDirection)
+	ErrInvalidIndexName              = errors.New(errInvalidIndexName)
+	ErrCannotSetIndexID              = errors.New(errCannotSetIndexID)
+	ErrNonExistingFieldForIndex      = errors.New(errNonExistingFieldForIndex)
+	ErrUnsupportedIndexFieldKind     = errors.New(errUnsupportedIndexFieldKind)
+	ErrDuplicateIndexField           = errors.New(errDuplicateIndexField)
+	ErrIndexWithNameAlreadyExists    = errors.New(errIndexWithNameAlreadyExists)
+	ErrIndexWithNameDoesNotExist     = errors.New(errIndexWithNameDoesNotExist)
+	ErrCannotModifyIndexesWithPatch  = errors.New(errCannotModifyIndexesWithPatch)
+	ErrFailedToStoreIndexedField     = errors.New(errFailedToStoreIndexedField)
+	ErrFailedToDeleteIndexedField    = errors.New(errFailedToDeleteIndexedField)
+	ErrCannotManageUniqueIndex       = errors.New(errCannotManageUniqueIndex)
+	ErrCannotModifyUniqueConstraints = errors.New(errCannotModifyUniqueConstraints)
+	// ErrUniqueConstraintViolation occurs when a document is written with values that
+	// violate a unique constraint of its schema.
+	ErrUniqueConstraintViolation = errors.New(errUniqueConstraintViolation)
+	ErrInvalidBackupFormat       = errors.New(errInvalidBackupFormat)
+	ErrFailedToCreateBackupFile  = errors.New(errFailedToCreateBackupFile)
+	ErrFailedToOpenBackupFile    = errors.New(errFailedToOpenBackupFile)
+	ErrInvalidBackup             = errors.New(errInvalidBackup)
+	ErrMissingBackupBlock        = errors.New(errMissingBackupBlock)
+	ErrDocumentAccessDenied      = errors.New(errDocumentAccessDenied)
+	ErrInvalidBlobValue          = errors.New(errInvalidBlobValue)
+	ErrCannotAddEmbeddedField    = errors.New(errCannotAddEmbeddedField)
+	ErrInvalidEmbeddedValue      = errors.New(errInvalidEmbeddedValue)
+	ErrCannotRemoveEnumValue     = errors.New(errCannotRemoveEnumValue)
+	ErrDuplicateEnumValue        = errors.New(errDuplicateEnumValue)
+	ErrVersionNotFound           = errors.New(errVersionNotFound)
+	ErrDiffFromNotAncestor       = errors.New(errDiffFromNotAncestor)
+	ErrCannotExportPrunedHistory = errors.New(errCannotExportPrunedHistory)
+	ErrNegativeHistoryDuration   = errors.New(errNegativeHistoryDuration)
+	ErrInvalidManyToManyLinks    = errors.New(errInvalidManyToManyLinks)
+	ErrLinkedDocNotFound         = errors.New(errLinkedDocNotFound)
 )
 
 // NewErrFailedToGetHeads returns a new error indicating that the heads of a document
@@ -207,6 +285,18 @@ func NewErrInvalidCRDTType(name string, crdtType client.CType) error {
 	)
 }
 
+func NewErrInvalidCRDTTypeForKind(name string, kind client.FieldKind) error {
+	return errors.New(
+		errInvalidCRDTTypeForKind,
+		errors.NewKV("Name", name),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+func NewErrInvalidCounterOperation(name string) error {
+	return errors.New(errInvalidCounterOperation, errors.NewKV("Field", name))
+}
+
 func NewErrCannotDeleteField(name string, id client.FieldID) error {
 	return errors.New(
 		errCannotDeleteField,
@@ -214,3 +304,243 @@ func NewErrCannotDeleteField(name string, id client.FieldID) error {
 		errors.NewKV("ID", id),
 	)
 }
+
+// NewErrInvalidIndexFieldDirection returns a new error indicating that the given index field
+// direction is neither ascending nor descending.
+func NewErrInvalidIndexFieldDirection(name string, direction client.IndexDirection) error {
+	return errors.New(
+		errInvalidIndexFieldDirection,
+		errors.NewKV("Field", name),
+		errors.NewKV("Direction", direction),
+	)
+}
+
+// NewErrInvalidIndexName returns a new error indicating that the given index name
+// contains characters that are not permitted.
+func NewErrInvalidIndexName(name string) error {
+	return errors.New(errInvalidIndexName, errors.NewKV("Name", name))
+}
+
+// NewErrCannotSetIndexID returns a new error indicating that an index ID was provided
+// by the caller.
+func NewErrCannotSetIndexID(name string, id uint32) error {
+	return errors.New(
+		errCannotSetIndexID,
+		errors.NewKV("Name", name),
+		errors.NewKV("ID", id),
+	)
+}
+
+// NewErrNonExistingFieldForIndex returns a new error indicating that an index was requested
+// on a field that does not exist within the collection.
+func NewErrNonExistingFieldForIndex(field string) error {
+	return errors.New(errNonExistingFieldForIndex, errors.NewKV("Field", field))
+}
+
+// NewErrUnsupportedIndexFieldKind returns a new error indicating that an index was requested
+// on a field of a kind that cannot be indexed.
+func NewErrUnsupportedIndexFieldKind(field string, kind client.FieldKind) error {
+	return errors.New(
+		errUnsupportedIndexFieldKind,
+		errors.NewKV("Field", field),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+// NewErrDuplicateIndexField returns a new error indicating that a field was listed more
+// than once within an index.
+func NewErrDuplicateIndexField(field string) error {
+	return errors.New(errDuplicateIndexField, errors.NewKV("Field", field))
+}
+
+// NewErrIndexWithNameAlreadyExists returns a new error indicating that an index with the
+// given name already exists on the collection.
+func NewErrIndexWithNameAlreadyExists(indexName string) error {
+	return errors.New(errIndexWithNameAlreadyExists, errors.NewKV("Name", indexName))
+}
+
+// NewErrIndexWithNameDoesNotExist returns a new error indicating that no index with the
+// given name exists on the collection.
+func NewErrIndexWithNameDoesNotExist(indexName string) error {
+	return errors.New(errIndexWithNameDoesNotExist, errors.NewKV("Name", indexName))
+}
+
+// NewErrCannotModifyIndexesWithPatch returns a new error indicating that a schema patch
+// attempted to modify the indexes of a collection.
+func NewErrCannotModifyIndexesWithPatch(collectionName string) error {
+	return errors.New(errCannotModifyIndexesWithPatch, errors.NewKV("Collection", collectionName))
+}
+
+// NewErrFailedToStoreIndexedField returns a new error indicating that the index entry of a
+// document could not be stored.
+func NewErrFailedToStoreIndexedField(indexName string, inner error) error {
+	return errors.Wrap(errFailedToStoreIndexedField, inner, errors.NewKV("Index", indexName))
+}
+
+// NewErrFailedToDeleteIndexedField returns a new error indicating that the index entry of a
+// document could not be deleted.
+func NewErrFailedToDeleteIndexedField(indexName string, inner error) error {
+	return errors.Wrap(errFailedToDeleteIndexedField, inner, errors.NewKV("Index", indexName))
+}
+
+// NewErrCannotManageUniqueIndex returns a new error indicating that an attempt was made to
+// directly create or drop an index that backs a unique constraint.
+func NewErrCannotManageUniqueIndex(indexName string) error {
+	return errors.New(errCannotManageUniqueIndex, errors.NewKV("Name", indexName))
+}
+
+// NewErrCannotModifyUniqueConstraints returns a new error indicating that a schema patch
+// attempted to modify the unique constraints of a schema.
+func NewErrCannotModifyUniqueConstraints(schemaName string) error {
+	return errors.New(errCannotModifyUniqueConstraints, errors.NewKV("Schema", schemaName))
+}
+
+// NewErrUniqueConstraintViolation returns a new error indicating that writing the document
+// with the given key would violate the unique constraint on the given fields, as the document
+// with the given existing key already holds the same values.
+func NewErrUniqueConstraintViolation(fields []string, docKey string, existingDocKey string) error {
+	return errors.New(
+		errUniqueConstraintViolation,
+		errors.NewKV("Fields", fields),
+		errors.NewKV("DocKey", docKey),
+		errors.NewKV("ExistingDocKey", existingDocKey),
+	)
+}
+
+// NewErrInvalidBackupFormat returns a new error indicating that the requested backup
+// format is not supported.
+func NewErrInvalidBackupFormat(format string) error {
+	return errors.New(errInvalidBackupFormat, errors.NewKV("Format", format))
+}
+
+// NewErrFailedToCreateBackupFile returns a new error indicating that the backup file
+// could not be created.
+func NewErrFailedToCreateBackupFile(inner error) error {
+	return errors.Wrap(errFailedToCreateBackupFile, inner)
+}
+
+// NewErrFailedToOpenBackupFile returns a new error indicating that the backup file
+// could not be opened.
+func NewErrFailedToOpenBackupFile(inner error) error {
+	return errors.Wrap(errFailedToOpenBackupFile, inner)
+}
+
+// NewErrInvalidBackup returns a new error indicating that the contents of the backup
+// file could not be parsed.
+func NewErrInvalidBackup(inner error) error {
+	return errors.Wrap(errInvalidBackup, inner)
+}
+
+// NewErrMissingBackupBlock returns a new error indicating that a block linked to by the
+// history of a backed up document is not part of the backup.
+func NewErrMissingBackupBlock(c cid.Cid) error {
+	return errors.New(errMissingBackupBlock, errors.NewKV("CID", c))
+}
+
+// NewErrDocumentAccessDenied returns a new error indicating that the document with the given
+// key may not be modified by the identity of the request.
+func NewErrDocumentAccessDenied(docKey string) error {
+	return errors.New(errDocumentAccessDenied, errors.NewKV("DocKey", docKey))
+}
+
+// NewErrInvalidBlobValue returns a new error indicating that the value given to the
+// blob field with the given name is not a valid hex encoded string.
+func NewErrInvalidBlobValue(name string, value any) error {
+	return errors.New(
+		errInvalidBlobValue,
+		errors.NewKV("Field", name),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrCannotAddEmbeddedField returns a new error indicating that the embedded object field
+// with the given name cannot be added by patching the schema.
+func NewErrCannotAddEmbeddedField(name string) error {
+	return errors.New(errCannotAddEmbeddedField, errors.NewKV("Field", name))
+}
+
+// NewErrInvalidEmbeddedValue returns a new error indicating that the value given to the
+// embedded object field with the given name is not an object.
+func NewErrInvalidEmbeddedValue(name string, value any) error {
+	return errors.New(
+		errInvalidEmbeddedValue,
+		errors.NewKV("Field", name),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrCannotRemoveEnumValue returns a new error indicating that a schema patch attempted to
+// remove, or move, the given value of the given enum.
+func NewErrCannotRemoveEnumValue(enumName string, value string) error {
+	return errors.New(
+		errCannotRemoveEnumValue,
+		errors.NewKV("Enum", enumName),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrDuplicateEnumValue returns a new error indicating that the given value was declared
+// more than once by the given enum.
+func NewErrDuplicateEnumValue(enumName string, value string) error {
+	return errors.New(
+		errDuplicateEnumValue,
+		errors.NewKV("Enum", enumName),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrVersionNotFound returns a new error indicating that the given version (composite commit CID)
+// is not part of the history of the document with the given key.
+func NewErrVersionNotFound(docKey string, version cid.Cid) error {
+	return errors.New(
+		errVersionNotFound,
+		errors.NewKV("DocKey", docKey),
+		errors.NewKV("CID", version),
+	)
+}
+
+// NewErrDiffFromNotAncestor returns a new error indicating that the from version of a diff
+// does not precede its to version.
+func NewErrDiffFromNotAncestor(from cid.Cid, to cid.Cid) error {
+	return errors.New(
+		errDiffFromNotAncestor,
+		errors.NewKV("From", from),
+		errors.NewKV("To", to),
+	)
+}
+
+// NewErrCannotExportPrunedHistory returns a new error indicating that the history of the document
+// with the given key cannot be exported as it has been pruned.
+func NewErrCannotExportPrunedHistory(docKey string) error {
+	return errors.New(errCannotExportPrunedHistory, errors.NewKV("DocKey", docKey))
+}
+
+// NewErrNegativeHistoryDuration returns a new error indicating that the history policy of the
+// given collection keeps history for a negative duration.
+func NewErrNegativeHistoryDuration(collectionName string, duration time.Duration) error {
+	return errors.New(
+		errNegativeHistoryDuration,
+		errors.NewKV("Collection", collectionName),
+		errors.NewKV("Duration", duration),
+	)
+}
+
+// NewErrInvalidManyToManyLinks returns a new error indicating that the links given for the
+// many-to-many relation field of the given name are not a list of dockeys.
+func NewErrInvalidManyToManyLinks(name string, value any) error {
+	return errors.New(
+		errInvalidManyToManyLinks,
+		errors.NewKV("Field", name),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrLinkedDocNotFound returns a new error indicating that the document of the given key,
+// linked to through the many-to-many relation field of the given name, does not exist.
+func NewErrLinkedDocNotFound(name string, docKey string) error {
+	return errors.New(
+		errLinkedDocNotFound,
+		errors.NewKV("Field", name),
+		errors.NewKV("DocKey", docKey),
+	)
+}
@@ -0,0 +1,358 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fetcher
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dag "github.com/ipfs/go-merkledag"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+var (
+	// interface check
+	_ Fetcher = (*AsOfFetcher)(nil)
+)
+
+// AsOfFetcher fetches the documents of a collection as they were at a given point in their
+// history, described by a [request.AsOf].
+//
+// The version of each document matching the given point is found by walking its composite
+// DAG back from its current heads, the state at that version then being reconstructed by
+// a [VersionedFetcher]. Documents that did not exist yet at the given point are skipped.
+//
+// Current limitations:
+//   - Where several concurrent versions of a document match the given point equally well, only
+//     one of them (that with the lowest CID) is returned, as the VersionedFetcher can only
+//     reconstruct the state at a single version.
+//   - Commits merged before commit times were recorded are treated as preceding any point in time.
+type AsOfFetcher struct {
+	asOf request.AsOf
+
+	col         *client.CollectionDescription
+	fields      []*client.FieldDescription
+	reverse     bool
+	showDeleted bool
+
+	txn     datastore.Txn
+	docKeys []string
+
+	// The fetcher reconstructing the document currently being read, nil if there is none.
+	current *VersionedFetcher
+}
+
+// NewAsOfFetcher returns a new [AsOfFetcher] fetching documents as they were at the given point.
+func NewAsOfFetcher(asOf request.AsOf) *AsOfFetcher {
+	return &AsOfFetcher{
+		asOf: asOf,
+	}
+}
+
+// Init initializes the AsOfFetcher.
+func (f *AsOfFetcher) Init(
+	col *client.CollectionDescription,
+	fields []*client.FieldDescription,
+	reverse bool,
+	showDeleted bool,
+) error {
+	f.col = col
+	f.fields = fields
+	f.reverse = reverse
+	f.showDeleted = showDeleted
+	return nil
+}
+
+// Start collects the keys of the documents within the given spans, including those that have
+// since been deleted, ready for their past state to be fetched.
+func (f *AsOfFetcher) Start(ctx context.Context, txn datastore.Txn, spans core.Spans) error {
+	if f.col == nil {
+		return client.NewErrUninitializeProperty("AsOfFetcher", "CollectionDescription")
+	}
+	if err := f.closeCurrent(); err != nil {
+		return err
+	}
+
+	prefix := core.PrimaryDataStoreKey{
+		CollectionId: f.col.IDString(),
+	}
+	q, err := txn.Datastore().Query(ctx, query.Query{
+		Prefix:   prefix.ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	docKeys := []string{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return res.Error
+		}
+		docKey := ds.NewKey(res.Key).BaseNamespace()
+		if isDocWithinSpans(base.MakeDocKey(*f.col, docKey), spans) {
+			docKeys = append(docKeys, docKey)
+		}
+	}
+	if err := q.Close(); err != nil {
+		return err
+	}
+
+	if f.reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(docKeys)))
+	} else {
+		sort.Strings(docKeys)
+	}
+
+	f.txn = txn
+	f.docKeys = docKeys
+	return nil
+}
+
+// isDocWithinSpans returns true if the given document key falls within any of the given spans,
+// or if no spans are given.
+func isDocWithinSpans(docKey core.DataStoreKey, spans core.Spans) bool {
+	if !spans.HasValue {
+		return true
+	}
+	key := docKey.ToString()
+	for _, span := range spans.Value {
+		start := span.Start()
+		start.InstanceType = ""
+		end := span.End()
+		end.InstanceType = ""
+		if key >= start.ToString() && key < end.ToString() {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchNext returns the next document as it was at the requested point in raw binary form.
+func (f *AsOfFetcher) FetchNext(ctx context.Context) (*encodedDocument, error) {
+	for {
+		if f.current != nil {
+			encdoc, err := f.current.FetchNext(ctx)
+			if err != nil || encdoc != nil {
+				return encdoc, err
+			}
+		}
+		hasNext, err := f.startNextDoc(ctx)
+		if err != nil || !hasNext {
+			return nil, err
+		}
+	}
+}
+
+// FetchNextDecoded returns the next document as it was at the requested point.
+func (f *AsOfFetcher) FetchNextDecoded(ctx context.Context) (*client.Document, error) {
+	for {
+		if f.current != nil {
+			doc, err := f.current.FetchNextDecoded(ctx)
+			if err != nil || doc != nil {
+				return doc, err
+			}
+		}
+		hasNext, err := f.startNextDoc(ctx)
+		if err != nil || !hasNext {
+			return nil, err
+		}
+	}
+}
+
+// FetchNextDoc returns the next document as it was at the requested point as a core.Doc.
+// The first return value is the parsed document key.
+func (f *AsOfFetcher) FetchNextDoc(
+	ctx context.Context,
+	mapping *core.DocumentMapping,
+) ([]byte, core.Doc, error) {
+	for {
+		if f.current != nil {
+			key, doc, err := f.current.FetchNextDoc(ctx, mapping)
+			if err != nil || len(doc.Fields) != 0 {
+				return key, doc, err
+			}
+		}
+		hasNext, err := f.startNextDoc(ctx)
+		if err != nil || !hasNext {
+			return nil, core.Doc{}, err
+		}
+	}
+}
+
+// startNextDoc starts reconstructing the next document that existed at the requested point.
+//
+// Returns false if there are no more documents.
+func (f *AsOfFetcher) startNextDoc(ctx context.Context) (bool, error) {
+	if err := f.closeCurrent(); err != nil {
+		return false, err
+	}
+
+	for len(f.docKeys) > 0 {
+		docKey := f.docKeys[0]
+		f.docKeys = f.docKeys[1:]
+
+		version, hasVersion, err := f.findVersion(ctx, docKey)
+		if err != nil {
+			return false, err
+		}
+		if !hasVersion {
+			continue
+		}
+
+		vf := new(VersionedFetcher)
+		err = vf.Init(f.col, f.fields, false, f.showDeleted)
+		if err != nil {
+			return false, err
+		}
+		err = vf.Start(ctx, f.txn, NewVersionedSpan(core.DataStoreKey{DocKey: docKey}, version))
+		if err != nil {
+			return false, err
+		}
+		f.current = vf
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// findVersion returns the CID of the latest composite commit of the given document matching
+// the requested point.
+//
+// Returns false if the document did not exist at that point, or if the history of the document
+// at that point has been pruned.
+func (f *AsOfFetcher) findVersion(ctx context.Context, docKey string) (cid.Cid, bool, error) {
+	headset := clock.NewHeadSet(
+		f.txn.Headstore(),
+		core.DataStoreKey{DocKey: docKey}.WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey(),
+	)
+	queued, _, err := headset.List(ctx)
+	if err != nil {
+		return cid.Cid{}, false, err
+	}
+
+	compositeKey := base.MakeCollectionKey(*f.col).WithInstanceInfo(
+		core.DataStoreKey{DocKey: docKey},
+	).WithFieldId(core.COMPOSITE_NAMESPACE)
+
+	snapshot, hasSnapshot, err := corecrdt.GetHistorySnapshot(ctx, f.txn, compositeKey.WithFieldId(""))
+	if err != nil {
+		return cid.Cid{}, false, err
+	}
+
+	var version cid.Cid
+	var versionHeight uint64
+	visited := map[cid.Cid]struct{}{}
+	for len(queued) > 0 {
+		c := queued[0]
+		queued = queued[1:]
+		if _, ok := visited[c]; ok {
+			continue
+		}
+		visited[c] = struct{}{}
+
+		nd, height, err := f.getCompositeBlock(ctx, c)
+		if err != nil {
+			return cid.Cid{}, false, err
+		}
+
+		matches, err := f.matchesAsOf(ctx, compositeKey, c, height)
+		if err != nil {
+			return cid.Cid{}, false, err
+		}
+		if matches {
+			if !version.Defined() || height > versionHeight ||
+				(height == versionHeight && c.String() < version.String()) {
+				version = c
+				versionHeight = height
+			}
+			// The ancestors of a matching commit all have a lower height, so there is no
+			// need to look any further back along this branch.
+			continue
+		}
+		if hasSnapshot && c == snapshot.Commit {
+			// The history preceding the snapshot has been pruned, the document is treated
+			// as not having existed at any earlier point.
+			continue
+		}
+
+		for _, link := range nd.Links() {
+			if link.Name == core.HEAD {
+				queued = append(queued, link.Cid)
+			}
+		}
+	}
+
+	return version, version.Defined(), nil
+}
+
+// getCompositeBlock returns the composite block of the given CID along with its height.
+func (f *AsOfFetcher) getCompositeBlock(ctx context.Context, c cid.Cid) (*dag.ProtoNode, uint64, error) {
+	blk, err := f.txn.DAGstore().Get(ctx, c)
+	if err != nil {
+		return nil, 0, NewErrVFetcherFailedToGetBlock(err)
+	}
+	nd, err := dag.DecodeProtobuf(blk.RawData())
+	if err != nil {
+		return nil, 0, NewErrVFetcherFailedToDecodeNode(err)
+	}
+	delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+	if err != nil {
+		return nil, 0, err
+	}
+	return nd, delta.GetPriority(), nil
+}
+
+// matchesAsOf returns true if the given composite commit was made at or before the requested point.
+func (f *AsOfFetcher) matchesAsOf(
+	ctx context.Context,
+	compositeKey core.DataStoreKey,
+	c cid.Cid,
+	height uint64,
+) (bool, error) {
+	if f.asOf.Height.HasValue() {
+		return height <= f.asOf.Height.Value(), nil
+	}
+	if !f.asOf.Time.HasValue() {
+		return true, nil
+	}
+
+	commitTime, hasTime, err := corecrdt.GetCommitTime(ctx, f.txn.Datastore(), compositeKey, c)
+	if err != nil {
+		return false, err
+	}
+	return !hasTime || !commitTime.After(f.asOf.Time.Value()), nil
+}
+
+func (f *AsOfFetcher) closeCurrent() error {
+	if f.current == nil {
+		return nil
+	}
+	err := f.current.Close()
+	f.current = nil
+	return err
+}
+
+// Close closes the AsOfFetcher.
+func (f *AsOfFetcher) Close() error {
+	return f.closeCurrent()
+}
@@ -12,12 +12,14 @@ package fetcher
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
 )
 
 type EPTuple []encProperty
@@ -35,6 +37,16 @@ type encProperty struct {
 func (e encProperty) Decode() (client.CType, any, error) {
 	ctype := client.CType(e.Raw[0])
 	buf := e.Raw[1:]
+
+	switch e.Desc.Kind {
+	case client.FieldKind_JSON:
+		val, err := base.DecodeJSONValue(buf)
+		return ctype, val, err
+	case client.FieldKind_BLOB:
+		val, err := base.DecodeBlobValue(buf)
+		return ctype, val, err
+	}
+
 	var val any
 	err := cbor.Unmarshal(buf, &val)
 	if err != nil {
@@ -180,12 +192,22 @@ func convertToInt(propertyName string, untypedValue any) (int64, error) {
 type encodedDocument struct {
 	Key        []byte
 	Properties map[client.FieldDescription]*encProperty
+
+	// SchemaVersionID is the schema version the document was last written at.
+	//
+	// It will be empty if the document was written before schema versions were recorded.
+	SchemaVersionID string
+
+	// Owner is the identity owning the document, it will be empty if the document has no owner.
+	Owner string
 }
 
 // Reset re-initializes the EncodedDocument object.
 func (encdoc *encodedDocument) Reset() {
 	encdoc.Properties = make(map[client.FieldDescription]*encProperty)
 	encdoc.Key = nil
+	encdoc.SchemaVersionID = ""
+	encdoc.Owner = ""
 }
 
 // Decode returns a properly decoded document object
@@ -219,7 +241,35 @@ func (encdoc *encodedDocument) DecodeToDoc(mapping *core.DocumentMapping) (core.
 		if err != nil {
 			return core.Doc{}, err
 		}
+		if embeddedName, name, isEmbedded := strings.Cut(fieldDesc.Name, client.EmbeddedFieldSeparator); isEmbedded {
+			setEmbeddedValue(&doc, mapping, embeddedName, name, val)
+			continue
+		}
 		doc.Fields[fieldDesc.ID] = val
 	}
 	return doc, nil
 }
+
+// setEmbeddedValue sets the given value of the field of the given name within the embedded
+// object of the given name.
+//
+// The values of embedded objects are held as child documents, they are skipped if the mapping
+// has no child mapping for the embedded object.
+func setEmbeddedValue(doc *core.Doc, mapping *core.DocumentMapping, embeddedName string, name string, val any) {
+	embeddedIndexes := mapping.IndexesByName[embeddedName]
+	if len(embeddedIndexes) == 0 || embeddedIndexes[0] >= len(mapping.ChildMappings) {
+		return
+	}
+	embeddedIndex := embeddedIndexes[0]
+	embeddedMapping := mapping.ChildMappings[embeddedIndex]
+	if embeddedMapping == nil || len(embeddedMapping.IndexesByName[name]) == 0 {
+		return
+	}
+
+	embeddedDoc, hasDoc := doc.Fields[embeddedIndex].(core.Doc)
+	if !hasDoc {
+		embeddedDoc = embeddedMapping.NewDoc()
+	}
+	embeddedMapping.SetFirstOfName(&embeddedDoc, name, val)
+	doc.Fields[embeddedIndex] = embeddedDoc
+}
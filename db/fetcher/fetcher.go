@@ -16,11 +16,13 @@ import (
 
 	dsq "github.com/ipfs/go-datastore/query"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/datastore/iterable"
 	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/lens"
 )
 
 // Fetcher is the interface for collecting documents from the underlying data store.
@@ -61,6 +63,10 @@ type DocumentFetcher struct {
 	kvEnd             bool
 	isReadingDocument bool
 
+	// migrations is lazily loaded the first time a document written at a different
+	// schema version is fetched.
+	migrations *lens.Registry
+
 	// Since deleted documents are stored under a different instance type than active documents,
 	// we use a parallel fetcher to be able to return the documents in the expected order.
 	// That being lexicographically ordered dockeys.
@@ -177,6 +183,7 @@ func (df *DocumentFetcher) start(ctx context.Context, txn datastore.Txn, spans c
 
 	df.curSpanIndex = -1
 	df.txn = txn
+	df.migrations = nil
 
 	if df.reverse {
 		df.order = []dsq.Order{dsq.OrderByKeyDescending{}}
@@ -324,6 +331,15 @@ func (df *DocumentFetcher) processKV(kv *core.KeyValue) error {
 		return nil
 	}
 
+	switch kv.Key.FieldId {
+	case core.DATASTORE_DOC_VERSION_FIELD_ID:
+		df.doc.SchemaVersionID = string(kv.Value)
+		return nil
+	case core.DATASTORE_DOC_OWNER_FIELD_ID:
+		df.doc.Owner = string(kv.Value)
+		return nil
+	}
+
 	// extract the FieldID and update the encoded doc properties map
 	fieldID, err := kv.Key.FieldID()
 	if err != nil {
@@ -375,6 +391,19 @@ func (df *DocumentFetcher) FetchNext(ctx context.Context) (*encodedDocument, err
 			return nil, err
 		}
 		if end {
+			if !acp.CanAccess(ctx, df.doc.Owner) {
+				// Documents the request may not access are skipped as if they did not exist.
+				if df.kvEnd {
+					return nil, nil
+				}
+				df.isReadingDocument = false
+				continue
+			}
+
+			err = df.migrate(ctx)
+			if err != nil {
+				return nil, err
+			}
 			return df.doc, nil
 		}
 
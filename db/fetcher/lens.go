@@ -0,0 +1,96 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fetcher
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/lens"
+)
+
+// migrate transforms the current document using the registered schema migrations if it was
+// last written at a schema version other than the one the fetcher is reading at.
+//
+// The document is left untouched if no migration path exists between the two versions.
+func (df *DocumentFetcher) migrate(ctx context.Context) error {
+	targetVersionID := df.col.Schema.VersionID
+	if df.doc.SchemaVersionID == "" || df.doc.SchemaVersionID == targetVersionID {
+		return nil
+	}
+
+	if df.migrations == nil {
+		migrations, err := lens.LoadRegistry(ctx, df.txn.Systemstore())
+		if err != nil {
+			return err
+		}
+		df.migrations = migrations
+	}
+
+	original := make(map[string]any, len(df.doc.Properties))
+	values := make(map[string]any, len(df.doc.Properties))
+	for fieldDesc, prop := range df.doc.Properties {
+		_, val, err := prop.Decode()
+		if err != nil {
+			return err
+		}
+		original[fieldDesc.Name] = val
+		values[fieldDesc.Name] = val
+	}
+
+	if !df.migrations.Migrate(values, df.doc.SchemaVersionID, targetVersionID) {
+		return nil
+	}
+
+	for _, fieldDesc := range df.schemaFields {
+		val, ok := values[fieldDesc.Name]
+		if !ok || reflect.DeepEqual(val, original[fieldDesc.Name]) {
+			continue
+		}
+		if val == nil {
+			delete(df.doc.Properties, fieldDesc)
+			continue
+		}
+
+		prop, err := encodeMigratedProperty(fieldDesc, val)
+		if err != nil {
+			return err
+		}
+		df.doc.Properties[fieldDesc] = prop
+	}
+	df.doc.SchemaVersionID = targetVersionID
+
+	return nil
+}
+
+// encodeMigratedProperty encodes the given migrated field value as it would have been
+// stored had it been written at the current schema version.
+func encodeMigratedProperty(fieldDesc client.FieldDescription, val any) (*encProperty, error) {
+	// Values declared within a lens are unmarshalled from JSON, so numbers will be floats.
+	if fieldDesc.Kind == client.FieldKind_INT {
+		if floatVal, isFloat := val.(float64); isFloat {
+			val = int64(floatVal)
+		}
+	}
+
+	buf, err := cbor.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encProperty{
+		Desc: fieldDesc,
+		Raw:  append([]byte{byte(fieldDesc.Typ)}, buf...),
+	}, nil
+}
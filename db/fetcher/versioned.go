@@ -16,11 +16,14 @@ import (
 
 	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
 	format "github.com/ipfs/go-ipld-format"
 	dag "github.com/ipfs/go-merkledag"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/datastore/memory"
 	"github.com/sourcenetwork/defradb/db/base"
@@ -62,6 +65,10 @@ var (
 // defined in the version, so that it can be used as a drop in replacement within
 // the scanNode request planner system.
 //
+// If the history of the document has been pruned, the state at the commit of the document's
+// history snapshot is restored from the snapshot instead of being reconstructed, and versions
+// preceding it can no longer be fetched.
+//
 // Current limitations:
 // - We can only return a single record from an VersionedFetcher instance.
 // - We can't request related sub objects (at the moment, as related objects
@@ -94,6 +101,10 @@ type VersionedFetcher struct {
 	col *client.CollectionDescription
 	// @todo index  *client.IndexDescription
 	mCRDTs map[uint32]crdt.MerkleCRDT
+
+	// snapshot is the snapshot of the document's history, if it has been pruned.
+	snapshot    corecrdt.HistorySnapshot
+	hasSnapshot bool
 }
 
 // Init initializes the VersionedFetcher.
@@ -158,6 +169,25 @@ func (vf *VersionedFetcher) Start(ctx context.Context, txn datastore.Txn, spans
 		return err
 	}
 
+	// The owner of the document is not part of its history, it is copied from the current state
+	// so that access to past versions is restricted in the same way as access to the current one.
+	docKey := base.MakeCollectionKey(*vf.col).WithInstanceInfo(dk)
+	owner, err := acp.GetOwner(ctx, txn.Datastore(), docKey)
+	if err != nil {
+		return err
+	}
+	if owner != "" {
+		err = acp.SetOwner(ctx, vf.store.Datastore(), docKey, owner)
+		if err != nil {
+			return err
+		}
+	}
+
+	vf.snapshot, vf.hasSnapshot, err = corecrdt.GetHistorySnapshot(ctx, txn, docKey)
+	if err != nil {
+		return err
+	}
+
 	if err := vf.seekTo(vf.version); err != nil {
 		return NewErrFailedToSeek(c, err)
 	}
@@ -170,6 +200,31 @@ func (vf *VersionedFetcher) Rootstore() ds.Datastore {
 	return vf.root
 }
 
+// StateEntries returns the datastore entries holding the state of the document at the
+// fetched version, keyed by their datastore key.
+//
+// The local times at which the commits were merged are not part of the state and are omitted.
+func (vf *VersionedFetcher) StateEntries(ctx context.Context) (map[string][]byte, error) {
+	results, err := vf.store.Datastore().Query(ctx, query.Query{})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := map[string][]byte{}
+	for res := range results.Next() {
+		if res.Error != nil {
+			_ = results.Close()
+			return nil, res.Error
+		}
+		key, err := core.NewDataStoreKey(res.Key)
+		if err == nil && key.InstanceType == core.CommitTimeKey {
+			continue
+		}
+		entries[res.Key] = res.Value
+	}
+	return entries, results.Close()
+}
+
 // Start a fetcher with the needed info (cid embedded in a span)
 
 /*
@@ -255,6 +310,12 @@ func (vf *VersionedFetcher) seekNext(c cid.Cid, topParent bool) error {
 	// @body: We could possibly append the DocKey to the CID either as a
 	// child key, or an instance on the CID key.
 
+	if vf.hasSnapshot && c == vf.snapshot.Commit {
+		// the history preceding the snapshot has been pruned, the state
+		// at the snapshot commit is restored from the snapshot instead
+		return vf.restoreSnapshot()
+	}
+
 	hasLocalBlock, err := vf.store.DAGstore().Has(vf.ctx, c)
 	if err != nil {
 		return NewErrVFetcherFailedToFindBlock(err)
@@ -292,8 +353,9 @@ func (vf *VersionedFetcher) seekNext(c cid.Cid, topParent bool) error {
 		return NewErrVFetcherFailedToGetDagLink(err)
 	}
 
-	// only seekNext on parent if we have a HEAD link
-	if !errors.Is(err, dag.ErrLinkNotFound) {
+	// only seekNext on parent if we have a HEAD link, the parents of field
+	// blocks are reached through the parents of their composite block
+	if topParent && !errors.Is(err, dag.ErrLinkNotFound) {
 		err := vf.seekNext(l.Cid, true)
 		if err != nil {
 			return err
@@ -315,6 +377,18 @@ func (vf *VersionedFetcher) seekNext(c cid.Cid, topParent bool) error {
 	return nil
 }
 
+// restoreSnapshot writes the state held by the snapshot of the document's history
+// into the VersionedFetcher state.
+func (vf *VersionedFetcher) restoreSnapshot() error {
+	for key, value := range vf.snapshot.Entries {
+		err := vf.store.Datastore().Put(vf.ctx, ds.NewKey(key), value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // merge in the state of the IPLD Block identified by CID c into the VersionedFetcher state.
 // Requires the CID to already exists in the DAGStore.
 // This function only works for merging Composite MerkleCRDT objects.
@@ -349,13 +423,11 @@ func (vf *VersionedFetcher) merge(c cid.Cid) error {
 			return err
 		}
 
-		fieldID := vf.col.Schema.GetFieldKey(l.Name)
-		if fieldID == uint32(0) {
+		field, ok := vf.col.GetField(l.Name)
+		if !ok || field.ID == client.FieldID(0) {
 			return client.NewErrFieldNotExist(l.Name)
 		}
-		// @todo: Right now we ONLY handle LWW_REGISTER, need to swith on this and
-		//        get CType from descriptions
-		if err := vf.processNode(fieldID, subNd, client.LWW_REGISTER, l.Name); err != nil {
+		if err := vf.processNode(uint32(field.ID), subNd, field.Typ, l.Name); err != nil {
 			return err
 		}
 	}
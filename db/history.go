@@ -0,0 +1,440 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore/query"
+	dag "github.com/ipfs/go-merkledag"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fetcher"
+)
+
+func (db *db) setHistoryPolicy(ctx context.Context, txn datastore.Txn, policy client.HistoryPolicy) error {
+	_, err := db.getCollectionByName(ctx, txn, policy.CollectionName)
+	if err != nil {
+		return NewErrFailedToGetCollection(policy.CollectionName, err)
+	}
+	if policy.KeepDuration < 0 {
+		return NewErrNegativeHistoryDuration(policy.CollectionName, policy.KeepDuration)
+	}
+
+	key := core.NewCollectionHistoryPolicyKey(policy.CollectionName)
+	if policy.KeepHeights == 0 && policy.KeepDuration == 0 {
+		return txn.Systemstore().Delete(ctx, key.ToDS())
+	}
+
+	buf, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return txn.Systemstore().Put(ctx, key.ToDS(), buf)
+}
+
+func (db *db) getHistoryPolicies(ctx context.Context, txn datastore.Txn) ([]client.HistoryPolicy, error) {
+	q, err := txn.Systemstore().Query(ctx, query.Query{
+		Prefix: core.NewCollectionHistoryPolicyKey("").ToString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policies := []client.HistoryPolicy{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return nil, res.Error
+		}
+		var policy client.HistoryPolicy
+		err = json.Unmarshal(res.Value, &policy)
+		if err != nil {
+			_ = q.Close()
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, q.Close()
+}
+
+// pruneHistory prunes the history of the documents of each collection that has a history
+// policy, as of the given time.
+func (db *db) pruneHistory(ctx context.Context, txn datastore.Txn, now time.Time) error {
+	policies, err := db.getHistoryPolicies(ctx, txn)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		col, err := db.getCollectionByName(ctx, txn, policy.CollectionName)
+		if err != nil {
+			return NewErrFailedToGetCollection(policy.CollectionName, err)
+		}
+		err = col.WithTxn(txn).(*collection).pruneHistory(ctx, txn, policy, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneHistoryPeriodically prunes the history of the documents of each collection that has a
+// history policy at the given interval, until the database is closed.
+func (db *db) pruneHistoryPeriodically(ctx context.Context, interval time.Duration) {
+	defer db.background.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.closing:
+			return
+		case now := <-ticker.C:
+			err := db.pruneHistoryPerDoc(ctx, now)
+			if err != nil {
+				log.ErrorE(ctx, "Failed to prune history", err)
+			}
+		}
+	}
+}
+
+// pruneHistoryPerDoc prunes the history of the documents of each collection that has a history
+// policy, as of the given time, committing the pruning of each document in its own transaction.
+//
+// Pruning the history of every document within a single transaction may exceed the size
+// limits of the store's transactions.
+func (db *db) pruneHistoryPerDoc(ctx context.Context, now time.Time) error {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	policies, err := db.getHistoryPolicies(ctx, txn)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		col, err := db.getCollectionByName(ctx, txn, policy.CollectionName)
+		if err != nil {
+			return NewErrFailedToGetCollection(policy.CollectionName, err)
+		}
+		keys, err := col.WithTxn(txn).(*collection).getDocKeys(ctx, txn)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			err := db.pruneDocHistory(ctx, col.(*collection), key, policy, now)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pruneDocHistory prunes the history of the document of the given collection with the given
+// key in a new transaction.
+func (db *db) pruneDocHistory(
+	ctx context.Context,
+	col *collection,
+	key client.DocKey,
+	policy client.HistoryPolicy,
+	now time.Time,
+) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	err = col.WithTxn(txn).(*collection).pruneDocHistory(ctx, txn, key, policy, now)
+	if err != nil {
+		return err
+	}
+
+	return txn.Commit(ctx)
+}
+
+func (c *collection) pruneHistory(
+	ctx context.Context,
+	txn datastore.Txn,
+	policy client.HistoryPolicy,
+	now time.Time,
+) error {
+	keys, err := c.getDocKeys(ctx, txn)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err := c.pruneDocHistory(ctx, txn, key, policy, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDocKeys returns the keys of all the documents of the collection.
+//
+// The keys are collected before pruning anything, so that the store is not written
+// to whilst being iterated over.
+func (c *collection) getDocKeys(ctx context.Context, txn datastore.Txn) ([]client.DocKey, error) {
+	keysCh, err := c.getAllDocKeysChan(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []client.DocKey{}
+	for res := range keysCh {
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		keys = append(keys, res.Key)
+	}
+	return keys, nil
+}
+
+// pruneDocHistory prunes the history of the document with the given key.
+//
+// The history is pruned at the latest commit allowed by the policy that all other commits of
+// the document either precede or follow. The state of the document at that commit is written
+// to the document's history snapshot, and the commits preceding it are deleted along with
+// their field blocks and recorded commit times.
+//
+// The heads held in the headstore are kept, as the heights they hold are needed to continue
+// the clocks of the document.
+func (c *collection) pruneDocHistory(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	policy client.HistoryPolicy,
+	now time.Time,
+) error {
+	history, err := c.getCompositeHistory(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+
+	docKey := base.MakeDocKey(c.desc, key.String())
+	compositeKey := docKey.WithFieldId(core.COMPOSITE_NAMESPACE)
+
+	maxHeight, err := getMaxPruneHeight(ctx, txn, compositeKey, history, policy, now)
+	if err != nil {
+		return err
+	}
+	pruneCommit, canPrune := findPruneCommit(history, maxHeight)
+	if !canPrune {
+		return nil
+	}
+
+	pruned := getAncestors(history, pruneCommit)
+	delete(pruned, pruneCommit)
+	if len(pruned) == 0 {
+		return nil
+	}
+
+	entries, err := c.getVersionState(ctx, txn, key, pruneCommit)
+	if err != nil {
+		return err
+	}
+
+	for commitCid := range pruned {
+		err := deleteCompositeCommit(ctx, txn, compositeKey, commitCid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return corecrdt.SetHistorySnapshot(ctx, txn, docKey, corecrdt.HistorySnapshot{
+		Commit:  pruneCommit,
+		Height:  history[pruneCommit].height,
+		Entries: entries,
+	})
+}
+
+// getMaxPruneHeight returns the maximum height of the commit the given history may be pruned
+// at, such that all the commits that are to be kept by the given policy are kept.
+func getMaxPruneHeight(
+	ctx context.Context,
+	txn datastore.Txn,
+	compositeKey core.DataStoreKey,
+	history map[cid.Cid]compositeCommit,
+	policy client.HistoryPolicy,
+	now time.Time,
+) (uint64, error) {
+	maxHeight := uint64(math.MaxUint64)
+
+	if policy.KeepHeights > 0 {
+		var latestHeight uint64
+		for _, commit := range history {
+			if commit.height > latestHeight {
+				latestHeight = commit.height
+			}
+		}
+		if latestHeight < policy.KeepHeights {
+			return 0, nil
+		}
+		maxHeight = latestHeight - policy.KeepHeights + 1
+	}
+
+	if policy.KeepDuration > 0 {
+		keepFrom := now.Add(-policy.KeepDuration)
+		for commitCid, commit := range history {
+			if commit.height >= maxHeight {
+				continue
+			}
+			commitTime, hasTime, err := corecrdt.GetCommitTime(ctx, txn.Datastore(), compositeKey, commitCid)
+			if err != nil {
+				return 0, err
+			}
+			// Commits without a recorded time are kept.
+			if !hasTime || !commitTime.Before(keepFrom) {
+				maxHeight = commit.height
+			}
+		}
+	}
+
+	return maxHeight, nil
+}
+
+// findPruneCommit returns the latest commit of the given history, at or below the given height,
+// that all the other commits of the history either precede or follow.
+//
+// Returns false if there is no such commit.
+func findPruneCommit(history map[cid.Cid]compositeCommit, maxHeight uint64) (cid.Cid, bool) {
+	children := map[cid.Cid][]cid.Cid{}
+	candidates := []cid.Cid{}
+	for commitCid, commit := range history {
+		for _, parent := range commit.parents {
+			children[parent] = append(children[parent], commitCid)
+		}
+		if commit.height <= maxHeight {
+			candidates = append(candidates, commitCid)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := history[candidates[i]], history[candidates[j]]
+		if a.height != b.height {
+			return a.height > b.height
+		}
+		return candidates[i].String() < candidates[j].String()
+	})
+
+	for _, candidate := range candidates {
+		descendants := map[cid.Cid]struct{}{}
+		toVisit := children[candidate]
+		for len(toVisit) > 0 {
+			commitCid := toVisit[len(toVisit)-1]
+			toVisit = toVisit[:len(toVisit)-1]
+			if _, visited := descendants[commitCid]; visited {
+				continue
+			}
+			descendants[commitCid] = struct{}{}
+			toVisit = append(toVisit, children[commitCid]...)
+		}
+
+		if len(getAncestors(history, candidate))+len(descendants) == len(history) {
+			return candidate, true
+		}
+	}
+	return cid.Undef, false
+}
+
+// getVersionState returns the datastore entries holding the state of the document with the
+// given key at the given version.
+func (c *collection) getVersionState(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	version cid.Cid,
+) (map[string][]byte, error) {
+	vf := new(fetcher.VersionedFetcher)
+	err := vf.Init(&c.desc, nil, false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vf.Start(ctx, txn, fetcher.NewVersionedSpan(core.DataStoreKeyFromDocKey(key), version))
+	if err != nil {
+		_ = vf.Close()
+		return nil, err
+	}
+
+	entries, err := vf.StateEntries(ctx)
+	if err != nil {
+		_ = vf.Close()
+		return nil, err
+	}
+	return entries, vf.Close()
+}
+
+// deleteCompositeCommit deletes the given composite commit, along with the field blocks it
+// links to and its recorded commit time.
+func deleteCompositeCommit(
+	ctx context.Context,
+	txn datastore.Txn,
+	compositeKey core.DataStoreKey,
+	commitCid cid.Cid,
+) error {
+	block, err := txn.DAGstore().Get(ctx, commitCid)
+	if err != nil {
+		return err
+	}
+	nd, err := dag.DecodeProtobuf(block.RawData())
+	if err != nil {
+		return err
+	}
+
+	for _, link := range nd.Links() {
+		if link.Name == core.HEAD {
+			continue
+		}
+		err := txn.DAGstore().DeleteBlock(ctx, link.Cid)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = txn.DAGstore().DeleteBlock(ctx, commitCid)
+	if err != nil {
+		return err
+	}
+	return corecrdt.DeleteCommitTime(ctx, txn.Datastore(), compositeKey, commitCid)
+}
+
+// getDocPrunedHeight returns the height up to which the history of the document with the
+// given key has been pruned, or zero if it has not been pruned.
+func (c *collection) getDocPrunedHeight(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+) (uint64, error) {
+	snapshot, hasSnapshot, err := corecrdt.GetHistorySnapshot(ctx, txn, base.MakeDocKey(c.desc, key.DocKey))
+	if err != nil || !hasSnapshot {
+		return 0, err
+	}
+	return snapshot.PrunedHeight(), nil
+}
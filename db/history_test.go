@@ -0,0 +1,240 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func newHistoryTestDoc(
+	t *testing.T,
+	ctx context.Context,
+	db *implicitTxnDB,
+	col client.Collection,
+	versionCount int,
+) (*client.Document, []cid.Cid) {
+	doc := createTestDoc(t, ctx, col, `{"Name": "John", "Age": 1}`)
+
+	versions := []cid.Cid{getTestDocHead(t, ctx, db, doc.Key())}
+	for i := 2; i <= versionCount; i++ {
+		saveTestDoc(t, ctx, col, doc, map[string]any{"Age": i})
+		versions = append(versions, getTestDocHead(t, ctx, db, doc.Key()))
+	}
+	return doc, versions
+}
+
+func TestPruneHistoryWithKeepHeightsRemovesOlderVersions(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	doc, versions := newHistoryTestDoc(t, ctx, db, col, 4)
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users", KeepHeights: 2})
+	require.NoError(t, err)
+	err = db.PruneHistory(ctx)
+	require.NoError(t, err)
+
+	for _, version := range versions[:2] {
+		hasBlock, err := db.Blockstore().Has(ctx, version)
+		require.NoError(t, err)
+		require.False(t, hasBlock)
+	}
+	_, err = col.Diff(ctx, doc.Key(), versions[0], versions[3])
+	require.ErrorIs(t, err, ErrVersionNotFound)
+
+	// The state of the oldest kept version is restored from the history snapshot.
+	diff, err := col.Diff(ctx, doc.Key(), versions[2], versions[3])
+	require.NoError(t, err)
+	require.Len(t, diff, 1)
+	require.Equal(t, "Age", diff[0].FieldName)
+	require.Equal(t, uint64(3), diff[0].OldValue)
+	require.Equal(t, uint64(4), diff[0].NewValue)
+
+	currentDoc, err := col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	age, err := currentDoc.Get("Age")
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), age)
+}
+
+func TestPruneHistoryWithMultiplePoliciesPrunesAllCollections(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	err = db.AddSchema(ctx, `type Book { title: String }`)
+	require.NoError(t, err)
+	books, err := db.GetCollectionByName(ctx, "Book")
+	require.NoError(t, err)
+
+	_, userVersions := newHistoryTestDoc(t, ctx, db, col, 3)
+	book := createTestDoc(t, ctx, books, `{"title": "Dune"}`)
+	bookVersions := []cid.Cid{getTestDocHead(t, ctx, db, book.Key())}
+	for _, title := range []string{"Dune Messiah", "Children of Dune"} {
+		saveTestDoc(t, ctx, books, book, map[string]any{"title": title})
+		bookVersions = append(bookVersions, getTestDocHead(t, ctx, db, book.Key()))
+	}
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users", KeepHeights: 1})
+	require.NoError(t, err)
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "Book", KeepHeights: 1})
+	require.NoError(t, err)
+	err = db.PruneHistory(ctx)
+	require.NoError(t, err)
+
+	for _, version := range []cid.Cid{userVersions[0], userVersions[1], bookVersions[0], bookVersions[1]} {
+		hasBlock, err := db.Blockstore().Has(ctx, version)
+		require.NoError(t, err)
+		require.False(t, hasBlock)
+	}
+
+	currentBook, err := books.Get(ctx, book.Key(), false)
+	require.NoError(t, err)
+	title, err := currentBook.Get("title")
+	require.NoError(t, err)
+	require.Equal(t, "Children of Dune", title)
+}
+
+func TestPruneHistoryKeepsDocumentWritable(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	doc, versions := newHistoryTestDoc(t, ctx, db, col, 3)
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users", KeepHeights: 1})
+	require.NoError(t, err)
+	err = db.PruneHistory(ctx)
+	require.NoError(t, err)
+
+	saveTestDoc(t, ctx, col, doc, map[string]any{"Name": "Johnny"})
+	v4 := getTestDocHead(t, ctx, db, doc.Key())
+
+	err = col.Revert(ctx, doc.Key(), versions[2])
+	require.NoError(t, err)
+
+	revertedDoc, err := col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	name, err := revertedDoc.Get("Name")
+	require.NoError(t, err)
+	require.Equal(t, "John", name)
+
+	// Pruning again moves the snapshot forward.
+	err = db.PruneHistory(ctx)
+	require.NoError(t, err)
+	_, err = col.Diff(ctx, doc.Key(), v4, getTestDocHead(t, ctx, db, doc.Key()))
+	require.ErrorIs(t, err, ErrVersionNotFound)
+}
+
+func TestPruneHistoryWithKeepDurationKeepsRecentVersions(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	doc, versions := newHistoryTestDoc(t, ctx, db, col, 3)
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users", KeepDuration: time.Hour})
+	require.NoError(t, err)
+	err = db.PruneHistory(ctx)
+	require.NoError(t, err)
+
+	_, err = col.Diff(ctx, doc.Key(), versions[0], versions[2])
+	require.NoError(t, err)
+
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+	err = db.pruneHistory(ctx, txn, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	_, err = col.Diff(ctx, doc.Key(), versions[0], versions[2])
+	require.ErrorIs(t, err, ErrVersionNotFound)
+	diff, err := col.Diff(ctx, doc.Key(), versions[2], versions[2])
+	require.NoError(t, err)
+	require.Len(t, diff, 0)
+}
+
+func TestPruneHistoryWithoutPolicyKeepsHistory(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	doc, versions := newHistoryTestDoc(t, ctx, db, col, 3)
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users", KeepHeights: 1})
+	require.NoError(t, err)
+	// A policy that keeps nothing removes the policy of the collection.
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users"})
+	require.NoError(t, err)
+	err = db.PruneHistory(ctx)
+	require.NoError(t, err)
+
+	_, err = col.Diff(ctx, doc.Key(), versions[0], versions[2])
+	require.NoError(t, err)
+}
+
+func TestExportWithHistoryOfPrunedDocReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+	newHistoryTestDoc(t, ctx, db, col, 3)
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users", KeepHeights: 1})
+	require.NoError(t, err)
+	err = db.PruneHistory(ctx)
+	require.NoError(t, err)
+
+	err = db.BasicExport(ctx, &client.BackupConfig{
+		Filepath:       filepath.Join(t.TempDir(), "export.json"),
+		IncludeHistory: true,
+	})
+	require.ErrorIs(t, err, ErrCannotExportPrunedHistory)
+}
+
+func TestSetHistoryPolicyWithNegativeDurationReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	_, err = newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "users", KeepDuration: -time.Hour})
+	require.ErrorIs(t, err, ErrNegativeHistoryDuration)
+}
+
+func TestSetHistoryPolicyWithUnknownCollectionReturnsError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	_, err = newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	err = db.SetHistoryPolicy(ctx, client.HistoryPolicy{CollectionName: "Author", KeepHeights: 1})
+	require.ErrorIs(t, err, ErrFailedToGetCollection)
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/lens"
+)
+
+func (db *db) setMigration(ctx context.Context, txn datastore.Txn, cfg client.LensConfig) error {
+	err := lens.Validate(cfg)
+	if err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	key := core.NewSchemaVersionMigrationKey(cfg.SourceSchemaVersionID, cfg.DestinationSchemaVersionID)
+	return txn.Systemstore().Put(ctx, key.ToDS(), buf)
+}
+
+// saveMigratedFields persists the field values of the given document, as migrated to the current
+// schema version, if the document was last written at a different schema version.
+//
+// Once the document is updated it will be recorded as having been written at the current schema
+// version, so any values computed by the migration must be written for them not to be lost.
+// Fields present in the given properties are about to be written by the caller and are skipped,
+// those that are written are added to the properties.
+func (c *collection) saveMigratedFields(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+	properties map[string]any,
+) ([]core.DAGLink, error) {
+	versionKey := key.ToDataStoreKey().WithValueFlag().WithFieldId(core.DATASTORE_DOC_VERSION_FIELD_ID)
+	versionID, err := txn.Datastore().Get(ctx, versionKey.ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if string(versionID) == c.Schema().VersionID {
+		return nil, nil
+	}
+
+	doc, err := c.get(ctx, txn, key, false)
+	if err != nil {
+		return nil, err
+	}
+
+	links := []core.DAGLink{}
+	for _, field := range c.desc.Schema.Fields {
+		if _, isWritten := properties[field.Name]; isWritten || field.Typ == client.PN_COUNTER {
+			continue
+		}
+		if _, isSecondaryRelationID := c.isSecondaryIDField(field); isSecondaryRelationID {
+			continue
+		}
+		fieldKey, fieldExists := c.tryGetFieldKey(key, field.Name)
+		if !fieldExists {
+			continue
+		}
+
+		var val client.Value
+		value, err := doc.Get(field.Name)
+		if err == nil {
+			val = client.NewCBORValue(field.Typ, value)
+			properties[field.Name] = value
+		} else {
+			// The field may have been cleared by the migration, in which case the stored
+			// value must be deleted.
+			exists, err := txn.Datastore().Has(ctx, fieldKey.WithValueFlag().ToDS())
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				continue
+			}
+			val = client.NewCBORValue(field.Typ, nil)
+			val.Delete()
+			properties[field.Name] = nil
+		}
+
+		node, _, err := c.saveDocValue(ctx, txn, fieldKey, val)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, core.DAGLink{
+			Name: field.Name,
+			Cid:  node.Cid(),
+		})
+	}
+
+	return links, nil
+}
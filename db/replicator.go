@@ -48,6 +48,14 @@ func (db *db) setReplicator(ctx context.Context, txn datastore.Txn, rep client.R
 		}
 	}
 	rep.Schemas = append(existingRep.Schemas, newSchemas...)
+
+	// The field filters of the schemas the replicator already had are kept.
+	for schema, filter := range existingRep.FieldFilters {
+		if rep.FieldFilters == nil {
+			rep.FieldFilters = map[string]client.FieldFilter{}
+		}
+		rep.FieldFilters[schema] = filter
+	}
 	return db.saveReplicator(ctx, txn, rep)
 }
 
@@ -81,6 +89,8 @@ func (db *db) deleteSchemasForReplicator(ctx context.Context, txn datastore.Txn,
 		}
 		if !found {
 			updatedSchemaList = append(updatedSchemaList, s)
+		} else {
+			delete(existingRep.FieldFilters, s)
 		}
 	}
 
@@ -240,3 +240,82 @@ func TestDeleteReplicatorWith2Addition(t *testing.T) {
 		},
 	}, reps)
 }
+
+func TestSetReplicatorWithFieldFiltersOnSamePeer(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close(ctx)
+	a, err := ma.NewMultiaddr("/ip4/192.168.1.12/tcp/9000/p2p/12D3KooWNXm3dmrwCYSxGoRUyZstaKYiHPdt8uZH5vgVaEJyzU8B")
+	require.NoError(t, err)
+
+	// Extract the peer ID from the multiaddr.
+	info, err := peer.AddrInfoFromP2pAddr(a)
+	require.NoError(t, err)
+
+	err = db.SetReplicator(ctx, client.Replicator{
+		Info:    *info,
+		Schemas: []string{"test"},
+		FieldFilters: map[string]client.FieldFilter{
+			"test": {Deny: []string{"email"}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = db.SetReplicator(ctx, client.Replicator{
+		Info:    *info,
+		Schemas: []string{"test2"},
+		FieldFilters: map[string]client.FieldFilter{
+			"test2": {Allow: []string{"name"}},
+		},
+	})
+	require.NoError(t, err)
+
+	rep, err := db.getReplicator(ctx, *info)
+	require.NoError(t, err)
+
+	assert.Equal(t, client.Replicator{
+		Info:    *info,
+		Schemas: []string{"test", "test2"},
+		FieldFilters: map[string]client.FieldFilter{
+			"test":  {Deny: []string{"email"}},
+			"test2": {Allow: []string{"name"}},
+		},
+	}, rep)
+}
+
+func TestDeleteSchemaForReplicatorWithFieldFilters(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close(ctx)
+	a, err := ma.NewMultiaddr("/ip4/192.168.1.12/tcp/9000/p2p/12D3KooWNXm3dmrwCYSxGoRUyZstaKYiHPdt8uZH5vgVaEJyzU8B")
+	require.NoError(t, err)
+
+	// Extract the peer ID from the multiaddr.
+	info, err := peer.AddrInfoFromP2pAddr(a)
+	require.NoError(t, err)
+
+	err = db.SetReplicator(ctx, client.Replicator{
+		Info:    *info,
+		Schemas: []string{"test", "test2"},
+		FieldFilters: map[string]client.FieldFilter{
+			"test2": {Deny: []string{"email"}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = db.DeleteReplicator(ctx, client.Replicator{
+		Info:    *info,
+		Schemas: []string{"test2"},
+	})
+	require.NoError(t, err)
+
+	rep, err := db.getReplicator(ctx, *info)
+	require.NoError(t, err)
+
+	assert.Equal(t, client.Replicator{
+		Info:    *info,
+		Schemas: []string{"test"},
+	}, rep)
+}
@@ -19,7 +19,12 @@ import (
 )
 
 // execRequest executes a request against the database.
-func (db *db) execRequest(ctx context.Context, request string, txn datastore.Txn) *client.RequestResult {
+func (db *db) execRequest(
+	ctx context.Context,
+	request string,
+	options client.RequestOptions,
+	txn datastore.Txn,
+) *client.RequestResult {
 	res := &client.RequestResult{}
 	ast, err := db.parser.BuildRequestAST(request)
 	if err != nil {
@@ -27,10 +32,10 @@ func (db *db) execRequest(ctx context.Context, request string, txn datastore.Txn
 		return res
 	}
 	if db.parser.IsIntrospection(ast) {
-		return db.parser.ExecuteIntrospection(request)
+		return db.parser.ExecuteIntrospection(request, options)
 	}
 
-	parsedRequest, errors := db.parser.Parse(ast)
+	parsedRequest, errors := db.parser.Parse(ast, options)
 	if len(errors) > 0 {
 		res.GQL.Errors = errors
 		return res
@@ -62,5 +67,5 @@ func (db *db) execRequest(ctx context.Context, request string, txn datastore.Txn
 
 // ExecIntrospection executes an introspection request against the database.
 func (db *db) ExecIntrospection(request string) *client.RequestResult {
-	return db.parser.ExecuteIntrospection(request)
+	return db.parser.ExecuteIntrospection(request, client.RequestOptions{})
 }
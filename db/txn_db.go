@@ -12,6 +12,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/datastore"
@@ -32,7 +33,11 @@ type explicitTxnDB struct {
 }
 
 // ExecRequest executes a request against the database.
-func (db *implicitTxnDB) ExecRequest(ctx context.Context, request string) *client.RequestResult {
+func (db *implicitTxnDB) ExecRequest(
+	ctx context.Context,
+	request string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
 	txn, err := db.NewTxn(ctx, false)
 	if err != nil {
 		res := &client.RequestResult{}
@@ -41,7 +46,11 @@ func (db *implicitTxnDB) ExecRequest(ctx context.Context, request string) *clien
 	}
 	defer txn.Discard(ctx)
 
-	res := db.execRequest(ctx, request, txn)
+	res := db.execRequest(ctx, request, client.NewRequestOptions(opts...), txn)
+	if len(res.GQL.Errors) > 0 {
+		// Nothing written by a failed request should be persisted.
+		return res
+	}
 
 	if err := txn.Commit(ctx); err != nil {
 		res.GQL.Errors = []error{err}
@@ -55,8 +64,9 @@ func (db *implicitTxnDB) ExecRequest(ctx context.Context, request string) *clien
 func (db *explicitTxnDB) ExecRequest(
 	ctx context.Context,
 	request string,
+	opts ...client.RequestOption,
 ) *client.RequestResult {
-	return db.execRequest(ctx, request, db.txn)
+	return db.execRequest(ctx, request, client.NewRequestOptions(opts...), db.txn)
 }
 
 // GetCollectionByName returns an existing collection within the database.
@@ -253,6 +263,66 @@ func (db *explicitTxnDB) PatchSchema(ctx context.Context, patchString string) er
 	return db.patchSchema(ctx, db.txn, patchString)
 }
 
+// SetMigration registers the given schema migration, replacing any existing migration
+// between the same source and destination schema versions.
+func (db *implicitTxnDB) SetMigration(ctx context.Context, cfg client.LensConfig) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	err = db.setMigration(ctx, txn, cfg)
+	if err != nil {
+		return err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// SetMigration registers the given schema migration, replacing any existing migration
+// between the same source and destination schema versions.
+func (db *explicitTxnDB) SetMigration(ctx context.Context, cfg client.LensConfig) error {
+	return db.setMigration(ctx, db.txn, cfg)
+}
+
+// SetHistoryPolicy sets the history policy of a collection, replacing any existing policy
+// of the collection.
+func (db *implicitTxnDB) SetHistoryPolicy(ctx context.Context, policy client.HistoryPolicy) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	err = db.setHistoryPolicy(ctx, txn, policy)
+	if err != nil {
+		return err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// SetHistoryPolicy sets the history policy of a collection, replacing any existing policy
+// of the collection.
+func (db *explicitTxnDB) SetHistoryPolicy(ctx context.Context, policy client.HistoryPolicy) error {
+	return db.setHistoryPolicy(ctx, db.txn, policy)
+}
+
+// PruneHistory prunes the commit history of the documents of each collection that has a
+// history policy.
+//
+// The history of each document is pruned in its own transaction.
+func (db *implicitTxnDB) PruneHistory(ctx context.Context) error {
+	return db.pruneHistoryPerDoc(ctx, time.Now())
+}
+
+// PruneHistory prunes the commit history of the documents of each collection that has a
+// history policy.
+func (db *explicitTxnDB) PruneHistory(ctx context.Context) error {
+	return db.pruneHistory(ctx, db.txn, time.Now())
+}
+
 // SetReplicator adds a new replicator to the database.
 func (db *implicitTxnDB) SetReplicator(ctx context.Context, rep client.Replicator) error {
 	txn, err := db.NewTxn(ctx, false)
@@ -328,3 +398,40 @@ func (db *implicitTxnDB) GetAllP2PCollections(ctx context.Context) ([]string, er
 func (db *explicitTxnDB) GetAllP2PCollections(ctx context.Context) ([]string, error) {
 	return db.getAllP2PCollections(ctx, db.txn)
 }
+
+// BasicExport writes the documents of the database to the file described by the given config.
+func (db *implicitTxnDB) BasicExport(ctx context.Context, config *client.BackupConfig) error {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	return db.basicExport(ctx, txn, config)
+}
+
+// BasicExport writes the documents of the database to the file described by the given config.
+func (db *explicitTxnDB) BasicExport(ctx context.Context, config *client.BackupConfig) error {
+	return db.basicExport(ctx, db.txn, config)
+}
+
+// BasicImport imports the documents held by the given backup file into the database.
+func (db *implicitTxnDB) BasicImport(ctx context.Context, filepath string) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	err = db.basicImport(ctx, txn, filepath)
+	if err != nil {
+		return err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// BasicImport imports the documents held by the given backup file into the database.
+func (db *explicitTxnDB) BasicImport(ctx context.Context, filepath string) error {
+	return db.basicImport(ctx, db.txn, filepath)
+}
@@ -30,4 +30,11 @@ type Update struct {
 	SchemaID string
 	Block    ipld.Node
 	Priority uint64
+
+	// Owner is the identity owning the document, it will be empty if the document has no owner.
+	Owner string
+
+	// PrunedHeight is the height up to which the history of the document has been pruned,
+	// it will be zero if the history has not been pruned.
+	PrunedHeight uint64
 }
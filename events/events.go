@@ -49,4 +49,8 @@ func New[T any](commandBufferSize int, eventBufferSize int) Channel[T] {
 type Events struct {
 	// Updates publishes an `Update` for each document written to in the database.
 	Updates UpdateChannel
+
+	// IndexConflicts publishes an `IndexConflict` for each document received from a peer
+	// that violates a unique constraint.
+	IndexConflicts IndexConflictChannel
 }
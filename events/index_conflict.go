@@ -0,0 +1,40 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package events
+
+import (
+	"github.com/sourcenetwork/immutable"
+)
+
+// IndexConflictChannel is the bus onto which unique index conflicts are published.
+type IndexConflictChannel = immutable.Option[Channel[IndexConflict]]
+
+// IndexConflict represents a document received from a peer that has been indexed despite
+// violating a unique constraint of its collection.
+//
+// Unique constraints can't be enforced across peers, both documents are kept and it is up
+// to the application to resolve the conflict.
+type IndexConflict struct {
+	// CollectionName is the name of the collection holding both documents.
+	CollectionName string
+
+	// IndexName is the name of the unique index that has been violated.
+	IndexName string
+
+	// Fields are the names of the fields on which the constraint is declared.
+	Fields []string
+
+	// DocKey is the key of the document received from the peer.
+	DocKey string
+
+	// ConflictingDocKey is the key of the document already holding the same field values.
+	ConflictingDocKey string
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lens
+
+import (
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	errMigrationToSameVersion     string = "a migration must have different source and destination versions"
+	errLensOperationMissingField  string = "lens operation must have a field"
+	errLensOperationMissingFrom   string = "lens operation must have a from field"
+	errUnknownLensOperation       string = "unknown lens operation"
+	errFailedToUnmarshalMigration string = "failed to unmarshal migration"
+)
+
+var (
+	ErrMigrationVersionIDEmpty    = errors.New("migration source and destination schema version IDs can't be empty")
+	ErrMigrationToSameVersion     = errors.New(errMigrationToSameVersion)
+	ErrLensOperationMissingField  = errors.New(errLensOperationMissingField)
+	ErrLensOperationMissingFrom   = errors.New(errLensOperationMissingFrom)
+	ErrUnknownLensOperation       = errors.New(errUnknownLensOperation)
+	ErrFailedToUnmarshalMigration = errors.New(errFailedToUnmarshalMigration)
+)
+
+// NewErrMigrationToSameVersion returns a new error indicating that a migration was given the
+// same source and destination schema version.
+func NewErrMigrationToSameVersion(schemaVersionID string) error {
+	return errors.New(errMigrationToSameVersion, errors.NewKV("SchemaVersionID", schemaVersionID))
+}
+
+// NewErrLensOperationMissingField returns a new error indicating that a lens operation did not
+// specify the field it writes to.
+func NewErrLensOperationMissingField(op string) error {
+	return errors.New(errLensOperationMissingField, errors.NewKV("Op", op))
+}
+
+// NewErrLensOperationMissingFrom returns a new error indicating that a lens operation did not
+// specify the field it reads from.
+func NewErrLensOperationMissingFrom(op string) error {
+	return errors.New(errLensOperationMissingFrom, errors.NewKV("Op", op))
+}
+
+// NewErrUnknownLensOperation returns a new error indicating that a lens operation is not
+// of a known kind.
+func NewErrUnknownLensOperation(op string) error {
+	return errors.New(errUnknownLensOperation, errors.NewKV("Op", op))
+}
+
+// NewErrFailedToUnmarshalMigration returns a new error indicating that the migration stored
+// at the given key could not be unmarshalled.
+func NewErrFailedToUnmarshalMigration(key string, inner error) error {
+	return errors.Wrap(errFailedToUnmarshalMigration, inner, errors.NewKV("Key", key))
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+/*
+Package lens implements schema migrations, transforming documents written at one schema version
+so that they may be read at another.
+
+Migrations are described by declarative lenses, see [client.LensConfig], and are persisted in the
+system store keyed by their source and destination schema versions.
+*/
+package lens
+
+import (
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+var (
+	log = logging.MustNewLogger("defra.lens")
+)
+
+// Validate returns an error if the given migration config is not valid.
+func Validate(config client.LensConfig) error {
+	if config.SourceSchemaVersionID == "" || config.DestinationSchemaVersionID == "" {
+		return ErrMigrationVersionIDEmpty
+	}
+	if config.SourceSchemaVersionID == config.DestinationSchemaVersionID {
+		return NewErrMigrationToSameVersion(config.SourceSchemaVersionID)
+	}
+
+	for _, op := range config.Lens {
+		if op.Field == "" {
+			return NewErrLensOperationMissingField(op.Op)
+		}
+		switch op.Op {
+		case client.LensOpSet, client.LensOpDefault, client.LensOpRemove:
+		case client.LensOpCopy, client.LensOpRename:
+			if op.From == "" {
+				return NewErrLensOperationMissingFrom(op.Op)
+			}
+		default:
+			return NewErrUnknownLensOperation(op.Op)
+		}
+	}
+	return nil
+}
+
+// Apply transforms the given document field values in place, applying the given operations in order.
+//
+// Cleared fields are set to nil so that they may be told apart from fields the lens did not touch.
+func Apply(doc map[string]any, lens []client.LensOperation) {
+	for _, op := range lens {
+		switch op.Op {
+		case client.LensOpSet:
+			doc[op.Field] = op.Value
+		case client.LensOpDefault:
+			if doc[op.Field] == nil {
+				doc[op.Field] = op.Value
+			}
+		case client.LensOpCopy:
+			doc[op.Field] = doc[op.From]
+		case client.LensOpRename:
+			value := doc[op.From]
+			doc[op.From] = nil
+			doc[op.Field] = value
+		case client.LensOpRemove:
+			doc[op.Field] = nil
+		}
+	}
+}
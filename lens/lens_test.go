@@ -0,0 +1,99 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestApply(t *testing.T) {
+	doc := map[string]any{
+		"name":  "John",
+		"age":   uint64(30),
+		"email": "john@example.com",
+	}
+
+	Apply(doc, []client.LensOperation{
+		{Op: client.LensOpDefault, Field: "points", Value: float64(10)},
+		{Op: client.LensOpDefault, Field: "name", Value: "Fred"},
+		{Op: client.LensOpCopy, Field: "nickname", From: "name"},
+		{Op: client.LensOpRename, Field: "contact", From: "email"},
+		{Op: client.LensOpRemove, Field: "age"},
+		{Op: client.LensOpSet, Field: "verified", Value: true},
+	})
+
+	require.Equal(t, map[string]any{
+		"name":     "John",
+		"nickname": "John",
+		"points":   float64(10),
+		"email":    nil,
+		"contact":  "john@example.com",
+		"age":      nil,
+		"verified": true,
+	}, doc)
+}
+
+func TestValidate(t *testing.T) {
+	valid := client.LensConfig{
+		SourceSchemaVersionID:      "v1",
+		DestinationSchemaVersionID: "v2",
+		Lens: []client.LensOperation{
+			{Op: client.LensOpCopy, Field: "nickname", From: "name"},
+		},
+	}
+	require.NoError(t, Validate(valid))
+
+	config := valid
+	config.SourceSchemaVersionID = ""
+	require.ErrorIs(t, Validate(config), ErrMigrationVersionIDEmpty)
+
+	config = valid
+	config.DestinationSchemaVersionID = "v1"
+	require.ErrorIs(t, Validate(config), ErrMigrationToSameVersion)
+
+	config = valid
+	config.Lens = []client.LensOperation{{Op: client.LensOpSet}}
+	require.ErrorIs(t, Validate(config), ErrLensOperationMissingField)
+
+	config = valid
+	config.Lens = []client.LensOperation{{Op: client.LensOpRename, Field: "nickname"}}
+	require.ErrorIs(t, Validate(config), ErrLensOperationMissingFrom)
+
+	config = valid
+	config.Lens = []client.LensOperation{{Op: "delete", Field: "nickname"}}
+	require.ErrorIs(t, Validate(config), ErrUnknownLensOperation)
+}
+
+func TestRegistryMigrateChainsMigrations(t *testing.T) {
+	registry := &Registry{migrations: map[string]map[string]client.LensConfig{}}
+	registry.add(client.LensConfig{
+		SourceSchemaVersionID:      "v1",
+		DestinationSchemaVersionID: "v2",
+		Lens:                       []client.LensOperation{{Op: client.LensOpRename, Field: "fullName", From: "name"}},
+	})
+	registry.add(client.LensConfig{
+		SourceSchemaVersionID:      "v2",
+		DestinationSchemaVersionID: "v3",
+		Lens:                       []client.LensOperation{{Op: client.LensOpDefault, Field: "points", Value: "0"}},
+	})
+
+	doc := map[string]any{"name": "John"}
+	require.True(t, registry.Migrate(doc, "v1", "v3"))
+	require.Equal(t, map[string]any{"name": nil, "fullName": "John", "points": "0"}, doc)
+
+	doc = map[string]any{"name": "John"}
+	require.False(t, registry.Migrate(doc, "v3", "v1"))
+	require.Equal(t, map[string]any{"name": "John"}, doc)
+}
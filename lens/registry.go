@@ -0,0 +1,120 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lens
+
+import (
+	"context"
+	"encoding/json"
+
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// Registry holds the schema migrations persisted within a store.
+type Registry struct {
+	// migrations maps source schema version IDs to the migrations from that version, keyed
+	// by their destination schema version ID.
+	migrations map[string]map[string]client.LensConfig
+}
+
+// LoadRegistry returns a registry holding all the migrations persisted within the given
+// system store.
+func LoadRegistry(ctx context.Context, store datastore.DSReaderWriter) (*Registry, error) {
+	q, err := store.Query(ctx, dsq.Query{
+		Prefix: core.NewSchemaVersionMigrationKey("", "").ToString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := q.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close migration query", err)
+		}
+	}()
+
+	registry := &Registry{migrations: map[string]map[string]client.LensConfig{}}
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		var config client.LensConfig
+		err = json.Unmarshal(res.Value, &config)
+		if err != nil {
+			return nil, NewErrFailedToUnmarshalMigration(res.Key, err)
+		}
+		registry.add(config)
+	}
+
+	return registry, nil
+}
+
+func (r *Registry) add(config client.LensConfig) {
+	destinations, ok := r.migrations[config.SourceSchemaVersionID]
+	if !ok {
+		destinations = map[string]client.LensConfig{}
+		r.migrations[config.SourceSchemaVersionID] = destinations
+	}
+	destinations[config.DestinationSchemaVersionID] = config
+}
+
+// Migrate transforms the given document field values in place, from the given source schema
+// version to the given destination schema version.
+//
+// If there is no direct migration between the two versions, the shortest chain of migrations
+// leading from the source version to the destination version is applied.
+//
+// Returns false, leaving the document untouched, if no such chain exists.
+func (r *Registry) Migrate(doc map[string]any, sourceVersionID, destinationVersionID string) bool {
+	path := r.findPath(sourceVersionID, destinationVersionID)
+	if path == nil {
+		return false
+	}
+
+	for _, config := range path {
+		Apply(doc, config.Lens)
+	}
+	return true
+}
+
+// findPath returns the shortest chain of migrations from the given source version to the
+// given destination version, or nil if there is none.
+func (r *Registry) findPath(sourceVersionID, destinationVersionID string) []client.LensConfig {
+	// previous maps each visited version to the migration it was reached by
+	previous := map[string]client.LensConfig{sourceVersionID: {}}
+	toVisit := []string{sourceVersionID}
+	for len(toVisit) > 0 {
+		current := toVisit[0]
+		toVisit = toVisit[1:]
+
+		if current == destinationVersionID {
+			var path []client.LensConfig
+			for current != sourceVersionID {
+				config := previous[current]
+				path = append([]client.LensConfig{config}, path...)
+				current = config.SourceSchemaVersionID
+			}
+			return path
+		}
+
+		for destination, config := range r.migrations[current] {
+			if _, visited := previous[destination]; visited {
+				continue
+			}
+			previous[destination] = config
+			toVisit = append(toVisit, destination)
+		}
+	}
+	return nil
+}
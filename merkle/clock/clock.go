@@ -63,7 +63,7 @@ func (mc *MerkleClock) putBlock(
 		delta.SetPriority(height)
 	}
 
-	node, err := makeNode(delta, heads)
+	node, err := makeNode(delta, heads, signerFromContext(ctx))
 	if err != nil {
 		return nil, NewErrCreatingBlock(err)
 	}
@@ -26,6 +26,9 @@ const (
 	errReplacingHead          = "error replacing head"
 	errCouldNotFindBlock      = "error checking for known block "
 	errFailedToGetNextQResult = "failed to get next query result"
+	errInvalidBlockSignature  = "invalid block signature"
+	errUnsignedBlock          = "block is not signed"
+	errUntrustedSigner        = "block is signed by an untrusted identity"
 )
 
 var (
@@ -39,6 +42,10 @@ var (
 	ErrCouldNotFindBlock      = errors.New(errCouldNotFindBlock)
 	ErrFailedToGetNextQResult = errors.New(errFailedToGetNextQResult)
 	ErrDecodingHeight         = errors.New("error decoding height")
+	ErrInvalidBlockSignature  = errors.New(errInvalidBlockSignature)
+	ErrUnsignedBlock          = errors.New(errUnsignedBlock)
+	ErrUntrustedSigner        = errors.New(errUntrustedSigner)
+	ErrSignatureMismatch      = errors.New("signature does not match the block payload")
 )
 
 func NewErrCreatingBlock(inner error) error {
@@ -81,3 +88,15 @@ func NewErrCouldNotFindBlock(cid cid.Cid, inner error) error {
 func NewErrFailedToGetNextQResult(inner error) error {
 	return errors.Wrap(errFailedToGetNextQResult, inner)
 }
+
+func NewErrInvalidBlockSignature(cid cid.Cid, inner error) error {
+	return errors.Wrap(errInvalidBlockSignature, inner, errors.NewKV("Cid", cid))
+}
+
+func NewErrUnsignedBlock(cid cid.Cid) error {
+	return errors.New(errUnsignedBlock, errors.NewKV("Cid", cid))
+}
+
+func NewErrUntrustedSigner(cid cid.Cid, signer string) error {
+	return errors.New(errUntrustedSigner, errors.NewKV("Cid", cid), errors.NewKV("Signer", signer))
+}
@@ -17,6 +17,7 @@ import (
 	cid "github.com/ipfs/go-cid"
 	ipld "github.com/ipfs/go-ipld-format"
 	dag "github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	mh "github.com/multiformats/go-multihash"
 
 	"github.com/sourcenetwork/defradb/core"
@@ -113,7 +114,10 @@ func (ng *CrdtNodeGetter) GetDeltas(ctx context.Context, cids []cid.Cid) <-chan
 // 	return d, err
 // }
 
-func makeNode(delta core.Delta, heads []cid.Cid) (ipld.Node, error) {
+// makeNode returns a new block holding the given delta and linking to the given heads.
+//
+// The block is signed using the given key if it is not nil.
+func makeNode(delta core.Delta, heads []cid.Cid, signer crypto.PrivKey) (ipld.Node, error) {
 	var data []byte
 	var err error
 	if delta != nil {
@@ -149,6 +153,18 @@ func makeNode(delta core.Delta, heads []cid.Cid) (ipld.Node, error) {
 			}
 		}
 	}
+
+	if signed, ok := delta.(core.SignedDelta); ok && signer != nil {
+		if err = sign(signer, signed, nd.Links()); err != nil {
+			return nil, err
+		}
+		data, err = delta.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		nd.SetData(data)
+	}
+
 	return nd, nil
 }
 
@@ -0,0 +1,149 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clock
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/core"
+)
+
+type signerContextKey struct{}
+
+// WithSigner returns a new context, within which blocks added to a MerkleClock are signed
+// using the given private key.
+func WithSigner(ctx context.Context, key crypto.PrivKey) context.Context {
+	return context.WithValue(ctx, signerContextKey{}, key)
+}
+
+func signerFromContext(ctx context.Context) crypto.PrivKey {
+	key, _ := ctx.Value(signerContextKey{}).(crypto.PrivKey)
+	return key
+}
+
+// SignaturePolicy defines which blocks received from other nodes may be merged.
+type SignaturePolicy struct {
+	// RequireSignature causes unsigned blocks to be rejected.
+	RequireSignature bool
+
+	// TrustedSigners holds the identities allowed to sign blocks.
+	//
+	// If empty, blocks signed by any identity are accepted.
+	TrustedSigners []string
+}
+
+// Verify returns an error if the signature of the given block is invalid, or if the block
+// is not allowed by the policy.
+func (policy SignaturePolicy) Verify(nd ipld.Node, delta core.Delta) error {
+	signer, err := GetSigner(nd, delta)
+	if err != nil {
+		return err
+	}
+	if signer == "" {
+		if policy.RequireSignature {
+			return NewErrUnsignedBlock(nd.Cid())
+		}
+		return nil
+	}
+	if len(policy.TrustedSigners) == 0 {
+		return nil
+	}
+	for _, trusted := range policy.TrustedSigners {
+		if signer == trusted {
+			return nil
+		}
+	}
+	return NewErrUntrustedSigner(nd.Cid(), signer)
+}
+
+// GetSigner returns the identity of the node that signed the given block, or an empty
+// string if the block is unsigned.
+//
+// An error is returned if the block signature is invalid.
+func GetSigner(nd ipld.Node, delta core.Delta) (string, error) {
+	signed, ok := delta.(core.SignedDelta)
+	if !ok || signed.GetSignature() == nil {
+		return "", nil
+	}
+	signature := signed.GetSignature()
+
+	pubKey, err := crypto.UnmarshalPublicKey(signature.PublicKey)
+	if err != nil {
+		return "", NewErrInvalidBlockSignature(nd.Cid(), err)
+	}
+
+	// The signature covers the delta as it was before being signed.
+	signed.SetSignature(nil)
+	data, err := delta.Marshal()
+	signed.SetSignature(signature)
+	if err != nil {
+		return "", err
+	}
+
+	valid, err := pubKey.Verify(signingPayload(data, nd.Links()), signature.Value)
+	if err != nil {
+		return "", NewErrInvalidBlockSignature(nd.Cid(), err)
+	}
+	if !valid {
+		return "", NewErrInvalidBlockSignature(nd.Cid(), ErrSignatureMismatch)
+	}
+
+	return acp.NewIdentity(pubKey)
+}
+
+// sign signs the given delta, which is to be held by a block with the given links.
+func sign(key crypto.PrivKey, delta core.SignedDelta, links []*ipld.Link) error {
+	data, err := delta.Marshal()
+	if err != nil {
+		return err
+	}
+	pubKey, err := crypto.MarshalPublicKey(key.GetPublic())
+	if err != nil {
+		return err
+	}
+	value, err := key.Sign(signingPayload(data, links))
+	if err != nil {
+		return err
+	}
+	delta.SetSignature(&core.Signature{
+		PublicKey: pubKey,
+		Value:     value,
+	})
+	return nil
+}
+
+// signingPayload returns the bytes signed for a block holding the given delta data and links.
+//
+// Links are sorted so that the payload does not depend on the order in which they are encoded.
+func signingPayload(data []byte, links []*ipld.Link) []byte {
+	sorted := make([]*ipld.Link, len(links))
+	copy(sorted, links)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return bytes.Compare(sorted[i].Cid.Bytes(), sorted[j].Cid.Bytes()) < 0
+	})
+
+	payload := bytes.NewBuffer(nil)
+	payload.Write(data)
+	for _, link := range sorted {
+		payload.WriteString(link.Name)
+		payload.Write(link.Cid.Bytes())
+	}
+	return payload.Bytes()
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clock
+
+import (
+	"context"
+	"testing"
+
+	dag "github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/core/crdt"
+)
+
+func newTestSigningKey(t *testing.T) (crypto.PrivKey, string) {
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	identity, err := acp.NewIdentity(key.GetPublic())
+	require.NoError(t, err)
+	return key, identity
+}
+
+func TestMerkleClockAddDAGNodeWithSignerSignsBlocks(t *testing.T) {
+	key, identity := newTestSigningKey(t)
+	ctx := WithSigner(context.Background(), key)
+	clk := newTestMerkleClock()
+
+	for _, value := range []string{"first", "second"} {
+		node, err := clk.AddDAGNode(ctx, &crdt.LWWRegDelta{Data: []byte(value)})
+		require.NoError(t, err)
+
+		// Decode the stored block to make sure the signature survives encoding.
+		decoded, err := dag.DecodeProtobuf(node.RawData())
+		require.NoError(t, err)
+		delta, err := clk.crdt.DeltaDecode(decoded)
+		require.NoError(t, err)
+
+		signer, err := GetSigner(decoded, delta)
+		require.NoError(t, err)
+		require.Equal(t, identity, signer)
+	}
+}
+
+func TestMerkleClockAddDAGNodeWithoutSignerCreatesUnsignedBlocks(t *testing.T) {
+	clk := newTestMerkleClock()
+
+	node, err := clk.AddDAGNode(context.Background(), &crdt.LWWRegDelta{Data: []byte("test")})
+	require.NoError(t, err)
+	delta, err := clk.crdt.DeltaDecode(node)
+	require.NoError(t, err)
+
+	signer, err := GetSigner(node, delta)
+	require.NoError(t, err)
+	require.Equal(t, "", signer)
+}
+
+func TestGetSignerWithTamperedDeltaReturnsError(t *testing.T) {
+	key, _ := newTestSigningKey(t)
+	clk := newTestMerkleClock()
+
+	node, err := clk.AddDAGNode(WithSigner(context.Background(), key), &crdt.LWWRegDelta{Data: []byte("test")})
+	require.NoError(t, err)
+	delta, err := clk.crdt.DeltaDecode(node)
+	require.NoError(t, err)
+
+	delta.(*crdt.LWWRegDelta).Data = []byte("forged")
+
+	_, err = GetSigner(node, delta)
+	require.ErrorIs(t, err, ErrInvalidBlockSignature)
+}
+
+func TestSignaturePolicyVerify(t *testing.T) {
+	key, identity := newTestSigningKey(t)
+	_, otherIdentity := newTestSigningKey(t)
+	clk := newTestMerkleClock()
+
+	signedNode, err := clk.AddDAGNode(WithSigner(context.Background(), key), &crdt.LWWRegDelta{Data: []byte("a")})
+	require.NoError(t, err)
+	signedDelta, err := clk.crdt.DeltaDecode(signedNode)
+	require.NoError(t, err)
+
+	unsignedNode, err := clk.AddDAGNode(context.Background(), &crdt.LWWRegDelta{Data: []byte("b")})
+	require.NoError(t, err)
+	unsignedDelta, err := clk.crdt.DeltaDecode(unsignedNode)
+	require.NoError(t, err)
+
+	policy := SignaturePolicy{}
+	require.NoError(t, policy.Verify(signedNode, signedDelta))
+	require.NoError(t, policy.Verify(unsignedNode, unsignedDelta))
+
+	policy = SignaturePolicy{RequireSignature: true}
+	require.NoError(t, policy.Verify(signedNode, signedDelta))
+	require.ErrorIs(t, policy.Verify(unsignedNode, unsignedDelta), ErrUnsignedBlock)
+
+	policy = SignaturePolicy{TrustedSigners: []string{identity}}
+	require.NoError(t, policy.Verify(signedNode, signedDelta))
+
+	policy = SignaturePolicy{TrustedSigners: []string{otherIdentity}}
+	require.ErrorIs(t, policy.Verify(signedNode, signedDelta), ErrUntrustedSigner)
+}
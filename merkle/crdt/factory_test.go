@@ -14,6 +14,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/fxamacker/cbor/v2"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
 
@@ -232,3 +233,19 @@ func TestCompositeRegisterFactoryFn(t *testing.T) {
 	_, _, err := merkleReg.Set(ctx, []byte("hi"), []core.DAGLink{})
 	assert.NoError(t, err)
 }
+
+func TestPNCounterFactoryFn(t *testing.T) {
+	ctx := context.Background()
+	m := newStores()
+	f := NewFactory(m) // here factory is only needed to satisfy datastore.MultiStore interface
+	crdt := pnCounterFactoryFn(f, core.CollectionSchemaVersionKey{}, events.EmptyUpdateChannel)(core.MustNewDataStoreKey("/1/0/MyKey"))
+
+	counter, ok := crdt.(*MerklePNCounter)
+	assert.True(t, ok)
+
+	value, err := cbor.Marshal(int64(1))
+	assert.NoError(t, err)
+
+	_, _, err = counter.Increment(ctx, value)
+	assert.NoError(t, err)
+}
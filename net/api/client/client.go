@@ -48,17 +48,30 @@ func (c *Client) Close() error {
 }
 
 // SetReplicator sends a request to add a target replicator to the DB peer.
+//
+// The given field filters, keyed by collection name, restrict the fields of the collections
+// that are replicated.
 func (c *Client) SetReplicator(
 	ctx context.Context,
 	paddr ma.Multiaddr,
+	fieldFilters map[string]client.FieldFilter,
 	collections ...string,
 ) (peer.ID, error) {
 	if paddr == nil {
 		return "", errors.New("target address can't be empty")
 	}
+	pbFieldFilters := []*pb.FieldFilter{}
+	for collection, filter := range fieldFilters {
+		pbFieldFilters = append(pbFieldFilters, &pb.FieldFilter{
+			Collection: collection,
+			Allow:      filter.Allow,
+			Deny:       filter.Deny,
+		})
+	}
 	resp, err := c.c.SetReplicator(ctx, &pb.SetReplicatorRequest{
-		Collections: collections,
-		Addr:        paddr.Bytes(),
+		Collections:  collections,
+		Addr:         paddr.Bytes(),
+		FieldFilters: pbFieldFilters,
 	})
 	if err != nil {
 		return "", errors.Wrap("could not add replicator", err)
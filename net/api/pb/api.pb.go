@@ -29,6 +29,8 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type SetReplicatorRequest struct {
 	Collections []string `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
 	Addr        []byte   `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	// fieldFilters restrict the fields of the given collections that are replicated.
+	FieldFilters []*FieldFilter `protobuf:"bytes,3,rep,name=fieldFilters,proto3" json:"fieldFilters,omitempty"`
 }
 
 func (m *SetReplicatorRequest) Reset()         { *m = SetReplicatorRequest{} }
@@ -78,6 +80,76 @@ func (m *SetReplicatorRequest) GetAddr() []byte {
 	return nil
 }
 
+func (m *SetReplicatorRequest) GetFieldFilters() []*FieldFilter {
+	if m != nil {
+		return m.FieldFilters
+	}
+	return nil
+}
+
+type FieldFilter struct {
+	// collection is the name of the collection the filter applies to.
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// allow holds the names of the only fields that are replicated, all fields are if empty.
+	Allow []string `protobuf:"bytes,2,rep,name=allow,proto3" json:"allow,omitempty"`
+	// deny holds the names of the fields that are never replicated.
+	Deny []string `protobuf:"bytes,3,rep,name=deny,proto3" json:"deny,omitempty"`
+}
+
+func (m *FieldFilter) Reset()         { *m = FieldFilter{} }
+func (m *FieldFilter) String() string { return proto.CompactTextString(m) }
+func (*FieldFilter) ProtoMessage()    {}
+func (*FieldFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+func (m *FieldFilter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FieldFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FieldFilter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FieldFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldFilter.Merge(m, src)
+}
+func (m *FieldFilter) XXX_Size() int {
+	return m.Size()
+}
+func (m *FieldFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldFilter proto.InternalMessageInfo
+
+func (m *FieldFilter) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *FieldFilter) GetAllow() []string {
+	if m != nil {
+		return m.Allow
+	}
+	return nil
+}
+
+func (m *FieldFilter) GetDeny() []string {
+	if m != nil {
+		return m.Deny
+	}
+	return nil
+}
+
 type SetReplicatorReply struct {
 	PeerID []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
 }
@@ -86,7 +158,7 @@ func (m *SetReplicatorReply) Reset()         { *m = SetReplicatorReply{} }
 func (m *SetReplicatorReply) String() string { return proto.CompactTextString(m) }
 func (*SetReplicatorReply) ProtoMessage()    {}
 func (*SetReplicatorReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
 }
 func (m *SetReplicatorReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -130,7 +202,7 @@ func (m *DeleteReplicatorRequest) Reset()         { *m = DeleteReplicatorRequest
 func (m *DeleteReplicatorRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteReplicatorRequest) ProtoMessage()    {}
 func (*DeleteReplicatorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
 }
 func (m *DeleteReplicatorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -174,7 +246,7 @@ func (m *DeleteReplicatorReply) Reset()         { *m = DeleteReplicatorReply{} }
 func (m *DeleteReplicatorReply) String() string { return proto.CompactTextString(m) }
 func (*DeleteReplicatorReply) ProtoMessage()    {}
 func (*DeleteReplicatorReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
 }
 func (m *DeleteReplicatorReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -217,7 +289,7 @@ func (m *GetAllReplicatorRequest) Reset()         { *m = GetAllReplicatorRequest
 func (m *GetAllReplicatorRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAllReplicatorRequest) ProtoMessage()    {}
 func (*GetAllReplicatorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
 }
 func (m *GetAllReplicatorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -254,7 +326,7 @@ func (m *GetAllReplicatorReply) Reset()         { *m = GetAllReplicatorReply{} }
 func (m *GetAllReplicatorReply) String() string { return proto.CompactTextString(m) }
 func (*GetAllReplicatorReply) ProtoMessage()    {}
 func (*GetAllReplicatorReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
 }
 func (m *GetAllReplicatorReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -299,7 +371,7 @@ func (m *GetAllReplicatorReply_Replicators) Reset()         { *m = GetAllReplica
 func (m *GetAllReplicatorReply_Replicators) String() string { return proto.CompactTextString(m) }
 func (*GetAllReplicatorReply_Replicators) ProtoMessage()    {}
 func (*GetAllReplicatorReply_Replicators) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{5, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6, 0}
 }
 func (m *GetAllReplicatorReply_Replicators) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -353,7 +425,7 @@ func (m *GetAllReplicatorReply_Replicators_Info) Reset() {
 func (m *GetAllReplicatorReply_Replicators_Info) String() string { return proto.CompactTextString(m) }
 func (*GetAllReplicatorReply_Replicators_Info) ProtoMessage()    {}
 func (*GetAllReplicatorReply_Replicators_Info) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{5, 0, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6, 0, 0}
 }
 func (m *GetAllReplicatorReply_Replicators_Info) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -404,7 +476,7 @@ func (m *AddP2PCollectionsRequest) Reset()         { *m = AddP2PCollectionsReque
 func (m *AddP2PCollectionsRequest) String() string { return proto.CompactTextString(m) }
 func (*AddP2PCollectionsRequest) ProtoMessage()    {}
 func (*AddP2PCollectionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
 }
 func (m *AddP2PCollectionsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -448,7 +520,7 @@ func (m *AddP2PCollectionsReply) Reset()         { *m = AddP2PCollectionsReply{}
 func (m *AddP2PCollectionsReply) String() string { return proto.CompactTextString(m) }
 func (*AddP2PCollectionsReply) ProtoMessage()    {}
 func (*AddP2PCollectionsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
 }
 func (m *AddP2PCollectionsReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -492,7 +564,7 @@ func (m *RemoveP2PCollectionsRequest) Reset()         { *m = RemoveP2PCollection
 func (m *RemoveP2PCollectionsRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveP2PCollectionsRequest) ProtoMessage()    {}
 func (*RemoveP2PCollectionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
 }
 func (m *RemoveP2PCollectionsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -536,7 +608,7 @@ func (m *RemoveP2PCollectionsReply) Reset()         { *m = RemoveP2PCollectionsR
 func (m *RemoveP2PCollectionsReply) String() string { return proto.CompactTextString(m) }
 func (*RemoveP2PCollectionsReply) ProtoMessage()    {}
 func (*RemoveP2PCollectionsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
 }
 func (m *RemoveP2PCollectionsReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -579,7 +651,7 @@ func (m *GetAllP2PCollectionsRequest) Reset()         { *m = GetAllP2PCollection
 func (m *GetAllP2PCollectionsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAllP2PCollectionsRequest) ProtoMessage()    {}
 func (*GetAllP2PCollectionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
 }
 func (m *GetAllP2PCollectionsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -616,7 +688,7 @@ func (m *GetAllP2PCollectionsReply) Reset()         { *m = GetAllP2PCollectionsR
 func (m *GetAllP2PCollectionsReply) String() string { return proto.CompactTextString(m) }
 func (*GetAllP2PCollectionsReply) ProtoMessage()    {}
 func (*GetAllP2PCollectionsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
 }
 func (m *GetAllP2PCollectionsReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -661,7 +733,7 @@ func (m *GetAllP2PCollectionsReply_Collection) Reset()         { *m = GetAllP2PC
 func (m *GetAllP2PCollectionsReply_Collection) String() string { return proto.CompactTextString(m) }
 func (*GetAllP2PCollectionsReply_Collection) ProtoMessage()    {}
 func (*GetAllP2PCollectionsReply_Collection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12, 0}
 }
 func (m *GetAllP2PCollectionsReply_Collection) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -706,6 +778,7 @@ func (m *GetAllP2PCollectionsReply_Collection) GetName() string {
 
 func init() {
 	proto.RegisterType((*SetReplicatorRequest)(nil), "api.pb.SetReplicatorRequest")
+	proto.RegisterType((*FieldFilter)(nil), "api.pb.FieldFilter")
 	proto.RegisterType((*SetReplicatorReply)(nil), "api.pb.SetReplicatorReply")
 	proto.RegisterType((*DeleteReplicatorRequest)(nil), "api.pb.DeleteReplicatorRequest")
 	proto.RegisterType((*DeleteReplicatorReply)(nil), "api.pb.DeleteReplicatorReply")
@@ -725,40 +798,44 @@ func init() {
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 524 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x41, 0x6f, 0x94, 0x40,
-	0x14, 0x06, 0x8a, 0x6d, 0x78, 0xa8, 0x69, 0x5f, 0xb6, 0x2d, 0x4b, 0x2d, 0xae, 0xe3, 0xa5, 0x9a,
-	0x15, 0x15, 0xaf, 0x26, 0xa6, 0xb5, 0x89, 0x69, 0x6a, 0x4c, 0x43, 0x35, 0x5e, 0xa5, 0xf0, 0x1a,
-	0x49, 0xd8, 0x05, 0x01, 0x9b, 0xf4, 0xe6, 0x4f, 0xf0, 0xea, 0xc1, 0xc4, 0x9f, 0xe3, 0xb1, 0x47,
-	0x8f, 0x66, 0xf7, 0x8f, 0x18, 0x86, 0xa5, 0xec, 0x2e, 0x03, 0x69, 0xbc, 0xcd, 0xcc, 0x7b, 0xdf,
-	0xf7, 0x0d, 0xf3, 0x7d, 0x0f, 0xd0, 0xbc, 0x24, 0xb4, 0x93, 0x34, 0xce, 0x63, 0x5c, 0xe5, 0xcb,
-	0x33, 0xf6, 0x16, 0x7a, 0xa7, 0x94, 0xbb, 0x94, 0x44, 0xa1, 0xef, 0xe5, 0x71, 0xea, 0xd2, 0x97,
-	0xaf, 0x94, 0xe5, 0x38, 0x00, 0xdd, 0x8f, 0xa3, 0x88, 0xfc, 0x3c, 0x8c, 0xc7, 0x99, 0x21, 0x0f,
-	0x56, 0xf6, 0x34, 0x77, 0xfe, 0x08, 0x11, 0x54, 0x2f, 0x08, 0x52, 0x43, 0x19, 0xc8, 0x7b, 0xb7,
-	0x5d, 0xbe, 0x66, 0x43, 0xc0, 0x25, 0xb6, 0x24, 0xba, 0xc4, 0x2d, 0x58, 0x4d, 0x88, 0xd2, 0xa3,
-	0x43, 0x43, 0xe6, 0xbd, 0xb3, 0x1d, 0x7b, 0x0e, 0xdb, 0x87, 0x14, 0x51, 0x4e, 0x4d, 0xf9, 0x36,
-	0xc8, 0x53, 0xd8, 0x6c, 0x42, 0xba, 0x34, 0xfa, 0xb0, 0xfd, 0x86, 0xf2, 0xfd, 0x28, 0x6a, 0x68,
-	0xb0, 0x6f, 0x0a, 0x6c, 0x36, 0x6b, 0x05, 0xd9, 0x31, 0xe8, 0xe9, 0xf5, 0x51, 0xf9, 0xf1, 0xba,
-	0xf3, 0xc8, 0x2e, 0x9f, 0xcc, 0x16, 0x62, 0xec, 0x7a, 0x9f, 0xb9, 0xf3, 0x68, 0xf3, 0x87, 0x0c,
-	0xfa, 0x5c, 0x11, 0x0f, 0x40, 0x0d, 0xc7, 0xe7, 0x31, 0xbf, 0xa7, 0xee, 0xd8, 0x37, 0x66, 0xb5,
-	0x8f, 0xc6, 0xe7, 0xb1, 0xcb, 0xb1, 0x68, 0xc0, 0x5a, 0xe6, 0x7f, 0xa6, 0x91, 0x97, 0x19, 0x0a,
-	0x77, 0xa6, 0xda, 0x9a, 0x43, 0x50, 0x8b, 0x3e, 0xbc, 0x0b, 0x4a, 0x18, 0xcc, 0xde, 0x42, 0x09,
-	0x03, 0xec, 0xc1, 0xad, 0xc2, 0xa1, 0x6c, 0x66, 0x57, 0xb9, 0x61, 0x2f, 0xc1, 0xd8, 0x0f, 0x82,
-	0x13, 0xe7, 0xe4, 0x75, 0x6d, 0xec, 0x8d, 0x13, 0xc0, 0x1e, 0xc3, 0x96, 0x00, 0x5d, 0x3c, 0xe0,
-	0x3a, 0xac, 0x50, 0x9a, 0x72, 0x79, 0xcd, 0x2d, 0x96, 0xec, 0x15, 0xec, 0xb8, 0x34, 0x8a, 0x2f,
-	0xe8, 0x7f, 0xc5, 0x9e, 0x40, 0x5f, 0x4c, 0x20, 0xd6, 0xdb, 0x85, 0x9d, 0xf2, 0x45, 0x85, 0x7a,
-	0xec, 0xa7, 0x0c, 0x7d, 0x71, 0xbd, 0xa0, 0x7b, 0xd7, 0xbc, 0x8d, 0xee, 0x0c, 0x17, 0x9d, 0x12,
-	0xe0, 0xec, 0xfa, 0x60, 0xe1, 0xee, 0xe6, 0x33, 0x80, 0xba, 0x34, 0x67, 0x8d, 0xc6, 0xad, 0x41,
-	0x50, 0xc7, 0xde, 0x88, 0xb8, 0x33, 0x9a, 0xcb, 0xd7, 0xce, 0x2f, 0x15, 0xd6, 0x4e, 0x29, 0xbd,
-	0x08, 0x7d, 0xc2, 0x63, 0xb8, 0xb3, 0x30, 0x54, 0x78, 0xaf, 0xba, 0x89, 0x68, 0x72, 0x4d, 0xb3,
-	0xa5, 0x9a, 0x44, 0x97, 0x4c, 0xc2, 0xf7, 0xb0, 0xbe, 0x3c, 0x40, 0x78, 0xbf, 0x42, 0xb4, 0x4c,
-	0xa3, 0xb9, 0xdb, 0xde, 0x50, 0xb2, 0x7e, 0x80, 0x8d, 0xe5, 0xfc, 0x66, 0x35, 0x6d, 0xcb, 0x00,
-	0xd6, 0xb4, 0xc2, 0xec, 0x33, 0x09, 0x3f, 0xc2, 0x46, 0x23, 0x60, 0x38, 0xa8, 0x50, 0x6d, 0xc9,
-	0x35, 0xad, 0x8e, 0x8e, 0x92, 0xf8, 0x13, 0xf4, 0x44, 0x61, 0xc2, 0x87, 0x15, 0xb2, 0x23, 0xab,
-	0xe6, 0x83, 0xee, 0xa6, 0x6b, 0x05, 0x51, 0x4e, 0x6a, 0x85, 0x8e, 0x74, 0xd6, 0x0a, 0xad, 0x51,
-	0x63, 0xd2, 0x81, 0xf1, 0x7b, 0x62, 0xc9, 0x57, 0x13, 0x4b, 0xfe, 0x3b, 0xb1, 0xe4, 0xef, 0x53,
-	0x4b, 0xba, 0x9a, 0x5a, 0xd2, 0x9f, 0xa9, 0x25, 0x9d, 0xad, 0xf2, 0x5f, 0xfc, 0x8b, 0x7f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xb7, 0xed, 0x74, 0x34, 0xef, 0x05, 0x00, 0x00,
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x8d, 0x93, 0xd0, 0xca, 0xe3, 0x80, 0xda, 0x25, 0x6d, 0x1d, 0x97, 0x18, 0xd7, 0x5c, 0x02,
+	0x0a, 0x06, 0xcc, 0x81, 0x0b, 0x12, 0x6a, 0xa9, 0x8a, 0xaa, 0x4a, 0xa8, 0xda, 0x82, 0x7a, 0xc5,
+	0x8d, 0x27, 0xc2, 0xd2, 0x26, 0x36, 0xb6, 0x29, 0xca, 0x8d, 0x0b, 0x77, 0xae, 0x1c, 0x90, 0xf8,
+	0x39, 0x1c, 0x7b, 0xe4, 0x88, 0x92, 0x3f, 0x82, 0xbc, 0xb6, 0x6b, 0x27, 0xfe, 0x50, 0xc5, 0x6d,
+	0x67, 0x67, 0xde, 0x7b, 0x93, 0x99, 0xb7, 0x31, 0x88, 0x96, 0xe7, 0x18, 0x9e, 0xef, 0x86, 0x2e,
+	0x59, 0xe3, 0xc7, 0x0b, 0xfd, 0x9b, 0x00, 0xdd, 0x33, 0x0c, 0x29, 0x7a, 0xcc, 0x19, 0x59, 0xa1,
+	0xeb, 0x53, 0xfc, 0xf4, 0x19, 0x83, 0x90, 0x68, 0x20, 0x8d, 0x5c, 0xc6, 0x70, 0x14, 0x3a, 0xee,
+	0x34, 0x90, 0x05, 0xad, 0x35, 0x10, 0x69, 0xfe, 0x8a, 0x10, 0x68, 0x5b, 0xb6, 0xed, 0xcb, 0x4d,
+	0x4d, 0x18, 0x74, 0x28, 0x3f, 0x93, 0x17, 0xd0, 0x19, 0x3b, 0xc8, 0xec, 0x23, 0x87, 0x85, 0xe8,
+	0x07, 0x72, 0x4b, 0x6b, 0x0d, 0x24, 0xf3, 0xae, 0x11, 0xab, 0x19, 0x47, 0x59, 0x8e, 0x2e, 0x15,
+	0xea, 0xe7, 0x20, 0xe5, 0x92, 0x44, 0x05, 0xc8, 0xa4, 0x64, 0x41, 0x13, 0x06, 0x22, 0xcd, 0xdd,
+	0x90, 0x2e, 0xdc, 0xb2, 0x18, 0x73, 0xbf, 0xc8, 0x4d, 0xde, 0x57, 0x1c, 0x44, 0x1d, 0xd9, 0x38,
+	0x9d, 0x71, 0x55, 0x91, 0xf2, 0xb3, 0x3e, 0x04, 0xb2, 0xf2, 0xfb, 0x3c, 0x36, 0x23, 0xdb, 0xb0,
+	0xe6, 0x21, 0xfa, 0xc7, 0x87, 0x9c, 0xbb, 0x43, 0x93, 0x48, 0x7f, 0x06, 0x3b, 0x87, 0xc8, 0x30,
+	0xc4, 0xe2, 0x40, 0xaa, 0x20, 0x4f, 0x60, 0xab, 0x08, 0xa9, 0xd3, 0xe8, 0xc1, 0xce, 0x1b, 0x0c,
+	0xf7, 0x19, 0x2b, 0x68, 0xe8, 0x5f, 0x9b, 0xb0, 0x55, 0xcc, 0x45, 0x64, 0x27, 0x20, 0xf9, 0xd7,
+	0x57, 0xf1, 0x3a, 0x24, 0xf3, 0x61, 0x3a, 0xd7, 0x52, 0x8c, 0x91, 0xc5, 0x01, 0xcd, 0xa3, 0x95,
+	0x1f, 0x02, 0x48, 0xb9, 0x24, 0x39, 0x80, 0xb6, 0x33, 0x1d, 0xbb, 0xbc, 0x4f, 0xc9, 0x34, 0x6e,
+	0xcc, 0x6a, 0x1c, 0x4f, 0xc7, 0x2e, 0xe5, 0x58, 0x22, 0xc3, 0x7a, 0x30, 0xfa, 0x88, 0x13, 0x2b,
+	0x48, 0x76, 0x92, 0x86, 0xca, 0x10, 0xda, 0x51, 0x1d, 0xb9, 0x03, 0x4d, 0xc7, 0x4e, 0x66, 0xd1,
+	0x74, 0x6c, 0xbe, 0x43, 0xdb, 0xf6, 0x83, 0xc4, 0x40, 0x71, 0xa0, 0xbf, 0x04, 0x79, 0xdf, 0xb6,
+	0x4f, 0xcd, 0xd3, 0xd7, 0x99, 0xd5, 0x6e, 0xec, 0x49, 0xfd, 0x11, 0x6c, 0x97, 0xa0, 0xa3, 0x01,
+	0x6e, 0x40, 0x0b, 0x7d, 0x3f, 0xb1, 0x52, 0x74, 0xd4, 0x5f, 0xc1, 0x2e, 0xc5, 0x89, 0x7b, 0x89,
+	0xff, 0x2b, 0xf6, 0x18, 0x7a, 0xe5, 0x04, 0xe5, 0x7a, 0x7d, 0xd8, 0x8d, 0x27, 0x5a, 0xaa, 0xa7,
+	0xff, 0x14, 0xa0, 0x57, 0x9e, 0x8f, 0xe8, 0xde, 0x16, 0xbb, 0x91, 0xcc, 0xe1, 0xf2, 0xa6, 0x4a,
+	0x70, 0x46, 0x76, 0xb1, 0xd4, 0xbb, 0xf2, 0x14, 0x20, 0x4b, 0xe5, 0x56, 0x23, 0xf2, 0xd5, 0x10,
+	0x68, 0x4f, 0xad, 0x09, 0xf2, 0xcd, 0x88, 0x94, 0x9f, 0xcd, 0x5f, 0x6d, 0x58, 0x3f, 0x43, 0xff,
+	0xd2, 0x19, 0x21, 0x39, 0x81, 0xdb, 0x4b, 0x8f, 0x8a, 0xdc, 0x4b, 0x3b, 0x29, 0xfb, 0x2f, 0x51,
+	0x94, 0x8a, 0xac, 0xc7, 0x66, 0x7a, 0x83, 0xbc, 0x83, 0x8d, 0xd5, 0x07, 0x44, 0xee, 0xa7, 0x88,
+	0x8a, 0xd7, 0xa8, 0xf4, 0xab, 0x0b, 0x62, 0xd6, 0xf7, 0xb0, 0xb9, 0xea, 0xdf, 0x20, 0xa3, 0xad,
+	0x78, 0x80, 0x4a, 0xbf, 0xba, 0x20, 0xa6, 0x3d, 0x87, 0xcd, 0x82, 0xc1, 0x88, 0x96, 0xa2, 0xaa,
+	0x9c, 0xab, 0xa8, 0x35, 0x15, 0x31, 0xf1, 0x07, 0xe8, 0x96, 0x99, 0x89, 0x3c, 0x48, 0x91, 0x35,
+	0x5e, 0x55, 0xf6, 0xea, 0x8b, 0xae, 0x15, 0xca, 0x7c, 0x92, 0x29, 0xd4, 0xb8, 0x53, 0xd9, 0xab,
+	0x2f, 0xe2, 0x0a, 0x07, 0xf2, 0xef, 0xb9, 0x2a, 0x5c, 0xcd, 0x55, 0xe1, 0xef, 0x5c, 0x15, 0xbe,
+	0x2f, 0xd4, 0xc6, 0xd5, 0x42, 0x6d, 0xfc, 0x59, 0xa8, 0x8d, 0x8b, 0x35, 0xfe, 0xd5, 0x79, 0xfe,
+	0x6f, 0x00, 0x0f, 0x76, 0x4a, 0x36, 0x82, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1047,6 +1124,20 @@ func (m *SetReplicatorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.FieldFilters) > 0 {
+		for iNdEx := len(m.FieldFilters) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.FieldFilters[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.Addr) > 0 {
 		i -= len(m.Addr)
 		copy(dAtA[i:], m.Addr)
@@ -1066,6 +1157,54 @@ func (m *SetReplicatorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FieldFilter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FieldFilter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FieldFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Deny) > 0 {
+		for iNdEx := len(m.Deny) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Deny[iNdEx])
+			copy(dAtA[i:], m.Deny[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Deny[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Allow) > 0 {
+		for iNdEx := len(m.Allow) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Allow[iNdEx])
+			copy(dAtA[i:], m.Allow[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Allow[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Collection) > 0 {
+		i -= len(m.Collection)
+		copy(dAtA[i:], m.Collection)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Collection)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SetReplicatorReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1545,6 +1684,37 @@ func (m *SetReplicatorRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if len(m.FieldFilters) > 0 {
+		for _, e := range m.FieldFilters {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FieldFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Collection)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.Allow) > 0 {
+		for _, s := range m.Allow {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if len(m.Deny) > 0 {
+		for _, s := range m.Deny {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1845,6 +2015,186 @@ func (m *SetReplicatorRequest) Unmarshal(dAtA []byte) error {
 				m.Addr = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FieldFilters", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FieldFilters = append(m.FieldFilters, &FieldFilter{})
+			if err := m.FieldFilters[len(m.FieldFilters)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FieldFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FieldFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FieldFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Collection", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Collection = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Allow = append(m.Allow, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deny", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deny = append(m.Deny, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -20,6 +20,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/logging"
 	"github.com/sourcenetwork/defradb/net"
 	pb "github.com/sourcenetwork/defradb/net/api/pb"
@@ -48,7 +49,15 @@ func (s *Service) SetReplicator(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	pid, err := s.peer.SetReplicator(ctx, addr, req.Collections...)
+	fieldFilters := map[string]client.FieldFilter{}
+	for _, filter := range req.FieldFilters {
+		fieldFilters[filter.Collection] = client.FieldFilter{
+			Allow: filter.Allow,
+			Deny:  filter.Deny,
+		}
+	}
+
+	pid, err := s.peer.SetReplicator(ctx, addr, fieldFilters, req.Collections...)
 	if err != nil {
 		return nil, err
 	}
@@ -17,9 +17,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/logging"
@@ -54,15 +57,101 @@ func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID) er
 		Log: &pb.Document_Log{
 			Block: evt.Block.RawData(),
 		},
+		Owner:        evt.Owner,
+		PrunedHeight: evt.PrunedHeight,
 	}
+	return s.sendPushLog(ctx, body, pid)
+}
+
+// pushPartialLog creates pushLog requests for the given update and sends them to a node
+// holding a partial replica of the document, that may only receive the fields permitted
+// by the given filter.
+//
+// The composite block of the update holds the values of all the fields it updates, so the
+// blocks of the permitted fields it links to are sent as field logs instead. The composite
+// blocks of deletions hold no field values and are sent as is.
+func (s *server) pushPartialLog(
+	ctx context.Context,
+	evt events.Update,
+	pid peer.ID,
+	filter client.FieldFilter,
+) error {
+	dockey, err := client.NewDocKeyFromString(evt.DocKey)
+	if err != nil {
+		return errors.Wrap("failed to get DocKey from broadcast message", err)
+	}
+
+	delta, err := corecrdt.CompositeDAG{}.DeltaDecode(evt.Block)
+	if err != nil {
+		return errors.Wrap("failed to decode delta object", err)
+	}
+	if compositeDelta, ok := delta.(*corecrdt.CompositeDAGDelta); ok && compositeDelta.Status.IsDeleted() {
+		return s.sendPushLog(ctx, &pb.PushLogRequest_Body{
+			DocKey:   &pb.ProtoDocKey{DocKey: dockey},
+			Cid:      &pb.ProtoCid{Cid: evt.Cid},
+			SchemaID: []byte(evt.SchemaID),
+			Creator:  s.peer.host.ID().String(),
+			Log: &pb.Document_Log{
+				Block: evt.Block.RawData(),
+			},
+			Owner:   evt.Owner,
+			Partial: true,
+		}, pid)
+	}
+
+	for _, link := range evt.Block.Links() {
+		if link.Name == core.HEAD || !filter.Permits(link.Name) {
+			continue
+		}
+		err := s.pushFieldLog(ctx, dockey, evt.SchemaID, link.Name, link.Cid, evt.Owner, pid)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushFieldLog creates a pushLog request for the field block of the given CID and sends it
+// to a node holding a partial replica of the document.
+func (s *server) pushFieldLog(
+	ctx context.Context,
+	dockey client.DocKey,
+	schemaID string,
+	fieldName string,
+	c cid.Cid,
+	owner string,
+	pid peer.ID,
+) error {
+	blk, err := s.db.Blockstore().Get(ctx, c)
+	if err != nil {
+		return errors.Wrap(fmt.Sprintf("failed to get block %s", c), err)
+	}
+
+	return s.sendPushLog(ctx, &pb.PushLogRequest_Body{
+		DocKey:   &pb.ProtoDocKey{DocKey: dockey},
+		Cid:      &pb.ProtoCid{Cid: c},
+		SchemaID: []byte(schemaID),
+		Creator:  s.peer.host.ID().String(),
+		Log: &pb.Document_Log{
+			Block: blk.RawData(),
+		},
+		Owner:     owner,
+		FieldName: fieldName,
+		Partial:   true,
+	}, pid)
+}
+
+// sendPushLog sends a pushLog request with the given body to another node
+// over libp2p grpc connection
+func (s *server) sendPushLog(ctx context.Context, body *pb.PushLogRequest_Body, pid peer.ID) error {
 	req := &pb.PushLogRequest{
 		Body: body,
 	}
 
 	log.Debug(
 		ctx, "Pushing log",
-		logging.NewKV("DocKey", dockey),
-		logging.NewKV("CID", evt.Cid),
+		logging.NewKV("DocKey", body.DocKey.DocKey),
+		logging.NewKV("CID", body.Cid.Cid),
 		logging.NewKV("PID", pid))
 
 	client, err := s.dial(pid) // grpc dial over p2p stream
@@ -74,7 +163,55 @@ func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID) er
 	defer cancel()
 
 	if _, err := client.PushLog(cctx, req); err != nil {
-		return errors.Wrap(fmt.Sprintf("Failed PushLog RPC request %s for %s to %s", evt.Cid, dockey, pid), err)
+		return errors.Wrap(
+			fmt.Sprintf("Failed PushLog RPC request %s for %s to %s", body.Cid.Cid, body.DocKey.DocKey, pid),
+			err,
+		)
 	}
 	return nil
 }
+
+// getDocGraph requests the current heads of every document in the given collection
+// from another node over libp2p grpc connection
+func (s *server) getDocGraph(ctx context.Context, pid peer.ID, schemaID string) ([]*pb.Document, error) {
+	client, err := s.dial(pid) // grpc dial over p2p stream
+	if err != nil {
+		return nil, errors.Wrap("failed to get doc graph", err)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+
+	reply, err := client.GetDocGraph(cctx, &pb.GetDocGraphRequest{SchemaID: []byte(schemaID)})
+	if err != nil {
+		return nil, errors.Wrap(fmt.Sprintf("Failed GetDocGraph RPC request for %s to %s", schemaID, pid), err)
+	}
+	return reply.Docs, nil
+}
+
+// getLog requests the blocks of the given CIDs from another node over libp2p grpc connection
+func (s *server) getLog(ctx context.Context, pid peer.ID, cids []cid.Cid) ([]*pb.Document_Log, error) {
+	client, err := s.dial(pid) // grpc dial over p2p stream
+	if err != nil {
+		return nil, errors.Wrap("failed to get log", err)
+	}
+
+	req := &pb.GetLogRequest{}
+	for _, c := range cids {
+		req.Cids = append(req.Cids, pb.ProtoCid{Cid: c})
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+
+	reply, err := client.GetLog(cctx, req)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Sprintf("Failed GetLog RPC request to %s", pid), err)
+	}
+	if len(reply.Logs) != len(cids) {
+		return nil, errors.New(
+			fmt.Sprintf("GetLog RPC request to %s returned %d logs, expected %d", pid, len(reply.Logs), len(cids)),
+		)
+	}
+	return reply.Logs, nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	errUnknownSchemaVersion string = "block was written at a schema version unknown to this node"
+)
+
+var (
+	// ErrUnknownSchemaVersion is returned when a block written at a schema version this node
+	// does not have is received or requested from a peer.
+	//
+	// Blocks written at older schema versions are merged as they are, and migrated through the
+	// registered schema migrations when the document is read. Blocks written at newer schema
+	// versions can only be exchanged once the schema of the node has been patched to that version.
+	ErrUnknownSchemaVersion = errors.New(errUnknownSchemaVersion)
+)
+
+// NewErrUnknownSchemaVersion returns a new error indicating that the given block was written
+// at a schema version this node does not have.
+func NewErrUnknownSchemaVersion(c cid.Cid, schemaVersionID string) error {
+	return errors.New(errUnknownSchemaVersion, errors.NewKV("CID", c), errors.NewKV("SchemaVersionID", schemaVersionID))
+}
@@ -33,6 +33,11 @@ type Document struct {
 	DocKey *ProtoDocKey `protobuf:"bytes,1,opt,name=docKey,proto3,customtype=ProtoDocKey" json:"docKey,omitempty"`
 	// head of the log.
 	Head *ProtoCid `protobuf:"bytes,4,opt,name=head,proto3,customtype=ProtoCid" json:"head,omitempty"`
+	// owner is the identity owning the document, empty if the document has no owner.
+	Owner string `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	// prunedHeight is the height up to which the history of the document has been pruned,
+	// zero if it has not been pruned.
+	PrunedHeight uint64 `protobuf:"varint,6,opt,name=prunedHeight,proto3" json:"prunedHeight,omitempty"`
 }
 
 func (m *Document) Reset()         { *m = Document{} }
@@ -68,6 +73,20 @@ func (m *Document) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Document proto.InternalMessageInfo
 
+func (m *Document) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Document) GetPrunedHeight() uint64 {
+	if m != nil {
+		return m.PrunedHeight
+	}
+	return 0
+}
+
 // Record is a thread record containing link data.
 type Document_Log struct {
 	// block is the top-level node's raw data as an ipld.Block.
@@ -115,6 +134,8 @@ func (m *Document_Log) GetBlock() []byte {
 }
 
 type GetDocGraphRequest struct {
+	// schemaID is the SchemaID of the collection to get the document graphs of.
+	SchemaID []byte `protobuf:"bytes,1,opt,name=schemaID,proto3" json:"schemaID,omitempty"`
 }
 
 func (m *GetDocGraphRequest) Reset()         { *m = GetDocGraphRequest{} }
@@ -150,7 +171,16 @@ func (m *GetDocGraphRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetDocGraphRequest proto.InternalMessageInfo
 
+func (m *GetDocGraphRequest) GetSchemaID() []byte {
+	if m != nil {
+		return m.SchemaID
+	}
+	return nil
+}
+
 type GetDocGraphReply struct {
+	// docs hold a document entry for each head of each document in the collection.
+	Docs []*Document `protobuf:"bytes,1,rep,name=docs,proto3" json:"docs,omitempty"`
 }
 
 func (m *GetDocGraphReply) Reset()         { *m = GetDocGraphReply{} }
@@ -186,7 +216,16 @@ func (m *GetDocGraphReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetDocGraphReply proto.InternalMessageInfo
 
+func (m *GetDocGraphReply) GetDocs() []*Document {
+	if m != nil {
+		return m.Docs
+	}
+	return nil
+}
+
 type PushDocGraphRequest struct {
+	// logs hold a log for each head of the document graph.
+	Logs []*PushLogRequest_Body `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
 }
 
 func (m *PushDocGraphRequest) Reset()         { *m = PushDocGraphRequest{} }
@@ -222,6 +261,13 @@ func (m *PushDocGraphRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PushDocGraphRequest proto.InternalMessageInfo
 
+func (m *PushDocGraphRequest) GetLogs() []*PushLogRequest_Body {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
 type PushDocGraphReply struct {
 }
 
@@ -259,6 +305,8 @@ func (m *PushDocGraphReply) XXX_DiscardUnknown() {
 var xxx_messageInfo_PushDocGraphReply proto.InternalMessageInfo
 
 type GetLogRequest struct {
+	// cids are the CIDs of the blocks to get.
+	Cids []ProtoCid `protobuf:"bytes,1,rep,name=cids,proto3,customtype=ProtoCid" json:"cids,omitempty"`
 }
 
 func (m *GetLogRequest) Reset()         { *m = GetLogRequest{} }
@@ -295,6 +343,8 @@ func (m *GetLogRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_GetLogRequest proto.InternalMessageInfo
 
 type GetLogReply struct {
+	// logs hold the blocks of the requested CIDs, in the requested order.
+	Logs []*Document_Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
 }
 
 func (m *GetLogReply) Reset()         { *m = GetLogReply{} }
@@ -330,6 +380,13 @@ func (m *GetLogReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetLogReply proto.InternalMessageInfo
 
+func (m *GetLogReply) GetLogs() []*Document_Log {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
 type PushLogRequest struct {
 	Body *PushLogRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
 }
@@ -375,16 +432,35 @@ func (m *PushLogRequest) GetBody() *PushLogRequest_Body {
 }
 
 type PushLogRequest_Body struct {
-	// docKey is the target DocKey.
+	// docKey is the DocKey of the document that is affected by the log.
 	DocKey *ProtoDocKey `protobuf:"bytes,1,opt,name=docKey,proto3,customtype=ProtoDocKey" json:"docKey,omitempty"`
-	// cid is the target CID.
+	// cid is the CID of the composite of the document.
 	Cid *ProtoCid `protobuf:"bytes,2,opt,name=cid,proto3,customtype=ProtoCid" json:"cid,omitempty"`
-	//
+	// schemaID is the SchemaID of the collection that the document resides in.
 	SchemaID []byte `protobuf:"bytes,3,opt,name=schemaID,proto3" json:"schemaID,omitempty"`
-	// from is the peer ID of the peer that created the log
+	// creator is the peer ID of the peer that created the log.
 	Creator string `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
-	// record is the actual record payload.
+	// log hold the block that represent version of the document.
 	Log *Document_Log `protobuf:"bytes,5,opt,name=log,proto3" json:"log,omitempty"`
+	// owner is the identity owning the document, empty if the document has no owner.
+	//
+	// Logs of documents owned by another identity than the one recorded locally are rejected.
+	Owner string `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`
+	// prunedHeight is the height up to which the history of the document has been pruned by
+	// the peer sending the log, zero if it has not been pruned.
+	//
+	// Logs of documents that have not been synced locally up to this height are rejected, as
+	// the history required to sync them is no longer available from the peer.
+	PrunedHeight uint64 `protobuf:"varint,7,opt,name=prunedHeight,proto3" json:"prunedHeight,omitempty"`
+	// fieldName is the name of the field the log belongs to, empty if the log is the log of
+	// the composite of the document.
+	FieldName string `protobuf:"bytes,8,opt,name=fieldName,proto3" json:"fieldName,omitempty"`
+	// partial is true if the document is replicated to the receiving peer without some of its
+	// fields.
+	//
+	// The blocks linked to by the composite logs of partial replicas are not fetched from the
+	// peer, and receiving them does not subscribe the receiving peer to the document.
+	Partial bool `protobuf:"varint,9,opt,name=partial,proto3" json:"partial,omitempty"`
 }
 
 func (m *PushLogRequest_Body) Reset()         { *m = PushLogRequest_Body{} }
@@ -441,7 +517,37 @@ func (m *PushLogRequest_Body) GetLog() *Document_Log {
 	return nil
 }
 
+func (m *PushLogRequest_Body) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *PushLogRequest_Body) GetPrunedHeight() uint64 {
+	if m != nil {
+		return m.PrunedHeight
+	}
+	return 0
+}
+
+func (m *PushLogRequest_Body) GetFieldName() string {
+	if m != nil {
+		return m.FieldName
+	}
+	return ""
+}
+
+func (m *PushLogRequest_Body) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
 type GetHeadLogRequest struct {
+	// docKey is the DocKey of the document to get the heads of.
+	DocKey *ProtoDocKey `protobuf:"bytes,1,opt,name=docKey,proto3,customtype=ProtoDocKey" json:"docKey,omitempty"`
 }
 
 func (m *GetHeadLogRequest) Reset()         { *m = GetHeadLogRequest{} }
@@ -514,6 +620,8 @@ func (m *PushLogReply) XXX_DiscardUnknown() {
 var xxx_messageInfo_PushLogReply proto.InternalMessageInfo
 
 type GetHeadLogReply struct {
+	// heads are the CIDs of the current composite heads of the document.
+	Heads []ProtoCid `protobuf:"bytes,1,rep,name=heads,proto3,customtype=ProtoCid" json:"heads,omitempty"`
 }
 
 func (m *GetHeadLogReply) Reset()         { *m = GetHeadLogReply{} }
@@ -568,37 +676,46 @@ func init() {
 func init() { proto.RegisterFile("net.proto", fileDescriptor_a5b10ce944527a32) }
 
 var fileDescriptor_a5b10ce944527a32 = []byte{
-	// 480 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0x6e, 0xda, 0x40,
-	0x10, 0x86, 0x71, 0x20, 0x40, 0x06, 0x12, 0x9a, 0x81, 0xb4, 0xce, 0x46, 0x72, 0x22, 0x0e, 0x6d,
-	0x2f, 0x35, 0x52, 0x2a, 0x55, 0xea, 0x95, 0x52, 0x91, 0xaa, 0x39, 0x44, 0xee, 0x13, 0xd8, 0xeb,
-	0xad, 0x8d, 0x0a, 0x59, 0x6a, 0xd6, 0x95, 0x78, 0x8b, 0xbe, 0x48, 0xdf, 0xa3, 0xc7, 0xf4, 0x56,
-	0xe5, 0x10, 0x55, 0xf0, 0x04, 0x7d, 0x83, 0x6a, 0x67, 0x63, 0xc0, 0xe0, 0x43, 0x6f, 0x9e, 0xf9,
-	0xff, 0x99, 0xd9, 0xf9, 0x46, 0x86, 0x83, 0x5b, 0xa1, 0xdc, 0x69, 0x22, 0x95, 0xc4, 0x2a, 0x7d,
-	0x06, 0xec, 0x55, 0x34, 0x52, 0x71, 0x1a, 0xb8, 0x5c, 0x4e, 0x7a, 0x91, 0x8c, 0x64, 0x8f, 0xe4,
-	0x20, 0xfd, 0x4c, 0x11, 0x05, 0xf4, 0x65, 0xca, 0xba, 0x09, 0xd4, 0x07, 0x92, 0xa7, 0x13, 0x71,
-	0xab, 0xf0, 0x05, 0x54, 0x43, 0xc9, 0x3f, 0x8a, 0xb9, 0x6d, 0x5d, 0x58, 0x2f, 0x9b, 0xfd, 0xd6,
-	0xfd, 0xc3, 0x79, 0xe3, 0x46, 0xdb, 0x06, 0x94, 0xf6, 0x1e, 0x65, 0xbc, 0x80, 0x4a, 0x2c, 0xfc,
-	0xd0, 0xae, 0x90, 0xad, 0x79, 0xff, 0x70, 0x5e, 0x27, 0xdb, 0xbb, 0x51, 0xe8, 0x91, 0xc2, 0xce,
-	0xa0, 0x7c, 0x2d, 0x23, 0xec, 0xc0, 0x7e, 0x30, 0x96, 0xfc, 0x8b, 0x69, 0xe8, 0x99, 0xa0, 0xdb,
-	0x01, 0x1c, 0x0a, 0x35, 0x90, 0x7c, 0x98, 0xf8, 0xd3, 0xd8, 0x13, 0x5f, 0x53, 0x31, 0x53, 0x5d,
-	0x84, 0x27, 0xb9, 0xec, 0x74, 0x3c, 0xef, 0x9e, 0x40, 0xfb, 0x26, 0x9d, 0xc5, 0xdb, 0xd6, 0x36,
-	0x1c, 0xe7, 0xd3, 0xda, 0xdb, 0x82, 0xc3, 0xa1, 0x50, 0xd7, 0x32, 0xca, 0x5c, 0x87, 0xd0, 0xc8,
-	0x12, 0x5a, 0xff, 0x6b, 0xc1, 0x91, 0xae, 0x5a, 0x3b, 0xb0, 0x07, 0x95, 0x40, 0x86, 0x66, 0xdd,
-	0xc6, 0xe5, 0x99, 0x6b, 0x10, 0xba, 0x79, 0x97, 0xdb, 0x97, 0xe1, 0xdc, 0x23, 0x23, 0xfb, 0x61,
-	0x41, 0x45, 0x87, 0xff, 0x8f, 0xca, 0x81, 0x32, 0x1f, 0x85, 0xf6, 0x5e, 0x01, 0x29, 0x2d, 0x20,
-	0x83, 0xfa, 0x8c, 0xc7, 0x62, 0xe2, 0x7f, 0x18, 0xd8, 0x65, 0x82, 0xb4, 0x8a, 0xd1, 0x86, 0x1a,
-	0x4f, 0x84, 0xaf, 0x64, 0x42, 0xa4, 0x0f, 0xbc, 0x2c, 0xc4, 0xe7, 0x50, 0x1e, 0xcb, 0xc8, 0xde,
-	0xa7, 0x77, 0x77, 0xb2, 0x77, 0x67, 0x87, 0x74, 0xf5, 0xe3, 0xb5, 0x41, 0x83, 0x1a, 0x0a, 0x75,
-	0x25, 0xfc, 0x70, 0x83, 0xcb, 0x11, 0x34, 0x57, 0x1b, 0x6a, 0x30, 0xc7, 0xd0, 0xda, 0x34, 0x4d,
-	0xc7, 0xf3, 0xcb, 0x5f, 0x7b, 0x50, 0xfb, 0x24, 0x92, 0x6f, 0x23, 0x2e, 0xf0, 0x3d, 0x61, 0xcc,
-	0x58, 0x23, 0xcb, 0xa6, 0xed, 0x9e, 0x90, 0xd9, 0x85, 0x9a, 0x9e, 0x51, 0xc2, 0x2b, 0x33, 0x75,
-	0xd5, 0x27, 0x47, 0x7b, 0xbb, 0xd1, 0x69, 0xb1, 0x68, 0x3a, 0xbd, 0x81, 0xaa, 0xb9, 0x2b, 0x9e,
-	0x6c, 0xcc, 0x5b, 0x2f, 0xc8, 0xda, 0xdb, 0x69, 0x53, 0xf7, 0x16, 0x6a, 0x8f, 0x7b, 0xe3, 0xd3,
-	0xe2, 0x53, 0xb3, 0xce, 0x4e, 0xde, 0x94, 0xf6, 0x01, 0xd6, 0x88, 0xf0, 0x74, 0xa3, 0x7f, 0x9e,
-	0x2d, 0x7b, 0x56, 0x24, 0x51, 0x8f, 0xbe, 0xfd, 0x73, 0xe1, 0x58, 0x77, 0x0b, 0xc7, 0xfa, 0xb3,
-	0x70, 0xac, 0xef, 0x4b, 0xa7, 0x74, 0xb7, 0x74, 0x4a, 0xbf, 0x97, 0x4e, 0x29, 0xa8, 0xd2, 0xaf,
-	0xf8, 0xfa, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4b, 0x46, 0x27, 0x1c, 0xce, 0x03, 0x00, 0x00,
+	// 618 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5b, 0x6e, 0xd3, 0x50,
+	0x10, 0x8d, 0x63, 0xd7, 0x49, 0xa6, 0xa1, 0x8f, 0xdb, 0x02, 0xae, 0x8b, 0x5c, 0xcb, 0x42, 0xe0,
+	0x1f, 0x5c, 0x28, 0xe2, 0x25, 0xf1, 0x15, 0x02, 0x09, 0xa2, 0x42, 0x95, 0x59, 0x81, 0x1f, 0xb7,
+	0xb6, 0x85, 0x93, 0x6b, 0x1c, 0x1b, 0x94, 0x5d, 0xb0, 0x03, 0x36, 0xc0, 0x42, 0xf8, 0x2c, 0x7f,
+	0xa8, 0x1f, 0x15, 0x4a, 0x36, 0x82, 0xee, 0xbd, 0x71, 0x12, 0x27, 0x06, 0xfa, 0xe7, 0x99, 0x33,
+	0x73, 0x3c, 0x73, 0xe6, 0xd8, 0xd0, 0x1a, 0xe2, 0xcc, 0x4a, 0x52, 0x92, 0x11, 0x24, 0xb3, 0x47,
+	0x57, 0x7d, 0x10, 0x44, 0x59, 0x98, 0xbb, 0x96, 0x47, 0x06, 0xc7, 0x01, 0x09, 0xc8, 0x31, 0x83,
+	0xdd, 0xfc, 0x9c, 0x45, 0x2c, 0x60, 0x4f, 0xbc, 0xcd, 0xf8, 0x2e, 0x40, 0xb3, 0x4b, 0xbc, 0x7c,
+	0x80, 0x87, 0x19, 0xba, 0x0f, 0xb2, 0x4f, 0xbc, 0x77, 0x78, 0xac, 0x08, 0xba, 0x60, 0xb6, 0x3b,
+	0xdb, 0x97, 0x57, 0x47, 0x9b, 0x67, 0xb4, 0xae, 0xcb, 0xd2, 0xf6, 0x0c, 0x46, 0x3a, 0x48, 0x21,
+	0x76, 0x7c, 0x45, 0x62, 0x65, 0xed, 0xcb, 0xab, 0xa3, 0x26, 0x2b, 0x7b, 0x15, 0xf9, 0x36, 0x43,
+	0xd0, 0x3e, 0x6c, 0x90, 0x2f, 0x43, 0x9c, 0x2a, 0x1b, 0xba, 0x60, 0xb6, 0x6c, 0x1e, 0x20, 0x03,
+	0xda, 0x49, 0x9a, 0x0f, 0xb1, 0xdf, 0xc7, 0x51, 0x10, 0x66, 0x8a, 0xac, 0x0b, 0xa6, 0x64, 0x97,
+	0x72, 0xea, 0x21, 0x88, 0xa7, 0x24, 0xa0, 0x04, 0x6e, 0x4c, 0xbc, 0x8f, 0x7c, 0x14, 0x9b, 0x07,
+	0xc6, 0x43, 0x40, 0x3d, 0x9c, 0x75, 0x89, 0xd7, 0x4b, 0x9d, 0x24, 0xb4, 0xf1, 0xa7, 0x1c, 0x8f,
+	0x32, 0xa4, 0x42, 0x73, 0xe4, 0x85, 0x78, 0xe0, 0xbc, 0xed, 0xce, 0xca, 0xe7, 0xb1, 0xf1, 0x1c,
+	0x76, 0x4a, 0x1d, 0x49, 0x3c, 0x46, 0x77, 0x41, 0xf2, 0x89, 0x37, 0x52, 0x04, 0x5d, 0x34, 0x37,
+	0x4f, 0x76, 0x2c, 0x2e, 0x9d, 0x55, 0xe8, 0x60, 0x33, 0xd4, 0x78, 0x03, 0x7b, 0x67, 0xf9, 0x28,
+	0x5c, 0x7d, 0xd9, 0x31, 0x48, 0x31, 0x09, 0x8a, 0xe6, 0xc3, 0xa2, 0x99, 0x96, 0x9e, 0x92, 0x60,
+	0x56, 0x65, 0x75, 0x88, 0x3f, 0xb6, 0x59, 0xa1, 0xb1, 0x07, 0xbb, 0x65, 0x9e, 0x24, 0x1e, 0x1b,
+	0x8f, 0xe0, 0x46, 0x0f, 0x67, 0x8b, 0x06, 0x2a, 0xa9, 0x17, 0xf9, 0x9c, 0x76, 0x4d, 0x52, 0x8a,
+	0x18, 0xcf, 0x60, 0xb3, 0x68, 0xa1, 0x4b, 0x98, 0xa5, 0x39, 0xf6, 0x57, 0x97, 0xb0, 0x68, 0x21,
+	0x1f, 0x60, 0x5a, 0x87, 0xad, 0xf2, 0x78, 0x74, 0x09, 0x97, 0xf8, 0xfc, 0xce, 0xff, 0x5b, 0x82,
+	0x16, 0xaa, 0xdf, 0xea, 0x20, 0xd1, 0xf0, 0xfa, 0x1e, 0xd1, 0x40, 0xf4, 0x22, 0x5f, 0xa9, 0x57,
+	0x58, 0x84, 0x02, 0xa5, 0xa3, 0x89, 0xe5, 0xa3, 0x21, 0x05, 0x1a, 0x5e, 0x8a, 0x9d, 0x8c, 0xa4,
+	0xcc, 0x62, 0x2d, 0xbb, 0x08, 0xd1, 0x3d, 0x10, 0x63, 0x12, 0x30, 0x57, 0xfd, 0x6d, 0x69, 0x31,
+	0xe6, 0xf6, 0xe1, 0xfe, 0x93, 0xff, 0xe5, 0xbf, 0xc6, 0xba, 0xff, 0xd0, 0x1d, 0x68, 0x9d, 0x47,
+	0x38, 0xf6, 0xdf, 0x3b, 0x03, 0xac, 0x34, 0x59, 0xf7, 0x22, 0x41, 0x27, 0x4b, 0x9c, 0x34, 0x8b,
+	0x9c, 0x58, 0x69, 0xe9, 0x82, 0xd9, 0xb4, 0x8b, 0xd0, 0x78, 0x09, 0xbb, 0x3d, 0x9c, 0xf5, 0xb1,
+	0xe3, 0x2f, 0xe9, 0x7c, 0x5d, 0xb5, 0x8c, 0x2d, 0x68, 0xcf, 0xc5, 0xa7, 0xfe, 0x78, 0x02, 0xdb,
+	0xcb, 0x6c, 0xf4, 0xe0, 0x06, 0x6c, 0xd0, 0x4f, 0xab, 0xda, 0x22, 0x1c, 0x3a, 0xf9, 0x59, 0x87,
+	0xc6, 0x07, 0x9c, 0x7e, 0x8e, 0x3c, 0x8c, 0x5e, 0x33, 0xbf, 0x14, 0xb6, 0x43, 0x6a, 0x21, 0xd6,
+	0xfa, 0x07, 0xa4, 0x2a, 0x95, 0x18, 0x9d, 0xa3, 0x86, 0xfa, 0x7c, 0xb2, 0x39, 0x4f, 0xc9, 0x2c,
+	0xab, 0x44, 0x07, 0xd5, 0x20, 0x67, 0x7a, 0x0a, 0x32, 0x37, 0x30, 0xba, 0xb9, 0xf4, 0xbe, 0x85,
+	0x5a, 0xea, 0xde, 0x6a, 0x9a, 0xf7, 0xbd, 0x80, 0xc6, 0x4c, 0x1b, 0x74, 0xab, 0xda, 0xa9, 0xea,
+	0xfe, 0x5a, 0x9e, 0xb7, 0x76, 0x00, 0x16, 0x32, 0xa2, 0x83, 0x25, 0xfe, 0xf2, 0xa1, 0xd4, 0xdb,
+	0x55, 0x10, 0xe3, 0xe8, 0x28, 0x3f, 0x26, 0x9a, 0x70, 0x31, 0xd1, 0x84, 0xdf, 0x13, 0x4d, 0xf8,
+	0x3a, 0xd5, 0x6a, 0x17, 0x53, 0xad, 0xf6, 0x6b, 0xaa, 0xd5, 0x5c, 0x99, 0xfd, 0x43, 0x1f, 0xff,
+	0x19, 0x00, 0xe7, 0xf6, 0x77, 0xef, 0x87, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -855,6 +972,18 @@ func (m *Document) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.PrunedHeight != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.PrunedHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Head != nil {
 		{
 			size := m.Head.Size()
@@ -932,6 +1061,13 @@ func (m *GetDocGraphRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.SchemaID) > 0 {
+		i -= len(m.SchemaID)
+		copy(dAtA[i:], m.SchemaID)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.SchemaID)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -955,6 +1091,20 @@ func (m *GetDocGraphReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Docs) > 0 {
+		for iNdEx := len(m.Docs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Docs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -978,6 +1128,20 @@ func (m *PushDocGraphRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -1024,6 +1188,20 @@ func (m *GetLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Cids) > 0 {
+		for iNdEx := len(m.Cids) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Cids[iNdEx].Size()
+				i -= size
+				if _, err := m.Cids[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -1047,6 +1225,20 @@ func (m *GetLogReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -1105,6 +1297,35 @@ func (m *PushLogRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Partial {
+		i--
+		if m.Partial {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.FieldName) > 0 {
+		i -= len(m.FieldName)
+		copy(dAtA[i:], m.FieldName)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.FieldName)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.PrunedHeight != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.PrunedHeight))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Log != nil {
 		{
 			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
@@ -1178,6 +1399,18 @@ func (m *GetHeadLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.DocKey != nil {
+		{
+			size := m.DocKey.Size()
+			i -= size
+			if _, err := m.DocKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -1224,6 +1457,20 @@ func (m *GetHeadLogReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Heads) > 0 {
+		for iNdEx := len(m.Heads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Heads[iNdEx].Size()
+				i -= size
+				if _, err := m.Heads[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -1252,6 +1499,13 @@ func (m *Document) Size() (n int) {
 		l = m.Head.Size()
 		n += 1 + l + sovNet(uint64(l))
 	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.PrunedHeight != 0 {
+		n += 1 + sovNet(uint64(m.PrunedHeight))
+	}
 	return n
 }
 
@@ -1274,6 +1528,10 @@ func (m *GetDocGraphRequest) Size() (n int) {
 	}
 	var l int
 	_ = l
+	l = len(m.SchemaID)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
 	return n
 }
 
@@ -1283,6 +1541,12 @@ func (m *GetDocGraphReply) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if len(m.Docs) > 0 {
+		for _, e := range m.Docs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1292,6 +1556,12 @@ func (m *PushDocGraphRequest) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1310,6 +1580,12 @@ func (m *GetLogRequest) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if len(m.Cids) > 0 {
+		for _, e := range m.Cids {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1319,6 +1595,12 @@ func (m *GetLogReply) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1361,6 +1643,20 @@ func (m *PushLogRequest_Body) Size() (n int) {
 		l = m.Log.Size()
 		n += 1 + l + sovNet(uint64(l))
 	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.PrunedHeight != 0 {
+		n += 1 + sovNet(uint64(m.PrunedHeight))
+	}
+	l = len(m.FieldName)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Partial {
+		n += 2
+	}
 	return n
 }
 
@@ -1370,6 +1666,10 @@ func (m *GetHeadLogRequest) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if m.DocKey != nil {
+		l = m.DocKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
 	return n
 }
 
@@ -1388,6 +1688,12 @@ func (m *GetHeadLogReply) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if len(m.Heads) > 0 {
+		for _, e := range m.Heads {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1496,6 +1802,57 @@ func (m *Document) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrunedHeight", wireType)
+			}
+			m.PrunedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrunedHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -1630,6 +1987,40 @@ func (m *GetDocGraphRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: GetDocGraphRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SchemaID = append(m.SchemaID[:0], dAtA[iNdEx:postIndex]...)
+			if m.SchemaID == nil {
+				m.SchemaID = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -1680,6 +2071,40 @@ func (m *GetDocGraphReply) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: GetDocGraphReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Docs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Docs = append(m.Docs, &Document{})
+			if err := m.Docs[len(m.Docs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -1730,6 +2155,40 @@ func (m *PushDocGraphRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: PushDocGraphRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &PushLogRequest_Body{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -1830,6 +2289,41 @@ func (m *GetLogRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: GetLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cids", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Cids = append(m.Cids, v)
+			if err := m.Cids[len(m.Cids)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -1880,6 +2374,40 @@ func (m *GetLogReply) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: GetLogReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &Document_Log{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -2188,6 +2716,109 @@ func (m *PushLogRequest_Body) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrunedHeight", wireType)
+			}
+			m.PrunedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrunedHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FieldName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FieldName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partial", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Partial = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -2238,6 +2869,41 @@ func (m *GetHeadLogRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: GetHeadLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoDocKey
+			m.DocKey = &v
+			if err := m.DocKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -2338,6 +3004,41 @@ func (m *GetHeadLogReply) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: GetHeadLogReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Heads = append(m.Heads, v)
+			if err := m.Heads[len(m.Heads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -28,7 +28,9 @@ import (
 	dag "github.com/ipfs/go-merkledag"
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
+	libnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	peerstore "github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/routing"
@@ -65,6 +67,12 @@ type Peer struct {
 	server *server
 	p2pRPC *grpc.Server // rpc server over the p2p network
 
+	// signaturePolicy defines which blocks received from other peers may be merged.
+	signaturePolicy clock.SignaturePolicy
+
+	// connectionSub receives an event when the status of a peer connection changes.
+	connectionSub event.Subscription
+
 	// Used to close the dagWorker pool for a given document.
 	// The string represents a dockey.
 	closeJob chan string
@@ -73,8 +81,8 @@ type Peer struct {
 	// outstanding log request currently being processed
 	queuedChildren *cidSafeSet
 
-	// replicators is a map from collectionName => peerId
-	replicators map[string]map[peer.ID]struct{}
+	// replicators is a map from collectionName => peerId => the field filter of the replicator
+	replicators map[string]map[peer.ID]client.FieldFilter
 	mu          sync.Mutex
 
 	// peer DAG service
@@ -94,6 +102,7 @@ func NewPeer(
 	dht routing.Routing,
 	ps *pubsub.PubSub,
 	tcpAddr ma.Multiaddr,
+	signaturePolicy clock.SignaturePolicy,
 	serverOptions []grpc.ServerOption,
 	dialOptions []grpc.DialOption,
 ) (*Peer, error) {
@@ -103,17 +112,18 @@ func NewPeer(
 
 	ctx, cancel := context.WithCancel(ctx)
 	p := &Peer{
-		host:           h,
-		dht:            dht,
-		ps:             ps,
-		db:             db,
-		p2pRPC:         grpc.NewServer(serverOptions...),
-		ctx:            ctx,
-		cancel:         cancel,
-		closeJob:       make(chan string),
-		sendJobs:       make(chan *dagJob),
-		replicators:    make(map[string]map[peer.ID]struct{}),
-		queuedChildren: newCidSafeSet(),
+		host:            h,
+		dht:             dht,
+		ps:              ps,
+		db:              db,
+		p2pRPC:          grpc.NewServer(serverOptions...),
+		ctx:             ctx,
+		cancel:          cancel,
+		closeJob:        make(chan string),
+		sendJobs:        make(chan *dagJob),
+		replicators:     make(map[string]map[peer.ID]client.FieldFilter),
+		queuedChildren:  newCidSafeSet(),
+		signaturePolicy: signaturePolicy,
 	}
 	var err error
 	p.server, err = newServer(p, db, dialOptions...)
@@ -172,6 +182,13 @@ func (p *Peer) Start() error {
 	// start sendJobWorker
 	go p.sendJobWorker()
 
+	// catch up on the changes made by our peers whilst we were disconnected from them
+	p.connectionSub, err = p.host.EventBus().Subscribe(new(event.EvtPeerConnectednessChanged))
+	if err != nil {
+		return err
+	}
+	go p.handlePeerConnectionLoop()
+
 	return nil
 }
 
@@ -202,6 +219,17 @@ func (p *Peer) Close() error {
 			log.Info(p.ctx, "Could not close push log event emitter", logging.NewKV("Error", err.Error()))
 		}
 	}
+	if p.server.peerSyncEmitter != nil {
+		if err := p.server.peerSyncEmitter.Close(); err != nil {
+			log.Info(p.ctx, "Could not close peer sync event emitter", logging.NewKV("Error", err.Error()))
+		}
+	}
+
+	if p.connectionSub != nil {
+		if err := p.connectionSub.Close(); err != nil {
+			log.Info(p.ctx, "Could not close peer connection subscription", logging.NewKV("Error", err.Error()))
+		}
+	}
 
 	if p.db.Events().Updates.HasValue() {
 		p.db.Events().Updates.Value().Unsubscribe(p.updateChannel)
@@ -243,6 +271,145 @@ func (p *Peer) handleBroadcastLoop() {
 	}
 }
 
+// handlePeerConnectionLoop catches up with each peer we (re)connect to.
+func (p *Peer) handlePeerConnectionLoop() {
+	for e := range p.connectionSub.Out() {
+		evt, ok := e.(event.EvtPeerConnectednessChanged)
+		if !ok || evt.Connectedness != libnetwork.Connected {
+			continue
+		}
+		go p.syncWithPeer(p.ctx, evt.Peer)
+	}
+}
+
+// syncWithPeer fetches the changes that the given peer has and we don't, for example because
+// they were broadcast whilst we were disconnected.
+//
+// Only the documents we already have, and those in the P2P collections we are subscribed to,
+// are synced. An EvtPeerSynced event is emitted once done.
+func (p *Peer) syncWithPeer(ctx context.Context, pid peer.ID) {
+	log.Debug(ctx, "Syncing with peer", logging.NewKV("PID", pid))
+
+	cols, err := p.db.GetAllCollections(ctx)
+	if err != nil {
+		log.ErrorE(ctx, "Failed to get collections for peer sync", err, logging.NewKV("PID", pid))
+	}
+
+	p2pCollections, err := p.db.GetAllP2PCollections(ctx)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		log.ErrorE(ctx, "Failed to get P2P collections for peer sync", err, logging.NewKV("PID", pid))
+	}
+	subscribed := make(map[string]struct{}, len(p2pCollections))
+	for _, schemaID := range p2pCollections {
+		subscribed[schemaID] = struct{}{}
+	}
+
+	for _, col := range cols {
+		_, allDocs := subscribed[col.SchemaID()]
+		err := p.syncCollectionWithPeer(ctx, pid, col, allDocs)
+		if err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to sync collection with peer",
+				err,
+				logging.NewKV("PID", pid),
+				logging.NewKV("Collection", col.Name()),
+			)
+		}
+	}
+
+	if p.server.peerSyncEmitter != nil {
+		err := p.server.peerSyncEmitter.Emit(EvtPeerSynced{Peer: pid})
+		if err != nil {
+			log.Info(ctx, "could not emit peer sync event", logging.NewKV("Error", err.Error()))
+		}
+	}
+}
+
+// syncCollectionWithPeer fetches the heads of the given collection's documents that the given
+// peer has and we don't, and merges them (and their missing children) into our document graphs.
+//
+// If allDocs is false, only the documents we already have are synced.
+func (p *Peer) syncCollectionWithPeer(
+	ctx context.Context,
+	pid peer.ID,
+	col client.Collection,
+	allDocs bool,
+) error {
+	docs, err := p.server.getDocGraph(ctx, pid, col.SchemaID())
+	if err != nil {
+		return err
+	}
+
+	localKeys := make(map[client.DocKey]struct{})
+	if !allDocs {
+		keysCh, err := col.GetAllDocKeys(ctx)
+		if err != nil {
+			return err
+		}
+		for key := range keysCh {
+			if key.Err != nil {
+				return key.Err
+			}
+			localKeys[key.Key] = struct{}{}
+		}
+	}
+
+	dockeys := []client.DocKey{}
+	cids := []cid.Cid{}
+	owners := []string{}
+	prunedHeights := []uint64{}
+	for _, doc := range docs {
+		if doc.DocKey == nil || doc.Head == nil {
+			continue
+		}
+		if _, ok := localKeys[doc.DocKey.DocKey]; !allDocs && !ok {
+			continue
+		}
+		exists, err := p.db.Blockstore().Has(ctx, doc.Head.Cid)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		dockeys = append(dockeys, doc.DocKey.DocKey)
+		cids = append(cids, doc.Head.Cid)
+		owners = append(owners, doc.Owner)
+		prunedHeights = append(prunedHeights, doc.PrunedHeight)
+	}
+	if len(cids) == 0 {
+		return nil
+	}
+
+	logs, err := p.server.getLog(ctx, pid, cids)
+	if err != nil {
+		return err
+	}
+
+	for i, lg := range logs {
+		body := &pb.PushLogRequest_Body{
+			DocKey:       &pb.ProtoDocKey{DocKey: dockeys[i]},
+			Cid:          &pb.ProtoCid{Cid: cids[i]},
+			SchemaID:     []byte(col.SchemaID()),
+			Log:          lg,
+			Owner:        owners[i],
+			PrunedHeight: prunedHeights[i],
+		}
+		if _, err := p.server.handlePushLog(ctx, body); err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to merge log from peer sync",
+				err,
+				logging.NewKV("DocKey", dockeys[i]),
+				logging.NewKV("CID", cids[i]),
+				logging.NewKV("PID", pid),
+			)
+		}
+	}
+	return nil
+}
+
 // RegisterNewDocument registers a new document with the peer node.
 func (p *Peer) RegisterNewDocument(
 	ctx context.Context,
@@ -250,6 +417,7 @@ func (p *Peer) RegisterNewDocument(
 	c cid.Cid,
 	nd ipld.Node,
 	schemaID string,
+	owner string,
 ) error {
 	log.Debug(
 		p.ctx,
@@ -277,6 +445,7 @@ func (p *Peer) RegisterNewDocument(
 		Log: &pb.Document_Log{
 			Block: nd.RawData(),
 		},
+		Owner: owner,
 	}
 	req := &pb.PushLogRequest{
 		Body: body,
@@ -286,9 +455,13 @@ func (p *Peer) RegisterNewDocument(
 }
 
 // SetReplicator adds a target peer node as a replication destination for documents in our DB.
+//
+// The given field filters, keyed by collection name, restrict the fields of the collections
+// that are replicated to the peer.
 func (p *Peer) SetReplicator(
 	ctx context.Context,
 	paddr ma.Multiaddr,
+	fieldFilters map[string]client.FieldFilter,
 	collectionNames ...string,
 ) (peer.ID, error) {
 	txn, err := p.db.NewTxn(ctx, true)
@@ -297,7 +470,7 @@ func (p *Peer) SetReplicator(
 	}
 	store := p.db.WithTxn(txn)
 
-	pid, err := p.setReplicator(ctx, store, paddr, collectionNames...)
+	pid, err := p.setReplicator(ctx, store, paddr, fieldFilters, collectionNames...)
 	if err != nil {
 		txn.Discard(ctx)
 		return "", err
@@ -311,6 +484,7 @@ func (p *Peer) setReplicator(
 	ctx context.Context,
 	store client.Store,
 	paddr ma.Multiaddr,
+	fieldFilters map[string]client.FieldFilter,
 	collectionNames ...string,
 ) (peer.ID, error) {
 	var pid peer.ID
@@ -338,6 +512,11 @@ func (p *Peer) setReplicator(
 		}
 	}
 
+	schemaFilters, err := getSchemaFieldFilters(collections, fieldFilters)
+	if err != nil {
+		return pid, err
+	}
+
 	// extra peerID
 	// Extract peer portion
 	p2p, err := paddr.ValueForProtocol(ma.P_P2P)
@@ -354,6 +533,18 @@ func (p *Peer) setReplicator(
 		return pid, errors.New("can't target ourselves as a replicator")
 	}
 
+	// Subscribers of the collection topic receive the composite logs holding the values of all
+	// the fields of the documents, so they may not be partial replicas.
+	for _, col := range collections {
+		if _, isFiltered := schemaFilters[col.SchemaID()]; isFiltered && p.isTopicPeer(col.SchemaID(), pid) {
+			return pid, errors.New(fmt.Sprintf(
+				"can't filter the fields of %s replicated to %s as it subscribes to the collection",
+				col.Name(),
+				pid,
+			))
+		}
+	}
+
 	// add peer to peerstore
 	// Extract the peer ID from the multiaddr.
 	info, err := peer.AddrInfoFromP2pAddr(paddr)
@@ -378,17 +569,18 @@ func (p *Peer) setReplicator(
 				))
 			}
 		} else {
-			p.replicators[col.SchemaID()] = make(map[peer.ID]struct{})
+			p.replicators[col.SchemaID()] = make(map[peer.ID]client.FieldFilter)
 		}
 		// add to replicators list for the collection
-		p.replicators[col.SchemaID()][pid] = struct{}{}
+		p.replicators[col.SchemaID()][pid] = schemaFilters[col.SchemaID()]
 	}
 	p.mu.Unlock()
 
 	// Persist peer in datastore
 	err = p.db.SetReplicator(ctx, client.Replicator{
-		Info:    *info,
-		Schemas: schemas,
+		Info:         *info,
+		Schemas:      schemas,
+		FieldFilters: schemaFilters,
 	})
 	if err != nil {
 		return pid, errors.Wrap("failed to persist replicator", err)
@@ -416,17 +608,56 @@ func (p *Peer) setReplicator(
 			)
 		}
 
-		p.pushToReplicator(ctx, txn, col, keysCh, pid)
+		p.pushToReplicator(ctx, txn, col, keysCh, pid, schemaFilters[col.SchemaID()])
 	}
 	return pid, nil
 }
 
+// getSchemaFieldFilters returns the given field filters, keyed by collection name, keyed by the
+// schema ID of their collection instead.
+//
+// Returns an error if a filter is given for a collection that is not replicated, or if a
+// filter references a field that does not exist.
+func getSchemaFieldFilters(
+	collections []client.Collection,
+	fieldFilters map[string]client.FieldFilter,
+) (map[string]client.FieldFilter, error) {
+	if len(fieldFilters) == 0 {
+		return nil, nil
+	}
+
+	schemaFilters := map[string]client.FieldFilter{}
+	for name, filter := range fieldFilters {
+		var col client.Collection
+		for _, c := range collections {
+			if c.Name() == name {
+				col = c
+				break
+			}
+		}
+		if col == nil {
+			return nil, errors.New(fmt.Sprintf("field filter given for collection %s that is not replicated", name))
+		}
+
+		for _, fieldName := range append(append([]string{}, filter.Allow...), filter.Deny...) {
+			if _, ok := col.Description().GetField(fieldName); !ok {
+				return nil, errors.New(fmt.Sprintf("field filter references unknown field %s of %s", fieldName, name))
+			}
+		}
+		if !filter.IsEmpty() {
+			schemaFilters[col.SchemaID()] = filter
+		}
+	}
+	return schemaFilters, nil
+}
+
 func (p *Peer) pushToReplicator(
 	ctx context.Context,
 	txn datastore.Txn,
 	collection client.Collection,
 	keysCh <-chan client.DocKeysResult,
 	pid peer.ID,
+	filter client.FieldFilter,
 ) {
 	for key := range keysCh {
 		if key.Err != nil {
@@ -449,6 +680,32 @@ func (p *Peer) pushToReplicator(
 				logging.NewKV("Collection", collection.Name()))
 			continue
 		}
+		owner, err := getDocOwner(ctx, txn, collection, dockey)
+		if err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to get document owner",
+				err,
+				logging.NewKV("DocKey", key.Key.String()),
+				logging.NewKV("PID", pid),
+				logging.NewKV("Collection", collection.Name()))
+			continue
+		}
+		if !filter.IsEmpty() {
+			p.pushFieldsToReplicator(ctx, txn, collection, key.Key, owner, pid, filter)
+			continue
+		}
+		prunedHeight, err := getDocPrunedHeight(ctx, txn, collection, dockey)
+		if err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to get document pruned height",
+				err,
+				logging.NewKV("DocKey", key.Key.String()),
+				logging.NewKV("PID", pid),
+				logging.NewKV("Collection", collection.Name()))
+			continue
+		}
 		// loop over heads, get block, make the required logs, and send
 		for _, c := range cids {
 			blk, err := txn.DAGstore().Get(ctx, c)
@@ -468,11 +725,13 @@ func (p *Peer) pushToReplicator(
 			}
 
 			evt := events.Update{
-				DocKey:   key.Key.String(),
-				Cid:      c,
-				SchemaID: collection.SchemaID(),
-				Block:    nd,
-				Priority: priority,
+				DocKey:       key.Key.String(),
+				Cid:          c,
+				SchemaID:     collection.SchemaID(),
+				Block:        nd,
+				Priority:     priority,
+				Owner:        owner,
+				PrunedHeight: prunedHeight,
 			}
 			if err := p.server.pushLog(ctx, evt, pid); err != nil {
 				log.ErrorE(
@@ -487,6 +746,77 @@ func (p *Peer) pushToReplicator(
 	}
 }
 
+// pushFieldsToReplicator pushes the heads of the fields of the given document that are
+// permitted by the given filter to a replicator holding a partial replica of the document.
+func (p *Peer) pushFieldsToReplicator(
+	ctx context.Context,
+	txn datastore.Txn,
+	collection client.Collection,
+	key client.DocKey,
+	owner string,
+	pid peer.ID,
+	filter client.FieldFilter,
+) {
+	for _, field := range collection.Description().Schema.Fields {
+		if !filter.Permits(field.Name) {
+			continue
+		}
+		headset := clock.NewHeadSet(
+			txn.Headstore(),
+			core.DataStoreKeyFromDocKey(key).WithFieldId(field.ID.String()).ToHeadStoreKey(),
+		)
+		cids, _, err := headset.List(ctx)
+		if err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to get heads",
+				err,
+				logging.NewKV("DocKey", key.String()),
+				logging.NewKV("Field", field.Name),
+				logging.NewKV("PID", pid),
+				logging.NewKV("Collection", collection.Name()))
+			continue
+		}
+		for _, c := range cids {
+			err := p.server.pushFieldLog(ctx, key, collection.SchemaID(), field.Name, c, owner, pid)
+			if err != nil {
+				log.ErrorE(
+					ctx,
+					"Failed to replicate log",
+					err,
+					logging.NewKV("CID", c),
+					logging.NewKV("PID", pid),
+				)
+			}
+		}
+	}
+}
+
+// isTopicPeer returns true if the given peer subscribes to the given pubsub topic.
+func (p *Peer) isTopicPeer(topic string, pid peer.ID) bool {
+	if p.ps == nil {
+		return false
+	}
+	for _, topicPeer := range p.ps.ListPeers(topic) {
+		if topicPeer == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// isPartialReplicator returns true if the given peer is a replicator of the collection with the
+// given schema ID that only receives the fields permitted by a field filter.
+//
+// Blocks are not tagged with the field they belong to, so such peers are only sent the blocks
+// pushed to them and may not pull any.
+func (p *Peer) isPartialReplicator(schemaID string, pid peer.ID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	filter, exists := p.replicators[schemaID][pid]
+	return exists && !filter.IsEmpty()
+}
+
 // DeleteReplicator adds a target peer node as a replication destination for documents in our DB.
 func (p *Peer) DeleteReplicator(
 	ctx context.Context,
@@ -590,11 +920,11 @@ func (p *Peer) loadReplicators(ctx context.Context) error {
 					continue
 				}
 			} else {
-				p.replicators[schema] = make(map[peer.ID]struct{})
+				p.replicators[schema] = make(map[peer.ID]client.FieldFilter)
 			}
 
 			// add to replicators list
-			p.replicators[schema][rep.Info.ID] = struct{}{}
+			p.replicators[schema][rep.Info.ID] = rep.FieldFilters[schema]
 		}
 
 		// Add the destination's peer multiaddress in the peerstore.
@@ -632,7 +962,7 @@ func (p *Peer) handleDocCreateLog(evt events.Update) error {
 	// push to each peer (replicator)
 	p.pushLogToReplicators(p.ctx, evt)
 
-	return p.RegisterNewDocument(p.ctx, dockey, evt.Cid, evt.Block, evt.SchemaID)
+	return p.RegisterNewDocument(p.ctx, dockey, evt.Cid, evt.Block, evt.SchemaID, evt.Owner)
 }
 
 func (p *Peer) handleDocUpdateLog(evt events.Update) error {
@@ -655,6 +985,8 @@ func (p *Peer) handleDocUpdateLog(evt events.Update) error {
 		Log: &pb.Document_Log{
 			Block: evt.Block.RawData(),
 		},
+		Owner:        evt.Owner,
+		PrunedHeight: evt.PrunedHeight,
 	}
 	req := &pb.PushLogRequest{
 		Body: body,
@@ -689,14 +1021,23 @@ func (p *Peer) pushLogToReplicators(ctx context.Context, lg events.Update) {
 	p.mu.Unlock()
 
 	if exists {
-		for pid := range reps {
+		for pid, filter := range reps {
 			// Don't push if pid is in the list of peers for the topic.
 			// It will be handled by the pubsub system.
-			if _, ok := peers[pid.String()]; ok {
+			//
+			// Partial replicas are always pushed to, as the logs published through the pubsub
+			// system hold the values of all the fields.
+			if _, ok := peers[pid.String()]; ok && filter.IsEmpty() {
 				continue
 			}
-			go func(peerID peer.ID) {
-				if err := p.server.pushLog(p.ctx, lg, peerID); err != nil {
+			go func(peerID peer.ID, filter client.FieldFilter) {
+				var err error
+				if filter.IsEmpty() {
+					err = p.server.pushLog(p.ctx, lg, peerID)
+				} else {
+					err = p.server.pushPartialLog(p.ctx, lg, peerID, filter)
+				}
+				if err != nil {
 					log.ErrorE(
 						p.ctx,
 						"Failed pushing log",
@@ -705,7 +1046,7 @@ func (p *Peer) pushLogToReplicators(ctx context.Context, lg events.Update) {
 						logging.NewKV("CID", lg.Cid),
 						logging.NewKV("PeerId", peerID))
 				}
-			}(pid)
+			}(pid, filter)
 		}
 	}
 }
@@ -759,8 +1100,16 @@ type EvtPubSub struct {
 	Peer peer.ID
 }
 
+// EvtPeerSynced is emitted once we have caught up with the changes of a (re)connected peer.
+type EvtPeerSynced struct {
+	Peer peer.ID
+}
+
 // AddP2PCollections adds the given collectionIDs to the pubsup topics.
 //
+// Once added, the documents of the given collections that our connected peers already have
+// are fetched from them.
+//
 // It will error if any of the given collectionIDs are invalid, in such a case some of the
 // changes to the server may still be applied.
 func (p *Peer) AddP2PCollections(collections []string) error {
@@ -804,7 +1153,48 @@ func (p *Peer) AddP2PCollections(collections []string) error {
 		addedTopics = append(addedTopics, col)
 	}
 
-	return txn.Commit(p.ctx)
+	err = txn.Commit(p.ctx)
+	if err != nil {
+		return err
+	}
+
+	p.syncCollectionsWithPeers(p.ctx, collections)
+	return nil
+}
+
+// syncCollectionsWithPeers fetches all the documents of the given collections from each of
+// our connected peers.
+func (p *Peer) syncCollectionsWithPeers(ctx context.Context, schemaIDs []string) {
+	cols := []client.Collection{}
+	for _, schemaID := range schemaIDs {
+		col, err := p.db.GetCollectionBySchemaID(ctx, schemaID)
+		if err != nil {
+			log.ErrorE(ctx, "Failed to get collection for peer sync", err, logging.NewKV("SchemaID", schemaID))
+			continue
+		}
+		cols = append(cols, col)
+	}
+
+	var wg sync.WaitGroup
+	for _, pid := range p.host.Network().Peers() {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			for _, col := range cols {
+				err := p.syncCollectionWithPeer(ctx, pid, col, true)
+				if err != nil {
+					log.ErrorE(
+						ctx,
+						"Failed to sync collection with peer",
+						err,
+						logging.NewKV("PID", pid),
+						logging.NewKV("Collection", col.Name()),
+					)
+				}
+			}
+		}(pid)
+	}
+	wg.Wait()
 }
 
 // RemoveP2PCollections removes the given collectionIDs from the pubsup topics.
@@ -20,9 +20,13 @@ import (
 	"github.com/ipfs/go-cid"
 	ipld "github.com/ipfs/go-ipld-format"
 	"github.com/ipfs/go-libipfs/blocks"
+	dag "github.com/ipfs/go-merkledag"
+	"github.com/ugorji/go/codec"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/errors"
@@ -57,6 +61,18 @@ func (p *Peer) processLog(
 		return nil, errors.Wrap("failed to decode delta object", err)
 	}
 
+	if err := p.signaturePolicy.Verify(nd, delta); err != nil {
+		return nil, err
+	}
+
+	owner, err := getDocOwner(ctx, txn, col, dockey)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyOwnerSignature(nd, delta, dockey, owner); err != nil {
+		return nil, err
+	}
+
 	log.Debug(
 		ctx,
 		"Processing PushLog request",
@@ -120,6 +136,244 @@ func initCRDTForType(
 	)
 }
 
+// getDocOwner returns the identity owning the given document, or an empty string if it has no owner.
+func getDocOwner(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+) (string, error) {
+	return acp.GetOwner(ctx, txn.Datastore(), base.MakeCollectionKey(col.Description()).WithInstanceInfo(dockey))
+}
+
+// checkDocAccess returns an error if the given log of a document said by the sending peer to be
+// owned by the given identity may not be merged into the local copy of the document.
+//
+// The ownership claimed by the sending peer is only trusted if the log is signed by the owner.
+// The given owner is then recorded locally if the document does not exist yet.
+func checkDocAccess(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	field string,
+	nd ipld.Node,
+	owner string,
+) error {
+	localOwner, err := getDocOwner(ctx, txn, col, dockey)
+	if err != nil {
+		return err
+	}
+	if localOwner != "" || owner == "" {
+		if localOwner != owner {
+			return errors.New(fmt.Sprintf("unauthorised update of document %s", dockey.DocKey))
+		}
+		return checkOwnerSignature(ctx, txn, col, dockey, field, nd, owner)
+	}
+
+	key, err := client.NewDocKeyFromString(dockey.DocKey)
+	if err != nil {
+		return err
+	}
+	heads, err := getHeads(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+	if len(heads) > 0 {
+		// Documents created without an owner may not be claimed by an identity later on.
+		return errors.New(fmt.Sprintf("unauthorised claim of ownership of document %s", dockey.DocKey))
+	}
+
+	err = checkOwnerSignature(ctx, txn, col, dockey, field, nd, owner)
+	if err != nil {
+		return err
+	}
+
+	return acp.SetOwner(ctx, txn.Datastore(), base.MakeCollectionKey(col.Description()).WithInstanceInfo(dockey), owner)
+}
+
+// checkOwnerSignature returns an error if the given log of a document owned by the given identity
+// is not signed by it.
+func checkOwnerSignature(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	field string,
+	nd ipld.Node,
+	owner string,
+) error {
+	if owner == "" {
+		return nil
+	}
+
+	crdt, err := initCRDTForType(ctx, txn, col, dockey, field)
+	if err != nil {
+		return err
+	}
+	delta, err := crdt.DeltaDecode(nd)
+	if err != nil {
+		return errors.Wrap("failed to decode delta object", err)
+	}
+	return verifyOwnerSignature(nd, delta, dockey, owner)
+}
+
+// verifyOwnerSignature returns an error if the given block of a document owned by the given
+// identity is not signed by it.
+//
+// Ownership is bound to the key of the owner, blocks of owned documents that are unsigned or
+// signed by anyone else are rejected.
+func verifyOwnerSignature(nd ipld.Node, delta core.Delta, dockey core.DataStoreKey, owner string) error {
+	if owner == "" {
+		return nil
+	}
+	signer, err := clock.GetSigner(nd, delta)
+	if err != nil {
+		return err
+	}
+	if signer != owner {
+		return errors.New(fmt.Sprintf("block %s of document %s is not signed by its owner", nd.Cid(), dockey.DocKey))
+	}
+	return nil
+}
+
+// getDocPrunedHeight returns the height up to which the history of the given document has been
+// pruned, or zero if it has not been pruned.
+func getDocPrunedHeight(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+) (uint64, error) {
+	snapshot, hasSnapshot, err := corecrdt.GetHistorySnapshot(
+		ctx,
+		txn,
+		base.MakeCollectionKey(col.Description()).WithInstanceInfo(dockey),
+	)
+	if err != nil || !hasSnapshot {
+		return 0, err
+	}
+	return snapshot.PrunedHeight(), nil
+}
+
+// checkPrunedHistory returns an error if the given document has not been synced locally up to
+// the height its history has been pruned to by the sending peer, as the blocks required to
+// sync it can then no longer be fetched from that peer.
+func checkPrunedHistory(
+	ctx context.Context,
+	txn datastore.Txn,
+	dockey core.DataStoreKey,
+	prunedHeight uint64,
+) error {
+	if prunedHeight == 0 {
+		return nil
+	}
+	headset := clock.NewHeadSet(txn.Headstore(), dockey.WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey())
+	_, height, err := headset.List(ctx)
+	if err != nil {
+		return err
+	}
+	if height < prunedHeight {
+		return errors.New(fmt.Sprintf(
+			"the history of document %s required to sync it has been pruned by the peer",
+			dockey.DocKey,
+		))
+	}
+	return nil
+}
+
+// ensureDocMarker writes the object marker of the given document if it does not exist yet.
+func ensureDocMarker(ctx context.Context, txn datastore.Txn, col client.Collection, dockey core.DataStoreKey) error {
+	key := base.MakeCollectionKey(col.Description()).WithInstanceInfo(dockey).ToPrimaryDataStoreKey()
+	exists, err := txn.Datastore().Has(ctx, key.ToDS())
+	if err != nil || exists {
+		return err
+	}
+	return txn.Datastore().Put(ctx, key.ToDS(), []byte{base.ObjectMarker})
+}
+
+// indexedCollection is implemented by collections that maintain secondary indexes, which
+// need updating when a document is modified by changes received from a peer.
+type indexedCollection interface {
+	client.Collection
+	SyncIndexedDoc(ctx context.Context, oldDoc *client.Document, key client.DocKey) error
+}
+
+// getDocForIndexSync returns the current state of the given document if the given collection
+// has indexes that will need to be synced once changes have been merged into the document.
+//
+// Returns nil if there is nothing to sync or if the document does not exist yet.
+func getDocForIndexSync(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+) (*client.Document, error) {
+	if _, ok := col.(indexedCollection); !ok || len(col.Description().Indexes) == 0 {
+		return nil, nil
+	}
+
+	key, err := client.NewDocKeyFromString(dockey.DocKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index entries must reflect the document regardless of who owns it.
+	doc, err := col.WithTxn(txn).Get(acp.WithoutAccessControl(ctx), key, false)
+	if err != nil {
+		if errors.Is(err, client.ErrDocumentNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc, nil
+}
+
+// syncIndexedDoc updates the indexes of the given collection to reflect the changes merged
+// into the given document, oldDoc being the state returned by getDocForIndexSync.
+//
+// The indexes are updated within the given transaction, which must be the one the changes
+// have been merged in so that the merged state of the document is visible.
+func syncIndexedDoc(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	oldDoc *client.Document,
+) error {
+	indexedCol, ok := col.WithTxn(txn).(indexedCollection)
+	if !ok || len(col.Description().Indexes) == 0 {
+		return nil
+	}
+
+	key, err := client.NewDocKeyFromString(dockey.DocKey)
+	if err != nil {
+		return err
+	}
+
+	return indexedCol.SyncIndexedDoc(ctx, oldDoc, key)
+}
+
+// getBlockDocKey returns the key of the document the given block belongs to, along with the
+// schema version ID of the collection of the document at the time the block was created.
+func getBlockDocKey(nd ipld.Node) (core.DataStoreKey, string, error) {
+	pbNode, ok := nd.(*dag.ProtoNode)
+	if !ok {
+		return core.DataStoreKey{}, "", client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", nd)
+	}
+
+	// The deltas of all CRDT types hold these fields.
+	var delta struct {
+		SchemaVersionID string
+		DocKey          []byte
+	}
+	err := codec.NewDecoderBytes(pbNode.Data(), &codec.CborHandle{}).Decode(&delta)
+	if err != nil {
+		return core.DataStoreKey{}, "", errors.Wrap("failed to decode delta object", err)
+	}
+	return core.DataStoreKey{DocKey: string(delta.DocKey)}, delta.SchemaVersionID, nil
+}
+
 func decodeBlockBuffer(buf []byte, cid cid.Cid) (ipld.Node, error) {
 	blk, err := blocks.NewBlockWithCid(buf, cid)
 	if err != nil {
@@ -128,6 +382,16 @@ func decodeBlockBuffer(buf []byte, cid cid.Cid) (ipld.Node, error) {
 	return ipld.Decode(blk)
 }
 
+// getHeads returns the CIDs of the current composite heads of the given document.
+func getHeads(ctx context.Context, txn datastore.Txn, dockey client.DocKey) ([]cid.Cid, error) {
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.DataStoreKeyFromDocKey(dockey).WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey(),
+	)
+	cids, _, err := headset.List(ctx)
+	return cids, err
+}
+
 func (p *Peer) createNodeGetter(
 	crdt crdt.MerkleCRDT,
 	getter ipld.NodeGetter,
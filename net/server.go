@@ -18,6 +18,8 @@ import (
 	"sync"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/libp2p/go-libp2p/core/event"
 	libpeer "github.com/libp2p/go-libp2p/core/peer"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/datastore/badger/v3"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/logging"
@@ -49,8 +52,9 @@ type server struct {
 
 	conns map[libpeer.ID]*grpc.ClientConn
 
-	pubSubEmitter  event.Emitter
-	pushLogEmitter event.Emitter
+	pubSubEmitter   event.Emitter
+	pushLogEmitter  event.Emitter
+	peerSyncEmitter event.Emitter
 }
 
 // pubsubTopic is a wrapper of rpc.Topic to be able to track if the topic has
@@ -115,29 +119,197 @@ func newServer(p *Peer, db client.DB, opts ...grpc.DialOption) (*server, error)
 	if err != nil {
 		log.Info(s.peer.ctx, "could not create event emitter", logging.NewKV("Error", err.Error()))
 	}
+	s.peerSyncEmitter, err = s.peer.host.EventBus().Emitter(new(EvtPeerSynced))
+	if err != nil {
+		log.Info(s.peer.ctx, "could not create event emitter", logging.NewKV("Error", err.Error()))
+	}
 
 	return s, nil
 }
 
 // GetDocGraph receives a get graph request
+//
+// It replies with the current heads of every document in the requested collection, leaving out
+// the documents owned by an identity other than the requesting peer. Replicators of a subset of
+// the fields of the collection are refused, as the composite heads hold the values of all fields.
 func (s *server) GetDocGraph(
 	ctx context.Context,
 	req *pb.GetDocGraphRequest,
 ) (*pb.GetDocGraphReply, error) {
-	return nil, nil
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schemaID := string(req.SchemaID)
+
+	txn, err := s.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	col, err := s.db.WithTxn(txn).GetCollectionBySchemaID(ctx, schemaID)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Sprintf("Failed to get collection from schemaID %s", schemaID), err)
+	}
+	if s.peer.isPartialReplicator(schemaID, pid) {
+		return nil, errors.New(fmt.Sprintf("peer %s may only receive some fields of %s", pid, col.Name()))
+	}
+	keysCh, err := col.WithTxn(txn).GetAllDocKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.GetDocGraphReply{}
+	for key := range keysCh {
+		if key.Err != nil {
+			return nil, key.Err
+		}
+		heads, err := getHeads(ctx, txn, key.Key)
+		if err != nil {
+			return nil, err
+		}
+		owner, err := getDocOwner(ctx, txn, col, core.DataStoreKeyFromDocKey(key.Key))
+		if err != nil {
+			return nil, err
+		}
+		if !canPeerAccessDoc(pid, owner) {
+			continue
+		}
+		prunedHeight, err := getDocPrunedHeight(ctx, txn, col, core.DataStoreKeyFromDocKey(key.Key))
+		if err != nil {
+			return nil, err
+		}
+		for _, head := range heads {
+			reply.Docs = append(reply.Docs, &pb.Document{
+				DocKey:       &pb.ProtoDocKey{DocKey: key.Key},
+				Head:         &pb.ProtoCid{Cid: head},
+				Owner:        owner,
+				PrunedHeight: prunedHeight,
+			})
+		}
+	}
+	return reply, nil
 }
 
 // PushDocGraph receives a push graph request
+//
+// Each of the given logs is handled as if it was received through a PushLog request.
 func (s *server) PushDocGraph(
 	ctx context.Context,
 	req *pb.PushDocGraphRequest,
 ) (*pb.PushDocGraphReply, error) {
-	return nil, nil
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug(ctx, "Received a PushDocGraph request", logging.NewKV("PID", pid))
+
+	for _, body := range req.Logs {
+		processed, err := s.handlePushLog(ctx, body)
+		if processed {
+			s.emitPushLogEvent(ctx, pid, body)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pb.PushDocGraphReply{}, nil
 }
 
 // GetLog receives a get log request
+//
+// It replies with the blocks of the requested CIDs. Blocks of documents owned by an identity
+// other than the requesting peer are refused, as are all the blocks of the collections the
+// requesting peer only replicates a subset of the fields of.
 func (s *server) GetLog(ctx context.Context, req *pb.GetLogRequest) (*pb.GetLogReply, error) {
-	return nil, nil
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := s.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	reply := &pb.GetLogReply{}
+	for _, c := range req.Cids {
+		blk, err := s.db.Blockstore().Get(ctx, c.Cid)
+		if err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("failed to get block %s", c.Cid), err)
+		}
+		err = s.checkBlockAccess(ctx, txn, pid, c.Cid, blk.RawData())
+		if err != nil {
+			return nil, err
+		}
+		reply.Logs = append(reply.Logs, &pb.Document_Log{
+			Block: blk.RawData(),
+		})
+	}
+	return reply, nil
+}
+
+// checkBlockAccess returns an error if the given block may not be served to the given peer.
+func (s *server) checkBlockAccess(
+	ctx context.Context,
+	txn datastore.Txn,
+	pid libpeer.ID,
+	c cid.Cid,
+	block []byte,
+) error {
+	nd, err := decodeBlockBuffer(block, c)
+	if err != nil {
+		return errors.Wrap("failed to decode block to ipld.Node", err)
+	}
+	dockey, schemaVersionID, err := getBlockDocKey(nd)
+	if err != nil {
+		return err
+	}
+
+	col, err := getBlockCollection(ctx, s.db.WithTxn(txn), c, schemaVersionID)
+	if err != nil {
+		return err
+	}
+	if s.peer.isPartialReplicator(col.SchemaID(), pid) {
+		return errors.New(fmt.Sprintf("peer %s may only receive some fields of block %s of %s", pid, c, col.Name()))
+	}
+	owner, err := getDocOwner(ctx, txn, col, dockey)
+	if err != nil {
+		return err
+	}
+	if !canPeerAccessDoc(pid, owner) {
+		return errors.New(fmt.Sprintf("peer %s may not access block %s of document %s", pid, c, dockey.DocKey))
+	}
+	return nil
+}
+
+// getBlockCollection returns the collection at the schema version the given block was written at.
+//
+// Returns an ErrUnknownSchemaVersion error if the node does not have that schema version.
+func getBlockCollection(
+	ctx context.Context,
+	store client.Store,
+	c cid.Cid,
+	schemaVersionID string,
+) (client.Collection, error) {
+	col, err := store.GetCollectionByVersionID(ctx, schemaVersionID)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, NewErrUnknownSchemaVersion(c, schemaVersionID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(fmt.Sprintf("Failed to get collection from schema version %s", schemaVersionID), err)
+	}
+	return col, nil
+}
+
+// canPeerAccessDoc returns true if the given peer may be sent the blocks of a document owned by
+// the given identity.
+//
+// Owned documents are only shared with their owner, whose identity is that of the key of its node.
+func canPeerAccessDoc(pid libpeer.ID, owner string) bool {
+	return owner == "" || owner == pid.String()
 }
 
 // PushLog receives a push log request
@@ -148,27 +320,42 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 	}
 	log.Debug(ctx, "Received a PushLog request", logging.NewKV("PID", pid))
 
+	processed, err := s.handlePushLog(ctx, req.Body)
+	if processed {
+		s.emitPushLogEvent(ctx, pid, req.Body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PushLogReply{}, nil
+}
+
+// handlePushLog merges the given log, and any of its missing children, into the local
+// document graph.
+//
+// It returns false if the log was already known (or is currently being processed).
+func (s *server) handlePushLog(ctx context.Context, body *pb.PushLogRequest_Body) (bool, error) {
 	// parse request object
-	cid := req.Body.Cid.Cid
+	cid := body.Cid.Cid
 
 	// make sure were not processing twice
 	if canVisit := s.peer.queuedChildren.Visit(cid); !canVisit {
-		return &pb.PushLogReply{}, nil
+		return false, nil
 	}
 	defer s.peer.queuedChildren.Remove(cid)
 
 	// check if we already have this block
 	exists, err := s.db.Blockstore().Has(ctx, cid)
 	if err != nil {
-		return nil, errors.Wrap(fmt.Sprintf("failed to check for existing block %s", cid), err)
+		return false, errors.Wrap(fmt.Sprintf("failed to check for existing block %s", cid), err)
 	}
 	if exists {
 		log.Debug(ctx, fmt.Sprintf("Already have block %s locally, skipping.", cid))
-		return &pb.PushLogReply{}, nil
+		return false, nil
 	}
 
-	schemaID := string(req.Body.SchemaID)
-	docKey := core.DataStoreKeyFromDocKey(req.Body.DocKey.DocKey)
+	schemaID := string(body.SchemaID)
+	docKey := core.DataStoreKeyFromDocKey(body.DocKey.DocKey)
 
 	var txnErr error
 	for retry := 0; retry < s.peer.db.MaxTxnRetries(); retry++ {
@@ -176,14 +363,14 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 		// each process on a single transaction.
 		txn, err := s.db.NewConcurrentTxn(ctx, false)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 		defer txn.Discard(ctx)
 		store := s.db.WithTxn(txn)
 
 		col, err := store.GetCollectionBySchemaID(ctx, schemaID)
 		if err != nil {
-			return nil, errors.Wrap(fmt.Sprintf("Failed to get collection from schemaID %s", schemaID), err)
+			return false, errors.Wrap(fmt.Sprintf("Failed to get collection from schemaID %s", schemaID), err)
 		}
 
 		// Create a new DAG service with the current transaction
@@ -194,12 +381,40 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 		}
 
 		// handleComposite
-		nd, err := decodeBlockBuffer(req.Body.Log.Block, cid)
+		nd, err := decodeBlockBuffer(body.Log.Block, cid)
+		if err != nil {
+			return false, errors.Wrap("failed to decode block to ipld.Node", err)
+		}
+
+		// Blocks written at older schema versions are migrated when read, however the
+		// fields of blocks written at newer schema versions may not exist on this node.
+		_, schemaVersionID, err := getBlockDocKey(nd)
+		if err != nil {
+			return false, err
+		}
+		if schemaVersionID != "" {
+			_, err = getBlockCollection(ctx, store, cid, schemaVersionID)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		err = checkDocAccess(ctx, txn, col, docKey, body.FieldName, nd, body.Owner)
+		if err != nil {
+			return false, err
+		}
+
+		err = checkPrunedHistory(ctx, txn, docKey, body.PrunedHeight)
 		if err != nil {
-			return nil, errors.Wrap("failed to decode block to ipld.Node", err)
+			return false, err
 		}
 
-		cids, err := s.peer.processLog(ctx, txn, col, docKey, cid, "", nd, getter, false)
+		oldDoc, err := getDocForIndexSync(ctx, txn, col, docKey)
+		if err != nil {
+			return false, err
+		}
+
+		cids, err := s.peer.processLog(ctx, txn, col, docKey, cid, body.FieldName, nd, getter, false)
 		if err != nil {
 			log.ErrorE(
 				ctx,
@@ -210,8 +425,20 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			)
 		}
 
+		if body.FieldName != "" {
+			// Partial replicas do not receive the composite logs that mark the document as existing.
+			err = ensureDocMarker(ctx, txn, col, docKey)
+			if err != nil {
+				return false, err
+			}
+		}
+
 		// handleChildren
-		if len(cids) > 0 { // we have child nodes to get
+		if body.Partial && body.FieldName == "" {
+			// The composite blocks linked to by the composite log of a partial replica hold the values
+			// of fields that may not be replicated, and are not fetched.
+			log.Debug(ctx, "Not processing children of partial log", logging.NewKV("CID", cid))
+		} else if len(cids) > 0 { // we have child nodes to get
 			log.Debug(
 				ctx,
 				"Handling children for log",
@@ -219,7 +446,7 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 				logging.NewKV("CID", cid),
 			)
 			var session sync.WaitGroup
-			s.peer.handleChildBlocks(&session, txn, col, docKey, "", nd, cids, getter)
+			s.peer.handleChildBlocks(&session, txn, col, docKey, body.FieldName, nd, cids, getter)
 			session.Wait()
 			// dagWorkers specific to the dockey will have been spawned within handleChildBlocks.
 			// Once we are done with the dag syncing process, we can get rid of those workers.
@@ -228,42 +455,78 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			log.Debug(ctx, "No more children to process for log", logging.NewKV("CID", cid))
 		}
 
+		err = syncIndexedDoc(ctx, txn, col, docKey, oldDoc)
+		if err != nil {
+			return false, errors.Wrap("failed to sync indexes with PushLog changes", err)
+		}
+
 		if txnErr = txn.Commit(ctx); txnErr != nil {
 			if errors.Is(txnErr, badger.ErrTxnConflict) {
 				continue
 			}
-			return &pb.PushLogReply{}, txnErr
+			return false, txnErr
 		}
 
-		if s.pushLogEmitter != nil {
-			byPeer, err := libpeer.Decode(req.Body.Creator)
-			if err != nil {
-				log.Info(ctx, "could not decode the peer id of the log creator", logging.NewKV("Error", err.Error()))
-			}
-			err = s.pushLogEmitter.Emit(EvtReceivedPushLog{
-				FromPeer: pid,
-				ByPeer:   byPeer,
-			})
-			if err != nil {
-				// logging instead of returning an error because the event bus should
-				// not break the PushLog execution.
-				log.Info(ctx, "could not emit push log event", logging.NewKV("Error", err.Error()))
-			}
+		if body.Partial {
+			// The logs published on the dockey topic hold the values of all the fields of the document.
+			return true, nil
 		}
 
 		// Once processed, subscribe to the dockey topic on the pubsub network.
-		return &pb.PushLogReply{}, s.addPubSubTopic(docKey.DocKey, true)
+		return true, s.addPubSubTopic(docKey.DocKey, true)
 	}
 
-	return &pb.PushLogReply{}, client.NewErrMaxTxnRetries(txnErr)
+	return false, client.NewErrMaxTxnRetries(txnErr)
+}
+
+// emitPushLogEvent notifies subscribers that the given log, received from the given peer,
+// has been merged.
+func (s *server) emitPushLogEvent(ctx context.Context, pid libpeer.ID, body *pb.PushLogRequest_Body) {
+	if s.pushLogEmitter == nil {
+		return
+	}
+	byPeer, err := libpeer.Decode(body.Creator)
+	if err != nil {
+		log.Info(ctx, "could not decode the peer id of the log creator", logging.NewKV("Error", err.Error()))
+	}
+	err = s.pushLogEmitter.Emit(EvtReceivedPushLog{
+		FromPeer: pid,
+		ByPeer:   byPeer,
+	})
+	if err != nil {
+		// logging instead of returning an error because the event bus should
+		// not break the PushLog execution.
+		log.Info(ctx, "could not emit push log event", logging.NewKV("Error", err.Error()))
+	}
 }
 
 // GetHeadLog receives a get head log request
+//
+// It replies with the current heads of the requested document.
 func (s *server) GetHeadLog(
 	ctx context.Context,
 	req *pb.GetHeadLogRequest,
 ) (*pb.GetHeadLogReply, error) {
-	return nil, nil
+	if req.DocKey == nil {
+		return nil, errors.New("a DocKey is required to get the head log")
+	}
+
+	txn, err := s.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	heads, err := getHeads(ctx, txn, req.DocKey.DocKey)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.GetHeadLogReply{}
+	for _, head := range heads {
+		reply.Heads = append(reply.Heads, pb.ProtoCid{Cid: head})
+	}
+	return reply, nil
 }
 
 // addPubSubTopic subscribes to a topic on the pubsub network
@@ -19,6 +19,8 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	ma "github.com/multiformats/go-multiaddr"
 	"google.golang.org/grpc"
+
+	"github.com/sourcenetwork/defradb/merkle/clock"
 )
 
 // Options is the node options.
@@ -31,6 +33,7 @@ type Options struct {
 	GRPCServerOptions []grpc.ServerOption
 	GRPCDialOptions   []grpc.DialOption
 	ConnManager       cconnmgr.ConnManager
+	SignaturePolicy   clock.SignaturePolicy
 }
 
 type NodeOpt func(*Options) error
@@ -82,6 +85,24 @@ func WithEnableRelay(enable bool) NodeOpt {
 	}
 }
 
+// WithRequireSignedBlocks enables the rejection of unsigned blocks received from other peers.
+func WithRequireSignedBlocks(enable bool) NodeOpt {
+	return func(opt *Options) error {
+		opt.SignaturePolicy.RequireSignature = enable
+		return nil
+	}
+}
+
+// WithTrustedSigners sets the identities allowed to sign the blocks received from other peers.
+//
+// Blocks signed by any identity are accepted if none are given.
+func WithTrustedSigners(identities ...string) NodeOpt {
+	return func(opt *Options) error {
+		opt.SignaturePolicy.TrustedSigners = identities
+		return nil
+	}
+}
+
 // ListenP2PAddrStrings sets the address to listen on given as strings.
 func ListenP2PAddrStrings(addrs ...string) NodeOpt {
 	return func(opt *Options) error {
@@ -75,6 +75,13 @@ type Node struct {
 	// receives an event when a pushLog request has been processed.
 	pushLogEvent chan net.EvtReceivedPushLog
 
+	// syncedPeers holds the peers that the node has caught up with at least once.
+	//
+	// peerSynced is closed, and replaced, each time a peer is added to syncedPeers.
+	syncedPeers map[peer.ID]struct{}
+	peerSynced  chan struct{}
+	peerSyncMu  sync.Mutex
+
 	ctx context.Context
 }
 
@@ -101,7 +108,7 @@ func NewNode(
 	}
 	fin.Add(peerstore)
 
-	hostKey, err := getHostKey(options.DataPath)
+	hostKey, err := GetHostKey(options.DataPath)
 	if err != nil {
 		return nil, fin.Cleanup(err)
 	}
@@ -162,6 +169,7 @@ func NewNode(
 		ddht,
 		ps,
 		options.TCPAddr,
+		options.SignaturePolicy,
 		options.GRPCServerOptions,
 		options.GRPCDialOptions,
 	)
@@ -190,6 +198,7 @@ func NewNode(
 	n.subscribeToPeerConnectionEvents()
 	n.subscribeToPubSubEvents()
 	n.subscribeToPushLogEvents()
+	n.subscribeToPeerSyncEvents()
 
 	return n, nil
 }
@@ -299,6 +308,29 @@ func (n *Node) subscribeToPushLogEvents() {
 	}()
 }
 
+// subscribeToPeerSyncEvents subscribes the node to the event bus for a peer sync completion.
+func (n *Node) subscribeToPeerSyncEvents() {
+	n.syncedPeers = make(map[peer.ID]struct{})
+	n.peerSynced = make(chan struct{})
+
+	sub, err := n.host.EventBus().Subscribe(new(net.EvtPeerSynced))
+	if err != nil {
+		log.Info(
+			n.ctx,
+			fmt.Sprintf("failed to subscribe to peer sync event: %v", err),
+		)
+	}
+	go func() {
+		for e := range sub.Out() {
+			n.peerSyncMu.Lock()
+			n.syncedPeers[e.(net.EvtPeerSynced).Peer] = struct{}{}
+			close(n.peerSynced)
+			n.peerSynced = make(chan struct{})
+			n.peerSyncMu.Unlock()
+		}
+	}()
+}
+
 // WaitForPeerConnectionEvent listens to the event channel for a connection event from a given peer.
 func (n *Node) WaitForPeerConnectionEvent(id peer.ID) error {
 	if n.host.Network().Connectedness(id) == network.Connected {
@@ -374,8 +406,33 @@ func (n *Node) WaitForPushLogFromPeerEvent(id peer.ID) error {
 	}
 }
 
+// WaitForPeerSync blocks until the node has caught up with the given peer at least once.
+//
+// Unlike the WaitForFoo event funcs, it returns immediately if that has already happened.
+func (n *Node) WaitForPeerSync(id peer.ID) error {
+	timeout := time.After(evtWaitTimeout)
+	for {
+		n.peerSyncMu.Lock()
+		_, synced := n.syncedPeers[id]
+		peerSynced := n.peerSynced
+		n.peerSyncMu.Unlock()
+		if synced {
+			return nil
+		}
+
+		select {
+		case <-peerSynced:
+		case <-timeout:
+			return errors.New("waiting for peer sync timed out")
+		}
+	}
+}
+
+// GetHostKey returns the private key of the node storing its data at the given path,
+// generating it if it does not exist yet.
+//
 // replace with proper keystore
-func getHostKey(keypath string) (crypto.PrivKey, error) {
+func GetHostKey(keypath string) (crypto.PrivKey, error) {
 	// If a local datastore is used, the key is written to a file
 	pth := filepath.Join(keypath, "key")
 	_, err := os.Stat(pth)
@@ -16,7 +16,9 @@ import (
 	ipld "github.com/ipfs/go-ipld-format"
 	"github.com/ipfs/go-libipfs/blocks"
 	dag "github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p/core/crypto"
 
+	"github.com/sourcenetwork/defradb/acp"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/db/fetcher"
@@ -211,6 +213,12 @@ func (n *dagScanNode) Next() (bool, error) {
 	// use the stored cid to scan through the blockstore
 	// clear the cid after
 	block, err := store.Get(n.planner.ctx, *currentCid)
+	if ipld.IsNotFound(err) {
+		// The block has been removed by pruning the history of the document,
+		// so it, and any blocks it links to, are skipped.
+		n.visitedNodes[currentCid.String()] = true
+		return n.Next()
+	}
 	if err != nil {
 		return false, err
 	}
@@ -318,6 +326,14 @@ func (n *dagScanNode) dagBlockToNodeDoc(block blocks.Block) (core.Doc, []*ipld.L
 	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.HeightFieldName, int64(prio))
 	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.DeltaFieldName, delta["Data"])
 
+	signer, err := getBlockSigner(nd.Data())
+	if err != nil {
+		return core.Doc{}, nil, err
+	}
+	if signer != "" {
+		n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.SignerFieldName, signer)
+	}
+
 	dockey, ok := delta["DocKey"].([]byte)
 	if !ok {
 		return core.Doc{}, nil, ErrDeltaMissingDockey
@@ -364,3 +380,24 @@ func (n *dagScanNode) dagBlockToNodeDoc(block blocks.Block) (core.Doc, []*ipld.L
 }
 
 func (n *dagScanNode) Append() bool { return true }
+
+// getBlockSigner returns the identity of the node that signed the block holding the given delta
+// data, or an empty string if the block is unsigned.
+//
+// Signatures are verified before blocks are merged, so they are not verified again here.
+func getBlockSigner(data []byte) (string, error) {
+	var signed struct {
+		Signature *core.Signature
+	}
+	if err := cbor.Unmarshal(data, &signed); err != nil {
+		return "", err
+	}
+	if signed.Signature == nil {
+		return "", nil
+	}
+	pubKey, err := crypto.UnmarshalPublicKey(signed.Signature.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return acp.NewIdentity(pubKey)
+}
@@ -12,20 +12,22 @@ package planner
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
-	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/planner/mapper"
 )
 
 // createNode is used to construct and execute
 // an object create mutation.
 //
-// Create nodes are the simplest of the object mutations
-// Each Iteration of the plan, creates and returns one
-// document, until we've exhausted the payload. No filtering
+// Create nodes are the simplest of the object mutations.
+// The payload may hold a single document or a list of
+// documents, all of which are created on the first iteration
+// of the plan and then returned one per iteration, in key order. No filtering
 // or Select plans
 type createNode struct {
 	documentIterator
@@ -37,14 +39,14 @@ type createNode struct {
 	// collection name, meta-data, etc.
 	collection client.Collection
 
-	// newDoc is the JSON string of the new document, unparsed
+	// newDoc is the JSON string of the new document(s), unparsed
 	newDocStr string
-	doc       *client.Document
+	docs      []*client.Document
 
 	err error
 
-	returned bool
-	results  planNode
+	created bool
+	results planNode
 
 	execInfo createExecInfo
 }
@@ -59,58 +61,82 @@ func (n *createNode) Kind() string { return "createNode" }
 func (n *createNode) Init() error { return nil }
 
 func (n *createNode) Start() error {
-	doc, err := client.NewDocFromJSON([]byte(n.newDocStr))
+	docs, err := newDocsFromJSON(n.newDocStr)
 	if err != nil {
 		n.err = err
 		return err
 	}
-	n.doc = doc
+	n.docs = docs
 	return nil
 }
 
-// Next only returns once.
-func (n *createNode) Next() (bool, error) {
-	n.execInfo.iterations++
-
-	if n.err != nil {
-		return false, n.err
+// newDocsFromJSON parses the given create payload, which may be either a single
+// JSON object, or a JSON array of objects.
+func newDocsFromJSON(data string) ([]*client.Document, error) {
+	if !strings.HasPrefix(strings.TrimSpace(data), "[") {
+		doc, err := client.NewDocFromJSON([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+		return []*client.Document{doc}, nil
 	}
 
-	if n.returned {
-		return false, nil
-	}
-
-	if err := n.collection.WithTxn(n.p.txn).Create(n.p.ctx, n.doc); err != nil {
-		return false, err
+	var docsData []json.RawMessage
+	err := json.Unmarshal([]byte(data), &docsData)
+	if err != nil {
+		return nil, err
 	}
 
-	currentValue := n.documentMapping.NewDoc()
-
-	currentValue.SetKey(n.doc.Key().String())
-	for i, value := range n.doc.Values() {
-		// On create the document will have no aliased fields/aggregates/etc so we can safely take
-		// the first index.
-		n.documentMapping.SetFirstOfName(&currentValue, i.Name(), value.Value())
+	docs := make([]*client.Document, len(docsData))
+	for i, docData := range docsData {
+		doc, err := client.NewDocFromJSON(docData)
+		if err != nil {
+			return nil, err
+		}
+		docs[i] = doc
 	}
+	return docs, nil
+}
 
-	n.returned = true
-	n.currentValue = currentValue
-
-	desc := n.collection.Description()
-	docKey := base.MakeDocKey(desc, currentValue.GetKey())
-	n.results.Spans(core.NewSpans(core.NewSpan(docKey, docKey.PrefixEnd())))
+// Next creates all the documents on the first call, then yields them
+// one at a time.
+func (n *createNode) Next() (bool, error) {
+	n.execInfo.iterations++
 
-	err := n.results.Init()
-	if err != nil {
-		return false, err
+	if n.err != nil {
+		return false, n.err
 	}
 
-	err = n.results.Start()
-	if err != nil {
-		return false, err
+	if !n.created {
+		n.created = true
+		if len(n.docs) == 0 {
+			return false, nil
+		}
+
+		if err := n.collection.WithTxn(n.p.txn).CreateMany(n.p.ctx, n.docs); err != nil {
+			return false, err
+		}
+
+		// The spans must be sorted, the created documents are yielded in key order.
+		docKeys := make([]string, len(n.docs))
+		for i, doc := range n.docs {
+			docKeys[i] = doc.Key().String()
+		}
+		sort.Strings(docKeys)
+		n.results.Spans(docKeySpans(n.collection.Description(), docKeys))
+
+		err := n.results.Init()
+		if err != nil {
+			return false, err
+		}
+
+		err = n.results.Start()
+		if err != nil {
+			return false, err
+		}
 	}
 
-	// get the next result based on our point lookup
+	// get the next result based on our point lookups
 	next, err := n.results.Next()
 	if err != nil {
 		return false, err
@@ -132,7 +158,7 @@ func (n *createNode) Close() error {
 func (n *createNode) Source() planNode { return n.results }
 
 func (n *createNode) simpleExplain() (map[string]any, error) {
-	data := map[string]any{}
+	var data any
 	err := json.Unmarshal([]byte(n.newDocStr), &data)
 	if err != nil {
 		return nil, err
@@ -37,7 +37,7 @@ func (p *Planner) getSource(parsed *mapper.Select) (planSource, error) {
 
 // @todo: Add field selection
 func (p *Planner) getCollectionScanPlan(parsed *mapper.Select) (planSource, error) {
-	colDesc, err := p.getCollectionDesc(parsed.CollectionName)
+	colDesc, err := p.getIndexedCollectionDesc(parsed.CollectionName)
 	if err != nil {
 		return planSource{}, err
 	}
@@ -56,6 +56,28 @@ func (p *Planner) getCollectionScanPlan(parsed *mapper.Select) (planSource, erro
 	}, nil
 }
 
+// getIndexedCollectionDesc returns the description of the collection with the given name,
+// including its secondary indexes.
+func (p *Planner) getIndexedCollectionDesc(name string) (client.CollectionDescription, error) {
+	colDesc, err := p.getCollectionDesc(name)
+	if err != nil {
+		return client.CollectionDescription{}, err
+	}
+
+	// Indexes are local to the node and are not part of the stored collection version,
+	// so they need to be fetched separately.
+	col, err := p.db.GetCollectionByName(p.ctx, name)
+	if err != nil {
+		return client.CollectionDescription{}, err
+	}
+	colDesc.Indexes, err = col.GetIndexes(p.ctx)
+	if err != nil {
+		return client.CollectionDescription{}, err
+	}
+
+	return colDesc, nil
+}
+
 func (p *Planner) getCollectionDesc(name string) (client.CollectionDescription, error) {
 	collectionKey := core.NewCollectionKey(name)
 	var desc client.CollectionDescription
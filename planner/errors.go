@@ -16,6 +16,8 @@ const (
 	errUnknownDependency              string = "given field does not exist"
 	errFailedToClosePlan              string = "failed to close the plan"
 	errFailedToCollectExecExplainInfo string = "failed to collect execution explain information"
+	errInvalidCursor                  string = "invalid cursor"
+	errCursorPaginationWithLimit      string = "cursor pagination cannot be combined with limit or offset"
 )
 
 var (
@@ -33,6 +35,12 @@ var (
 	ErrUnknownExplainRequestType           = errors.New("can not explain request of unknown type")
 	ErrFailedToCollectExecExplainInfo      = errors.New(errFailedToCollectExecExplainInfo)
 	ErrUnknownDependency                   = errors.New(errUnknownDependency)
+	ErrInvalidCursor                       = errors.New(errInvalidCursor)
+	ErrCursorPaginationWithLimit           = errors.New(errCursorPaginationWithLimit)
+	ErrPageWithinGroup                     = errors.New("cursor pagination may not be used within _group")
+	ErrUpsertWithoutFilter                 = errors.New("upsert requires a filter")
+	ErrRevertWithoutDocKey                 = errors.New("revert requires a single dockey")
+	ErrRevertWithoutVersion                = errors.New("revert requires a cid")
 )
 
 func NewErrUnknownDependency(name string) error {
@@ -46,3 +54,7 @@ func NewErrFailedToClosePlan(inner error, location string) error {
 func NewErrFailedToCollectExecExplainInfo(inner error) error {
 	return errors.Wrap(errFailedToCollectExecExplainInfo, inner)
 }
+
+func NewErrInvalidCursor(cursor string) error {
+	return errors.New(errInvalidCursor, errors.NewKV("Cursor", cursor))
+}
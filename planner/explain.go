@@ -39,7 +39,10 @@ var (
 	_ explainablePlanNode = (*deleteNode)(nil)
 	_ explainablePlanNode = (*groupNode)(nil)
 	_ explainablePlanNode = (*limitNode)(nil)
+	_ explainablePlanNode = (*minMaxNode)(nil)
 	_ explainablePlanNode = (*orderNode)(nil)
+	_ explainablePlanNode = (*pageNode)(nil)
+	_ explainablePlanNode = (*revertNode)(nil)
 	_ explainablePlanNode = (*scanNode)(nil)
 	_ explainablePlanNode = (*selectNode)(nil)
 	_ explainablePlanNode = (*selectTopNode)(nil)
@@ -47,20 +50,29 @@ var (
 	_ explainablePlanNode = (*topLevelNode)(nil)
 	_ explainablePlanNode = (*typeIndexJoin)(nil)
 	_ explainablePlanNode = (*updateNode)(nil)
+	_ explainablePlanNode = (*upsertNode)(nil)
 )
 
 const (
+	afterLabel          = "after"
+	beforeLabel         = "before"
 	childFieldNameLabel = "childFieldName"
+	cidLabel            = "cid"
 	collectionIDLabel   = "collectionID"
 	collectionNameLabel = "collectionName"
 	dataLabel           = "data"
+	dockeyLabel         = "dockey"
 	fieldNameLabel      = "fieldName"
 	filterLabel         = "filter"
+	firstLabel          = "first"
 	idsLabel            = "ids"
+	indexLabel          = "index"
+	lastLabel           = "last"
 	limitLabel          = "limit"
 	offsetLabel         = "offset"
 	sourcesLabel        = "sources"
 	spansLabel          = "spans"
+	updateLabel         = "update"
 )
 
 // buildSimpleExplainGraph builds the explainGraph from the given top level plan.
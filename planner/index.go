@@ -0,0 +1,389 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"sort"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/connor"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// indexScan describes how a secondary index may be used to find the candidate
+// documents of a scan.
+//
+// Only the first field of the index is used to narrow down the candidates, the full
+// filter is still applied to every document read by the scan.
+type indexScan struct {
+	index client.IndexDescription
+
+	// points contains the encoded values that the first indexed field must be equal to.
+	//
+	// If empty, the (inclusive) range bounds are used instead.
+	points []string
+
+	lowerBound immutable.Option[string]
+	upperBound immutable.Option[string]
+}
+
+// findIndexScan returns an index scan for the given filter if any of the indexes of the
+// given collection can be used to narrow down the documents matching it.
+//
+// An index may be used if the filter contains an `_eq`, `_in`, `_gt`, `_ge`, `_lt` or `_le`
+// condition on the first field of the index at its top level, or within a top level `_and`.
+func findIndexScan(
+	desc client.CollectionDescription,
+	filter *mapper.Filter,
+	mapping *core.DocumentMapping,
+) immutable.Option[indexScan] {
+	if filter == nil || len(desc.Indexes) == 0 {
+		return immutable.None[indexScan]()
+	}
+
+	for _, index := range desc.Indexes {
+		field, ok := desc.GetField(index.Fields[0].Name)
+		if !ok {
+			continue
+		}
+		propertyIndexes, ok := mapping.IndexesByName[field.Name]
+		if !ok || len(propertyIndexes) == 0 {
+			continue
+		}
+
+		conditions := findFieldConditions(filter.Conditions, propertyIndexes[0])
+		scan, ok := newIndexScan(index, field.Kind, conditions)
+		if ok {
+			return immutable.Some(scan)
+		}
+	}
+
+	return immutable.None[indexScan]()
+}
+
+// findPageIndexScan returns an index scan narrowing the documents down to those that may lie
+// between the cursors of the given page if the primary order field of the page is indexed.
+//
+// The bounds are inclusive, documents sharing the order value of a cursor must still be
+// compared against the full cursor.
+func findPageIndexScan(desc client.CollectionDescription, page *pageNode) immutable.Option[indexScan] {
+	if len(page.ordering) == 0 || (!page.after.HasValue() && !page.before.HasValue()) {
+		return immutable.None[indexScan]()
+	}
+
+	order := page.ordering[0]
+	if len(order.FieldIndexes) != 1 {
+		return immutable.None[indexScan]()
+	}
+	// Indexes store nil values before all other values, so they can only be used if nil
+	// values are ordered as if they were the smallest value.
+	if (order.Direction == mapper.ASC && order.Nulls == mapper.NullsLast) ||
+		(order.Direction == mapper.DESC && order.Nulls == mapper.NullsFirst) {
+		return immutable.None[indexScan]()
+	}
+
+	name, ok := page.documentMapping.TryToFindNameFromIndex(order.FieldIndexes[0])
+	if !ok {
+		return immutable.None[indexScan]()
+	}
+	field, ok := desc.GetField(name)
+	if !ok {
+		return immutable.None[indexScan]()
+	}
+	switch field.Kind {
+	case client.FieldKind_BOOL, client.FieldKind_INT, client.FieldKind_FLOAT, client.FieldKind_STRING:
+		// The index ordering of the values of these kinds matches the ordering of the
+		// documents, unlike date times which are indexed chronologically.
+	default:
+		return immutable.None[indexScan]()
+	}
+
+	lowerCursor, upperCursor := page.after, page.before
+	if order.Direction == mapper.DESC {
+		lowerCursor, upperCursor = upperCursor, lowerCursor
+	}
+	conditions := map[string]any{}
+	if lowerCursor.HasValue() {
+		conditions["_ge"] = lowerCursor.Value().OrderValues[0]
+	}
+	if upperCursor.HasValue() {
+		conditions["_le"] = upperCursor.Value().OrderValues[0]
+	}
+
+	for _, index := range desc.Indexes {
+		if index.Fields[0].Name != field.Name {
+			continue
+		}
+		scan, ok := newIndexScan(index, field.Kind, conditions)
+		if ok {
+			return immutable.Some(scan)
+		}
+	}
+
+	return immutable.None[indexScan]()
+}
+
+// findFieldConditions returns the operator conditions that must all be satisfied by the
+// property of the given index for the given conditions to match.
+func findFieldConditions(conditions map[connor.FilterKey]any, propertyIndex int) map[string]any {
+	result := map[string]any{}
+	for key, clause := range conditions {
+		switch typedKey := key.(type) {
+		case *mapper.PropertyIndex:
+			if typedKey.Index != propertyIndex {
+				continue
+			}
+			operators, ok := clause.(map[connor.FilterKey]any)
+			if !ok {
+				continue
+			}
+			for opKey, value := range operators {
+				if op, ok := opKey.(*mapper.Operator); ok {
+					result[op.Operation] = value
+				}
+			}
+
+		case *mapper.Operator:
+			if typedKey.Operation != "_and" {
+				continue
+			}
+			innerClauses, ok := clause.([]any)
+			if !ok {
+				continue
+			}
+			for _, innerClause := range innerClauses {
+				innerConditions, ok := innerClause.(map[connor.FilterKey]any)
+				if !ok {
+					continue
+				}
+				for op, value := range findFieldConditions(innerConditions, propertyIndex) {
+					result[op] = value
+				}
+			}
+		}
+	}
+	return result
+}
+
+// newIndexScan attempts to build an index scan from the given field conditions.
+//
+// Returns false if none of the conditions can be served by the index.
+func newIndexScan(
+	index client.IndexDescription,
+	kind client.FieldKind,
+	conditions map[string]any,
+) (indexScan, bool) {
+	direction := index.Fields[0].Direction
+	scan := indexScan{index: index}
+
+	if value, ok := conditions["_eq"]; ok {
+		encoded, err := core.EncodeIndexFieldValue(kind, value, direction)
+		if err != nil {
+			return indexScan{}, false
+		}
+		scan.points = []string{encoded}
+		return scan, true
+	}
+
+	if values, ok := conditions["_in"].([]any); ok {
+		for _, value := range values {
+			encoded, err := core.EncodeIndexFieldValue(kind, value, direction)
+			if err != nil {
+				return indexScan{}, false
+			}
+			scan.points = append(scan.points, encoded)
+		}
+		if len(scan.points) == 0 {
+			// Nothing can match an empty set, however the index gives us no means
+			// to express that, so we fall back to a regular scan.
+			return indexScan{}, false
+		}
+		return scan, true
+	}
+
+	// The exclusive operators are treated as inclusive, the filter will remove any
+	// documents that sit on the boundary.
+	var lower, upper immutable.Option[string]
+	for _, op := range []string{"_gt", "_ge"} {
+		if value, ok := conditions[op]; ok && value != nil {
+			encoded, err := core.EncodeIndexFieldValue(kind, value, direction)
+			if err == nil {
+				lower = immutable.Some(encoded)
+			}
+		}
+	}
+	for _, op := range []string{"_lt", "_le"} {
+		if value, ok := conditions[op]; ok && value != nil {
+			encoded, err := core.EncodeIndexFieldValue(kind, value, direction)
+			if err == nil {
+				upper = immutable.Some(encoded)
+			}
+		}
+	}
+	if !lower.HasValue() && !upper.HasValue() {
+		return indexScan{}, false
+	}
+
+	if direction == client.Descending {
+		// Descending indexes store the largest values first.
+		lower, upper = upper, lower
+	}
+	scan.lowerBound = lower
+	scan.upperBound = upper
+
+	return scan, true
+}
+
+// spansFromIndex reads the candidate documents from the index of the scan, returning
+// a span for each of them.
+//
+// The spans are returned in ascending order so that documents are yielded in the
+// same order as a full collection scan would yield them.
+func (n *scanNode) spansFromIndex() (core.Spans, error) {
+	return n.p.spansFromIndexScan(n.desc, n.indexScan.Value())
+}
+
+// spansFromIndexScan reads the candidate documents of the given collection from the index
+// of the given scan, returning a span for each of them in ascending order.
+func (p *Planner) spansFromIndexScan(desc client.CollectionDescription, scan indexScan) (core.Spans, error) {
+	indexPrefix := core.IndexDataStoreKey{
+		CollectionID: desc.ID,
+		IndexID:      scan.index.ID,
+	}
+
+	docKeys := map[string]struct{}{}
+	if len(scan.points) > 0 {
+		for _, point := range scan.points {
+			prefix := indexPrefix
+			prefix.FieldValues = []string{point}
+			err := p.collectIndexedDocKeys(prefix, docKeys)
+			if err != nil {
+				return core.Spans{}, err
+			}
+		}
+	} else {
+		start, end := indexRangeKeys(indexPrefix, scan)
+		err := p.collectIndexedDocKeysInRange(start, end, docKeys)
+		if err != nil {
+			return core.Spans{}, err
+		}
+	}
+
+	sortedDocKeys := make([]string, 0, len(docKeys))
+	for docKey := range docKeys {
+		sortedDocKeys = append(sortedDocKeys, docKey)
+	}
+	sort.Strings(sortedDocKeys)
+
+	return docKeySpans(desc, sortedDocKeys), nil
+}
+
+// docKeySpans returns a span for each of the given (sorted) document keys.
+func docKeySpans(desc client.CollectionDescription, docKeys []string) core.Spans {
+	spans := make([]core.Span, len(docKeys))
+	for i, docKey := range docKeys {
+		dockeyIndexKey := base.MakeDocKey(desc, docKey)
+		spans[i] = core.NewSpan(dockeyIndexKey, dockeyIndexKey.PrefixEnd())
+	}
+	return core.NewSpans(spans...)
+}
+
+// indexRangeKeys returns the (inclusive) keys between which the index entries whose first
+// field value sits within the bounds of the given scan are stored.
+//
+// The encoded field values are never a prefix of one another, so the entries of the upper
+// bound value all sort before the key directly following its value segment.
+func indexRangeKeys(indexPrefix core.IndexDataStoreKey, scan indexScan) (ds.Key, ds.Key) {
+	start := indexPrefix
+	if scan.lowerBound.HasValue() {
+		start.FieldValues = []string{scan.lowerBound.Value()}
+	}
+
+	end := indexPrefix
+	if scan.upperBound.HasValue() {
+		end.FieldValues = []string{scan.upperBound.Value()}
+	}
+	// '0' is the character directly following the '/' key separator.
+	return start.ToDS(), ds.RawKey(end.ToString() + "0")
+}
+
+// collectIndexedDocKeysInRange adds the document keys of the index entries stored between
+// the given (inclusive) keys to the given set, seeking directly to the start of the range.
+func (p *Planner) collectIndexedDocKeysInRange(start ds.Key, end ds.Key, docKeys map[string]struct{}) error {
+	iterator, err := p.txn.Datastore().GetIterator(query.Query{})
+	if err != nil {
+		return err
+	}
+
+	results, err := iterator.IteratePrefix(p.ctx, start, end)
+	if err != nil {
+		_ = iterator.Close()
+		return err
+	}
+
+	err = addIndexedDocKeys(results, docKeys)
+	if err != nil {
+		_ = iterator.Close()
+		return err
+	}
+	return iterator.Close()
+}
+
+// collectIndexedDocKeys adds the document keys of the index entries stored under the given
+// prefix, including the unique entry stored at the prefix itself, to the given set.
+func (p *Planner) collectIndexedDocKeys(prefix core.IndexDataStoreKey, docKeys map[string]struct{}) error {
+	value, err := p.txn.Datastore().Get(p.ctx, prefix.ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if len(value) > 0 {
+		docKeys[string(value)] = struct{}{}
+	}
+
+	results, err := p.txn.Datastore().Query(p.ctx, query.Query{Prefix: prefix.ToString()})
+	if err != nil {
+		return err
+	}
+	return addIndexedDocKeys(results, docKeys)
+}
+
+// addIndexedDocKeys adds the document keys of the given index entries to the given set,
+// closing the results once read.
+func addIndexedDocKeys(results query.Results, docKeys map[string]struct{}) error {
+	for res := range results.Next() {
+		if res.Error != nil {
+			_ = results.Close()
+			return res.Error
+		}
+
+		// Unique entries hold the document key as their value instead of within their key.
+		if len(res.Value) > 0 {
+			docKeys[string(res.Value)] = struct{}{}
+			continue
+		}
+
+		key, err := core.NewIndexDataStoreKey(res.Key)
+		if err != nil {
+			_ = results.Close()
+			return err
+		}
+		docKeys[key.DocKey] = struct{}{}
+	}
+
+	return results.Close()
+}
@@ -17,7 +17,6 @@ import (
 )
 
 // Limit the results, yielding only what the limit/offset permits
-// @todo: Handle cursor
 type limitNode struct {
 	docMapper
 
@@ -12,8 +12,23 @@ package mapper
 
 import "github.com/sourcenetwork/defradb/errors"
 
+const (
+	errOrderFieldNotFound        string = "unable to find the field to order by"
+	errOrderAggregateNotSelected string = "aggregates must be selected in order to order by them"
+)
+
 var (
-	ErrUnableToIdAggregateChild = errors.New("unable to identify aggregate child")
-	ErrAggregateTargetMissing   = errors.New("aggregate must be provided with a property to aggregate")
-	ErrFailedToFindHostField    = errors.New("failed to find host field")
+	ErrUnableToIdAggregateChild  = errors.New("unable to identify aggregate child")
+	ErrAggregateTargetMissing    = errors.New("aggregate must be provided with a property to aggregate")
+	ErrFailedToFindHostField     = errors.New("failed to find host field")
+	ErrOrderFieldNotFound        = errors.New(errOrderFieldNotFound)
+	ErrOrderAggregateNotSelected = errors.New(errOrderAggregateNotSelected)
 )
+
+func NewErrOrderFieldNotFound(field string) error {
+	return errors.New(errOrderFieldNotFound, errors.NewKV("Field", field))
+}
+
+func NewErrOrderAggregateNotSelected(aggregate string) error {
+	return errors.New(errOrderAggregateNotSelected, errors.NewKV("Aggregate", aggregate))
+}
@@ -32,7 +32,27 @@ import (
 func ToSelect(ctx context.Context, txn datastore.Txn, selectRequest *request.Select) (*Select, error) {
 	descriptionsRepo := NewDescriptionsRepo(ctx, txn)
 	// the top-level select will always have index=0, and no parent collection name
-	return toSelect(descriptionsRepo, 0, selectRequest, "")
+	selection, err := toSelect(descriptionsRepo, 0, selectRequest, "")
+	if err != nil {
+		return nil, err
+	}
+	if selection.AsOf.HasValue() {
+		setChildAsOf(selection.Fields, selection.AsOf)
+	}
+	return selection, nil
+}
+
+// setChildAsOf sets the given point in history on all the child selects within the given
+// fields, so that related documents and aggregates are read as of the same point as their host.
+func setChildAsOf(fields []Requestable, asOf immutable.Option[request.AsOf]) {
+	for _, field := range fields {
+		childSelect, ok := field.AsSelect()
+		if !ok {
+			continue
+		}
+		childSelect.AsOf = asOf
+		setChildAsOf(childSelect.Fields, asOf)
+	}
 }
 
 // toSelect converts the given [parser.Select] into a [Select].
@@ -70,7 +90,7 @@ func toSelect(
 
 	// Resolve order dependencies that may have been missed due to not being rendered.
 	if err := resolveOrderDependencies(
-		descriptionsRepo, collectionName, selectRequest.OrderBy, mapping, &fields); err != nil {
+		descriptionsRepo, collectionName, selectRequest, mapping, &fields, &aggregates); err != nil {
 		return nil, err
 	}
 
@@ -95,10 +115,16 @@ func toSelect(
 		}
 	}
 
+	targetable, err := toTargetable(thisIndex, selectRequest, mapping)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Select{
-		Targetable:      toTargetable(thisIndex, selectRequest, mapping),
+		Targetable:      targetable,
 		DocumentMapping: *mapping,
 		Cid:             selectRequest.CID,
+		AsOf:            selectRequest.AsOf,
 		CollectionName:  collectionName,
 		Fields:          fields,
 	}, nil
@@ -109,10 +135,12 @@ func toSelect(
 func resolveOrderDependencies(
 	descriptionsRepo *DescriptionsRepo,
 	descName string,
-	source immutable.Option[request.OrderBy],
+	selectRequest *request.Select,
 	mapping *core.DocumentMapping,
 	existingFields *[]Requestable,
+	existingAggregates *[]*aggregateRequest,
 ) error {
+	source := selectRequest.OrderBy
 	if !source.HasValue() {
 		return nil
 	}
@@ -120,6 +148,15 @@ func resolveOrderDependencies(
 	// If there is orderby, and any one of the condition fields that are join fields and have not been
 	// requested, we need to map them here.
 	for _, condition := range source.Value().Conditions {
+		if len(condition.Fields) == 1 {
+			if _, isAggregate := request.Aggregates[condition.Fields[0]]; isAggregate {
+				err := resolveOrderAggregate(selectRequest, condition.Fields[0], mapping, existingAggregates)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		if len(condition.Fields) <= 1 {
 			continue
 		}
@@ -149,6 +186,46 @@ func resolveOrderDependencies(
 	return nil
 }
 
+// resolveOrderAggregate maps the aggregate with the given name if it is used to order the results
+// but has not been requested.
+//
+// Only the count of grouped documents may be ordered by without being requested, as it is the only
+// aggregate with an unambiguous target.
+func resolveOrderAggregate(
+	selectRequest *request.Select,
+	name string,
+	mapping *core.DocumentMapping,
+	existingAggregates *[]*aggregateRequest,
+) error {
+	if len(mapping.IndexesByName[name]) != 0 {
+		return nil
+	}
+
+	if name != request.CountFieldName || !selectRequest.GroupBy.HasValue() {
+		return NewErrOrderAggregateNotSelected(name)
+	}
+
+	index := mapping.GetNextIndex()
+	aggregate, err := getAggregateRequests(index, &request.Aggregate{
+		Field: request.Field{
+			Name: name,
+		},
+		Targets: []*request.AggregateTarget{
+			{
+				HostName: request.GroupFieldName,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The aggregate is not given a render key, so it will not be returned to the consumer.
+	*existingAggregates = append(*existingAggregates, &aggregate)
+	mapping.Add(index, name)
+	return nil
+}
+
 // resolveAggregates figures out which fields the given aggregates are targeting
 // and converts the aggregateRequest into an Aggregate, appending it onto the given
 // fields slice.
@@ -213,11 +290,15 @@ func resolveAggregates(
 					childObjectIndex := mapping.FirstIndexOfName(target.hostExternalName)
 					childMapping := mapping.ChildMappings[childObjectIndex]
 					convertedFilter = ToFilter(target.filter, childMapping)
+					order, err := toOrderBy(target.order, childMapping)
+					if err != nil {
+						return nil, err
+					}
 					host, hasHost = tryGetTarget(
 						target.hostExternalName,
 						convertedFilter,
 						target.limit,
-						toOrderBy(target.order, childMapping),
+						order,
 						fields,
 					)
 				}
@@ -260,6 +341,11 @@ func resolveAggregates(
 					convertedFilter = ToFilter(target.filter, mapping.ChildMappings[index])
 				}
 
+				order, err := toOrderBy(target.order, childMapping)
+				if err != nil {
+					return nil, err
+				}
+
 				dummyJoin := &Select{
 					Targetable: Targetable{
 						Field: Field{
@@ -268,7 +354,7 @@ func resolveAggregates(
 						},
 						Filter:  convertedFilter,
 						Limit:   target.limit,
-						OrderBy: toOrderBy(target.order, childMapping),
+						OrderBy: order,
 					},
 					CollectionName:  childCollectionName,
 					DocumentMapping: *childMapping,
@@ -507,8 +593,43 @@ func getRequestables(
 				Key:   getRenderKey(f),
 			})
 		case *request.Select:
+			if fieldDesc, isField := desc.GetField(f.Name); isField && fieldDesc.IsEmbeddedObject() {
+				// Embedded objects are fetched along with the rest of the document, and
+				// so are already mapped.
+				index := mapping.FirstIndexOfName(f.Name)
+				fields = append(fields, &Field{
+					Index: index,
+					Name:  f.Name,
+				})
+				appendEmbeddedRenderKeys(f, mapping.ChildMappings[index])
+
+				mapping.RenderKeys = append(mapping.RenderKeys, core.RenderKey{
+					Index: index,
+					Key:   getRenderKey(&f.Field),
+				})
+				continue
+			}
+
 			index := mapping.GetNextIndex()
 
+			if f.Name == request.PageInfoFieldName {
+				// The page info is not a related object, it is an object value set on
+				// each document of the page.
+				fields = append(fields, &Field{
+					Index: index,
+					Name:  f.Name,
+				})
+				mapping.SetChildAt(index, toPageInfoMapping(f))
+
+				mapping.RenderKeys = append(mapping.RenderKeys, core.RenderKey{
+					Index: index,
+					Key:   getRenderKey(&f.Field),
+				})
+
+				mapping.Add(index, f.Name)
+				continue
+			}
+
 			innerSelect, err := toSelect(descriptionsRepo, index, f, desc.Name)
 			if err != nil {
 				return nil, nil, err
@@ -544,6 +665,46 @@ func getRequestables(
 	return
 }
 
+// toPageInfoMapping returns the document mapping of the given page info selection.
+func toPageInfoMapping(pageInfoRequest *request.Select) *core.DocumentMapping {
+	mapping := core.NewDocumentMapping()
+	for i, f := range request.PageInfoFields {
+		mapping.Add(i, f)
+	}
+
+	// Setting the type name must be done after adding the fields, as
+	// the typeName index is dynamic, but the field indexes are not
+	mapping.SetTypeName(request.PageInfoTypeName)
+
+	for _, field := range pageInfoRequest.Fields {
+		f, isField := field.(*request.Field)
+		if !isField {
+			continue
+		}
+		mapping.RenderKeys = append(mapping.RenderKeys, core.RenderKey{
+			Index: mapping.FirstIndexOfName(f.Name),
+			Key:   getRenderKey(f),
+		})
+	}
+
+	return mapping
+}
+
+// appendEmbeddedRenderKeys appends the render keys of the fields requested by the given
+// embedded object selection to the given embedded object mapping.
+func appendEmbeddedRenderKeys(embeddedRequest *request.Select, mapping *core.DocumentMapping) {
+	for _, field := range embeddedRequest.Fields {
+		f, isField := field.(*request.Field)
+		if !isField || len(mapping.IndexesByName[f.Name]) == 0 {
+			continue
+		}
+		mapping.RenderKeys = append(mapping.RenderKeys, core.RenderKey{
+			Index: mapping.FirstIndexOfName(f.Name),
+			Key:   getRenderKey(f),
+		})
+	}
+}
+
 func getRenderKey(field *request.Field) string {
 	if field.Alias.HasValue() {
 		return field.Alias.Value()
@@ -634,7 +795,15 @@ func getTopLevelInfo(
 				// have to be requested via selects.
 				continue
 			}
+			if strings.Contains(f.Name, client.EmbeddedFieldSeparator) {
+				// The values of embedded objects are mapped by the embedded object's
+				// child mapping.
+				continue
+			}
 			mapping.Add(int(f.ID), f.Name)
+			if f.IsEmbeddedObject() {
+				mapping.SetChildAt(int(f.ID), toEmbeddedMapping(&desc, f))
+			}
 		}
 
 		// Setting the type name must be done after adding the fields, as
@@ -642,6 +811,7 @@ func getTopLevelInfo(
 		mapping.SetTypeName(collectionName)
 
 		mapping.Add(mapping.GetNextIndex(), request.DeletedFieldName)
+		mapping.Add(mapping.GetNextIndex(), request.CursorFieldName)
 
 		return mapping, &desc, nil
 	}
@@ -667,6 +837,21 @@ func getTopLevelInfo(
 	return mapping, &client.CollectionDescription{}, nil
 }
 
+// toEmbeddedMapping returns the document mapping of the values within the given embedded
+// object field.
+func toEmbeddedMapping(desc *client.CollectionDescription, field client.FieldDescription) *core.DocumentMapping {
+	mapping := core.NewDocumentMapping()
+	for i, f := range desc.GetEmbeddedFields(field.Name) {
+		mapping.Add(i, strings.TrimPrefix(f.Name, field.Name+client.EmbeddedFieldSeparator))
+	}
+
+	// Setting the type name must be done after adding the fields, as
+	// the typeName index is dynamic, but the field indexes are not
+	mapping.SetTypeName(field.Schema)
+
+	return mapping
+}
+
 func resolveFilterDependencies(
 	descriptionsRepo *DescriptionsRepo,
 	parentCollectionName string,
@@ -698,6 +883,24 @@ func resolveInnerFilterDependencies(
 
 	for key := range source {
 		if strings.HasPrefix(key, "_") && key != request.KeyFieldName {
+			notFilter, isNotFilter := source[key].(map[string]any)
+			if key != "_not" || !isNotFilter {
+				continue
+			}
+
+			// The conditions within `_not` apply to the same object as the `_not` itself, and
+			// may depend on related objects.
+			notFields, err := resolveInnerFilterDependencies(
+				descriptionsRepo,
+				parentCollectionName,
+				notFilter,
+				mapping,
+				append(append([]Requestable{}, existingFields...), newFields...),
+			)
+			if err != nil {
+				return nil, err
+			}
+			newFields = append(newFields, notFields...)
 			continue
 		}
 
@@ -754,6 +957,12 @@ func resolveInnerFilterDependencies(
 			continue
 		}
 
+		if isEmbeddedObjectField(descriptionsRepo, parentCollectionName, key) {
+			// The values of embedded objects are fetched along with the rest of the document
+			// and there will be no inner dependencies to add.
+			continue
+		}
+
 		childSource := source[key]
 		childFilter, isChildFilter := childSource.(map[string]any)
 		if !isChildFilter {
@@ -817,6 +1026,19 @@ func resolveInnerFilterDependencies(
 	return newFields, nil
 }
 
+// isEmbeddedObjectField returns true if the field of the given name on the collection of the
+// given name is an embedded object.
+//
+// Returns false if there is no such collection, as is the case for commits.
+func isEmbeddedObjectField(descriptionsRepo *DescriptionsRepo, collectionName string, name string) bool {
+	desc, err := descriptionsRepo.getCollectionDesc(collectionName)
+	if err != nil {
+		return false
+	}
+	fieldDesc, isField := desc.GetField(name)
+	return isField && fieldDesc.IsEmbeddedObject()
+}
+
 // ToCommitSelect converts the given [request.CommitSelect] into a [CommitSelect].
 //
 // In the process of doing so it will construct the document map required to access the data
@@ -850,22 +1072,30 @@ func ToMutation(ctx context.Context, txn datastore.Txn, mutationRequest *request
 	}
 
 	return &Mutation{
-		Select: *underlyingSelect,
-		Type:   MutationType(mutationRequest.Type),
-		Data:   mutationRequest.Data,
+		Select:     *underlyingSelect,
+		Type:       MutationType(mutationRequest.Type),
+		Data:       mutationRequest.Data,
+		UpdateData: mutationRequest.UpdateData,
+		Version:    mutationRequest.Version,
 	}, nil
 }
 
-func toTargetable(index int, selectRequest *request.Select, docMap *core.DocumentMapping) Targetable {
+func toTargetable(index int, selectRequest *request.Select, docMap *core.DocumentMapping) (Targetable, error) {
+	orderBy, err := toOrderBy(selectRequest.OrderBy, docMap)
+	if err != nil {
+		return Targetable{}, err
+	}
+
 	return Targetable{
 		Field:       toField(index, selectRequest),
 		DocKeys:     selectRequest.DocKeys,
 		Filter:      ToFilter(selectRequest.Filter, docMap),
 		Limit:       toLimit(selectRequest.Limit, selectRequest.Offset),
+		Page:        toPage(selectRequest),
 		GroupBy:     toGroupBy(selectRequest.GroupBy, docMap),
-		OrderBy:     toOrderBy(selectRequest.OrderBy, docMap),
+		OrderBy:     orderBy,
 		ShowDeleted: selectRequest.ShowDeleted,
-	}
+	}, nil
 }
 
 func toField(index int, selectRequest *request.Select) Field {
@@ -928,8 +1158,16 @@ func toFilterMap(
 				returnClauses = append(returnClauses, returnClause)
 			}
 			return key, returnClauses
+		case map[string]any:
+			// If the clause is a map (e.g. `_not`) then we need to convert its inner keys.
+			returnClause := map[connor.FilterKey]any{}
+			for innerSourceKey, innerSourceValue := range typedClause {
+				rKey, rValue := toFilterMap(innerSourceKey, innerSourceValue, mapping)
+				returnClause[rKey] = rValue
+			}
+			return key, returnClause
 		default:
-			return key, typedClause
+			return key, toOperatorClause(sourceKey, typedClause)
 		}
 	} else {
 		// If there are multiple properties of the same name we can just take the first as
@@ -944,15 +1182,23 @@ func toFilterMap(
 		case map[string]any:
 			returnClause := map[connor.FilterKey]any{}
 			for innerSourceKey, innerSourceValue := range typedClause {
-				var innerMapping *core.DocumentMapping
-				switch innerSourceValue.(type) {
-				case map[string]any:
-					// If the innerSourceValue is also a map, then we should parse the nested clause
-					// using the child mapping, as this key must refer to a host property in a join
-					// and deeper keys must refer to properties on the child items.
+				if !strings.HasPrefix(innerSourceKey, "_") &&
+					(index >= len(mapping.ChildMappings) || mapping.ChildMappings[index] == nil) {
+					// If the key is not an operator and does not refer to a related object, then
+					// it must refer to a property within an object value (e.g. JSON).
+					rKey, rValue := toObjectFilterMap(innerSourceKey, innerSourceValue)
+					returnClause[rKey] = rValue
+					continue
+				}
+				innerMapping := mapping
+				if _, isMap := innerSourceValue.(map[string]any); isMap &&
+					index < len(mapping.ChildMappings) && mapping.ChildMappings[index] != nil {
+					// If the innerSourceValue is also a map, and the key refers to a host property
+					// in a join, then we should parse the nested clause using the child mapping as
+					// deeper keys must refer to properties on the child items.
+					//
+					// Otherwise the map holds the operators of an inline array (e.g. `_any`).
 					innerMapping = mapping.ChildMappings[index]
-				default:
-					innerMapping = mapping
 				}
 				rKey, rValue := toFilterMap(innerSourceKey, innerSourceValue, innerMapping)
 				returnClause[rKey] = rValue
@@ -964,6 +1210,73 @@ func toFilterMap(
 	}
 }
 
+// toObjectFilterMap converts a consumer-defined filter key-value targeting the contents
+// of an object value (e.g. JSON) into a filter clause.
+//
+// Return key will either be an object property, or an operator.
+func toObjectFilterMap(sourceKey string, sourceClause any) (connor.FilterKey, any) {
+	if !strings.HasPrefix(sourceKey, "_") {
+		key := &connor.ObjectProperty{
+			Name: sourceKey,
+		}
+		if typedClause, isMap := sourceClause.(map[string]any); isMap {
+			return key, toObjectFilterConditions(typedClause)
+		}
+		return key, sourceClause
+	}
+
+	key := &Operator{
+		Operation: sourceKey,
+	}
+	switch sourceKey {
+	case "_and", "_or", "_not", "_any", "_all", "_none":
+		// The clauses of these operators hold further conditions, whereas the clauses
+		// of all other operators are values that must be left untouched.
+	default:
+		return key, toOperatorClause(sourceKey, sourceClause)
+	}
+
+	switch typedClause := sourceClause.(type) {
+	case []any:
+		returnClauses := make([]any, len(typedClause))
+		for i, innerSourceClause := range typedClause {
+			if innerMap, isMap := innerSourceClause.(map[string]any); isMap {
+				returnClauses[i] = toObjectFilterConditions(innerMap)
+			} else {
+				returnClauses[i] = innerSourceClause
+			}
+		}
+		return key, returnClauses
+	case map[string]any:
+		return key, toObjectFilterConditions(typedClause)
+	default:
+		return key, typedClause
+	}
+}
+
+// toOperatorClause converts the value given to the given operator into the form in which
+// it is matched against documents.
+//
+// Regular expressions are compiled here, once per request, instead of once per document.
+// Invalid expressions are left as they are so that they are reported when matched.
+func toOperatorClause(operator string, clause any) any {
+	if pattern, isString := clause.(string); isString && operator == "_regex" {
+		if re, err := connor.CompileRegex(pattern); err == nil {
+			return re
+		}
+	}
+	return clause
+}
+
+func toObjectFilterConditions(source map[string]any) map[connor.FilterKey]any {
+	conditions := make(map[connor.FilterKey]any, len(source))
+	for sourceKey, sourceClause := range source {
+		key, clause := toObjectFilterMap(sourceKey, sourceClause)
+		conditions[key] = clause
+	}
+	return conditions
+}
+
 func toLimit(limit immutable.Option[uint64], offset immutable.Option[uint64]) *Limit {
 	var limitValue uint64
 	var offsetValue uint64
@@ -985,6 +1298,41 @@ func toLimit(limit immutable.Option[uint64], offset immutable.Option[uint64]) *L
 	}
 }
 
+// toPage returns the cursor pagination of the given select request.
+//
+// A page is returned if any pagination argument was given, or if a cursor or the page info
+// was requested, as these are only provided by pages. Nil is returned otherwise.
+func toPage(selectRequest *request.Select) *Page {
+	hasPageArgs := selectRequest.First.HasValue() || selectRequest.Last.HasValue() ||
+		selectRequest.After.HasValue() || selectRequest.Before.HasValue()
+	if !hasPageArgs && !requestsPageFields(selectRequest.Fields) {
+		return nil
+	}
+
+	return &Page{
+		First:  selectRequest.First,
+		Last:   selectRequest.Last,
+		After:  selectRequest.After,
+		Before: selectRequest.Before,
+	}
+}
+
+func requestsPageFields(fields []request.Selection) bool {
+	for _, field := range fields {
+		switch f := field.(type) {
+		case *request.Field:
+			if f.Name == request.CursorFieldName {
+				return true
+			}
+		case *request.Select:
+			if f.Name == request.PageInfoFieldName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func toGroupBy(source immutable.Option[request.GroupBy], mapping *core.DocumentMapping) *GroupBy {
 	if !source.HasValue() {
 		return nil
@@ -1009,9 +1357,9 @@ func toGroupBy(source immutable.Option[request.GroupBy], mapping *core.DocumentM
 	}
 }
 
-func toOrderBy(source immutable.Option[request.OrderBy], mapping *core.DocumentMapping) *OrderBy {
+func toOrderBy(source immutable.Option[request.OrderBy], mapping *core.DocumentMapping) (*OrderBy, error) {
 	if !source.HasValue() {
-		return nil
+		return nil, nil
 	}
 
 	conditions := make([]OrderCondition, len(source.Value().Conditions))
@@ -1019,6 +1367,10 @@ func toOrderBy(source immutable.Option[request.OrderBy], mapping *core.DocumentM
 		fieldIndexes := make([]int, len(condition.Fields))
 		currentMapping := mapping
 		for fieldIndex, field := range condition.Fields {
+			if currentMapping == nil || len(currentMapping.IndexesByName[field]) == 0 {
+				return nil, NewErrOrderFieldNotFound(strings.Join(condition.Fields, "."))
+			}
+
 			// If there are multiple properties of the same name we can just take the first as
 			// we have no other reasonable way of identifying which property they mean if multiple
 			// consumer specified requestables are available.  Aggregate dependencies should not
@@ -1027,6 +1379,9 @@ func toOrderBy(source immutable.Option[request.OrderBy], mapping *core.DocumentM
 			fieldIndexes[fieldIndex] = firstFieldIndex
 			if fieldIndex != len(condition.Fields)-1 {
 				// no need to do this for the last (and will panic)
+				if firstFieldIndex >= len(currentMapping.ChildMappings) {
+					return nil, NewErrOrderFieldNotFound(strings.Join(condition.Fields, "."))
+				}
 				currentMapping = currentMapping.ChildMappings[firstFieldIndex]
 			}
 		}
@@ -1034,12 +1389,13 @@ func toOrderBy(source immutable.Option[request.OrderBy], mapping *core.DocumentM
 		conditions[conditionIndex] = OrderCondition{
 			FieldIndexes: fieldIndexes,
 			Direction:    SortDirection(condition.Direction),
+			Nulls:        NullsPlacement(condition.Nulls),
 		}
 	}
 
 	return &OrderBy{
 		Conditions: conditions,
-	}
+	}, nil
 }
 
 // RunFilter runs the given filter expression
@@ -1067,6 +1423,10 @@ func (s Targetable) equal(other Targetable) bool {
 		return false
 	}
 
+	if !s.Page.equal(other.Page) {
+		return false
+	}
+
 	if !s.OrderBy.equal(other.OrderBy) {
 		return false
 	}
@@ -1086,6 +1446,21 @@ func (l *Limit) equal(other *Limit) bool {
 	return l.Limit == other.Limit && l.Offset == other.Offset
 }
 
+func (p *Page) equal(other *Page) bool {
+	if p == nil {
+		return other == nil
+	}
+
+	if other == nil {
+		return p == nil
+	}
+
+	return p.First == other.First &&
+		p.Last == other.Last &&
+		p.After == other.After &&
+		p.Before == other.Before
+}
+
 func (f *Filter) equal(other *Filter) bool {
 	if f == nil {
 		return other == nil
@@ -1154,7 +1529,7 @@ func (o *OrderBy) equal(other *OrderBy) bool {
 
 	for i, conditionA := range o.Conditions {
 		conditionB := other.Conditions[i]
-		if conditionA.Direction != conditionB.Direction {
+		if conditionA.Direction != conditionB.Direction || conditionA.Nulls != conditionB.Nulls {
 			return false
 		}
 
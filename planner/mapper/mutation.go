@@ -17,6 +17,8 @@ const (
 	CreateObjects
 	UpdateObjects
 	DeleteObjects
+	UpsertObjects
+	RevertObjects
 )
 
 // Mutation represents a request to mutate data stored in Defra.
@@ -30,6 +32,12 @@ type Mutation struct {
 	// The data to be used for the mutation.  For example, during a create this
 	// will be the json representation of the object to be inserted.
 	Data string
+
+	// The patch applied by an upsert to the documents matching the filter.
+	UpdateData string
+
+	// The CID of the version a revert restores the document to.
+	Version string
 }
 
 func (m *Mutation) CloneTo(index int) Requestable {
@@ -38,8 +46,10 @@ func (m *Mutation) CloneTo(index int) Requestable {
 
 func (m *Mutation) cloneTo(index int) *Mutation {
 	return &Mutation{
-		Select: *m.Select.cloneTo(index),
-		Type:   m.Type,
-		Data:   m.Data,
+		Select:     *m.Select.cloneTo(index),
+		Type:       m.Type,
+		Data:       m.Data,
+		UpdateData: m.UpdateData,
+		Version:    m.Version,
 	}
 }
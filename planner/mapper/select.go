@@ -13,6 +13,7 @@ package mapper
 import (
 	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 )
 
@@ -30,6 +31,11 @@ type Select struct {
 	// A commit identifier that can be specified to request data at a given time.
 	Cid immutable.Option[string]
 
+	// The point in history at which the documents of this Select should be read.
+	//
+	// If set, each document will be yielded as it was at that point.
+	AsOf immutable.Option[request.AsOf]
+
 	// The name of the collection that this Select selects data from.
 	CollectionName string
 
@@ -57,6 +63,7 @@ func (s *Select) cloneTo(index int) *Select {
 		Targetable:      *s.Targetable.cloneTo(index),
 		DocumentMapping: s.DocumentMapping,
 		Cid:             s.Cid,
+		AsOf:            s.AsOf,
 		CollectionName:  s.CollectionName,
 		Fields:          s.Fields,
 	}
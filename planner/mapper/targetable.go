@@ -97,6 +97,22 @@ type Limit struct {
 	Offset uint64
 }
 
+// Page represents a Relay style cursor pagination that controls which page
+// of records will be returned from a request.
+type Page struct {
+	// The maximum number of records to return from the start of the page.
+	First immutable.Option[uint64]
+
+	// The maximum number of records to return from the end of the page.
+	Last immutable.Option[uint64]
+
+	// The cursor of the record after which records will be returned.
+	After immutable.Option[string]
+
+	// The cursor of the record before which records will be returned.
+	Before immutable.Option[string]
+}
+
 // GroupBy represents a grouping instruction on a request.
 type GroupBy struct {
 	// The indexes of fields by which documents should be grouped. Ordered.
@@ -110,6 +126,17 @@ const (
 	DESC SortDirection = "DESC"
 )
 
+// NullsPlacement determines where documents without a value to sort by should be placed.
+type NullsPlacement string
+
+const (
+	// NullsDefault places nil values first when sorting in ascending order, and last when
+	// sorting in descending order.
+	NullsDefault NullsPlacement = ""
+	NullsFirst   NullsPlacement = "FIRST"
+	NullsLast    NullsPlacement = "LAST"
+)
+
 // OrderCondition represents a single property by which request results should
 // be ordered, and the direction in which they should be ordered.
 type OrderCondition struct {
@@ -120,6 +147,9 @@ type OrderCondition struct {
 
 	// The direction in which the sort should be applied.
 	Direction SortDirection
+
+	// The placement of documents without a value to sort by.
+	Nulls NullsPlacement
 }
 
 type OrderBy struct {
@@ -143,6 +173,10 @@ type Targetable struct {
 	// of documents returned.
 	Limit *Limit
 
+	// An optional cursor pagination, that can be specified to restrict the number
+	// and location of documents returned.
+	Page *Page
+
 	// An optional grouping clause, that can be specified to group results by property
 	// value.
 	GroupBy *GroupBy
@@ -160,6 +194,7 @@ func (t *Targetable) cloneTo(index int) *Targetable {
 		DocKeys:     t.DocKeys,
 		Filter:      t.Filter,
 		Limit:       t.Limit,
+		Page:        t.Page,
 		GroupBy:     t.GroupBy,
 		OrderBy:     t.OrderBy,
 		ShowDeleted: t.ShowDeleted,
@@ -0,0 +1,374 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/sourcenetwork/immutable/enumerable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// minMaxNode yields the smallest (`_min`) or largest (`_max`) value of the targeted
+// fields of each document.
+type minMaxNode struct {
+	documentIterator
+	docMapper
+
+	plan planNode
+
+	isMax             bool
+	virtualFieldIndex int
+	aggregateMapping  []mapper.AggregateTarget
+	// isDateTime holds, for each aggregate target, whether the targeted field is a DateTime.
+	isDateTime []bool
+
+	execInfo minMaxExecInfo
+}
+
+type minMaxExecInfo struct {
+	// Total number of times minMaxNode was executed.
+	iterations uint64
+}
+
+func (p *Planner) Min(field *mapper.Aggregate, parent *mapper.Select) (*minMaxNode, error) {
+	return p.newMinMaxNode(field, parent, false)
+}
+
+func (p *Planner) Max(field *mapper.Aggregate, parent *mapper.Select) (*minMaxNode, error) {
+	return p.newMinMaxNode(field, parent, true)
+}
+
+func (p *Planner) newMinMaxNode(
+	field *mapper.Aggregate,
+	parent *mapper.Select,
+	isMax bool,
+) (*minMaxNode, error) {
+	isDateTime := make([]bool, len(field.AggregateTargets))
+	for i := range field.AggregateTargets {
+		var err error
+		isDateTime[i], err = p.isValueDateTime(parent, &field.AggregateTargets[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &minMaxNode{
+		isMax:             isMax,
+		aggregateMapping:  field.AggregateTargets,
+		isDateTime:        isDateTime,
+		virtualFieldIndex: field.Index,
+		docMapper:         docMapper{&field.DocumentMapping},
+	}, nil
+}
+
+// Returns true if the value to be aggregated is a DateTime, otherwise false.
+func (p *Planner) isValueDateTime(
+	parent *mapper.Select,
+	source *mapper.AggregateTarget,
+) (bool, error) {
+	if !source.ChildTarget.HasValue {
+		parentDescription, err := p.getCollectionDesc(parent.CollectionName)
+		if err != nil {
+			return false, err
+		}
+
+		fieldDescription, fieldDescriptionFound := parentDescription.GetField(source.Name)
+		if !fieldDescriptionFound {
+			return false, client.NewErrFieldNotExist(source.Name)
+		}
+		return fieldDescription.Kind == client.FieldKind_DATETIME, nil
+	}
+
+	if source.ChildTarget.Name == request.CountFieldName {
+		return false, nil
+	}
+
+	child, isChildSelect := parent.FieldAt(source.Index).AsSelect()
+	if !isChildSelect {
+		return false, ErrMissingChildSelect
+	}
+
+	if _, isAggregate := request.Aggregates[source.ChildTarget.Name]; isAggregate {
+		// Only the min or max of a DateTime may itself be a DateTime, so we need to
+		// traverse the aggregation chain down to the root field.
+		if source.ChildTarget.Name != request.MinFieldName && source.ChildTarget.Name != request.MaxFieldName {
+			return false, nil
+		}
+
+		sourceField := child.FieldAt(source.ChildTarget.Index).(*mapper.Aggregate)
+		for _, aggregateTarget := range sourceField.AggregateTargets {
+			isDateTime, err := p.isValueDateTime(child, &aggregateTarget)
+			if err != nil || !isDateTime {
+				return false, err
+			}
+		}
+		return len(sourceField.AggregateTargets) > 0, nil
+	}
+
+	childCollectionDescription, err := p.getCollectionDesc(child.CollectionName)
+	if err != nil {
+		return false, err
+	}
+
+	fieldDescription, fieldDescriptionFound := childCollectionDescription.GetField(source.ChildTarget.Name)
+	if !fieldDescriptionFound {
+		return false, client.NewErrFieldNotExist(source.ChildTarget.Name)
+	}
+	return fieldDescription.Kind == client.FieldKind_DATETIME, nil
+}
+
+func (n *minMaxNode) Kind() string {
+	if n.isMax {
+		return "maxNode"
+	}
+	return "minNode"
+}
+
+func (n *minMaxNode) Init() error {
+	return n.plan.Init()
+}
+
+func (n *minMaxNode) Start() error { return n.plan.Start() }
+
+func (n *minMaxNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+
+func (n *minMaxNode) Close() error { return n.plan.Close() }
+
+func (n *minMaxNode) Source() planNode { return n.plan }
+
+func (n *minMaxNode) SetPlan(p planNode) { n.plan = p }
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *minMaxNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return explainAggregateTargets(n.aggregateMapping), nil
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (n *minMaxNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	hasNext, err := n.plan.Next()
+	if err != nil || !hasNext {
+		return hasNext, err
+	}
+
+	n.currentValue = n.plan.Value()
+
+	var result, resultKey any
+	for i, source := range n.aggregateMapping {
+		child := n.currentValue.Fields[source.Index]
+		var values []any
+		var err error
+		switch childCollection := child.(type) {
+		case []core.Doc:
+			for _, childItem := range childCollection {
+				// Hidden items are a grouping mechanic and must not be aggregated.
+				if !childItem.Hidden {
+					values = append(values, childItem.Fields[source.ChildTarget.Index])
+				}
+			}
+
+		case []int64:
+			values, err = minMaxItems(childCollection, &source, lessN[int64])
+
+		case []immutable.Option[int64]:
+			values, err = minMaxItems(childCollection, &source, lessO[int64])
+
+		case []float64:
+			values, err = minMaxItems(childCollection, &source, lessN[float64])
+
+		case []immutable.Option[float64]:
+			values, err = minMaxItems(childCollection, &source, lessO[float64])
+
+		case []string:
+			values, err = minMaxItems(childCollection, &source, lessN[string])
+
+		case []immutable.Option[string]:
+			values, err = minMaxItems(childCollection, &source, lessO[string])
+		}
+		if err != nil {
+			return false, err
+		}
+
+		for _, value := range values {
+			result, resultKey = n.pick(result, resultKey, value, n.isDateTime[i])
+		}
+	}
+
+	n.currentValue.Fields[n.virtualFieldIndex] = result
+
+	return true, nil
+}
+
+// pick returns whichever of the current result and the given value should be
+// yielded by this node, along with the key by which it is compared.
+//
+// Nil values, and values that cannot be compared with the current result, are ignored.
+// If isDateTime is true the value is compared as an RFC3339 date time.
+func (n *minMaxNode) pick(result any, resultKey any, value any, isDateTime bool) (any, any) {
+	value = normalizeMinMaxValue(value)
+	key := value
+	if isDateTime {
+		key = toMinMaxDateTime(value)
+	}
+	if key == nil {
+		return result, resultKey
+	}
+	if resultKey == nil {
+		return value, key
+	}
+
+	comparison, isComparable := compareMinMaxValues(key, resultKey)
+	if !isComparable {
+		return result, resultKey
+	}
+	if (n.isMax && comparison > 0) || (!n.isMax && comparison < 0) {
+		return value, key
+	}
+	return result, resultKey
+}
+
+func minMaxItems[T any](
+	source []T,
+	aggregateTarget *mapper.AggregateTarget,
+	less func(T, T) bool,
+) ([]any, error) {
+	values := []any{}
+	err := enumerable.ForEach(targetItems(source, aggregateTarget, less), func(item T) {
+		values = append(values, item)
+	})
+	return values, err
+}
+
+// normalizeMinMaxValue converts the given value into a type supported by compareMinMaxValues,
+// returning nil if the value has no value or cannot be compared.
+func normalizeMinMaxValue(value any) any {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case int64, float64, string, time.Time:
+		return v
+	case immutable.Option[int64]:
+		return optionValue(v)
+	case immutable.Option[float64]:
+		return optionValue(v)
+	case immutable.Option[string]:
+		return optionValue(v)
+	default:
+		return nil
+	}
+}
+
+// toMinMaxDateTime converts the given value of a DateTime field into a time.Time, so that
+// it is compared chronologically, returning nil if the value is not a valid date time.
+func toMinMaxDateTime(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil
+		}
+		return t
+	default:
+		return nil
+	}
+}
+
+func optionValue[T any](option immutable.Option[T]) any {
+	if !option.HasValue() {
+		return nil
+	}
+	return option.Value()
+}
+
+// compareMinMaxValues returns a negative number if a is less than b, zero if they are equal,
+// and a positive number if a is greater than b.
+//
+// False is returned if the values cannot be compared with each other.
+func compareMinMaxValues(a any, b any) (int, bool) {
+	switch aValue := a.(type) {
+	case int64:
+		switch bValue := b.(type) {
+		case int64:
+			return compareOrdered(aValue, bValue), true
+		case float64:
+			return compareOrdered(float64(aValue), bValue), true
+		}
+
+	case float64:
+		switch bValue := b.(type) {
+		case int64:
+			return compareOrdered(aValue, float64(bValue)), true
+		case float64:
+			return compareOrdered(aValue, bValue), true
+		}
+
+	case string:
+		bValue, isString := b.(string)
+		if !isString {
+			return 0, false
+		}
+		return strings.Compare(aValue, bValue), true
+
+	case time.Time:
+		bValue, isTime := b.(time.Time)
+		if !isTime {
+			return 0, false
+		}
+		return compareTimes(aValue, bValue), true
+	}
+
+	return 0, false
+}
+
+func compareOrdered[T ordered](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareTimes(a time.Time, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -18,10 +18,13 @@ var (
 	_ planNode = (*deleteNode)(nil)
 	_ planNode = (*groupNode)(nil)
 	_ planNode = (*limitNode)(nil)
+	_ planNode = (*minMaxNode)(nil)
 	_ planNode = (*multiScanNode)(nil)
 	_ planNode = (*orderNode)(nil)
+	_ planNode = (*pageNode)(nil)
 	_ planNode = (*parallelNode)(nil)
 	_ planNode = (*pipeNode)(nil)
+	_ planNode = (*revertNode)(nil)
 	_ planNode = (*scanNode)(nil)
 	_ planNode = (*selectNode)(nil)
 	_ planNode = (*selectTopNode)(nil)
@@ -29,8 +32,10 @@ var (
 	_ planNode = (*topLevelNode)(nil)
 	_ planNode = (*typeIndexJoin)(nil)
 	_ planNode = (*typeJoinMany)(nil)
+	_ planNode = (*typeJoinManyToMany)(nil)
 	_ planNode = (*typeJoinOne)(nil)
 	_ planNode = (*updateNode)(nil)
+	_ planNode = (*upsertNode)(nil)
 	_ planNode = (*valuesNode)(nil)
 
 	_ MultiNode = (*parallelNode)(nil)
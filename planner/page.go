@@ -0,0 +1,314 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// cursor is the position of a document within the ordered results of a request.
+type cursor struct {
+	// The values of the properties the results are ordered by, in order of precedence.
+	OrderValues []any `cbor:"1,keyasint"`
+
+	// The key of the document, breaking ties between documents with equal order values.
+	DocKey string `cbor:"2,keyasint"`
+}
+
+// encodeCursor encodes the given cursor into the opaque string handed to consumers.
+func encodeCursor(c cursor) (string, error) {
+	buf, err := cbor.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// decodeCursor decodes the given consumer provided cursor, ensuring that it holds
+// a value for each of the given order conditions.
+func decodeCursor(value string, ordering []mapper.OrderCondition) (cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor{}, NewErrInvalidCursor(value)
+	}
+
+	var c cursor
+	if err := cbor.Unmarshal(buf, &c); err != nil {
+		return cursor{}, NewErrInvalidCursor(value)
+	}
+
+	if len(c.OrderValues) != len(ordering) {
+		return cursor{}, NewErrInvalidCursor(value)
+	}
+
+	return c, nil
+}
+
+// Paginates the results, yielding only the documents of the requested page
+// as per the Relay cursor connections specification.
+type pageNode struct {
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	first  immutable.Option[uint64]
+	last   immutable.Option[uint64]
+	after  immutable.Option[cursor]
+	before immutable.Option[cursor]
+
+	// The raw cursors as provided by the consumer, kept for explain purposes.
+	afterValue  immutable.Option[string]
+	beforeValue immutable.Option[string]
+
+	ordering []mapper.OrderCondition
+
+	docs       []core.Doc
+	docIndex   int
+	pageLoaded bool
+
+	hasNextPage     bool
+	hasPreviousPage bool
+
+	execInfo pageExecInfo
+}
+
+type pageExecInfo struct {
+	// Total number of times pageNode was executed.
+	iterations uint64
+}
+
+// Page creates a new pageNode initalized from the given mapper.Page object.
+func (p *Planner) Page(parsed *mapper.Select, n *mapper.Page) (*pageNode, error) {
+	if n == nil {
+		return nil, nil // nothing to do
+	}
+	if parsed.Limit != nil {
+		return nil, ErrCursorPaginationWithLimit
+	}
+
+	ordering := []mapper.OrderCondition{}
+	if parsed.OrderBy != nil {
+		ordering = parsed.OrderBy.Conditions
+	}
+
+	page := &pageNode{
+		p:           p,
+		first:       n.First,
+		last:        n.Last,
+		afterValue:  n.After,
+		beforeValue: n.Before,
+		ordering:    ordering,
+		docIndex:    -1,
+		docMapper:   docMapper{&parsed.DocumentMapping},
+	}
+
+	if n.After.HasValue() {
+		after, err := decodeCursor(n.After.Value(), ordering)
+		if err != nil {
+			return nil, err
+		}
+		page.after = immutable.Some(after)
+	}
+
+	if n.Before.HasValue() {
+		before, err := decodeCursor(n.Before.Value(), ordering)
+		if err != nil {
+			return nil, err
+		}
+		page.before = immutable.Some(before)
+	}
+
+	return page, nil
+}
+
+func (n *pageNode) Kind() string {
+	return "pageNode"
+}
+
+func (n *pageNode) Init() error {
+	n.docs = nil
+	n.docIndex = -1
+	n.pageLoaded = false
+	n.hasNextPage = false
+	n.hasPreviousPage = false
+	return n.plan.Init()
+}
+
+func (n *pageNode) Start() error           { return n.plan.Start() }
+func (n *pageNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+func (n *pageNode) Close() error           { return n.plan.Close() }
+func (n *pageNode) Value() core.Doc        { return n.docs[n.docIndex] }
+
+func (n *pageNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if !n.pageLoaded {
+		if err := n.loadPage(); err != nil {
+			return false, err
+		}
+	}
+
+	if n.docIndex >= len(n.docs)-1 {
+		return false, nil
+	}
+	n.docIndex++
+	return true, nil
+}
+
+// loadPage reads the documents of the page from the source plan, and sets their
+// cursor and page info fields.
+func (n *pageNode) loadPage() error {
+	n.pageLoaded = true
+
+	for {
+		hasNext, err := n.plan.Next()
+		if err != nil {
+			return err
+		}
+		if !hasNext {
+			break
+		}
+
+		doc := n.plan.Value()
+		if n.after.HasValue() && n.compareToCursor(doc, n.after.Value()) <= 0 {
+			continue
+		}
+		if n.before.HasValue() && n.compareToCursor(doc, n.before.Value()) >= 0 {
+			// Documents are yielded in cursor order, so no document following this one
+			// can be part of the page either.
+			break
+		}
+
+		if n.first.HasValue() && uint64(len(n.docs)) == n.first.Value() {
+			n.hasNextPage = true
+			break
+		}
+		n.docs = append(n.docs, doc)
+	}
+
+	if n.last.HasValue() && uint64(len(n.docs)) > n.last.Value() {
+		n.docs = n.docs[uint64(len(n.docs))-n.last.Value():]
+		n.hasPreviousPage = true
+	}
+
+	cursors := make([]string, len(n.docs))
+	for i, doc := range n.docs {
+		cursorValue, err := encodeCursor(n.cursorOf(doc))
+		if err != nil {
+			return err
+		}
+		cursors[i] = cursorValue
+
+		for _, index := range n.documentMapping.IndexesByName[request.CursorFieldName] {
+			doc.Fields[index] = cursorValue
+		}
+	}
+
+	for _, doc := range n.docs {
+		for _, index := range n.documentMapping.IndexesByName[request.PageInfoFieldName] {
+			doc.Fields[index] = n.pageInfo(n.documentMapping.ChildMappings[index], cursors)
+		}
+	}
+
+	return nil
+}
+
+// pageInfo returns the page info document of the loaded page, using the given mapping.
+func (n *pageNode) pageInfo(mapping *core.DocumentMapping, cursors []string) core.Doc {
+	pageInfo := mapping.NewDoc()
+	mapping.SetFirstOfName(&pageInfo, request.HasNextPageFieldName, n.hasNextPage)
+	mapping.SetFirstOfName(&pageInfo, request.HasPreviousPageFieldName, n.hasPreviousPage)
+	if len(cursors) > 0 {
+		mapping.SetFirstOfName(&pageInfo, request.StartCursorFieldName, cursors[0])
+		mapping.SetFirstOfName(&pageInfo, request.EndCursorFieldName, cursors[len(cursors)-1])
+	}
+	return pageInfo
+}
+
+// cursorOf returns the cursor of the given document.
+func (n *pageNode) cursorOf(doc core.Doc) cursor {
+	orderValues := make([]any, len(n.ordering))
+	for i, condition := range n.ordering {
+		orderValues[i] = getDocProp(doc, condition.FieldIndexes)
+	}
+	return cursor{
+		OrderValues: orderValues,
+		DocKey:      doc.GetKey(),
+	}
+}
+
+// compareToCursor returns a negative number if the given document comes before the
+// given cursor, zero if it is at the cursor, and a positive number if it comes after it.
+func (n *pageNode) compareToCursor(doc core.Doc, c cursor) int {
+	for i, condition := range n.ordering {
+		// Decoded cursor values may not be of the same type as the values held by documents,
+		// for example integers may be decoded as uint64 when the document holds int64, which
+		// the comparison allows for.
+		result := compareOrderValues(getDocProp(doc, condition.FieldIndexes), c.OrderValues[i], condition)
+		if result != 0 {
+			return result
+		}
+	}
+	// Documents with equal order values are yielded in key order.
+	return strings.Compare(doc.GetKey(), c.DocKey)
+}
+
+func (n *pageNode) Source() planNode { return n.plan }
+
+func (n *pageNode) simpleExplain() (map[string]any, error) {
+	simpleExplainMap := map[string]any{
+		firstLabel:  nil,
+		lastLabel:   nil,
+		afterLabel:  nil,
+		beforeLabel: nil,
+	}
+
+	if n.first.HasValue() {
+		simpleExplainMap[firstLabel] = n.first.Value()
+	}
+	if n.last.HasValue() {
+		simpleExplainMap[lastLabel] = n.last.Value()
+	}
+	if n.afterValue.HasValue() {
+		simpleExplainMap[afterLabel] = n.afterValue.Value()
+	}
+	if n.beforeValue.HasValue() {
+		simpleExplainMap[beforeLabel] = n.beforeValue.Value()
+	}
+
+	return simpleExplainMap, nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *pageNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
@@ -13,6 +13,8 @@ package planner
 import (
 	"context"
 
+	"github.com/sourcenetwork/immutable"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
@@ -160,6 +162,12 @@ func (p *Planner) newObjectMutationPlan(stmt *mapper.Mutation) (planNode, error)
 	case mapper.DeleteObjects:
 		return p.DeleteDocs(stmt)
 
+	case mapper.UpsertObjects:
+		return p.UpsertDocs(stmt)
+
+	case mapper.RevertObjects:
+		return p.RevertDoc(stmt)
+
 	default:
 		return nil, client.NewErrUnhandledType("mutation", stmt.Type)
 	}
@@ -238,6 +246,12 @@ func (p *Planner) expandPlan(planNode planNode, parentPlan *selectTopNode) error
 	case *createNode:
 		return p.expandPlan(n.results, parentPlan)
 
+	case *upsertNode:
+		return p.expandPlan(n.results, parentPlan)
+
+	case *revertNode:
+		return p.expandPlan(n.results, parentPlan)
+
 	case *deleteNode:
 		return p.expandPlan(n.source, parentPlan)
 
@@ -275,6 +289,12 @@ func (p *Planner) expandSelectTopNodePlan(plan *selectTopNode, parentPlan *selec
 		p.expandLimitPlan(plan, parentPlan)
 	}
 
+	if plan.page != nil {
+		if err := p.expandPagePlan(plan, parentPlan); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -308,6 +328,8 @@ func (p *Planner) expandTypeIndexJoinPlan(plan *typeIndexJoin, parentPlan *selec
 		return p.expandPlan(node.subType, parentPlan)
 	case *typeJoinMany:
 		return p.expandPlan(node.subType, parentPlan)
+	case *typeJoinManyToMany:
+		return p.expandPlan(node.subType, parentPlan)
 	}
 	return client.NewErrUnhandledType("join plan", plan.joinPlan)
 }
@@ -388,6 +410,45 @@ func (p *Planner) expandLimitPlan(topNodeSelect *selectTopNode, parentPlan *sele
 	topNodeSelect.planNode = topNodeSelect.limit
 }
 
+func (p *Planner) expandPagePlan(topNodeSelect *selectTopNode, parentPlan *selectTopNode) error {
+	if topNodeSelect.page == nil {
+		return nil
+	}
+
+	// Unlike limits, pages are not handled internally by groups
+	if parentPlan != nil && parentPlan.group != nil && len(parentPlan.group.childSelects) != 0 {
+		return ErrPageWithinGroup
+	}
+
+	// Unordered documents are scanned in key order, so the scan can seek directly to
+	// the documents between the cursors.
+	if topNodeSelect.order == nil && topNodeSelect.group == nil {
+		scan, hasScanNode := walkAndFindPlanType[*scanNode](topNodeSelect.selectNode)
+		if hasScanNode {
+			if topNodeSelect.page.after.HasValue() {
+				scan.seekAfter = immutable.Some(topNodeSelect.page.after.Value().DocKey)
+			}
+			if topNodeSelect.page.before.HasValue() {
+				scan.seekBefore = immutable.Some(topNodeSelect.page.before.Value().DocKey)
+			}
+		}
+	}
+
+	// Ordered documents are still sorted in full, however if the primary order field is
+	// indexed the scan can be narrowed down to the documents between the cursors.
+	if topNodeSelect.order != nil && topNodeSelect.group == nil {
+		scan, hasScanNode := walkAndFindPlanType[*scanNode](topNodeSelect.selectNode)
+		if hasScanNode && !scan.spans.HasValue && !scan.indexScan.HasValue() &&
+			!scan.showDeleted && !topNodeSelect.selectNode.selectReq.AsOf.HasValue() {
+			scan.indexScan = findPageIndexScan(scan.desc, topNodeSelect.page)
+		}
+	}
+
+	topNodeSelect.page.plan = topNodeSelect.planNode
+	topNodeSelect.planNode = topNodeSelect.page
+	return nil
+}
+
 // walkAndReplace walks through the provided plan, and searches for an instance
 // of the target plan, and replaces it with the replace plan
 func (p *Planner) walkAndReplacePlan(planNode, target, replace planNode) error {
@@ -411,6 +472,8 @@ func (p *Planner) walkAndReplacePlan(planNode, target, replace planNode) error {
 		node.root = replace
 	case *typeJoinMany:
 		node.root = replace
+	case *typeJoinManyToMany:
+		node.root = replace
 	case *pipeNode:
 		/* Do nothing - pipe nodes should not be replaced */
 	// @todo: add more nodes that apply here
@@ -0,0 +1,157 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// revertNode is used to construct and execute a document revert mutation.
+//
+// On the first iteration of the plan the document is reverted to the given
+// version, the reverted document is then returned.
+type revertNode struct {
+	documentIterator
+	docMapper
+
+	p *Planner
+
+	collection client.Collection
+
+	docKey  string
+	version string
+
+	isReverting bool
+
+	results planNode
+
+	execInfo revertExecInfo
+}
+
+type revertExecInfo struct {
+	// Total number of times revertNode was executed.
+	iterations uint64
+}
+
+func (n *revertNode) Kind() string { return "revertNode" }
+
+func (n *revertNode) Init() error { return nil }
+
+func (n *revertNode) Start() error { return nil }
+
+// Next reverts the document on the first call, then yields the reverted document.
+func (n *revertNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if n.isReverting {
+		key, err := client.NewDocKeyFromString(n.docKey)
+		if err != nil {
+			return false, err
+		}
+		version, err := cid.Decode(n.version)
+		if err != nil {
+			return false, err
+		}
+
+		err = n.collection.Revert(n.p.ctx, key, version)
+		if err != nil {
+			return false, err
+		}
+		n.isReverting = false
+
+		err = n.results.Init()
+		if err != nil {
+			return false, err
+		}
+
+		err = n.results.Start()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	next, err := n.results.Next()
+	if err != nil {
+		return false, err
+	}
+	if !next {
+		return false, nil
+	}
+
+	n.currentValue = n.results.Value()
+	return true, nil
+}
+
+func (n *revertNode) Spans(spans core.Spans) { /* no-op */ }
+
+func (n *revertNode) Close() error {
+	return n.results.Close()
+}
+
+func (n *revertNode) Source() planNode { return n.results }
+
+func (n *revertNode) simpleExplain() (map[string]any, error) {
+	return map[string]any{
+		dockeyLabel: n.docKey,
+		cidLabel:    n.version,
+	}, nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *revertNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (p *Planner) RevertDoc(parsed *mapper.Mutation) (planNode, error) {
+	if !parsed.DocKeys.HasValue() || len(parsed.DocKeys.Value()) != 1 {
+		return nil, ErrRevertWithoutDocKey
+	}
+	if parsed.Version == "" {
+		return nil, ErrRevertWithoutVersion
+	}
+
+	col, err := p.db.GetCollectionByName(p.ctx, parsed.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsNode, err := p.Select(&parsed.Select)
+	if err != nil {
+		return nil, err
+	}
+
+	return &revertNode{
+		p:           p,
+		collection:  col.WithTxn(p.txn),
+		docKey:      parsed.DocKeys.Value()[0],
+		version:     parsed.Version,
+		isReverting: true,
+		results:     resultsNode,
+		docMapper:   docMapper{&parsed.DocumentMapping},
+	}, nil
+}
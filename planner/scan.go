@@ -11,6 +11,8 @@
 package planner
 
 import (
+	"github.com/sourcenetwork/immutable"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
@@ -46,6 +48,16 @@ type scanNode struct {
 	spans   core.Spans
 	reverse bool
 
+	// indexScan, if set, is used to narrow down the documents to scan when no
+	// spans have been explicitly set.
+	indexScan immutable.Option[indexScan]
+
+	// seekAfter and seekBefore, if set, are the keys of the documents between which the
+	// documents to scan lie. They are used to seek directly to the documents of a page
+	// when no spans have been explicitly set.
+	seekAfter  immutable.Option[string]
+	seekBefore immutable.Option[string]
+
 	filter *mapper.Filter
 
 	scanInitialized bool
@@ -79,9 +91,16 @@ func (n *scanNode) Start() error {
 }
 
 func (n *scanNode) initScan() error {
+	if !n.spans.HasValue && n.indexScan.HasValue() {
+		spans, err := n.spansFromIndex()
+		if err != nil {
+			return err
+		}
+		n.spans = spans
+	}
+
 	if !n.spans.HasValue {
-		start := base.MakeCollectionKey(n.desc)
-		n.spans = core.NewSpans(core.NewSpan(start, start.PrefixEnd()))
+		n.spans = core.NewSpans(n.collectionSpan())
 	}
 
 	err := n.fetcher.Start(n.p.ctx, n.p.txn, n.spans)
@@ -93,6 +112,22 @@ func (n *scanNode) initScan() error {
 	return nil
 }
 
+// collectionSpan returns the span covering the documents of the collection, narrowed down
+// to the documents between seekAfter and seekBefore if they are set.
+func (n *scanNode) collectionSpan() core.Span {
+	start := base.MakeCollectionKey(n.desc)
+	end := start.PrefixEnd()
+
+	if n.seekAfter.HasValue() {
+		start = base.MakeDocKey(n.desc, n.seekAfter.Value()).PrefixEnd()
+	}
+	if n.seekBefore.HasValue() && (!n.seekAfter.HasValue() || n.seekAfter.Value() < n.seekBefore.Value()) {
+		end = base.MakeDocKey(n.desc, n.seekBefore.Value())
+	}
+
+	return core.NewSpan(start, end)
+}
+
 // Next gets the next result.
 // Returns true, if there is a result,
 // and false otherwise.
@@ -173,6 +208,11 @@ func (n *scanNode) simpleExplain() (map[string]any, error) {
 	// Add the spans attribute.
 	simpleExplainMap[spansLabel] = n.explainSpans()
 
+	// Add the index attribute if an index is used.
+	if n.indexScan.HasValue() {
+		simpleExplainMap[indexLabel] = n.indexScan.Value().index.Name
+	}
+
 	return simpleExplainMap, nil
 }
 
@@ -206,6 +246,8 @@ func (p *Planner) Scan(parsed *mapper.Select) *scanNode {
 	var f fetcher.Fetcher
 	if parsed.Cid.HasValue() {
 		f = new(fetcher.VersionedFetcher)
+	} else if parsed.AsOf.HasValue() {
+		f = fetcher.NewAsOfFetcher(parsed.AsOf.Value())
 	} else {
 		f = new(fetcher.DocumentFetcher)
 	}
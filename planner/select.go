@@ -51,6 +51,7 @@ type selectTopNode struct {
 	group      *groupNode
 	order      *orderNode
 	limit      *limitNode
+	page       *pageNode
 	aggregates []aggregateNode
 
 	// selectNode is used pre-wiring of the plan (before expansion and all).
@@ -275,6 +276,14 @@ func (n *selectNode) initSource() ([]aggregateNode, error) {
 				spans[i] = core.NewSpan(dockeyIndexKey, dockeyIndexKey.PrefixEnd())
 			}
 			origScan.Spans(core.NewSpans(spans...))
+		} else if !n.selectReq.ShowDeleted && !n.selectReq.AsOf.HasValue() {
+			// Secondary indexes only hold entries for documents that have not been deleted,
+			// and only reflect their current state.
+			origScan.indexScan = findIndexScan(
+				sourcePlan.info.collectionDescription,
+				origScan.filter,
+				&n.selectReq.DocumentMapping,
+			)
 		}
 	}
 
@@ -298,6 +307,10 @@ func (n *selectNode) initFields(selectReq *mapper.Select) ([]aggregateNode, erro
 				plan, aggregateError = n.planner.Sum(f, selectReq)
 			case request.AverageFieldName:
 				plan, aggregateError = n.planner.Average(f)
+			case request.MinFieldName:
+				plan, aggregateError = n.planner.Min(f, selectReq)
+			case request.MaxFieldName:
+				plan, aggregateError = n.planner.Max(f, selectReq)
 			}
 
 			if aggregateError != nil {
@@ -441,6 +454,11 @@ func (p *Planner) SelectFromSource(
 		return nil, err
 	}
 
+	pagePlan, err := p.Page(selectReq, selectReq.Page)
+	if err != nil {
+		return nil, err
+	}
+
 	orderPlan, err := p.OrderBy(selectReq, orderBy)
 	if err != nil {
 		return nil, err
@@ -449,6 +467,7 @@ func (p *Planner) SelectFromSource(
 	top := &selectTopNode{
 		selectNode: s,
 		limit:      limitPlan,
+		page:       pagePlan,
 		order:      orderPlan,
 		group:      groupPlan,
 		aggregates: aggregates,
@@ -485,6 +504,11 @@ func (p *Planner) Select(selectReq *mapper.Select) (planNode, error) {
 		return nil, err
 	}
 
+	pagePlan, err := p.Page(selectReq, selectReq.Page)
+	if err != nil {
+		return nil, err
+	}
+
 	orderPlan, err := p.OrderBy(selectReq, orderBy)
 	if err != nil {
 		return nil, err
@@ -493,6 +517,7 @@ func (p *Planner) Select(selectReq *mapper.Select) (planNode, error) {
 	top := &selectTopNode{
 		selectNode: s,
 		limit:      limitPlan,
+		page:       pagePlan,
 		order:      orderPlan,
 		group:      groupPlan,
 		aggregates: aggregates,
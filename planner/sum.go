@@ -157,9 +157,14 @@ func (n *sumNode) Close() error { return n.plan.Close() }
 func (n *sumNode) Source() planNode { return n.plan }
 
 func (n *sumNode) simpleExplain() (map[string]any, error) {
-	sourceExplanations := make([]map[string]any, len(n.aggregateMapping))
+	return explainAggregateTargets(n.aggregateMapping), nil
+}
+
+// explainAggregateTargets returns the simple explanation of the given aggregate targets.
+func explainAggregateTargets(targets []mapper.AggregateTarget) map[string]any {
+	sourceExplanations := make([]map[string]any, len(targets))
 
-	for i, source := range n.aggregateMapping {
+	for i, source := range targets {
 		simpleExplainMap := map[string]any{}
 
 		// Add the filter attribute if it exists.
@@ -184,7 +189,7 @@ func (n *sumNode) simpleExplain() (map[string]any, error) {
 
 	return map[string]any{
 		sourcesLabel: sourceExplanations,
-	}, nil
+	}
 }
 
 // Explain method returns a map containing all attributes of this node that
@@ -321,6 +326,23 @@ func sumItems[T any](
 	less func(T, T) bool,
 	toFloat func(T) float64,
 ) (float64, error) {
+	items := targetItems(source, aggregateTarget, less)
+
+	var sum float64 = 0
+	err := enumerable.ForEach(items, func(item T) {
+		sum += toFloat(item)
+	})
+
+	return sum, err
+}
+
+// targetItems returns the items of the given inline array that are targeted by the given
+// aggregate target, applying its filter, order and limit.
+func targetItems[T any](
+	source []T,
+	aggregateTarget *mapper.AggregateTarget,
+	less func(T, T) bool,
+) enumerable.Enumerable[T] {
 	items := enumerable.New(source)
 	if aggregateTarget.Filter != nil {
 		items = enumerable.Where(items, func(item T) (bool, error) {
@@ -341,25 +363,20 @@ func sumItems[T any](
 		items = enumerable.Take(items, aggregateTarget.Limit.Limit)
 	}
 
-	var sum float64 = 0
-	err := enumerable.ForEach(items, func(item T) {
-		sum += toFloat(item)
-	})
-
-	return sum, err
+	return items
 }
 
 func (n *sumNode) SetPlan(p planNode) { n.plan = p }
 
-type number interface {
-	int64 | float64
+type ordered interface {
+	int64 | float64 | string
 }
 
-func lessN[T number](a T, b T) bool {
+func lessN[T ordered](a T, b T) bool {
 	return a < b
 }
 
-func lessO[T number](a immutable.Option[T], b immutable.Option[T]) bool {
+func lessO[T ordered](a immutable.Option[T], b immutable.Option[T]) bool {
 	if !a.HasValue() {
 		return true
 	}
@@ -203,6 +203,10 @@ func (p *Planner) Top(m *mapper.Select) (*topLevelNode, error) {
 				child, err = p.Sum(f, m)
 			case request.AverageFieldName:
 				child, err = p.Average(f)
+			case request.MinFieldName:
+				child, err = p.Min(f, m)
+			case request.MaxFieldName:
+				child, err = p.Max(f, m)
 			}
 			if err != nil {
 				return nil, err
@@ -11,11 +11,14 @@
 package planner
 
 import (
+	"sort"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fetcher"
 	"github.com/sourcenetwork/defradb/planner/mapper"
 	"github.com/sourcenetwork/defradb/request/graphql/schema"
 )
@@ -89,7 +92,9 @@ func (p *Planner) makeTypeIndexJoin(
 		joinPlan, err = p.makeTypeJoinOne(parent, source, subType)
 	} else if schema.IsOneToMany(meta) { // Many side of One-to-Many
 		joinPlan, err = p.makeTypeJoinMany(parent, source, subType)
-	} else { // more to come, Many-to-Many, Embedded?
+	} else if schema.IsManyToMany(meta) { // Either side of Many-to-Many
+		joinPlan, err = p.makeTypeJoinManyToMany(parent, source, subType)
+	} else { // more to come, Embedded?
 		return nil, ErrUnknownRelationType
 	}
 	if err != nil {
@@ -141,6 +146,7 @@ func (n *typeIndexJoin) simpleExplain() (map[string]any, error) {
 		joinSubTypeLabel            = "subType"
 		joinSubTypeNameLabel        = "subTypeName"
 		joinRootLabel               = "rootName"
+		joinLinkCollectionLabel     = "linkCollection"
 	)
 
 	simpleExplainMap := map[string]any{}
@@ -182,6 +188,20 @@ func (n *typeIndexJoin) simpleExplain() (map[string]any, error) {
 		// Add the joined (subType) type's entire explain graph.
 		simpleExplainMap[joinSubTypeLabel] = subTypeExplainGraph
 
+	case *typeJoinManyToMany:
+		// Add the attribute(s).
+		simpleExplainMap[joinRootLabel] = joinType.rootLinkFieldName
+		simpleExplainMap[joinSubTypeNameLabel] = joinType.subTypeName
+		simpleExplainMap[joinLinkCollectionLabel] = joinType.linkDesc.Name
+
+		subTypeExplainGraph, err := buildSimpleExplainGraph(joinType.subType)
+		if err != nil {
+			return nil, err
+		}
+
+		// Add the joined (subType) type's entire explain graph.
+		simpleExplainMap[joinSubTypeLabel] = subTypeExplainGraph
+
 	default:
 		return simpleExplainMap, client.NewErrUnhandledType("join plan", n.joinPlan)
 	}
@@ -218,6 +238,10 @@ func (n *typeIndexJoin) Merge() bool { return true }
 //
 // The subType filter is the conditions that apply to the
 // queried sub type ie: {birthday: "June 26, 1990", ...}.
+//
+// A top level `_not` condition that references the sub type can only be
+// evaluated once the sub type has been joined, and so is moved in full to
+// the subType filter.
 func splitFilterByType(filter *mapper.Filter, subType int) (*mapper.Filter, *mapper.Filter) {
 	if filter == nil {
 		return nil, nil
@@ -227,13 +251,20 @@ func splitFilterByType(filter *mapper.Filter, subType int) (*mapper.Filter, *map
 	}
 
 	keyFound, sub := removeConditionIndex(conditionKey, filter.Conditions)
-	if !keyFound {
+	notKey, notClause, notFound := removeNotConditionOnIndex(subType, filter.Conditions)
+	if !keyFound && !notFound {
 		return filter, &mapper.Filter{}
 	}
 
 	// create new splitup filter
 	// our schema ensures that if sub exists, its of type map[string]any
-	splitF := &mapper.Filter{Conditions: map[connor.FilterKey]any{conditionKey: sub}}
+	splitF := &mapper.Filter{Conditions: map[connor.FilterKey]any{}}
+	if keyFound {
+		splitF.Conditions[conditionKey] = sub
+	}
+	if notFound {
+		splitF.Conditions[notKey] = notClause
+	}
 	return filter, splitF
 }
 
@@ -572,6 +603,226 @@ func (n *typeJoinMany) Close() error {
 
 func (n *typeJoinMany) Source() planNode { return n.root }
 
+// typeJoinManyToMany is the plan node for a type index join on either side of a
+// many-to-many relation.
+//
+// The links of the relation are stored in a link collection holding a document per link.
+// For each root document the keys of the linked documents are read from the link collection,
+// using its index on the root link field, and the linked documents are then fetched using
+// point lookups.
+type typeJoinManyToMany struct {
+	documentIterator
+	docMapper
+
+	p *Planner
+
+	// the main type that is at the parent level of the request.
+	root planNode
+	// the subtype plan to get the subtype docs
+	subType     planNode
+	subTypeName string
+	subTypeDesc client.CollectionDescription
+
+	// the description of the link collection, including its indexes
+	linkDesc client.CollectionDescription
+	// the fetcher used to read the link documents
+	linkFetcher fetcher.Fetcher
+	// the name of the link field holding the keys of the root documents
+	rootLinkFieldName string
+	// the name of the link field holding the keys of the subtype documents
+	subTypeLinkFieldName string
+
+	subSelect *mapper.Select
+}
+
+func (p *Planner) makeTypeJoinManyToMany(
+	parent *selectNode,
+	source planNode,
+	subType *mapper.Select,
+) (*typeJoinManyToMany, error) {
+	// split filter
+	if scan, ok := source.(*scanNode); ok {
+		scan.filter, parent.filter = splitFilterByType(scan.filter, subType.Index)
+		subType.ShowDeleted = parent.selectReq.ShowDeleted
+	}
+
+	selectPlan, err := p.SubSelect(subType)
+	if err != nil {
+		return nil, err
+	}
+
+	subTypeFieldDesc, ok := parent.sourceInfo.collectionDescription.GetField(subType.Name)
+	if !ok {
+		return nil, client.NewErrFieldNotExist(subType.Name)
+	}
+
+	subTypeCollectionDesc, err := p.getCollectionDesc(subType.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The link collection is named after the relation.
+	linkDesc, err := p.getIndexedCollectionDesc(subTypeFieldDesc.RelationName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typeJoinManyToMany{
+		p:                    p,
+		root:                 source,
+		subSelect:            subType,
+		subTypeName:          subType.Name,
+		subTypeDesc:          subTypeCollectionDesc,
+		subType:              selectPlan,
+		linkDesc:             linkDesc,
+		linkFetcher:          new(fetcher.DocumentFetcher),
+		rootLinkFieldName:    schema.ManyToManyLinkFieldName(parent.sourceInfo.collectionDescription.Schema.Name),
+		subTypeLinkFieldName: schema.ManyToManyLinkFieldName(subTypeFieldDesc.Schema),
+		docMapper:            docMapper{parent.documentMapping},
+	}, nil
+}
+
+func (n *typeJoinManyToMany) Kind() string {
+	return "typeJoinManyToMany"
+}
+
+func (n *typeJoinManyToMany) Init() error {
+	if err := n.subType.Init(); err != nil {
+		return err
+	}
+	return n.root.Init()
+}
+
+func (n *typeJoinManyToMany) Start() error {
+	if err := n.subType.Start(); err != nil {
+		return err
+	}
+	return n.root.Start()
+}
+
+func (n *typeJoinManyToMany) Spans(spans core.Spans) {
+	n.root.Spans(spans)
+}
+
+func (n *typeJoinManyToMany) Next() (bool, error) {
+	hasNext, err := n.root.Next()
+	if err != nil || !hasNext {
+		return hasNext, err
+	}
+
+	n.currentValue = n.root.Value()
+
+	subDocKeys, err := n.linkedDocKeys(n.currentValue.GetKey())
+	if err != nil {
+		return false, err
+	}
+
+	subdocs := make([]core.Doc, 0, len(subDocKeys))
+	if len(subDocKeys) > 0 {
+		// do point lookups for the linked docs
+		n.subType.Spans(docKeySpans(n.subTypeDesc, subDocKeys))
+
+		// reset scan node
+		if err := n.subType.Init(); err != nil {
+			return false, err
+		}
+
+		for {
+			next, err := n.subType.Next()
+			if err != nil {
+				return false, err
+			}
+			if !next {
+				break
+			}
+
+			subdocs = append(subdocs, n.subType.Value())
+		}
+	}
+
+	n.currentValue.Fields[n.subSelect.Index] = subdocs
+	return true, nil
+}
+
+// linkedDocKeys returns the sorted keys of the subtype documents linked to the root
+// document with the given key.
+func (n *typeJoinManyToMany) linkedDocKeys(rootDocKey string) ([]string, error) {
+	// If the link collection has no index on the root link field we fall back to scanning
+	// all of the links.
+	linkSpans := core.Spans{}
+	for _, index := range n.linkDesc.Indexes {
+		if index.Fields[0].Name != n.rootLinkFieldName {
+			continue
+		}
+		scan, ok := newIndexScan(index, client.FieldKind_DocKey, map[string]any{"_eq": rootDocKey})
+		if !ok {
+			break
+		}
+
+		var err error
+		linkSpans, err = n.p.spansFromIndexScan(n.linkDesc, scan)
+		if err != nil {
+			return nil, err
+		}
+		if len(linkSpans.Value) == 0 {
+			return nil, nil
+		}
+		break
+	}
+
+	if err := n.linkFetcher.Init(&n.linkDesc, nil, false, false); err != nil {
+		return nil, err
+	}
+	if err := n.linkFetcher.Start(n.p.ctx, n.p.txn, linkSpans); err != nil {
+		return nil, err
+	}
+
+	docKeys := map[string]struct{}{}
+	for {
+		linkDoc, err := n.linkFetcher.FetchNextDecoded(n.p.ctx)
+		if err != nil {
+			return nil, err
+		}
+		if linkDoc == nil {
+			break
+		}
+
+		// Index matches are only candidates, and the full scan yields every link, so the
+		// root key must be checked.
+		rootValue, err := linkDoc.Get(n.rootLinkFieldName)
+		if err != nil || rootValue != rootDocKey {
+			continue
+		}
+		subTypeValue, err := linkDoc.Get(n.subTypeLinkFieldName)
+		if err != nil {
+			continue
+		}
+		if subDocKey, ok := subTypeValue.(string); ok {
+			docKeys[subDocKey] = struct{}{}
+		}
+	}
+
+	sortedDocKeys := make([]string, 0, len(docKeys))
+	for docKey := range docKeys {
+		sortedDocKeys = append(sortedDocKeys, docKey)
+	}
+	sort.Strings(sortedDocKeys)
+
+	return sortedDocKeys, nil
+}
+
+func (n *typeJoinManyToMany) Close() error {
+	if err := n.root.Close(); err != nil {
+		return err
+	}
+	if err := n.linkFetcher.Close(); err != nil {
+		return err
+	}
+	return n.subType.Close()
+}
+
+func (n *typeJoinManyToMany) Source() planNode { return n.root }
+
 func appendFilterToScanNode(plan planNode, filterCondition map[connor.FilterKey]any) error {
 	switch node := plan.(type) {
 	case *scanNode:
@@ -599,6 +850,50 @@ func appendFilterToScanNode(plan planNode, filterCondition map[connor.FilterKey]
 	return nil
 }
 
+// removeNotConditionOnIndex removes the top level `_not` condition from the given filter
+// conditions if it references the property at the given index.
+func removeNotConditionOnIndex(
+	index int,
+	filterConditions map[connor.FilterKey]any,
+) (connor.FilterKey, any, bool) {
+	for targetKey, clause := range filterConditions {
+		if opKey, isOpKey := targetKey.(*mapper.Operator); isOpKey && opKey.Operation == "_not" {
+			if conditionsReferenceIndex(clause, index) {
+				delete(filterConditions, targetKey)
+				return targetKey, clause, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// conditionsReferenceIndex returns true if the given filter conditions contain a condition on
+// the property at the given index, either directly or within nested operators such as `_and`.
+func conditionsReferenceIndex(conditions any, index int) bool {
+	switch typedConditions := conditions.(type) {
+	case map[connor.FilterKey]any:
+		for key, clause := range typedConditions {
+			switch typedKey := key.(type) {
+			case *mapper.PropertyIndex:
+				if typedKey.Index == index {
+					return true
+				}
+			case *mapper.Operator:
+				if conditionsReferenceIndex(clause, index) {
+					return true
+				}
+			}
+		}
+	case []any:
+		for _, clause := range typedConditions {
+			if conditionsReferenceIndex(clause, index) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func removeConditionIndex(
 	key *mapper.PropertyIndex,
 	filterConditions map[connor.FilterKey]any,
@@ -0,0 +1,214 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"encoding/json"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// upsertNode is used to construct and execute an object upsert mutation.
+//
+// On the first iteration of the plan the documents matching the filter are
+// updated, or a new document is created if none match. The updated or created
+// documents are then returned, regardless of whether they match the filter
+// after being written.
+type upsertNode struct {
+	documentIterator
+	docMapper
+
+	p *Planner
+
+	collection client.Collection
+
+	filter *mapper.Filter
+
+	// newDocStr is the JSON string of the document to create, unparsed
+	newDocStr string
+	doc       *client.Document
+
+	patch string
+
+	isUpserting bool
+
+	results planNode
+
+	execInfo upsertExecInfo
+}
+
+type upsertExecInfo struct {
+	// Total number of times upsertNode was executed.
+	iterations uint64
+
+	// Total number of documents updated.
+	updates uint64
+
+	// Total number of documents created.
+	creates uint64
+}
+
+func (n *upsertNode) Kind() string { return "upsertNode" }
+
+func (n *upsertNode) Init() error { return nil }
+
+func (n *upsertNode) Start() error {
+	doc, err := client.NewDocFromJSON([]byte(n.newDocStr))
+	if err != nil {
+		return err
+	}
+	n.doc = doc
+	return nil
+}
+
+// Next upserts the documents on the first call, then yields the written documents.
+func (n *upsertNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if n.isUpserting {
+		filter := immutable.Some(request.Filter{
+			Conditions: n.filter.ExternalConditions,
+		})
+		result, err := n.collection.Upsert(n.p.ctx, filter, n.doc, n.patch)
+		if err != nil {
+			return false, err
+		}
+		n.isUpserting = false
+
+		if result.Created {
+			n.execInfo.creates++
+		} else {
+			n.execInfo.updates += uint64(len(result.DocKeys))
+		}
+
+		desc := n.collection.Description()
+		spans := make([]core.Span, len(result.DocKeys))
+		for i, key := range result.DocKeys {
+			docKey := base.MakeDocKey(desc, key)
+			spans[i] = core.NewSpan(docKey, docKey.PrefixEnd())
+		}
+		n.results.Spans(core.NewSpans(spans...))
+
+		err = n.results.Init()
+		if err != nil {
+			return false, err
+		}
+
+		err = n.results.Start()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	next, err := n.results.Next()
+	if err != nil {
+		return false, err
+	}
+	if !next {
+		return false, nil
+	}
+
+	n.currentValue = n.results.Value()
+	return true, nil
+}
+
+func (n *upsertNode) Spans(spans core.Spans) { /* no-op */ }
+
+func (n *upsertNode) Close() error {
+	return n.results.Close()
+}
+
+func (n *upsertNode) Source() planNode { return n.results }
+
+func (n *upsertNode) simpleExplain() (map[string]any, error) {
+	simpleExplainMap := map[string]any{}
+
+	if n.filter == nil || n.filter.ExternalConditions == nil {
+		simpleExplainMap[filterLabel] = nil
+	} else {
+		simpleExplainMap[filterLabel] = n.filter.ExternalConditions
+	}
+
+	data := map[string]any{}
+	err := json.Unmarshal([]byte(n.newDocStr), &data)
+	if err != nil {
+		return nil, err
+	}
+	simpleExplainMap[dataLabel] = data
+
+	update := map[string]any{}
+	err = json.Unmarshal([]byte(n.patch), &update)
+	if err != nil {
+		return nil, err
+	}
+	simpleExplainMap[updateLabel] = update
+
+	return simpleExplainMap, nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *upsertNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+			"updates":    n.execInfo.updates,
+			"creates":    n.execInfo.creates,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (p *Planner) UpsertDocs(parsed *mapper.Mutation) (planNode, error) {
+	if parsed.Filter == nil {
+		return nil, ErrUpsertWithoutFilter
+	}
+
+	upsert := &upsertNode{
+		p:           p,
+		filter:      parsed.Filter,
+		newDocStr:   parsed.Data,
+		patch:       parsed.UpdateData,
+		isUpserting: true,
+		docMapper:   docMapper{&parsed.DocumentMapping},
+	}
+
+	// get collection
+	col, err := p.db.GetCollectionByName(p.ctx, parsed.Name)
+	if err != nil {
+		return nil, err
+	}
+	upsert.collection = col.WithTxn(p.txn)
+
+	// The written documents are returned by key, as a created document
+	// need not match the filter.
+	resultsSelect := parsed.Select
+	resultsSelect.Filter = nil
+	resultsNode, err := p.Select(&resultsSelect)
+	if err != nil {
+		return nil, err
+	}
+	upsert.results = resultsNode
+
+	return upsert, nil
+}
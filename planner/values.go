@@ -90,32 +90,45 @@ func (n *valuesNode) Less(i, j int) bool {
 	return n.docValueLess(da, db)
 }
 
-// docValueLess extracts and compare field values of a document, returns true only if strictly less when ASC,
-// and true if greater than or equal when DESC, otherwise returns false.
+// docValueLess extracts and compare field values of a document, returns true only if docA must
+// be sorted before docB.
+//
+// Each order condition is compared in turn, with later conditions only breaking the ties
+// of earlier ones.
 func (n *valuesNode) docValueLess(docA, docB core.Doc) bool {
 	for _, order := range n.ordering {
-		compare := base.Compare(
+		compare := compareOrderValues(
 			getDocProp(docA, order.FieldIndexes),
 			getDocProp(docB, order.FieldIndexes),
+			order,
 		)
-
-		if order.Direction == mapper.DESC {
-			if compare > 0 {
-				return true
-			} else {
-				return false
-			}
-		} else { // Otherwise assume order.Direction == mapper.ASC
-			if compare < 0 {
-				return true
-			} else {
-				return false
-			}
+		if compare != 0 {
+			return compare < 0
 		}
 	}
 	return false
 }
 
+// compareOrderValues compares the given values as per the given order condition, returning
+// a negative number if a must be sorted before b, and a positive number if after.
+//
+// Nil values are placed as per the condition's nulls placement, regardless of direction.
+func compareOrderValues(a any, b any, order mapper.OrderCondition) int {
+	if (a == nil || b == nil) && order.Nulls != mapper.NullsDefault {
+		compare := base.Compare(a, b)
+		if order.Nulls == mapper.NullsLast {
+			return -compare
+		}
+		return compare
+	}
+
+	compare := base.Compare(a, b)
+	if order.Direction == mapper.DESC {
+		return -compare
+	}
+	return compare
+}
+
 // Swap implements the golang sort.Sort interface.
 // It swaps the values at the ith and jth index
 // within the docContainer.
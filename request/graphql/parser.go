@@ -65,9 +65,14 @@ func (p *parser) IsIntrospection(ast *ast.Document) bool {
 	return defrap.IsIntrospectionQuery(*schema, ast)
 }
 
-func (p *parser) ExecuteIntrospection(request string) *client.RequestResult {
+func (p *parser) ExecuteIntrospection(request string, options client.RequestOptions) *client.RequestResult {
 	schema := p.schemaManager.Schema()
-	params := gql.Params{Schema: *schema, RequestString: request}
+	params := gql.Params{
+		Schema:         *schema,
+		RequestString:  request,
+		VariableValues: options.Variables,
+		OperationName:  options.OperationName,
+	}
 	r := gql.Do(params)
 
 	res := &client.RequestResult{
@@ -84,7 +89,7 @@ func (p *parser) ExecuteIntrospection(request string) *client.RequestResult {
 	return res
 }
 
-func (p *parser) Parse(ast *ast.Document) (*request.Request, []error) {
+func (p *parser) Parse(ast *ast.Document, options client.RequestOptions) (*request.Request, []error) {
 	schema := p.schemaManager.Schema()
 	validationResult := gql.ValidateDocument(schema, ast, nil)
 	if !validationResult.IsValid {
@@ -95,7 +100,7 @@ func (p *parser) Parse(ast *ast.Document) (*request.Request, []error) {
 		return nil, errors
 	}
 
-	query, parsingErrors := defrap.ParseRequest(*schema, ast)
+	query, parsingErrors := defrap.ParseRequest(*schema, ast, options)
 	if len(parsingErrors) > 0 {
 		return nil, parsingErrors
 	}
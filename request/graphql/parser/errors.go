@@ -12,9 +12,18 @@ package parser
 
 import "github.com/sourcenetwork/defradb/errors"
 
+const (
+	errUnknownOperationName      string = "no operation with the given name exists"
+	errMissingVariableValue      string = "missing value for non-null variable"
+	errInvalidVariableValue      string = "invalid variable value"
+	errUnknownVariableType       string = "unknown variable type"
+	errUnknownVariableInputField string = "unknown variable input field"
+)
+
 var (
 	ErrFilterMissingArgumentType      = errors.New("couldn't find filter argument type")
 	ErrInvalidOrderDirection          = errors.New("invalid order direction string")
+	ErrInvalidNullsPlacement          = errors.New("invalid order nulls placement string")
 	ErrFailedToParseConditionsFromAST = errors.New("couldn't parse conditions value from AST")
 	ErrFailedToParseConditionValue    = errors.New("failed to parse condition value from query filter statement")
 	ErrEmptyDataPayload               = errors.New("given data payload is empty")
@@ -23,4 +32,42 @@ var (
 	ErrInvalidNumberOfExplainArgs     = errors.New("invalid number of arguments to an explain request")
 	ErrUnknownExplainType             = errors.New("invalid / unknown explain type")
 	ErrUnknownGQLOperation            = errors.New("unknown GraphQL operation type")
+	ErrUnknownOperationName           = errors.New(errUnknownOperationName)
+	ErrMissingVariableValue           = errors.New(errMissingVariableValue)
+	ErrInvalidVariableValue           = errors.New(errInvalidVariableValue)
+	ErrUnknownVariableType            = errors.New(errUnknownVariableType)
+	ErrUnknownVariableInputField      = errors.New(errUnknownVariableInputField)
 )
+
+func NewErrUnknownOperationName(name string) error {
+	return errors.New(errUnknownOperationName, errors.NewKV("Name", name))
+}
+
+func NewErrMissingVariableValue(name string) error {
+	return errors.New(errMissingVariableValue, errors.NewKV("Variable", name))
+}
+
+func NewErrInvalidVariableValue(name string, expectedType string, value any) error {
+	return errors.New(
+		errInvalidVariableValue,
+		errors.NewKV("Variable", name),
+		errors.NewKV("ExpectedType", expectedType),
+		errors.NewKV("Value", value),
+	)
+}
+
+func NewErrUnknownVariableType(name string, typeName string) error {
+	return errors.New(
+		errUnknownVariableType,
+		errors.NewKV("Variable", name),
+		errors.NewKV("Type", typeName),
+	)
+}
+
+func NewErrUnknownVariableInputField(name string, field string) error {
+	return errors.New(
+		errUnknownVariableInputField,
+		errors.NewKV("Variable", name),
+		errors.NewKV("Field", field),
+	)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/connor"
 )
 
 // type condition
@@ -33,11 +34,44 @@ func NewFilter(stmt *ast.ObjectValue, inputType gql.Input) (immutable.Option[req
 	if err != nil {
 		return immutable.None[request.Filter](), err
 	}
+	err = validateRegexConditions(conditions)
+	if err != nil {
+		return immutable.None[request.Filter](), err
+	}
 	return immutable.Some(request.Filter{
 		Conditions: conditions,
 	}), nil
 }
 
+// validateRegexConditions returns an error if any `_regex` condition within the given
+// conditions is not a valid regular expression.
+func validateRegexConditions(conditions any) error {
+	switch typedConditions := conditions.(type) {
+	case map[string]any:
+		for key, clause := range typedConditions {
+			if pattern, isString := clause.(string); isString && key == "_regex" {
+				_, err := connor.CompileRegex(pattern)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			err := validateRegexConditions(clause)
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, clause := range typedConditions {
+			err := validateRegexConditions(clause)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // NewFilterFromString creates a new filter from a string.
 func NewFilterFromString(
 	schema gql.Schema,
@@ -90,6 +124,7 @@ func parseConditionsInOrder(stmt *ast.ObjectValue) (any, error) {
 	if stmt == nil {
 		return conditions, nil
 	}
+	var nulls request.NullsPlacement
 	for _, field := range stmt.Fields {
 		name := field.Name.Value
 		val, err := parseVal(field.Value, parseConditionsInOrder)
@@ -97,6 +132,16 @@ func parseConditionsInOrder(stmt *ast.ObjectValue) (any, error) {
 			return nil, err
 		}
 
+		if name == request.OrderNullsName {
+			placementName, _ := val.(string)
+			placement, ok := request.NameToNullsPlacement[placementName]
+			if !ok {
+				return nil, ErrInvalidNullsPlacement
+			}
+			nulls = placement
+			continue
+		}
+
 		switch v := val.(type) {
 		case string: // base direction parsed (hopefully, check NameToOrderDirection)
 			dir, ok := request.NameToOrderDirection[v]
@@ -124,6 +169,15 @@ func parseConditionsInOrder(stmt *ast.ObjectValue) (any, error) {
 		}
 	}
 
+	// The nulls placement applies to all conditions declared alongside it, unless
+	// a nested object declares its own.
+	// Eg. order: {name: ASC, author: {age: DESC}, _nulls: LAST}
+	for i := range conditions {
+		if conditions[i].Nulls == "" {
+			conditions[i].Nulls = nulls
+		}
+	}
+
 	return conditions, nil
 }
 
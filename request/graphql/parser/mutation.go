@@ -26,6 +26,8 @@ var (
 		"create": request.CreateObjects,
 		"update": request.UpdateObjects,
 		"delete": request.DeleteObjects,
+		"upsert": request.UpsertObjects,
+		"revert": request.RevertObjects,
 	}
 )
 
@@ -73,7 +75,7 @@ func parseMutation(schema gql.Schema, parent *gql.Object, field *ast.Field) (*re
 	// parse the mutation type
 	// mutation names are either generated from a type
 	// which means they are in the form name_type, where
-	// the name is the object mutation name (ie: create, update, delete)
+	// the name is the object mutation name (ie: create, update, delete, upsert, revert)
 	// or its an general API mutation, which is in the form
 	// name (camelCase).
 	// This means we can split on the "_" character, and always
@@ -99,12 +101,18 @@ func parseMutation(schema gql.Schema, parent *gql.Object, field *ast.Field) (*re
 	for _, argument := range field.Arguments {
 		prop := argument.Name.Value
 		// parse each individual arg type seperately
-		if prop == request.Data { // parse data
+		if prop == request.Data || prop == request.Create { // parse data
 			raw := argument.Value.(*ast.StringValue)
 			if raw.Value == "" {
 				return nil, ErrEmptyDataPayload
 			}
 			mut.Data = raw.Value
+		} else if prop == request.Update { // parse upsert update patch
+			raw := argument.Value.(*ast.StringValue)
+			if raw.Value == "" {
+				return nil, ErrEmptyDataPayload
+			}
+			mut.UpdateData = raw.Value
 		} else if prop == request.FilterClause { // parse filter
 			obj := argument.Value.(*ast.ObjectValue)
 			filterType, ok := getArgumentType(fieldDef, request.FilterClause)
@@ -117,6 +125,12 @@ func parseMutation(schema gql.Schema, parent *gql.Object, field *ast.Field) (*re
 			}
 
 			mut.Filter = filter
+		} else if prop == request.DocKey { // parse revert dockey
+			raw := argument.Value.(*ast.StringValue)
+			mut.IDs = immutable.Some([]string{raw.Value})
+		} else if prop == request.Cid { // parse revert version
+			raw := argument.Value.(*ast.StringValue)
+			mut.Version = raw.Value
 		} else if prop == request.Id {
 			raw := argument.Value.(*ast.StringValue)
 			mut.IDs = immutable.Some([]string{raw.Value})
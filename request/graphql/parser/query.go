@@ -12,6 +12,7 @@ package parser
 
 import (
 	"strconv"
+	"time"
 
 	gql "github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
@@ -137,6 +138,24 @@ func parseSelect(
 		case request.Cid: // parse single CID query field
 			val := astValue.(*ast.StringValue)
 			slct.CID = immutable.Some(val.Value)
+		case request.AsOfClause: // parse time-travel point in time
+			val := astValue.(*ast.StringValue)
+			asOfTime, err := time.Parse(time.RFC3339, val.Value)
+			if err != nil {
+				return nil, client.NewErrParsingFailed(err, request.AsOfClause)
+			}
+			asOf := slct.AsOf.Value()
+			asOf.Time = immutable.Some(asOfTime)
+			slct.AsOf = immutable.Some(asOf)
+		case request.AsOfHeightClause: // parse time-travel commit height
+			val := astValue.(*ast.IntValue)
+			height, err := strconv.ParseUint(val.Value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			asOf := slct.AsOf.Value()
+			asOf.Height = immutable.Some(height)
+			slct.AsOf = immutable.Some(asOf)
 		case request.LimitClause: // parse limit/offset
 			val := astValue.(*ast.IntValue)
 			limit, err := strconv.ParseUint(val.Value, 10, 64)
@@ -151,6 +170,26 @@ func parseSelect(
 				return nil, err
 			}
 			slct.Offset = immutable.Some(offset)
+		case request.FirstClause: // parse cursor pagination
+			val := astValue.(*ast.IntValue)
+			first, err := strconv.ParseUint(val.Value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			slct.First = immutable.Some(first)
+		case request.LastClause: // parse cursor pagination
+			val := astValue.(*ast.IntValue)
+			last, err := strconv.ParseUint(val.Value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			slct.Last = immutable.Some(last)
+		case request.AfterClause: // parse cursor pagination
+			val := astValue.(*ast.StringValue)
+			slct.After = immutable.Some(val.Value)
+		case request.BeforeClause: // parse cursor pagination
+			val := astValue.(*ast.StringValue)
+			slct.Before = immutable.Some(val.Value)
 		case request.OrderClause: // parse order by
 			obj := astValue.(*ast.ObjectValue)
 			cond, err := ParseConditionsInOrder(obj)
@@ -23,7 +23,14 @@ import (
 
 // ParseRequest parses a root ast.Document, and returns a formatted Request object.
 // Requires a non-nil doc, will error otherwise.
-func ParseRequest(schema gql.Schema, doc *ast.Document) (*request.Request, []error) {
+//
+// Any variables referenced by the request are resolved using the given options. If an
+// operation name is given, only the operation with that name is parsed.
+func ParseRequest(
+	schema gql.Schema,
+	doc *ast.Document,
+	options client.RequestOptions,
+) (*request.Request, []error) {
 	if doc == nil {
 		return nil, []error{client.NewErrUninitializeProperty("ParseRequest", "doc")}
 	}
@@ -34,12 +41,24 @@ func ParseRequest(schema gql.Schema, doc *ast.Document) (*request.Request, []err
 		Subscription: make([]*request.OperationDefinition, 0),
 	}
 
+	hasOperation := false
 	for _, def := range doc.Definitions {
 		astOpDef, isOpDef := def.(*ast.OperationDefinition)
 		if !isOpDef {
 			continue
 		}
 
+		if options.OperationName != "" &&
+			(astOpDef.Name == nil || astOpDef.Name.Value != options.OperationName) {
+			continue
+		}
+		hasOperation = true
+
+		err := resolveVariables(schema, astOpDef, options.Variables)
+		if err != nil {
+			return nil, []error{err}
+		}
+
 		switch astOpDef.Operation {
 		case ast.OperationTypeQuery:
 			parsedQueryOpDef, errs := parseQueryOperationDefinition(schema, astOpDef)
@@ -48,7 +67,7 @@ func ParseRequest(schema gql.Schema, doc *ast.Document) (*request.Request, []err
 			}
 
 			parsedDirectives, err := parseDirectives(astOpDef.Directives)
-			if errs != nil {
+			if err != nil {
 				return nil, []error{err}
 			}
 			parsedQueryOpDef.Directives = parsedDirectives
@@ -88,6 +107,10 @@ func ParseRequest(schema gql.Schema, doc *ast.Document) (*request.Request, []err
 		}
 	}
 
+	if options.OperationName != "" && !hasOperation {
+		return nil, []error{NewErrUnknownOperationName(options.OperationName)}
+	}
+
 	return r, nil
 }
 
@@ -0,0 +1,294 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// resolveVariables replaces all the variable references within the given operation with
+// literal values built from the given variable values, so that the rest of the parser
+// only ever has to handle literals.
+//
+// References to variables that have neither been given a value, nor have a default value,
+// are removed as if the argument or input field had never been provided.
+//
+// The given operation definition is modified in place.
+func resolveVariables(
+	schema gql.Schema,
+	opDef *ast.OperationDefinition,
+	variables map[string]any,
+) error {
+	values := make(map[string]ast.Value, len(opDef.VariableDefinitions))
+	for _, varDef := range opDef.VariableDefinitions {
+		name := varDef.Variable.Name.Value
+		inputType, err := inputTypeFromAST(schema, name, varDef.Type)
+		if err != nil {
+			return err
+		}
+
+		value, isProvided := variables[name]
+		if !isProvided {
+			if varDef.DefaultValue != nil {
+				values[name] = varDef.DefaultValue
+				continue
+			}
+			if _, isNonNull := inputType.(*gql.NonNull); isNonNull {
+				return NewErrMissingVariableValue(name)
+			}
+			continue
+		}
+
+		values[name], err = variableValueToAST(name, value, inputType)
+		if err != nil {
+			return err
+		}
+	}
+
+	opDef.Directives = substituteVariablesInDirectives(opDef.Directives, values)
+	substituteVariablesInSelectionSet(opDef.SelectionSet, values)
+	return nil
+}
+
+// inputTypeFromAST returns the schema type matching the given variable type.
+func inputTypeFromAST(schema gql.Schema, name string, astType ast.Type) (gql.Input, error) {
+	switch t := astType.(type) {
+	case *ast.NonNull:
+		ofType, err := inputTypeFromAST(schema, name, t.Type)
+		if err != nil {
+			return nil, err
+		}
+		return gql.NewNonNull(ofType), nil
+
+	case *ast.List:
+		ofType, err := inputTypeFromAST(schema, name, t.Type)
+		if err != nil {
+			return nil, err
+		}
+		return gql.NewList(ofType), nil
+
+	case *ast.Named:
+		inputType, isInput := schema.Type(t.Name.Value).(gql.Input)
+		if !isInput || inputType == nil {
+			return nil, NewErrUnknownVariableType(name, t.Name.Value)
+		}
+		return inputType, nil
+
+	default:
+		return nil, NewErrUnknownVariableType(name, astType.String())
+	}
+}
+
+// variableValueToAST returns the AST literal matching the given variable value, as
+// defined by the given type.
+//
+// Values are expected to be of the types produced by unmarshalling JSON into an `any`.
+func variableValueToAST(name string, value any, inputType gql.Input) (ast.Value, error) {
+	if nonNull, isNonNull := inputType.(*gql.NonNull); isNonNull {
+		if value == nil {
+			return nil, NewErrMissingVariableValue(name)
+		}
+		return variableValueToAST(name, value, nonNull.OfType.(gql.Input))
+	}
+
+	if value == nil {
+		return ast.NewNullValue(&ast.NullValue{}), nil
+	}
+
+	switch t := inputType.(type) {
+	case *gql.List:
+		itemType := t.OfType.(gql.Input)
+		items, isList := value.([]any)
+		if !isList {
+			// As per the GraphQL spec, a single value is coerced into a list of one.
+			item, err := variableValueToAST(name, value, itemType)
+			if err != nil {
+				return nil, err
+			}
+			return ast.NewListValue(&ast.ListValue{Values: []ast.Value{item}}), nil
+		}
+
+		astItems := make([]ast.Value, len(items))
+		for i, item := range items {
+			astItem, err := variableValueToAST(name, item, itemType)
+			if err != nil {
+				return nil, err
+			}
+			astItems[i] = astItem
+		}
+		return ast.NewListValue(&ast.ListValue{Values: astItems}), nil
+
+	case *gql.InputObject:
+		obj, isObject := value.(map[string]any)
+		if !isObject {
+			return nil, NewErrInvalidVariableValue(name, t.Name(), value)
+		}
+
+		// Map iteration order is random, the fields are sorted so that the resultant
+		// literal is deterministic.
+		fieldNames := make([]string, 0, len(obj))
+		for fieldName := range obj {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+
+		inputFields := t.Fields()
+		astFields := make([]*ast.ObjectField, len(fieldNames))
+		for i, fieldName := range fieldNames {
+			inputField, exists := inputFields[fieldName]
+			if !exists {
+				return nil, NewErrUnknownVariableInputField(name, fieldName)
+			}
+			astValue, err := variableValueToAST(name, obj[fieldName], inputField.Type)
+			if err != nil {
+				return nil, err
+			}
+			astFields[i] = ast.NewObjectField(&ast.ObjectField{
+				Name:  ast.NewName(&ast.Name{Value: fieldName}),
+				Value: astValue,
+			})
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: astFields}), nil
+
+	case *gql.Enum:
+		enumValue, isString := value.(string)
+		if !isString {
+			return nil, NewErrInvalidVariableValue(name, t.Name(), value)
+		}
+		return ast.NewEnumValue(&ast.EnumValue{Value: enumValue}), nil
+
+	case *gql.Scalar:
+		return scalarVariableValueToAST(name, value, t)
+
+	default:
+		return nil, NewErrUnknownVariableType(name, inputType.Name())
+	}
+}
+
+func scalarVariableValueToAST(name string, value any, scalar *gql.Scalar) (ast.Value, error) {
+	switch scalar.Name() {
+	case gql.Int.Name():
+		switch v := value.(type) {
+		case int:
+			return ast.NewIntValue(&ast.IntValue{Value: strconv.FormatInt(int64(v), 10)}), nil
+		case int64:
+			return ast.NewIntValue(&ast.IntValue{Value: strconv.FormatInt(v, 10)}), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return ast.NewIntValue(&ast.IntValue{Value: strconv.FormatFloat(v, 'f', -1, 64)}), nil
+			}
+		}
+
+	case gql.Float.Name():
+		switch v := value.(type) {
+		case int:
+			return ast.NewFloatValue(&ast.FloatValue{Value: strconv.FormatInt(int64(v), 10)}), nil
+		case int64:
+			return ast.NewFloatValue(&ast.FloatValue{Value: strconv.FormatInt(v, 10)}), nil
+		case float64:
+			return ast.NewFloatValue(&ast.FloatValue{Value: strconv.FormatFloat(v, 'f', -1, 64)}), nil
+		}
+
+	case gql.Boolean.Name():
+		if v, isBool := value.(bool); isBool {
+			return ast.NewBooleanValue(&ast.BooleanValue{Value: v}), nil
+		}
+
+	default:
+		if v, isString := value.(string); isString {
+			return ast.NewStringValue(&ast.StringValue{Value: v}), nil
+		}
+	}
+
+	return nil, NewErrInvalidVariableValue(name, scalar.Name(), value)
+}
+
+func substituteVariablesInSelectionSet(selectionSet *ast.SelectionSet, values map[string]ast.Value) {
+	if selectionSet == nil {
+		return
+	}
+
+	for _, selection := range selectionSet.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			s.Arguments = substituteVariablesInArguments(s.Arguments, values)
+			s.Directives = substituteVariablesInDirectives(s.Directives, values)
+			substituteVariablesInSelectionSet(s.SelectionSet, values)
+
+		case *ast.InlineFragment:
+			s.Directives = substituteVariablesInDirectives(s.Directives, values)
+			substituteVariablesInSelectionSet(s.SelectionSet, values)
+		}
+	}
+}
+
+func substituteVariablesInDirectives(directives []*ast.Directive, values map[string]ast.Value) []*ast.Directive {
+	for _, directive := range directives {
+		directive.Arguments = substituteVariablesInArguments(directive.Arguments, values)
+	}
+	return directives
+}
+
+func substituteVariablesInArguments(arguments []*ast.Argument, values map[string]ast.Value) []*ast.Argument {
+	result := make([]*ast.Argument, 0, len(arguments))
+	for _, argument := range arguments {
+		value, hasValue := substituteVariables(argument.Value, values)
+		if !hasValue {
+			continue
+		}
+		argument.Value = value
+		result = append(result, argument)
+	}
+	return result
+}
+
+// substituteVariables returns the given value with all variable references replaced by
+// their values.
+//
+// Returns false if the given value is a reference to a variable that has no value.
+func substituteVariables(value ast.Value, values map[string]ast.Value) (ast.Value, bool) {
+	switch v := value.(type) {
+	case *ast.Variable:
+		resolved, hasValue := values[v.Name.Value]
+		return resolved, hasValue
+
+	case *ast.ListValue:
+		for i, item := range v.Values {
+			resolved, hasValue := substituteVariables(item, values)
+			if !hasValue {
+				resolved = ast.NewNullValue(&ast.NullValue{})
+			}
+			v.Values[i] = resolved
+		}
+		return v, true
+
+	case *ast.ObjectValue:
+		fields := make([]*ast.ObjectField, 0, len(v.Fields))
+		for _, field := range v.Fields {
+			resolved, hasValue := substituteVariables(field.Value, values)
+			if !hasValue {
+				continue
+			}
+			field.Value = resolved
+			fields = append(fields, field)
+		}
+		v.Fields = fields
+		return v, true
+
+	default:
+		return value, true
+	}
+}
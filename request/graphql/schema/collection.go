@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
@@ -47,10 +48,17 @@ func fromAst(ctx context.Context, doc *ast.Document) ([]client.CollectionDescrip
 	relationManager := NewRelationManager()
 	descriptions := []client.CollectionDescription{}
 
+	// Enums must be known before the fields that reference them are parsed, and may be
+	// declared anywhere within the document.
+	enums, err := enumsFromAST(doc)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, def := range doc.Definitions {
 		switch defType := def.(type) {
 		case *ast.ObjectDefinition:
-			description, err := fromAstDefinition(ctx, relationManager, defType)
+			description, err := fromAstDefinition(ctx, relationManager, enums, defType)
 			if err != nil {
 				return nil, err
 			}
@@ -66,11 +74,24 @@ func fromAst(ctx context.Context, doc *ast.Document) ([]client.CollectionDescrip
 	// The details on the relations between objects depend on both sides
 	// of the relationship.  The relation manager handles this, and must be applied
 	// after all the collections have been processed.
-	err := finalizeRelations(relationManager, descriptions)
+	err = finalizeRelations(relationManager, descriptions)
 	if err != nil {
 		return nil, err
 	}
 
+	err = expandEmbeddedFields(descriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	appendEnumDescriptions(descriptions, enums)
+
+	linkDescriptions, err := manyToManyLinkCollections(relationManager)
+	if err != nil {
+		return nil, err
+	}
+	descriptions = append(descriptions, linkDescriptions...)
+
 	return descriptions, nil
 }
 
@@ -78,6 +99,7 @@ func fromAst(ctx context.Context, doc *ast.Document) ([]client.CollectionDescrip
 func fromAstDefinition(
 	ctx context.Context,
 	relationManager *RelationManager,
+	enums map[string]client.EnumDescription,
 	def *ast.ObjectDefinition,
 ) (client.CollectionDescription, error) {
 	fieldDescriptions := []client.FieldDescription{
@@ -88,16 +110,50 @@ func fromAstDefinition(
 		},
 	}
 
+	var indexes []client.IndexDescription
+	var uniqueConstraints []client.UniqueConstraintDescription
 	for _, field := range def.Fields {
 		kind, err := astTypeToKind(field.Type)
 		if err != nil {
 			return client.CollectionDescription{}, err
 		}
 
-		schema := ""
+		kind, enumName, err := enumKindFromAST(field, kind, enums)
+		if err != nil {
+			return client.CollectionDescription{}, err
+		}
+
+		if _, exists := findDirective(field, embeddedDirectiveLabel); exists {
+			kind, err = embeddedKindFromAST(field, kind)
+			if err != nil {
+				return client.CollectionDescription{}, err
+			}
+		}
+
+		if directive, exists := findDirective(field, indexDirectiveLabel); exists {
+			index, err := fieldIndexFromAST(field, directive)
+			if err != nil {
+				return client.CollectionDescription{}, err
+			}
+			indexes = append(indexes, index)
+		}
+
+		if directive, exists := findDirective(field, uniqueDirectiveLabel); exists {
+			constraint, err := fieldUniqueConstraintFromAST(field, directive)
+			if err != nil {
+				return client.CollectionDescription{}, err
+			}
+			uniqueConstraints = append(uniqueConstraints, constraint)
+		}
+
+		schema := enumName
 		relationName := ""
 		relationType := client.RelationType(0)
 
+		if kind == client.FieldKind_EMBEDDED_OBJECT {
+			schema = field.Type.(*ast.Named).Name.Value
+		}
+
 		if kind == client.FieldKind_FOREIGN_OBJECT || kind == client.FieldKind_FOREIGN_OBJECT_ARRAY {
 			if kind == client.FieldKind_FOREIGN_OBJECT {
 				schema = field.Type.(*ast.Named).Name.Value
@@ -136,10 +192,18 @@ func fromAstDefinition(
 			}
 		}
 
+		crdtType := defaultCRDTForFieldKind[kind]
+		if directive, exists := findDirective(field, crdtDirectiveLabel); exists {
+			crdtType, err = crdtTypeFromAST(field, kind, directive)
+			if err != nil {
+				return client.CollectionDescription{}, err
+			}
+		}
+
 		fieldDescription := client.FieldDescription{
 			Name:         field.Name.Value,
 			Kind:         kind,
-			Typ:          defaultCRDTForFieldKind[kind],
+			Typ:          crdtType,
 			Schema:       schema,
 			RelationName: relationName,
 			RelationType: relationType,
@@ -148,23 +212,34 @@ func fromAstDefinition(
 		fieldDescriptions = append(fieldDescriptions, fieldDescription)
 	}
 
-	// sort the fields lexicographically
-	sort.Slice(fieldDescriptions, func(i, j int) bool {
-		// make sure that the _key (KeyFieldName) is always at the beginning
-		if fieldDescriptions[i].Name == request.KeyFieldName {
-			return true
-		} else if fieldDescriptions[j].Name == request.KeyFieldName {
-			return false
+	for _, directive := range def.Directives {
+		switch directive.Name.Value {
+		case indexDirectiveLabel:
+			index, err := indexFromAST(directive)
+			if err != nil {
+				return client.CollectionDescription{}, err
+			}
+			indexes = append(indexes, index)
+
+		case uniqueDirectiveLabel:
+			constraint, err := uniqueConstraintFromAST(directive)
+			if err != nil {
+				return client.CollectionDescription{}, err
+			}
+			uniqueConstraints = append(uniqueConstraints, constraint)
 		}
-		return fieldDescriptions[i].Name < fieldDescriptions[j].Name
-	})
+	}
+
+	sortFieldDescriptions(fieldDescriptions)
 
 	return client.CollectionDescription{
 		Name: def.Name.Value,
 		Schema: client.SchemaDescription{
-			Name:   def.Name.Value,
-			Fields: fieldDescriptions,
+			Name:              def.Name.Value,
+			Fields:            fieldDescriptions,
+			UniqueConstraints: uniqueConstraints,
 		},
+		Indexes: indexes,
 	}, nil
 }
 
@@ -176,6 +251,8 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 		typeFloat    string = "Float"
 		typeDateTime string = "DateTime"
 		typeString   string = "String"
+		typeJSON     string = "JSON"
+		typeBlob     string = "Blob"
 	)
 
 	switch astTypeVal := t.(type) {
@@ -224,6 +301,10 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 			return client.FieldKind_DATETIME, nil
 		case typeString:
 			return client.FieldKind_STRING, nil
+		case typeJSON:
+			return client.FieldKind_JSON, nil
+		case typeBlob:
+			return client.FieldKind_BLOB, nil
 		default:
 			return client.FieldKind_FOREIGN_OBJECT, nil
 		}
@@ -236,6 +317,19 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 	}
 }
 
+// sortFieldDescriptions sorts the given fields lexicographically, keeping the _key field first.
+func sortFieldDescriptions(fieldDescriptions []client.FieldDescription) {
+	sort.Slice(fieldDescriptions, func(i, j int) bool {
+		// make sure that the _key (KeyFieldName) is always at the beginning
+		if fieldDescriptions[i].Name == request.KeyFieldName {
+			return true
+		} else if fieldDescriptions[j].Name == request.KeyFieldName {
+			return false
+		}
+		return fieldDescriptions[i].Name < fieldDescriptions[j].Name
+	})
+}
+
 func findDirective(field *ast.FieldDefinition, directiveName string) (*ast.Directive, bool) {
 	for _, directive := range field.Directives {
 		if directive.Name.Value == directiveName {
@@ -295,3 +389,70 @@ func finalizeRelations(relationManager *RelationManager, descriptions []client.C
 
 	return nil
 }
+
+// manyToManyLinkCollections returns the descriptions of the implicit collections storing
+// the links of all the many-to-many relations.
+//
+// The link collection of a relation is named after the relation, and holds a document per
+// link containing the keys of both linked documents. Both link fields are indexed so that
+// the relation may be traversed efficiently from either side.
+func manyToManyLinkCollections(relationManager *RelationManager) ([]client.CollectionDescription, error) {
+	relationNames := make([]string, 0, len(relationManager.relations))
+	for name, rel := range relationManager.relations {
+		if IsManyToMany(rel.Kind()) {
+			relationNames = append(relationNames, name)
+		}
+	}
+	// Map iteration order is random, the relations are sorted so that the collections
+	// are always created in the same order.
+	sort.Strings(relationNames)
+
+	descriptions := make([]client.CollectionDescription, len(relationNames))
+	for i, name := range relationNames {
+		rel := relationManager.relations[name]
+		if strings.EqualFold(rel.schemaTypes[0], rel.schemaTypes[1]) {
+			return nil, NewErrManyToManySelfRelation(name)
+		}
+
+		linkFieldNames := []string{
+			ManyToManyLinkFieldName(rel.schemaTypes[0]),
+			ManyToManyLinkFieldName(rel.schemaTypes[1]),
+		}
+		sort.Strings(linkFieldNames)
+
+		fields := []client.FieldDescription{
+			{
+				Name: request.KeyFieldName,
+				Kind: client.FieldKind_DocKey,
+				Typ:  client.NONE_CRDT,
+			},
+		}
+		indexes := []client.IndexDescription{}
+		for _, fieldName := range linkFieldNames {
+			fields = append(fields, client.FieldDescription{
+				Name: fieldName,
+				Kind: client.FieldKind_DocKey,
+				Typ:  defaultCRDTForFieldKind[client.FieldKind_DocKey],
+			})
+			indexes = append(indexes, client.IndexDescription{
+				Fields: []client.IndexedFieldDescription{
+					{
+						Name:      fieldName,
+						Direction: client.Ascending,
+					},
+				},
+			})
+		}
+
+		descriptions[i] = client.CollectionDescription{
+			Name: name,
+			Schema: client.SchemaDescription{
+				Name:   name,
+				Fields: fields,
+			},
+			Indexes: indexes,
+		}
+	}
+
+	return descriptions, nil
+}
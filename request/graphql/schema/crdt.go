@@ -0,0 +1,68 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+const (
+	crdtDirectiveLabel         = "crdt"
+	crdtDirectivePropType      = "type"
+	crdtDirectiveTypeLWW       = "lww"
+	crdtDirectiveTypePNCounter = "pncounter"
+)
+
+// crdtTypeFromAST returns the CRDT type declared by the given `@crdt` directive
+// on a field of the given kind.
+//
+// E.g. `likes: Int @crdt(type: "pncounter")`
+func crdtTypeFromAST(
+	field *ast.FieldDefinition,
+	kind client.FieldKind,
+	directive *ast.Directive,
+) (client.CType, error) {
+	var crdtType client.CType
+
+	for _, arg := range directive.Arguments {
+		switch arg.Name.Value {
+		case crdtDirectivePropType:
+			typeName, isString := arg.Value.GetValue().(string)
+			if !isString {
+				return client.NONE_CRDT, client.NewErrUnexpectedType[string]("CRDT type", arg.Value.GetValue())
+			}
+
+			switch typeName {
+			case crdtDirectiveTypeLWW:
+				crdtType = client.LWW_REGISTER
+			case crdtDirectiveTypePNCounter:
+				crdtType = client.PN_COUNTER
+			default:
+				return client.NONE_CRDT, NewErrInvalidCRDTType(field.Name.Value, typeName)
+			}
+
+		default:
+			return client.NONE_CRDT, NewErrInvalidCRDTArgument(arg.Name.Value)
+		}
+	}
+
+	if crdtType == client.NONE_CRDT {
+		return client.NONE_CRDT, ErrCRDTMissingType
+	}
+
+	if crdtType == client.PN_COUNTER && kind != client.FieldKind_INT && kind != client.FieldKind_FLOAT {
+		return client.NONE_CRDT, NewErrCRDTKindNotSupported(field.Name.Value, crdtDirectiveTypePNCounter)
+	}
+
+	return crdtType, nil
+}
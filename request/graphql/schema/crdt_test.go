@@ -0,0 +1,99 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestCRDTTypeFromSDL(t *testing.T) {
+	cases := []struct {
+		description string
+		sdl         string
+		fieldName   string
+		targetType  client.CType
+	}{
+		{
+			description: "field without crdt directive",
+			sdl:         `type user { likes: Int }`,
+			fieldName:   "likes",
+			targetType:  client.LWW_REGISTER,
+		},
+		{
+			description: "field with lww crdt directive",
+			sdl:         `type user { name: String @crdt(type: "lww") }`,
+			fieldName:   "name",
+			targetType:  client.LWW_REGISTER,
+		},
+		{
+			description: "int field with pncounter crdt directive",
+			sdl:         `type user { likes: Int @crdt(type: "pncounter") }`,
+			fieldName:   "likes",
+			targetType:  client.PN_COUNTER,
+		},
+		{
+			description: "float field with pncounter crdt directive",
+			sdl:         `type user { balance: Float @crdt(type: "pncounter") }`,
+			fieldName:   "balance",
+			targetType:  client.PN_COUNTER,
+		},
+	}
+
+	for _, test := range cases {
+		descs, err := FromString(context.Background(), test.sdl)
+		require.NoError(t, err, test.description)
+		require.Len(t, descs, 1, test.description)
+
+		field, ok := descs[0].GetField(test.fieldName)
+		require.True(t, ok, test.description)
+		assert.Equal(t, test.targetType, field.Typ, test.description)
+	}
+}
+
+func TestInvalidCRDTTypeFromSDL(t *testing.T) {
+	cases := []struct {
+		description   string
+		sdl           string
+		expectedError string
+	}{
+		{
+			description:   "crdt directive with unknown type",
+			sdl:           `type user { likes: Int @crdt(type: "gcounter") }`,
+			expectedError: NewErrInvalidCRDTType("likes", "gcounter").Error(),
+		},
+		{
+			description:   "crdt directive with unknown argument",
+			sdl:           `type user { likes: Int @crdt(name: "pncounter") }`,
+			expectedError: NewErrInvalidCRDTArgument("name").Error(),
+		},
+		{
+			description:   "crdt directive without type",
+			sdl:           `type user { likes: Int @crdt }`,
+			expectedError: ErrCRDTMissingType.Error(),
+		},
+		{
+			description:   "pncounter crdt directive on string field",
+			sdl:           `type user { name: String @crdt(type: "pncounter") }`,
+			expectedError: NewErrCRDTKindNotSupported("name", "pncounter").Error(),
+		},
+	}
+
+	for _, test := range cases {
+		_, err := FromString(context.Background(), test.sdl)
+		assert.ErrorContains(t, err, test.expectedError, test.description)
+	}
+}
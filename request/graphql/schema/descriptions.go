@@ -14,6 +14,7 @@ import (
 	gql "github.com/graphql-go/graphql"
 
 	"github.com/sourcenetwork/defradb/client"
+	schemaTypes "github.com/sourcenetwork/defradb/request/graphql/schema/types"
 )
 
 var (
@@ -32,8 +33,6 @@ var (
 		&gql.Object{}: client.FieldKind_FOREIGN_OBJECT,
 		&gql.List{}:   client.FieldKind_FOREIGN_OBJECT_ARRAY,
 		// More custom ones to come
-		// - JSON
-		// - ByteArray
 		// - Counters
 	}
 
@@ -52,6 +51,8 @@ var (
 		client.FieldKind_STRING:                gql.String,
 		client.FieldKind_STRING_ARRAY:          gql.NewList(gql.NewNonNull(gql.String)),
 		client.FieldKind_NILLABLE_STRING_ARRAY: gql.NewList(gql.String),
+		client.FieldKind_JSON:                  schemaTypes.JSONScalarType,
+		client.FieldKind_BLOB:                  schemaTypes.BlobScalarType,
 	}
 
 	// This map is fine to use
@@ -70,6 +71,10 @@ var (
 		client.FieldKind_STRING:                client.LWW_REGISTER,
 		client.FieldKind_STRING_ARRAY:          client.LWW_REGISTER,
 		client.FieldKind_NILLABLE_STRING_ARRAY: client.LWW_REGISTER,
+		client.FieldKind_JSON:                  client.LWW_REGISTER,
+		client.FieldKind_BLOB:                  client.LWW_REGISTER,
+		client.FieldKind_EMBEDDED_OBJECT:       client.OBJECT,
+		client.FieldKind_ENUM:                  client.LWW_REGISTER,
 		client.FieldKind_FOREIGN_OBJECT:        client.NONE_CRDT,
 		client.FieldKind_FOREIGN_OBJECT_ARRAY:  client.NONE_CRDT,
 	}
@@ -578,6 +578,137 @@ func TestSingleSimpleType(t *testing.T) {
 	}
 }
 
+func TestManyToManyRelation(t *testing.T) {
+	cases := []descriptionTestCase{
+		{
+			description: "Many-to-many relation, with link collection",
+			sdl: `
+			type author {
+				name: String
+				books: [book]
+			}
+
+			type book {
+				name: String
+				authors: [author]
+			}
+			`,
+			targetDescs: []client.CollectionDescription{
+				{
+					Name: "author",
+					Schema: client.SchemaDescription{
+						Name: "author",
+						Fields: []client.FieldDescription{
+							{
+								Name: "_key",
+								Kind: client.FieldKind_DocKey,
+								Typ:  client.NONE_CRDT,
+							},
+							{
+								Name:         "books",
+								RelationName: "author_book",
+								Kind:         client.FieldKind_FOREIGN_OBJECT_ARRAY,
+								Typ:          client.NONE_CRDT,
+								Schema:       "book",
+								RelationType: client.Relation_Type_MANY |
+									client.Relation_Type_MANYMANY |
+									client.Relation_Type_Primary,
+							},
+							{
+								Name: "name",
+								Kind: client.FieldKind_STRING,
+								Typ:  client.LWW_REGISTER,
+							},
+						},
+					},
+				},
+				{
+					Name: "book",
+					Schema: client.SchemaDescription{
+						Name: "book",
+						Fields: []client.FieldDescription{
+							{
+								Name: "_key",
+								Kind: client.FieldKind_DocKey,
+								Typ:  client.NONE_CRDT,
+							},
+							{
+								Name:         "authors",
+								RelationName: "author_book",
+								Kind:         client.FieldKind_FOREIGN_OBJECT_ARRAY,
+								Typ:          client.NONE_CRDT,
+								Schema:       "author",
+								RelationType: client.Relation_Type_MANY | client.Relation_Type_MANYMANY,
+							},
+							{
+								Name: "name",
+								Kind: client.FieldKind_STRING,
+								Typ:  client.LWW_REGISTER,
+							},
+						},
+					},
+				},
+				{
+					Name: "author_book",
+					Schema: client.SchemaDescription{
+						Name: "author_book",
+						Fields: []client.FieldDescription{
+							{
+								Name: "_key",
+								Kind: client.FieldKind_DocKey,
+								Typ:  client.NONE_CRDT,
+							},
+							{
+								Name: "author_id",
+								Kind: client.FieldKind_DocKey,
+								Typ:  client.LWW_REGISTER,
+							},
+							{
+								Name: "book_id",
+								Kind: client.FieldKind_DocKey,
+								Typ:  client.LWW_REGISTER,
+							},
+						},
+					},
+					Indexes: []client.IndexDescription{
+						{
+							Fields: []client.IndexedFieldDescription{
+								{
+									Name:      "author_id",
+									Direction: client.Ascending,
+								},
+							},
+						},
+						{
+							Fields: []client.IndexedFieldDescription{
+								{
+									Name:      "book_id",
+									Direction: client.Ascending,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range cases {
+		runCreateDescriptionTest(t, test)
+	}
+}
+
+func TestManyToManySelfRelationReturnsError(t *testing.T) {
+	_, err := FromString(context.Background(), `
+		type user {
+			name: String
+			friends: [user] @relation(name: "friends")
+			friendOf: [user] @relation(name: "friends")
+		}
+	`)
+	assert.ErrorIs(t, err, ErrManyToManySelfRelation)
+}
+
 func runCreateDescriptionTest(t *testing.T, testcase descriptionTestCase) {
 	ctx := context.Background()
 
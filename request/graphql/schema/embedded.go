@@ -0,0 +1,79 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+const (
+	embeddedDirectiveLabel = "embedded"
+)
+
+// embeddedKindFromAST returns the field kind of the given field declared with an `@embedded`
+// directive.
+//
+// E.g. `address: Address @embedded`
+func embeddedKindFromAST(field *ast.FieldDefinition, kind client.FieldKind) (client.FieldKind, error) {
+	if kind != client.FieldKind_FOREIGN_OBJECT {
+		return client.FieldKind_None, NewErrInvalidEmbeddedField(field.Name.Value)
+	}
+	return client.FieldKind_EMBEDDED_OBJECT, nil
+}
+
+// expandEmbeddedFields adds the fields holding the values of each embedded object field
+// to the descriptions hosting them.
+//
+// A field is added for each field of the embedded type, named after the path to it, e.g.
+// `address.city`.
+func expandEmbeddedFields(descriptions []client.CollectionDescription) error {
+	descriptionsByName := make(map[string]client.CollectionDescription, len(descriptions))
+	for _, description := range descriptions {
+		descriptionsByName[description.Name] = description
+	}
+
+	for i, description := range descriptions {
+		fields := description.Schema.Fields
+		for _, field := range description.Schema.Fields {
+			if !field.IsEmbeddedObject() {
+				continue
+			}
+
+			embeddedDescription, ok := descriptionsByName[field.Schema]
+			if !ok {
+				return NewErrTypeNotFound(field.Schema)
+			}
+
+			for _, embeddedField := range embeddedDescription.Schema.Fields {
+				if embeddedField.Kind == client.FieldKind_DocKey {
+					continue
+				}
+				if embeddedField.IsObject() || embeddedField.IsEmbeddedObject() {
+					return NewErrEmbeddedObjectField(field.Name, embeddedField.Name)
+				}
+
+				fields = append(fields, client.FieldDescription{
+					Name:   field.Name + client.EmbeddedFieldSeparator + embeddedField.Name,
+					Kind:   embeddedField.Kind,
+					Typ:    embeddedField.Typ,
+					Schema: embeddedField.Schema,
+				})
+			}
+		}
+
+		sortFieldDescriptions(fields)
+		descriptions[i].Schema.Fields = fields
+	}
+
+	return nil
+}
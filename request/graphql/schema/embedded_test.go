@@ -0,0 +1,114 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestEmbeddedObjectFromSDL(t *testing.T) {
+	descs, err := FromString(
+		context.Background(),
+		`
+		type user {
+			name: String
+			address: address @embedded
+		}
+		type address {
+			city: String
+			visits: Int @crdt(type: "pncounter")
+		}
+		`,
+	)
+	require.NoError(t, err)
+	require.Len(t, descs, 2)
+
+	assert.Equal(
+		t,
+		[]client.FieldDescription{
+			{
+				Name: "_key",
+				Kind: client.FieldKind_DocKey,
+				Typ:  client.NONE_CRDT,
+			},
+			{
+				Name:   "address",
+				Kind:   client.FieldKind_EMBEDDED_OBJECT,
+				Typ:    client.OBJECT,
+				Schema: "address",
+			},
+			{
+				Name: "address.city",
+				Kind: client.FieldKind_STRING,
+				Typ:  client.LWW_REGISTER,
+			},
+			{
+				Name: "address.visits",
+				Kind: client.FieldKind_INT,
+				Typ:  client.PN_COUNTER,
+			},
+			{
+				Name: "name",
+				Kind: client.FieldKind_STRING,
+				Typ:  client.LWW_REGISTER,
+			},
+		},
+		descs[0].Schema.Fields,
+	)
+	assert.Len(t, descs[0].GetEmbeddedFields("address"), 2)
+}
+
+func TestInvalidEmbeddedObjectFromSDL(t *testing.T) {
+	cases := []struct {
+		description   string
+		sdl           string
+		expectedError string
+	}{
+		{
+			description:   "embedded directive on scalar field",
+			sdl:           `type user { name: String @embedded }`,
+			expectedError: NewErrInvalidEmbeddedField("name").Error(),
+		},
+		{
+			description: "embedded directive on object list field",
+			sdl: `
+				type user { addresses: [address] @embedded }
+				type address { city: String }
+			`,
+			expectedError: NewErrInvalidEmbeddedField("addresses").Error(),
+		},
+		{
+			description: "embedded object containing an embedded object",
+			sdl: `
+				type user { address: address @embedded }
+				type address { location: location @embedded }
+				type location { latitude: Float }
+			`,
+			expectedError: NewErrEmbeddedObjectField("address", "location").Error(),
+		},
+		{
+			description:   "embedded object of unknown type",
+			sdl:           `type user { address: address @embedded }`,
+			expectedError: NewErrTypeNotFound("address").Error(),
+		},
+	}
+
+	for _, test := range cases {
+		_, err := FromString(context.Background(), test.sdl)
+		assert.ErrorContains(t, err, test.expectedError, test.description)
+	}
+}
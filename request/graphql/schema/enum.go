@@ -0,0 +1,89 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// enumsFromAST returns the enum types declared within the given document, keyed by name.
+//
+// E.g. `enum Status { ACTIVE ARCHIVED }`
+func enumsFromAST(doc *ast.Document) (map[string]client.EnumDescription, error) {
+	enums := map[string]client.EnumDescription{}
+	for _, def := range doc.Definitions {
+		enumDef, ok := def.(*ast.EnumDefinition)
+		if !ok {
+			continue
+		}
+
+		name := enumDef.Name.Value
+		if _, exists := enums[name]; exists {
+			return nil, NewErrDuplicateEnum(name)
+		}
+
+		values := make([]string, len(enumDef.Values))
+		for i, value := range enumDef.Values {
+			values[i] = value.Name.Value
+		}
+
+		enums[name] = client.EnumDescription{
+			Name:   name,
+			Values: values,
+		}
+	}
+	return enums, nil
+}
+
+// enumKindFromAST returns the enum kind and the name of the enum type of the given field if its
+// type is one of the given enums.
+func enumKindFromAST(
+	field *ast.FieldDefinition,
+	kind client.FieldKind,
+	enums map[string]client.EnumDescription,
+) (client.FieldKind, string, error) {
+	switch kind {
+	case client.FieldKind_FOREIGN_OBJECT:
+		name := field.Type.(*ast.Named).Name.Value
+		if _, isEnum := enums[name]; isEnum {
+			return client.FieldKind_ENUM, name, nil
+		}
+
+	case client.FieldKind_FOREIGN_OBJECT_ARRAY:
+		if named, ok := field.Type.(*ast.List).Type.(*ast.Named); ok {
+			if _, isEnum := enums[named.Name.Value]; isEnum {
+				return client.FieldKind_None, "", NewErrEnumArrayNotSupported(field.Name.Value)
+			}
+		}
+	}
+	return kind, "", nil
+}
+
+// appendEnumDescriptions adds the enum types of the enum fields of each of the given
+// descriptions to their schema.
+func appendEnumDescriptions(
+	descriptions []client.CollectionDescription,
+	enums map[string]client.EnumDescription,
+) {
+	for i, description := range descriptions {
+		for _, field := range description.Schema.Fields {
+			if field.Kind != client.FieldKind_ENUM {
+				continue
+			}
+			if _, exists := descriptions[i].Schema.GetEnum(field.Schema); exists {
+				continue
+			}
+			descriptions[i].Schema.Enums = append(descriptions[i].Schema.Enums, enums[field.Schema])
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestEnumFromSDL(t *testing.T) {
+	descs, err := FromString(
+		context.Background(),
+		`
+		type user {
+			name: String
+			status: Status
+		}
+		enum Status {
+			ACTIVE
+			ARCHIVED
+		}
+		`,
+	)
+	require.NoError(t, err)
+	require.Len(t, descs, 1)
+
+	assert.Equal(
+		t,
+		client.FieldDescription{
+			Name:   "status",
+			Kind:   client.FieldKind_ENUM,
+			Typ:    client.LWW_REGISTER,
+			Schema: "Status",
+		},
+		descs[0].Schema.Fields[2],
+	)
+	assert.Equal(
+		t,
+		[]client.EnumDescription{
+			{
+				Name:   "Status",
+				Values: []string{"ACTIVE", "ARCHIVED"},
+			},
+		},
+		descs[0].Schema.Enums,
+	)
+}
+
+func TestEnumWithinEmbeddedObjectFromSDL(t *testing.T) {
+	descs, err := FromString(
+		context.Background(),
+		`
+		enum Status { ACTIVE ARCHIVED }
+		type user { address: address @embedded }
+		type address { status: Status }
+		`,
+	)
+	require.NoError(t, err)
+	require.Len(t, descs, 2)
+
+	field, ok := descs[0].GetField("address.status")
+	require.True(t, ok)
+	assert.Equal(t, client.FieldKind_ENUM, field.Kind)
+	assert.Equal(t, "Status", field.Schema)
+
+	_, ok = descs[0].Schema.GetEnum("Status")
+	assert.True(t, ok)
+}
+
+func TestInvalidEnumFromSDL(t *testing.T) {
+	cases := []struct {
+		description   string
+		sdl           string
+		expectedError string
+	}{
+		{
+			description: "duplicate enum",
+			sdl: `
+				enum Status { ACTIVE }
+				enum Status { ARCHIVED }
+				type user { status: Status }
+			`,
+			expectedError: NewErrDuplicateEnum("Status").Error(),
+		},
+		{
+			description: "list of enum values",
+			sdl: `
+				enum Status { ACTIVE ARCHIVED }
+				type user { statuses: [Status] }
+			`,
+			expectedError: NewErrEnumArrayNotSupported("statuses").Error(),
+		},
+		{
+			description: "pncounter enum",
+			sdl: `
+				enum Status { ACTIVE ARCHIVED }
+				type user { status: Status @crdt(type: "pncounter") }
+			`,
+			expectedError: NewErrCRDTKindNotSupported("status", crdtDirectiveTypePNCounter).Error(),
+		},
+	}
+
+	for _, test := range cases {
+		_, err := FromString(context.Background(), test.sdl)
+		assert.ErrorContains(t, err, test.expectedError, test.description)
+	}
+}
@@ -22,22 +22,50 @@ const (
 	errTypeNotFound               string = "no type found for given name"
 	errRelationNotFound           string = "no relation found"
 	errNonNullForTypeNotSupported string = "NonNull variants for type are not supported"
+	errInvalidIndexArgument       string = "invalid @index argument"
+	errInvalidIndexDirection      string = "invalid @index direction, expected ASC or DESC"
+	errInvalidUniqueArgument      string = "invalid @unique argument"
+	errManyToManySelfRelation     string = "many-to-many relations between a type and itself are not supported"
+	errInvalidCRDTArgument        string = "invalid @crdt argument"
+	errInvalidCRDTType            string = "invalid @crdt type, expected lww or pncounter"
+	errCRDTKindNotSupported       string = "@crdt type is not supported for the field's type"
+	errInvalidEmbeddedField       string = "@embedded is only supported on fields of a single object type"
+	errEmbeddedObjectField        string = "embedded objects may not contain relations or embedded objects"
+	errDuplicateEnum              string = "enum type already declared"
+	errEnumArrayNotSupported      string = "arrays of enum values are not supported"
 )
 
 var (
-	ErrDuplicateField             = errors.New(errDuplicateField)
-	ErrFieldMissingRelation       = errors.New(errFieldMissingRelation)
-	ErrRelationMissingField       = errors.New(errRelationMissingField)
-	ErrAggregateTargetNotFound    = errors.New(errAggregateTargetNotFound)
-	ErrSchemaTypeAlreadyExist     = errors.New(errSchemaTypeAlreadyExist)
-	ErrObjectNotFoundDuringThunk  = errors.New(errObjectNotFoundDuringThunk)
-	ErrTypeNotFound               = errors.New(errTypeNotFound)
-	ErrRelationNotFound           = errors.New(errRelationNotFound)
-	ErrNonNullForTypeNotSupported = errors.New(errNonNullForTypeNotSupported)
-	ErrRelationMutlipleTypes      = errors.New("relation type can only be either One or Many, not both")
-	ErrRelationMissingTypes       = errors.New("relation is missing its defined types and fields")
-	ErrRelationInvalidType        = errors.New("relation has an invalid type to be finalize")
-	ErrMultipleRelationPrimaries  = errors.New("relation can only have a single field set as primary")
+	ErrDuplicateField                = errors.New(errDuplicateField)
+	ErrFieldMissingRelation          = errors.New(errFieldMissingRelation)
+	ErrRelationMissingField          = errors.New(errRelationMissingField)
+	ErrAggregateTargetNotFound       = errors.New(errAggregateTargetNotFound)
+	ErrSchemaTypeAlreadyExist        = errors.New(errSchemaTypeAlreadyExist)
+	ErrObjectNotFoundDuringThunk     = errors.New(errObjectNotFoundDuringThunk)
+	ErrTypeNotFound                  = errors.New(errTypeNotFound)
+	ErrRelationNotFound              = errors.New(errRelationNotFound)
+	ErrNonNullForTypeNotSupported    = errors.New(errNonNullForTypeNotSupported)
+	ErrInvalidIndexArgument          = errors.New(errInvalidIndexArgument)
+	ErrInvalidIndexDirection         = errors.New(errInvalidIndexDirection)
+	ErrInvalidUniqueArgument         = errors.New(errInvalidUniqueArgument)
+	ErrManyToManySelfRelation        = errors.New(errManyToManySelfRelation)
+	ErrInvalidCRDTArgument           = errors.New(errInvalidCRDTArgument)
+	ErrInvalidCRDTType               = errors.New(errInvalidCRDTType)
+	ErrCRDTKindNotSupported          = errors.New(errCRDTKindNotSupported)
+	ErrInvalidEmbeddedField          = errors.New(errInvalidEmbeddedField)
+	ErrEmbeddedObjectField           = errors.New(errEmbeddedObjectField)
+	ErrDuplicateEnum                 = errors.New(errDuplicateEnum)
+	ErrEnumArrayNotSupported         = errors.New(errEnumArrayNotSupported)
+	ErrRelationMutlipleTypes         = errors.New("relation type can only be either One or Many, not both")
+	ErrRelationMissingTypes          = errors.New("relation is missing its defined types and fields")
+	ErrRelationInvalidType           = errors.New("relation has an invalid type to be finalize")
+	ErrMultipleRelationPrimaries     = errors.New("relation can only have a single field set as primary")
+	ErrIndexMissingFields            = errors.New("@index on an object must specify the fields to index")
+	ErrIndexDirectionsLengthMismatch = errors.New(
+		"the number of @index directions must match the number of fields",
+	)
+	ErrUniqueMissingFields = errors.New("@unique on an object must specify the fields to constrain")
+	ErrCRDTMissingType     = errors.New("@crdt must specify a type")
 	// NonNull is the literal name of the GQL type, so we have to disable the linter
 	//nolint:revive
 	ErrNonNullNotSupported = errors.New("NonNull fields are not currently supported")
@@ -110,3 +138,77 @@ func NewErrRelationNotFound(relationName string) error {
 		errors.NewKV("RelationName", relationName),
 	)
 }
+
+func NewErrInvalidIndexArgument(name string) error {
+	return errors.New(
+		errInvalidIndexArgument,
+		errors.NewKV("Name", name),
+	)
+}
+
+func NewErrInvalidIndexDirection(direction string) error {
+	return errors.New(
+		errInvalidIndexDirection,
+		errors.NewKV("Direction", direction),
+	)
+}
+
+func NewErrInvalidUniqueArgument(name string) error {
+	return errors.New(
+		errInvalidUniqueArgument,
+		errors.NewKV("Name", name),
+	)
+}
+
+func NewErrManyToManySelfRelation(relationName string) error {
+	return errors.New(
+		errManyToManySelfRelation,
+		errors.NewKV("Relation", relationName),
+	)
+}
+
+func NewErrInvalidCRDTArgument(name string) error {
+	return errors.New(
+		errInvalidCRDTArgument,
+		errors.NewKV("Name", name),
+	)
+}
+
+func NewErrInvalidCRDTType(fieldName, crdtType string) error {
+	return errors.New(
+		errInvalidCRDTType,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Type", crdtType),
+	)
+}
+
+func NewErrCRDTKindNotSupported(fieldName, crdtType string) error {
+	return errors.New(
+		errCRDTKindNotSupported,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Type", crdtType),
+	)
+}
+
+func NewErrInvalidEmbeddedField(fieldName string) error {
+	return errors.New(
+		errInvalidEmbeddedField,
+		errors.NewKV("Field", fieldName),
+	)
+}
+
+func NewErrEmbeddedObjectField(fieldName, embeddedFieldName string) error {
+	return errors.New(
+		errEmbeddedObjectField,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("EmbeddedField", embeddedFieldName),
+	)
+}
+
+func NewErrDuplicateEnum(name string) error {
+	return errors.New(errDuplicateEnum, errors.NewKV("Name", name))
+}
+
+func NewErrEnumArrayNotSupported(fieldName string) error {
+	return errors.New(errEnumArrayNotSupported, errors.NewKV("Field", fieldName))
+}
@@ -13,6 +13,7 @@ package schema
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	gql "github.com/graphql-go/graphql"
 
@@ -326,6 +327,10 @@ func (g *Generator) createExpandedFieldList(
 			"order":              schemaTypes.NewArgConfig(g.manager.schema.TypeMap()[typeName+"OrderArg"]),
 			request.LimitClause:  schemaTypes.NewArgConfig(gql.Int),
 			request.OffsetClause: schemaTypes.NewArgConfig(gql.Int),
+			request.FirstClause:  schemaTypes.NewArgConfig(gql.Int),
+			request.LastClause:   schemaTypes.NewArgConfig(gql.Int),
+			request.AfterClause:  schemaTypes.NewArgConfig(gql.String),
+			request.BeforeClause: schemaTypes.NewArgConfig(gql.String),
 		},
 	}
 
@@ -348,6 +353,11 @@ func (g *Generator) buildTypes(
 	// get all the defined types from the AST
 	objs := make([]*gql.Object, 0)
 
+	err := g.buildEnumTypes(collections)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, c := range collections {
 		// Copy the loop variable before usage within the loop or it
 		// will be reassigned before the thunk is run
@@ -373,8 +383,15 @@ func (g *Generator) buildTypes(
 			fields[request.KeyFieldName] = &gql.Field{Type: gql.ID}
 
 			for _, field := range fieldDescriptions {
+				if strings.Contains(field.Name, client.EmbeddedFieldSeparator) {
+					// The values of embedded objects are exposed via the embedded object field.
+					continue
+				}
+
 				var ttype gql.Type
-				if field.Kind == client.FieldKind_FOREIGN_OBJECT {
+				if field.Kind == client.FieldKind_FOREIGN_OBJECT ||
+					field.Kind == client.FieldKind_EMBEDDED_OBJECT ||
+					field.Kind == client.FieldKind_ENUM {
 					var ok bool
 					ttype, ok = g.manager.schema.TypeMap()[field.Schema]
 					if !ok {
@@ -408,6 +425,10 @@ func (g *Generator) buildTypes(
 			// add _deleted field
 			fields[request.DeletedFieldName] = &gql.Field{Type: gql.Boolean}
 
+			// add the cursor pagination fields
+			fields[request.CursorFieldName] = &gql.Field{Type: gql.String}
+			fields[request.PageInfoFieldName] = &gql.Field{Type: schemaTypes.PageInfoObject}
+
 			gqlType, ok := g.manager.schema.TypeMap()[collection.Name]
 			if !ok {
 				return nil, NewErrObjectNotFoundDuringThunk(collection.Name)
@@ -432,9 +453,75 @@ func (g *Generator) buildTypes(
 	return objs, nil
 }
 
+// buildEnumTypes adds the GQL enum types, and their filter operator blocks, of the enums
+// declared by the given collections to the schema.
+//
+// Enums of the same name declared by multiple collections are merged into a single type.
+func (g *Generator) buildEnumTypes(collections []client.CollectionDescription) error {
+	enumNames := []string{}
+	enumValues := map[string]gql.EnumValueConfigMap{}
+	for _, collection := range collections {
+		for _, enum := range collection.Schema.Enums {
+			values, exists := enumValues[enum.Name]
+			if !exists {
+				enumNames = append(enumNames, enum.Name)
+				values = gql.EnumValueConfigMap{}
+				enumValues[enum.Name] = values
+			}
+			for _, value := range enum.Values {
+				values[value] = &gql.EnumValueConfig{Value: value}
+			}
+		}
+	}
+
+	for _, name := range enumNames {
+		if _, ok := g.manager.schema.TypeMap()[name]; ok {
+			return NewErrSchemaTypeAlreadyExist(name)
+		}
+
+		enum := gql.NewEnum(gql.EnumConfig{
+			Name:   name,
+			Values: enumValues[name],
+		})
+		err := g.manager.schema.AppendType(enum)
+		if err != nil {
+			return err
+		}
+
+		err = g.appendIfNotExists(genEnumOperatorBlock(enum))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// genEnumOperatorBlock returns the filter operator block for the given enum type.
+func genEnumOperatorBlock(enum *gql.Enum) *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name: genTypeName(enum, "OperatorBlock"),
+		Fields: gql.InputObjectConfigFieldMap{
+			"_eq": &gql.InputObjectFieldConfig{
+				Type: enum,
+			},
+			"_ne": &gql.InputObjectFieldConfig{
+				Type: enum,
+			},
+			"_in": &gql.InputObjectFieldConfig{
+				Type: gql.NewList(enum),
+			},
+			"_nin": &gql.InputObjectFieldConfig{
+				Type: gql.NewList(enum),
+			},
+		},
+	})
+}
+
 func (g *Generator) genAggregateFields(ctx context.Context) error {
 	topLevelCountInputs := map[string]*gql.InputObject{}
 	topLevelNumericAggInputs := map[string]*gql.InputObject{}
+	topLevelComparableAggInputs := map[string]*gql.InputObject{}
 
 	for _, t := range g.typeDefs {
 		numArg := g.genNumericAggregateBaseArgInputs(t)
@@ -453,6 +540,21 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 			}
 		}
 
+		comparableArg := g.genComparableAggregateBaseArgInputs(t)
+		topLevelComparableAggInputs[t.Name()] = comparableArg
+		err = g.appendIfNotExists(comparableArg)
+		if err != nil {
+			return err
+		}
+
+		comparableInlineArrayInputs := g.genComparableInlineArraySelectorObject(t)
+		for _, obj := range comparableInlineArrayInputs {
+			err = g.appendIfNotExists(obj)
+			if err != nil {
+				return err
+			}
+		}
+
 		obj := g.genCountBaseArgInputs(t)
 		topLevelCountInputs[t.Name()] = obj
 		err = g.appendIfNotExists(obj)
@@ -487,6 +589,14 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 			return err
 		}
 		t.AddFieldConfig(averageField.Name, &averageField)
+
+		for _, name := range []string{request.MinFieldName, request.MaxFieldName} {
+			minMaxField, err := g.genMinMaxFieldConfig(t, name)
+			if err != nil {
+				return err
+			}
+			t.AddFieldConfig(minMaxField.Name, &minMaxField)
+		}
 	}
 
 	queryType := g.manager.schema.QueryType()
@@ -498,6 +608,10 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 		queryType.AddFieldConfig(topLevelAgg.Name, topLevelAgg)
 	}
 
+	for _, topLevelAgg := range genTopLevelComparableAggregates(topLevelComparableAggInputs) {
+		queryType.AddFieldConfig(topLevelAgg.Name, topLevelAgg)
+	}
+
 	return nil
 }
 
@@ -536,6 +650,27 @@ func genTopLevelNumericAggregates(topLevelNumericAggInputs map[string]*gql.Input
 	return []*gql.Field{&topLevelSumField, &topLevelAverageField}
 }
 
+func genTopLevelComparableAggregates(topLevelComparableAggInputs map[string]*gql.InputObject) []*gql.Field {
+	topLevelMinField := gql.Field{
+		Name: request.MinFieldName,
+		Type: schemaTypes.ComparableScalarType,
+		Args: gql.FieldConfigArgument{},
+	}
+
+	topLevelMaxField := gql.Field{
+		Name: request.MaxFieldName,
+		Type: schemaTypes.ComparableScalarType,
+		Args: gql.FieldConfigArgument{},
+	}
+
+	for name, inputObject := range topLevelComparableAggInputs {
+		topLevelMinField.Args[name] = schemaTypes.NewArgConfig(inputObject)
+		topLevelMaxField.Args[name] = schemaTypes.NewArgConfig(inputObject)
+	}
+
+	return []*gql.Field{&topLevelMinField, &topLevelMaxField}
+}
+
 func (g *Generator) genCountFieldConfig(obj *gql.Object) (gql.Field, error) {
 	childTypesByFieldName := map[string]gql.Type{}
 
@@ -650,6 +785,50 @@ func (g *Generator) genAverageFieldConfig(obj *gql.Object) (gql.Field, error) {
 	return field, nil
 }
 
+// genMinMaxFieldConfig returns the `_min` or `_max` field config, as per the given name,
+// for the given object.
+//
+// The gql type of the field is the Comparable scalar, as the aggregated field is selected by
+// argument, the value yielded is of the same type as the aggregated values.
+func (g *Generator) genMinMaxFieldConfig(obj *gql.Object, name string) (gql.Field, error) {
+	childTypesByFieldName := map[string]gql.Type{}
+
+	for _, field := range obj.Fields() {
+		// we can only aggregate list items
+		listType, isList := field.Type.(*gql.List)
+		if !isList {
+			continue
+		}
+
+		var inputObjectName string
+		if isComparableArray(listType) {
+			inputObjectName = genComparableInlineArraySelectorName(obj.Name(), field.Name)
+		} else {
+			inputObjectName = genComparableObjectSelectorName(field.Type.Name())
+		}
+
+		subType, isSubTypeComparable := g.manager.schema.TypeMap()[inputObjectName]
+		// If the item is not in the type map, it must contain no comparable
+		//  fields (e.g. no Int/Floats/Strings/DateTimes)
+		if !isSubTypeComparable {
+			continue
+		}
+		childTypesByFieldName[field.Name] = subType
+	}
+
+	field := gql.Field{
+		Name: name,
+		Type: schemaTypes.ComparableScalarType,
+		Args: gql.FieldConfigArgument{},
+	}
+
+	for name, inputObject := range childTypesByFieldName {
+		field.Args[name] = schemaTypes.NewArgConfig(inputObject)
+	}
+
+	return field, nil
+}
+
 func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.InputObject {
 	objects := []*gql.InputObject{}
 	for _, field := range obj.Fields() {
@@ -686,6 +865,50 @@ func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.
 	return objects
 }
 
+func (g *Generator) genComparableInlineArraySelectorObject(obj *gql.Object) []*gql.InputObject {
+	objects := []*gql.InputObject{}
+	for _, field := range obj.Fields() {
+		// we can only act on list items
+		listType, isList := field.Type.(*gql.List)
+		if !isList {
+			continue
+		}
+
+		if isComparableArray(listType) {
+			// If it is an inline scalar array then we require an empty
+			//  object as an argument due to the lack of union input types
+			selectorObject := gql.NewInputObject(gql.InputObjectConfig{
+				Name: genComparableInlineArraySelectorName(obj.Name(), field.Name),
+				Fields: gql.InputObjectConfigFieldMap{
+					request.LimitClause: &gql.InputObjectFieldConfig{
+						Type:        gql.Int,
+						Description: "The maximum number of child items to aggregate.",
+					},
+					request.OffsetClause: &gql.InputObjectFieldConfig{
+						Type:        gql.Int,
+						Description: "The index from which to start aggregating items.",
+					},
+					request.OrderClause: &gql.InputObjectFieldConfig{
+						Type:        g.manager.schema.TypeMap()["Ordering"],
+						Description: "The order in which to aggregate items.",
+					},
+				},
+			})
+
+			objects = append(objects, selectorObject)
+		}
+	}
+	return objects
+}
+
+func genComparableObjectSelectorName(hostName string) string {
+	return fmt.Sprintf("%s__%s", hostName, "ComparableSelector")
+}
+
+func genComparableInlineArraySelectorName(hostName string, fieldName string) string {
+	return fmt.Sprintf("%s__%s__%s", hostName, fieldName, "ComparableSelector")
+}
+
 func genNumericObjectSelectorName(hostName string) string {
 	return fmt.Sprintf("%s__%s", hostName, "NumericSelector")
 }
@@ -783,6 +1006,8 @@ func (g *Generator) genNumericAggregateBaseArgInputs(obj *gql.Object) *gql.Input
 			// A child aggregate will always be aggregatable, as it can be present via an inner grouping
 			fieldsEnumCfg.Values[request.SumFieldName] = &gql.EnumValueConfig{Value: request.SumFieldName}
 			fieldsEnumCfg.Values[request.AverageFieldName] = &gql.EnumValueConfig{Value: request.AverageFieldName}
+			fieldsEnumCfg.Values[request.MinFieldName] = &gql.EnumValueConfig{Value: request.MinFieldName}
+			fieldsEnumCfg.Values[request.MaxFieldName] = &gql.EnumValueConfig{Value: request.MaxFieldName}
 
 			if !hasSumableFields {
 				return nil, nil
@@ -821,6 +1046,83 @@ func (g *Generator) genNumericAggregateBaseArgInputs(obj *gql.Object) *gql.Input
 	})
 }
 
+// Generates the base (comparable-only) aggregate input object-type for the given gql object,
+// declaring which fields are available for `_min` and `_max` aggregation.
+func (g *Generator) genComparableAggregateBaseArgInputs(obj *gql.Object) *gql.InputObject {
+	var fieldThunk gql.InputObjectConfigFieldMapThunk = func() (gql.InputObjectConfigFieldMap, error) {
+		fieldsEnum, enumExists := g.manager.schema.TypeMap()[genTypeName(obj, "ComparableFieldsArg")]
+		if !enumExists {
+			fieldsEnumCfg := gql.EnumConfig{
+				Name:   genTypeName(obj, "ComparableFieldsArg"),
+				Values: gql.EnumValueConfigMap{},
+			}
+
+			hasComparableFields := false
+			for _, field := range obj.Fields() {
+				if field.Name == request.CursorFieldName {
+					// Cursors are opaque and may not be aggregated
+					continue
+				}
+				if field.Type == gql.Float || field.Type == gql.Int ||
+					field.Type == gql.String || field.Type == gql.DateTime {
+					hasComparableFields = true
+					fieldsEnumCfg.Values[field.Name] = &gql.EnumValueConfig{Value: field.Name}
+					continue
+				}
+
+				if list, isList := field.Type.(*gql.List); isList {
+					hasComparableFields = true
+					if isComparableArray(list) {
+						fieldsEnumCfg.Values[field.Name] = &gql.EnumValueConfig{Value: field.Name}
+					} else {
+						// If it is a related list, we need to add count in here so that we can aggregate it
+						fieldsEnumCfg.Values[request.CountFieldName] = &gql.EnumValueConfig{Value: request.CountFieldName}
+					}
+				}
+			}
+			// A child aggregate will always be aggregatable, as it can be present via an inner grouping
+			fieldsEnumCfg.Values[request.SumFieldName] = &gql.EnumValueConfig{Value: request.SumFieldName}
+			fieldsEnumCfg.Values[request.AverageFieldName] = &gql.EnumValueConfig{Value: request.AverageFieldName}
+			fieldsEnumCfg.Values[request.MinFieldName] = &gql.EnumValueConfig{Value: request.MinFieldName}
+			fieldsEnumCfg.Values[request.MaxFieldName] = &gql.EnumValueConfig{Value: request.MaxFieldName}
+
+			if !hasComparableFields {
+				return nil, nil
+			}
+
+			fieldsEnum = gql.NewEnum(fieldsEnumCfg)
+
+			err := g.manager.schema.AppendType(fieldsEnum)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return gql.InputObjectConfigFieldMap{
+			"field": &gql.InputObjectFieldConfig{
+				Type: gql.NewNonNull(fieldsEnum),
+			},
+			request.LimitClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: "The maximum number of child items to aggregate.",
+			},
+			request.OffsetClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: "The index from which to start aggregating items.",
+			},
+			request.OrderClause: &gql.InputObjectFieldConfig{
+				Type:        g.manager.schema.TypeMap()[genTypeName(obj, "OrderArg")],
+				Description: "The order in which to aggregate items.",
+			},
+		}, nil
+	}
+
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:   genComparableObjectSelectorName(obj.Name()),
+		Fields: fieldThunk,
+	})
+}
+
 func appendCommitChildGroupField() {
 	schemaTypes.CommitObject.Fields()[request.GroupFieldName] = &gql.FieldDefinition{
 		Name: request.GroupFieldName,
@@ -883,14 +1185,23 @@ func (g *Generator) genTypeMutationFields(
 	if err != nil {
 		return nil, err
 	}
-	return []*gql.Field{create, update, delete}, nil
+	upsert, err := g.genTypeMutationUpsertField(obj, filterInput)
+	if err != nil {
+		return nil, err
+	}
+	revert, err := g.genTypeMutationRevertField(obj)
+	if err != nil {
+		return nil, err
+	}
+	return []*gql.Field{create, update, delete, upsert, revert}, nil
 }
 
 func (g *Generator) genTypeMutationCreateField(obj *gql.Object) (*gql.Field, error) {
 	field := &gql.Field{
 		// @todo: Handle collection name from @collection directive
 		Name: "create_" + obj.Name(),
-		Type: obj,
+		// The data payload may hold either a single document or a list of documents.
+		Type: gql.NewList(obj),
 		Args: gql.FieldConfigArgument{
 			"data": schemaTypes.NewArgConfig(gql.String),
 		},
@@ -934,6 +1245,36 @@ func (g *Generator) genTypeMutationDeleteField(
 	return field, nil
 }
 
+func (g *Generator) genTypeMutationUpsertField(
+	obj *gql.Object,
+	filter *gql.InputObject,
+) (*gql.Field, error) {
+	field := &gql.Field{
+		// @todo: Handle collection name from @collection directive
+		Name: "upsert_" + obj.Name(),
+		Type: gql.NewList(obj),
+		Args: gql.FieldConfigArgument{
+			"filter": schemaTypes.NewArgConfig(gql.NewNonNull(filter)),
+			"create": schemaTypes.NewArgConfig(gql.NewNonNull(gql.String)),
+			"update": schemaTypes.NewArgConfig(gql.NewNonNull(gql.String)),
+		},
+	}
+	return field, nil
+}
+
+func (g *Generator) genTypeMutationRevertField(obj *gql.Object) (*gql.Field, error) {
+	field := &gql.Field{
+		// @todo: Handle collection name from @collection directive
+		Name: "revert_" + obj.Name(),
+		Type: gql.NewList(obj),
+		Args: gql.FieldConfigArgument{
+			"dockey": schemaTypes.NewArgConfig(gql.NewNonNull(gql.ID)),
+			"cid":    schemaTypes.NewArgConfig(gql.NewNonNull(gql.String)),
+		},
+	}
+	return field, nil
+}
+
 // enum {Type.Name}Fields { ... }
 func (g *Generator) genTypeFieldsEnum(obj *gql.Object) *gql.Enum {
 	enumFieldsCfg := gql.EnumConfig{
@@ -976,13 +1317,22 @@ func (g *Generator) genTypeFilterArgInput(obj *gql.Object) *gql.InputObject {
 				if _, ok := request.ReservedFields[f]; ok && f != request.KeyFieldName {
 					continue
 				}
+				if field.Type == schemaTypes.JSONScalarType {
+					// JSON values may be filtered by the paths within them, and so their
+					// filters may hold any object.
+					// E.g. `{metadata: {author: {name: {_eq: "John"}}}}`
+					fields[field.Name] = &gql.InputObjectFieldConfig{
+						Type: schemaTypes.JSONScalarType,
+					}
+					continue
+				}
 				// scalars (leafs)
 				if gql.IsLeafType(field.Type) {
-					if _, isList := field.Type.(*gql.List); isList {
-						// Filtering by inline array value is currently not supported
-						continue
+					operatorBlockName := field.Type.Name() + "OperatorBlock"
+					if list, isList := field.Type.(*gql.List); isList {
+						operatorBlockName = genListOperatorBlockName(list)
 					}
-					operatorType, isFilterable := g.manager.schema.TypeMap()[field.Type.Name()+"OperatorBlock"]
+					operatorType, isFilterable := g.manager.schema.TypeMap()[operatorBlockName]
 					if !isFilterable {
 						continue
 					}
@@ -1035,6 +1385,9 @@ func (g *Generator) genLeafFilterArgInput(obj gql.Type) *gql.InputObject {
 
 		fields["_and"] = compoundListType
 		fields["_or"] = compoundListType
+		fields["_not"] = &gql.InputObjectFieldConfig{
+			Type: selfRefType,
+		}
 
 		operatorBlockName := fmt.Sprintf("%s%s", filterTypeName, "OperatorBlock")
 		operatorType, hasOperatorType := g.manager.schema.TypeMap()[operatorBlockName]
@@ -1069,13 +1422,18 @@ func (g *Generator) genTypeOrderArgInput(obj *gql.Object) *gql.InputObject {
 	}
 	fieldThunk := (gql.InputObjectConfigFieldMapThunk)(
 		func() (gql.InputObjectConfigFieldMap, error) {
-			fields := gql.InputObjectConfigFieldMap{}
+			typeMap := g.manager.schema.TypeMap()
+			fields := gql.InputObjectConfigFieldMap{
+				request.OrderNullsName: &gql.InputObjectFieldConfig{
+					Type: typeMap["NullsOrdering"],
+				},
+			}
 
 			for f, field := range obj.Fields() {
-				if _, ok := request.ReservedFields[f]; ok && f != request.KeyFieldName {
+				_, isAggregate := request.Aggregates[f]
+				if _, ok := request.ReservedFields[f]; ok && f != request.KeyFieldName && !isAggregate {
 					continue
 				}
-				typeMap := g.manager.schema.TypeMap()
 				if gql.IsLeafType(field.Type) { // only Scalars, and enums
 					fields[field.Name] = &gql.InputObjectFieldConfig{
 						Type: typeMap["Ordering"],
@@ -1134,6 +1492,13 @@ func (g *Generator) genTypeQueryableFieldList(
 			request.ShowDeleted:  schemaTypes.NewArgConfig(gql.Boolean),
 			request.LimitClause:  schemaTypes.NewArgConfig(gql.Int),
 			request.OffsetClause: schemaTypes.NewArgConfig(gql.Int),
+			request.FirstClause:  schemaTypes.NewArgConfig(gql.Int),
+			request.LastClause:   schemaTypes.NewArgConfig(gql.Int),
+			request.AfterClause:  schemaTypes.NewArgConfig(gql.String),
+			request.BeforeClause: schemaTypes.NewArgConfig(gql.String),
+
+			request.AsOfClause:       schemaTypes.NewArgConfig(gql.DateTime),
+			request.AsOfHeightClause: schemaTypes.NewArgConfig(gql.Int),
 		},
 	}
 
@@ -1161,6 +1526,16 @@ func genTypeName(obj gql.Type, name string) string {
 	return fmt.Sprintf("%s%s", obj.Name(), name)
 }
 
+// genListOperatorBlockName returns the name of the filter operator block of the given inline array type.
+func genListOperatorBlockName(list *gql.List) string {
+	if notNull, isNotNull := list.OfType.(*gql.NonNull); isNotNull {
+		// GQL does not support '!' in type names, and so we have to manipulate the
+		// underlying name like this if the items are non-nullable.
+		return fmt.Sprintf("NotNull%sListOperatorBlock", notNull.OfType.Name())
+	}
+	return genTypeName(list.OfType, "ListOperatorBlock")
+}
+
 // isNumericArray returns true if the given list is a list of numerical values.
 func isNumericArray(list *gql.List) bool {
 	// We have to compare the names here, as the gql lib we use
@@ -1171,6 +1546,14 @@ func isNumericArray(list *gql.List) bool {
 		list.OfType == gql.Float
 }
 
+// isComparableArray returns true if the given list is a list of values that can be
+// aggregated by `_min` and `_max`.
+func isComparableArray(list *gql.List) bool {
+	return isNumericArray(list) ||
+		list.OfType.Name() == gql.NewNonNull(gql.String).Name() ||
+		list.OfType == gql.String
+}
+
 /* Example
 
 typeDefs := ` ... `
@@ -0,0 +1,151 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+const (
+	indexDirectiveLabel              = "index"
+	indexDirectivePropName           = "name"
+	indexDirectivePropFields         = "fields"
+	indexDirectivePropDirection      = "direction"
+	indexDirectivePropDirections     = "directions"
+	indexDirectiveDirectionAscending = "ASC"
+	indexDirectiveDirectionDecending = "DESC"
+)
+
+// fieldIndexFromAST returns the description of the single field index declared by
+// the given `@index` directive on the given field.
+//
+// E.g. `name: String @index(name: "UsersByName", direction: DESC)`
+func fieldIndexFromAST(
+	field *ast.FieldDefinition,
+	directive *ast.Directive,
+) (client.IndexDescription, error) {
+	desc := client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{
+			{
+				Name:      field.Name.Value,
+				Direction: client.Ascending,
+			},
+		},
+	}
+
+	for _, arg := range directive.Arguments {
+		switch arg.Name.Value {
+		case indexDirectivePropName:
+			name, isString := arg.Value.GetValue().(string)
+			if !isString {
+				return client.IndexDescription{}, client.NewErrUnexpectedType[string]("Index name", arg.Value.GetValue())
+			}
+			desc.Name = name
+
+		case indexDirectivePropDirection:
+			direction, err := indexDirectionFromAST(arg.Value)
+			if err != nil {
+				return client.IndexDescription{}, err
+			}
+			desc.Fields[0].Direction = direction
+
+		default:
+			return client.IndexDescription{}, NewErrInvalidIndexArgument(arg.Name.Value)
+		}
+	}
+
+	return desc, nil
+}
+
+// indexFromAST returns the description of the (possibly composite) index declared by
+// the given `@index` directive on an object.
+//
+// E.g. `type User @index(fields: ["name", "age"], directions: [ASC, DESC])`
+func indexFromAST(directive *ast.Directive) (client.IndexDescription, error) {
+	desc := client.IndexDescription{}
+	var directions []client.IndexDirection
+
+	for _, arg := range directive.Arguments {
+		switch arg.Name.Value {
+		case indexDirectivePropName:
+			name, isString := arg.Value.GetValue().(string)
+			if !isString {
+				return client.IndexDescription{}, client.NewErrUnexpectedType[string]("Index name", arg.Value.GetValue())
+			}
+			desc.Name = name
+
+		case indexDirectivePropFields:
+			fieldsList, isList := arg.Value.(*ast.ListValue)
+			if !isList {
+				return client.IndexDescription{}, client.NewErrUnexpectedType[[]string]("Index fields", arg.Value.GetValue())
+			}
+			for _, fieldValue := range fieldsList.Values {
+				fieldName, isString := fieldValue.GetValue().(string)
+				if !isString {
+					return client.IndexDescription{}, client.NewErrUnexpectedType[string]("Index field", fieldValue.GetValue())
+				}
+				desc.Fields = append(desc.Fields, client.IndexedFieldDescription{
+					Name:      fieldName,
+					Direction: client.Ascending,
+				})
+			}
+
+		case indexDirectivePropDirections:
+			directionsList, isList := arg.Value.(*ast.ListValue)
+			if !isList {
+				return client.IndexDescription{}, client.NewErrUnexpectedType[[]string]("Index directions", arg.Value.GetValue())
+			}
+			for _, directionValue := range directionsList.Values {
+				direction, err := indexDirectionFromAST(directionValue)
+				if err != nil {
+					return client.IndexDescription{}, err
+				}
+				directions = append(directions, direction)
+			}
+
+		default:
+			return client.IndexDescription{}, NewErrInvalidIndexArgument(arg.Name.Value)
+		}
+	}
+
+	if len(desc.Fields) == 0 {
+		return client.IndexDescription{}, ErrIndexMissingFields
+	}
+
+	if directions != nil {
+		if len(directions) != len(desc.Fields) {
+			return client.IndexDescription{}, ErrIndexDirectionsLengthMismatch
+		}
+		for i := range desc.Fields {
+			desc.Fields[i].Direction = directions[i]
+		}
+	}
+
+	return desc, nil
+}
+
+func indexDirectionFromAST(value ast.Value) (client.IndexDirection, error) {
+	direction, isString := value.GetValue().(string)
+	if !isString {
+		return "", client.NewErrUnexpectedType[string]("Index direction", value.GetValue())
+	}
+
+	switch direction {
+	case indexDirectiveDirectionAscending:
+		return client.Ascending, nil
+	case indexDirectiveDirectionDecending:
+		return client.Descending, nil
+	default:
+		return "", NewErrInvalidIndexDirection(direction)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestIndexFromSDL(t *testing.T) {
+	cases := []indexTestCase{
+		{
+			description: "field index without arguments",
+			sdl:         `type user { name: String @index }`,
+			targetIndexes: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+				},
+			},
+		},
+		{
+			description: "field index with name and direction",
+			sdl:         `type user { name: String @index(name: "userByName", direction: DESC) }`,
+			targetIndexes: []client.IndexDescription{
+				{
+					Name: "userByName",
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Descending},
+					},
+				},
+			},
+		},
+		{
+			description: "composite index on object",
+			sdl: `type user @index(name: "userByNameAndAge", fields: ["name", "age"], directions: [ASC, DESC]) {
+				name: String
+				age: Int
+			}`,
+			targetIndexes: []client.IndexDescription{
+				{
+					Name: "userByNameAndAge",
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+						{Name: "age", Direction: client.Descending},
+					},
+				},
+			},
+		},
+		{
+			description: "field and object indexes",
+			sdl: `type user @index(fields: ["age"]) {
+				name: String @index
+				age: Int
+			}`,
+			targetIndexes: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+				},
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "age", Direction: client.Ascending},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range cases {
+		runParseIndexTest(t, test)
+	}
+}
+
+func TestInvalidIndexFromSDL(t *testing.T) {
+	cases := []invalidIndexTestCase{
+		{
+			description:   "unknown argument",
+			sdl:           `type user { name: String @index(unique: true) }`,
+			expectedError: NewErrInvalidIndexArgument("unique").Error(),
+		},
+		{
+			description:   "invalid direction",
+			sdl:           `type user { name: String @index(direction: UP) }`,
+			expectedError: NewErrInvalidIndexDirection("UP").Error(),
+		},
+		{
+			description:   "object index without fields",
+			sdl:           `type user @index(name: "userByName") { name: String }`,
+			expectedError: ErrIndexMissingFields.Error(),
+		},
+		{
+			description:   "object index with mismatching directions",
+			sdl:           `type user @index(fields: ["name"], directions: [ASC, DESC]) { name: String }`,
+			expectedError: ErrIndexDirectionsLengthMismatch.Error(),
+		},
+	}
+
+	for _, test := range cases {
+		_, err := FromString(context.Background(), test.sdl)
+		assert.ErrorContains(t, err, test.expectedError, test.description)
+	}
+}
+
+func runParseIndexTest(t *testing.T, testcase indexTestCase) {
+	descs, err := FromString(context.Background(), testcase.sdl)
+	require.NoError(t, err, testcase.description)
+	require.Len(t, descs, 1, testcase.description)
+
+	assert.Equal(t, testcase.targetIndexes, descs[0].Indexes, testcase.description)
+}
+
+type indexTestCase struct {
+	description   string
+	sdl           string
+	targetIndexes []client.IndexDescription
+}
+
+type invalidIndexTestCase struct {
+	description   string
+	sdl           string
+	expectedError string
+}
@@ -138,11 +138,15 @@ func defaultTypes() []gql.Type {
 		gql.ID,
 		gql.Int,
 		gql.String,
+		schemaTypes.JSONScalarType,
+		schemaTypes.BlobScalarType,
+		schemaTypes.ComparableScalarType,
 
 		// Base Query types
 
 		// Sort/Order enum
 		schemaTypes.OrderingEnum,
+		schemaTypes.NullsOrderingEnum,
 
 		// Filter scalar blocks
 		schemaTypes.BooleanOperatorBlock,
@@ -155,12 +159,25 @@ func defaultTypes() []gql.Type {
 		schemaTypes.NotNullIntOperatorBlock,
 		schemaTypes.StringOperatorBlock,
 		schemaTypes.NotNullstringOperatorBlock,
+		schemaTypes.BlobOperatorBlock,
+
+		// Filter inline array blocks
+		schemaTypes.BooleanListOperatorBlock,
+		schemaTypes.NotNullBooleanListOperatorBlock,
+		schemaTypes.FloatListOperatorBlock,
+		schemaTypes.NotNullFloatListOperatorBlock,
+		schemaTypes.IntListOperatorBlock,
+		schemaTypes.NotNullIntListOperatorBlock,
+		schemaTypes.StringListOperatorBlock,
+		schemaTypes.NotNullStringListOperatorBlock,
 
 		schemaTypes.CommitsOrderArg,
 		schemaTypes.CommitLinkObject,
 		schemaTypes.CommitObject,
 		schemaTypes.DeltaObject,
 
+		schemaTypes.PageInfoObject,
+
 		schemaTypes.ExplainEnum,
 	}
 }
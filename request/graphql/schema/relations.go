@@ -189,6 +189,12 @@ func genRelationName(t1, t2 string) (string, error) {
 	return fmt.Sprintf("%s_%s", t2, t1), nil
 }
 
+// ManyToManyLinkFieldName returns the name of the field, on the link collection of a
+// many-to-many relation, that holds the keys of the documents of the given schema type.
+func ManyToManyLinkFieldName(schemaType string) string {
+	return fmt.Sprintf("%s_id", strings.ToLower(schemaType))
+}
+
 // IsOne returns true if the Relation_ONE bit is set
 func IsOne(fieldmeta client.RelationType) bool {
 	return fieldmeta.IsSet(client.Relation_Type_ONE)
@@ -229,6 +229,15 @@ var StringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 		"_nlike": &gql.InputObjectFieldConfig{
 			Type: gql.String,
 		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Type: gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Type: gql.String,
+		},
+		"_regex": &gql.InputObjectFieldConfig{
+			Type: gql.String,
+		},
 	},
 })
 
@@ -254,6 +263,34 @@ var NotNullstringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 		"_nlike": &gql.InputObjectFieldConfig{
 			Type: gql.String,
 		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Type: gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Type: gql.String,
+		},
+		"_regex": &gql.InputObjectFieldConfig{
+			Type: gql.String,
+		},
+	},
+})
+
+// BlobOperatorBlock filter block for Blob types.
+var BlobOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "BlobOperatorBlock",
+	Fields: gql.InputObjectConfigFieldMap{
+		"_eq": &gql.InputObjectFieldConfig{
+			Type: BlobScalarType,
+		},
+		"_ne": &gql.InputObjectFieldConfig{
+			Type: BlobScalarType,
+		},
+		"_in": &gql.InputObjectFieldConfig{
+			Type: gql.NewList(BlobScalarType),
+		},
+		"_nin": &gql.InputObjectFieldConfig{
+			Type: gql.NewList(BlobScalarType),
+		},
 	},
 })
 
@@ -275,3 +312,86 @@ var IdOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 		},
 	},
 })
+
+// BooleanListOperatorBlock filter block for [Boolean] types.
+var BooleanListOperatorBlock = newListOperatorBlock(
+	"BooleanListOperatorBlock",
+	gql.Boolean,
+	BooleanOperatorBlock,
+)
+
+// NotNullBooleanListOperatorBlock filter block for [Boolean!] types.
+var NotNullBooleanListOperatorBlock = newListOperatorBlock(
+	"NotNullBooleanListOperatorBlock",
+	gql.NewNonNull(gql.Boolean),
+	NotNullBooleanOperatorBlock,
+)
+
+// FloatListOperatorBlock filter block for [Float] types.
+var FloatListOperatorBlock = newListOperatorBlock(
+	"FloatListOperatorBlock",
+	gql.Float,
+	FloatOperatorBlock,
+)
+
+// NotNullFloatListOperatorBlock filter block for [Float!] types.
+var NotNullFloatListOperatorBlock = newListOperatorBlock(
+	"NotNullFloatListOperatorBlock",
+	gql.NewNonNull(gql.Float),
+	NotNullFloatOperatorBlock,
+)
+
+// IntListOperatorBlock filter block for [Int] types.
+var IntListOperatorBlock = newListOperatorBlock(
+	"IntListOperatorBlock",
+	gql.Int,
+	IntOperatorBlock,
+)
+
+// NotNullIntListOperatorBlock filter block for [Int!] types.
+var NotNullIntListOperatorBlock = newListOperatorBlock(
+	"NotNullIntListOperatorBlock",
+	gql.NewNonNull(gql.Int),
+	NotNullIntOperatorBlock,
+)
+
+// StringListOperatorBlock filter block for [String] types.
+var StringListOperatorBlock = newListOperatorBlock(
+	"StringListOperatorBlock",
+	gql.String,
+	StringOperatorBlock,
+)
+
+// NotNullStringListOperatorBlock filter block for [String!] types.
+var NotNullStringListOperatorBlock = newListOperatorBlock(
+	"NotNullStringListOperatorBlock",
+	gql.NewNonNull(gql.String),
+	NotNullstringOperatorBlock,
+)
+
+// newListOperatorBlock returns a filter block for inline arrays of the given item type.
+//
+// `_any`, `_all` and `_none` match the items against the given item operator block, whilst
+// `_contains` and `_overlaps` match the items against a list of values.
+func newListOperatorBlock(name string, itemType gql.Input, itemBlock *gql.InputObject) *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name: name,
+		Fields: gql.InputObjectConfigFieldMap{
+			"_any": &gql.InputObjectFieldConfig{
+				Type: itemBlock,
+			},
+			"_all": &gql.InputObjectFieldConfig{
+				Type: itemBlock,
+			},
+			"_none": &gql.InputObjectFieldConfig{
+				Type: itemBlock,
+			},
+			"_contains": &gql.InputObjectFieldConfig{
+				Type: gql.NewList(itemType),
+			},
+			"_overlaps": &gql.InputObjectFieldConfig{
+				Type: gql.NewList(itemType),
+			},
+		},
+	})
+}
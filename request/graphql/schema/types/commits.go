@@ -33,6 +33,7 @@ var (
 	// 	CollectionID: Int
 	// 	SchemaVersionID: String
 	// 	Delta: String
+	// 	Signer: String
 	// 	Previous: [Commit]
 	//  Links: [Commit]
 	// }
@@ -60,6 +61,9 @@ var (
 			"delta": &gql.Field{
 				Type: gql.String,
 			},
+			"signer": &gql.Field{
+				Type: gql.String,
+			},
 			"links": &gql.Field{
 				Type: gql.NewList(CommitLinkObject),
 			},
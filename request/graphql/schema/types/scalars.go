@@ -0,0 +1,138 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// JSONScalarType is the GQL scalar type of fields holding arbitrary JSON values.
+//
+// Literal values may be objects, lists, or any other GQL value.
+var JSONScalarType = gql.NewScalar(gql.ScalarConfig{
+	Name:        "JSON",
+	Description: "The `JSON` scalar type represents a JSON value, which may be an object, a list, or a scalar.",
+	Serialize: func(value any) any {
+		return value
+	},
+	ParseValue: func(value any) any {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) any {
+		return parseJSONLiteral(valueAST)
+	},
+})
+
+// parseJSONLiteral converts the given literal into the Go value that would be produced
+// by unmarshalling the equivalent JSON, except for ints which are returned as int64.
+func parseJSONLiteral(valueAST ast.Value) any {
+	switch value := valueAST.(type) {
+	case *ast.ObjectValue:
+		result := make(map[string]any, len(value.Fields))
+		for _, field := range value.Fields {
+			result[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return result
+	case *ast.ListValue:
+		result := make([]any, len(value.Values))
+		for i, item := range value.Values {
+			result[i] = parseJSONLiteral(item)
+		}
+		return result
+	case *ast.IntValue:
+		if i, err := strconv.ParseInt(value.Value, 10, 64); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(value.Value, 64); err == nil {
+			return f
+		}
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(value.Value, 64); err == nil {
+			return f
+		}
+	case *ast.StringValue:
+		return value.Value
+	case *ast.EnumValue:
+		return value.Value
+	case *ast.BooleanValue:
+		return value.Value
+	}
+	return nil
+}
+
+// ComparableScalarType is the GQL scalar type of the `_min` and `_max` aggregates.
+//
+// The aggregated field is selected by argument, so the type of the result can't be declared
+// by the field itself. Values are yielded as they are, with the type of the aggregated values:
+// a number for Int and Float fields, a string for String fields, and a date time string for
+// DateTime fields.
+var ComparableScalarType = gql.NewScalar(gql.ScalarConfig{
+	Name: "Comparable",
+	Description: "The `Comparable` scalar type represents the result of a `_min` or `_max` aggregate, " +
+		"which has the type of the aggregated values: a number, a string or a date time.",
+	Serialize: func(value any) any {
+		return value
+	},
+	ParseValue: func(value any) any {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) any {
+		switch valueAST.(type) {
+		case *ast.IntValue, *ast.FloatValue, *ast.StringValue:
+			return parseJSONLiteral(valueAST)
+		}
+		return nil
+	},
+})
+
+// BlobScalarType is the GQL scalar type of fields holding binary data.
+//
+// Values are represented as hex encoded strings.
+var BlobScalarType = gql.NewScalar(gql.ScalarConfig{
+	Name:        "Blob",
+	Description: "The `Blob` scalar type represents binary data as a hex encoded string.",
+	Serialize: func(value any) any {
+		switch v := value.(type) {
+		case []byte:
+			return hex.EncodeToString(v)
+		case string:
+			return v
+		default:
+			return nil
+		}
+	},
+	ParseValue: func(value any) any {
+		return parseBlobString(value)
+	},
+	ParseLiteral: func(valueAST ast.Value) any {
+		if value, ok := valueAST.(*ast.StringValue); ok {
+			return parseBlobString(value.Value)
+		}
+		return nil
+	},
+})
+
+// parseBlobString returns the given value if it is a valid hex encoded string, otherwise
+// returns nil.
+func parseBlobString(value any) any {
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	if _, err := hex.DecodeString(str); err != nil {
+		return nil
+	}
+	return str
+}
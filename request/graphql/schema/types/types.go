@@ -12,6 +12,8 @@ package types
 
 import (
 	gql "github.com/graphql-go/graphql"
+
+	"github.com/sourcenetwork/defradb/client/request"
 )
 
 const (
@@ -38,6 +40,39 @@ var (
 		},
 	})
 
+	// NullsOrderingEnum is an enum for the placement of nil values within an Ordering.
+	NullsOrderingEnum = gql.NewEnum(gql.EnumConfig{
+		Name: "NullsOrdering",
+		Values: gql.EnumValueConfigMap{
+			"FIRST": &gql.EnumValueConfig{
+				Value: 0,
+			},
+			"LAST": &gql.EnumValueConfig{
+				Value: 1,
+			},
+		},
+	})
+
+	// PageInfoObject describes the page of documents returned by a cursor paginated
+	// selection, as per the Relay connection specification.
+	PageInfoObject = gql.NewObject(gql.ObjectConfig{
+		Name: request.PageInfoTypeName,
+		Fields: gql.Fields{
+			request.HasNextPageFieldName: &gql.Field{
+				Type: gql.Boolean,
+			},
+			request.HasPreviousPageFieldName: &gql.Field{
+				Type: gql.Boolean,
+			},
+			request.StartCursorFieldName: &gql.Field{
+				Type: gql.String,
+			},
+			request.EndCursorFieldName: &gql.Field{
+				Type: gql.String,
+			},
+		},
+	})
+
 	ExplainEnum = gql.NewEnum(gql.EnumConfig{
 		Name:        "ExplainType",
 		Description: "ExplainType is an enum selecting the type of explanation done by the @explain directive.",
@@ -0,0 +1,75 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+const (
+	uniqueDirectiveLabel      = "unique"
+	uniqueDirectivePropFields = "fields"
+)
+
+// fieldUniqueConstraintFromAST returns the description of the single field unique
+// constraint declared by a `@unique` directive on the given field.
+//
+// E.g. `email: String @unique`
+func fieldUniqueConstraintFromAST(
+	field *ast.FieldDefinition,
+	directive *ast.Directive,
+) (client.UniqueConstraintDescription, error) {
+	if len(directive.Arguments) > 0 {
+		return client.UniqueConstraintDescription{}, NewErrInvalidUniqueArgument(directive.Arguments[0].Name.Value)
+	}
+
+	return client.UniqueConstraintDescription{
+		Fields: []string{field.Name.Value},
+	}, nil
+}
+
+// uniqueConstraintFromAST returns the description of the (possibly composite) unique
+// constraint declared by the given `@unique` directive on an object.
+//
+// E.g. `type User @unique(fields: ["firstName", "lastName"])`
+func uniqueConstraintFromAST(directive *ast.Directive) (client.UniqueConstraintDescription, error) {
+	desc := client.UniqueConstraintDescription{}
+
+	for _, arg := range directive.Arguments {
+		switch arg.Name.Value {
+		case uniqueDirectivePropFields:
+			fieldsList, isList := arg.Value.(*ast.ListValue)
+			if !isList {
+				return client.UniqueConstraintDescription{},
+					client.NewErrUnexpectedType[[]string]("Unique fields", arg.Value.GetValue())
+			}
+			for _, fieldValue := range fieldsList.Values {
+				fieldName, isString := fieldValue.GetValue().(string)
+				if !isString {
+					return client.UniqueConstraintDescription{},
+						client.NewErrUnexpectedType[string]("Unique field", fieldValue.GetValue())
+				}
+				desc.Fields = append(desc.Fields, fieldName)
+			}
+
+		default:
+			return client.UniqueConstraintDescription{}, NewErrInvalidUniqueArgument(arg.Name.Value)
+		}
+	}
+
+	if len(desc.Fields) == 0 {
+		return client.UniqueConstraintDescription{}, ErrUniqueMissingFields
+	}
+
+	return desc, nil
+}
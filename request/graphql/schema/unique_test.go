@@ -0,0 +1,79 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestUniqueConstraintFromSDL(t *testing.T) {
+	cases := []struct {
+		description       string
+		sdl               string
+		targetConstraints []client.UniqueConstraintDescription
+	}{
+		{
+			description: "field unique constraint",
+			sdl:         `type user { email: String @unique }`,
+			targetConstraints: []client.UniqueConstraintDescription{
+				{Fields: []string{"email"}},
+			},
+		},
+		{
+			description: "composite unique constraint on object",
+			sdl: `type user @unique(fields: ["firstName", "lastName"]) {
+				firstName: String
+				lastName: String
+			}`,
+			targetConstraints: []client.UniqueConstraintDescription{
+				{Fields: []string{"firstName", "lastName"}},
+			},
+		},
+	}
+
+	for _, test := range cases {
+		descs, err := FromString(context.Background(), test.sdl)
+		require.NoError(t, err, test.description)
+		require.Len(t, descs, 1, test.description)
+
+		assert.Equal(t, test.targetConstraints, descs[0].Schema.UniqueConstraints, test.description)
+	}
+}
+
+func TestInvalidUniqueConstraintFromSDL(t *testing.T) {
+	cases := []struct {
+		description   string
+		sdl           string
+		expectedError string
+	}{
+		{
+			description:   "field unique constraint with argument",
+			sdl:           `type user { email: String @unique(name: "byEmail") }`,
+			expectedError: NewErrInvalidUniqueArgument("name").Error(),
+		},
+		{
+			description:   "object unique constraint without fields",
+			sdl:           `type user @unique { email: String }`,
+			expectedError: ErrUniqueMissingFields.Error(),
+		},
+	}
+
+	for _, test := range cases {
+		_, err := FromString(context.Background(), test.sdl)
+		assert.ErrorContains(t, err, test.expectedError, test.description)
+	}
+}
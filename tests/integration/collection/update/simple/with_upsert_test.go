@@ -0,0 +1,149 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration/collection"
+)
+
+func TestUpsertWithFilter(t *testing.T) {
+	docStr := `{
+		"Name": "John",
+		"Age": 21
+	}`
+
+	doc, err := client.NewDocFromJSON([]byte(docStr))
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	tests := []testUtils.TestCase{
+		{
+			Description: "Test upsert users with filter matching a document",
+			Docs: map[string][]string{
+				"users": {docStr},
+			},
+			CollectionCalls: map[string][]func(client.Collection) error{
+				"users": []func(c client.Collection) error{
+					func(c client.Collection) error {
+						ctx := context.Background()
+
+						newDoc, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 40}`))
+						if err != nil {
+							return err
+						}
+
+						result, err := c.Upsert(ctx, `{Name: {_eq: "John"}}`, newDoc, `{
+							"Age": 22
+						}`)
+						if err != nil {
+							return err
+						}
+
+						assert.False(t, result.Created)
+						assert.Equal(t, []string{doc.Key().String()}, result.DocKeys)
+
+						d, err := c.Get(ctx, doc.Key(), false)
+						if err != nil {
+							return err
+						}
+
+						age, err := d.Get("Age")
+						if err != nil {
+							return err
+						}
+
+						assert.Equal(t, uint64(22), age)
+
+						exists, err := c.Exists(ctx, newDoc.Key())
+						if err != nil {
+							return err
+						}
+
+						assert.False(t, exists)
+
+						return nil
+					},
+				},
+			},
+		}, {
+			Description: "Test upsert users with filter matching no documents",
+			Docs: map[string][]string{
+				"users": {docStr},
+			},
+			CollectionCalls: map[string][]func(client.Collection) error{
+				"users": []func(c client.Collection) error{
+					func(c client.Collection) error {
+						ctx := context.Background()
+
+						newDoc, err := client.NewDocFromJSON([]byte(`{"Name": "Eric", "Age": 40}`))
+						if err != nil {
+							return err
+						}
+
+						result, err := c.Upsert(ctx, `{Name: {_eq: "Eric"}}`, newDoc, `{
+							"Age": 41
+						}`)
+						if err != nil {
+							return err
+						}
+
+						assert.True(t, result.Created)
+						assert.Equal(t, []string{newDoc.Key().String()}, result.DocKeys)
+
+						d, err := c.Get(ctx, newDoc.Key(), false)
+						if err != nil {
+							return err
+						}
+
+						age, err := d.Get("Age")
+						if err != nil {
+							return err
+						}
+
+						assert.Equal(t, uint64(40), age)
+
+						return nil
+					},
+				},
+			},
+		}, {
+			Description: "Test upsert users with empty filter",
+			Docs:        map[string][]string{},
+			CollectionCalls: map[string][]func(client.Collection) error{
+				"users": []func(c client.Collection) error{
+					func(c client.Collection) error {
+						ctx := context.Background()
+
+						newDoc, err := client.NewDocFromJSON([]byte(`{"Name": "Eric"}`))
+						if err != nil {
+							return err
+						}
+
+						_, err = c.Upsert(ctx, "", newDoc, `{"Age": 41}`)
+						return err
+					},
+				},
+			},
+			ExpectedError: "invalid filter",
+		},
+	}
+
+	for _, test := range tests {
+		executeTestCase(t, test)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestExplainQueryWithFirstAndAfterSpecified(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Explain query request with first and after specified, seeking past the cursor.",
+
+		Request: `query @explain {
+			author(first: 1, after: "ogGAAngoYmFlLTQxNTk4ZjBjLTE5YmMtNWRhNi04MTNiLWU4MGYxNGExMGRmMw") {
+				name
+			}
+		}`,
+
+		Docs: map[int][]string{
+			// authors
+			2: {
+				// _key: bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+				`{
+					"name": "John Grisham",
+					"age": 65,
+					"verified": true
+				}`,
+
+				// _key: bae-aa839756-588e-5b57-887d-33689a06e375
+				`{
+					"name": "Shahzad Sisley",
+					"age": 26,
+					"verified": true
+				}`,
+			},
+		},
+
+		Results: []dataMap{
+			{
+				"explain": dataMap{
+					"selectTopNode": dataMap{
+						"pageNode": dataMap{
+							"first":  uint64(1),
+							"last":   nil,
+							"after":  "ogGAAngoYmFlLTQxNTk4ZjBjLTE5YmMtNWRhNi04MTNiLWU4MGYxNGExMGRmMw",
+							"before": nil,
+							"selectNode": dataMap{
+								"filter": nil,
+								"scanNode": dataMap{
+									"collectionID":   "3",
+									"collectionName": "author",
+									"filter":         nil,
+									"spans": []dataMap{
+										{
+											"start": "/3/bae-41598f0c-19bc-5da6-813b-e80f14a10df4",
+											"end":   "/4",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -48,8 +48,10 @@ var (
 		"limitNode":     {},
 		"multiScanNode": {},
 		"orderNode":     {},
+		"pageNode":      {},
 		"parallelNode":  {},
 		"pipeNode":      {},
+		"revertNode":    {},
 		"scanNode":      {},
 		"selectNode":    {},
 		"selectTopNode": {},
@@ -59,6 +61,7 @@ var (
 		"typeJoinMany":  {},
 		"typeJoinOne":   {},
 		"updateNode":    {},
+		"upsertNode":    {},
 		"valuesNode":    {},
 	}
 )
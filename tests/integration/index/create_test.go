@@ -0,0 +1,108 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestIndexCreate_WithNonExistingField_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating an index on a field that does not exist",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users @index(fields: ["Email"]) {
+						Name: String
+					}
+				`,
+				ExpectedError: "creating an index on a non-existing field",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestIndexCreate_WithRelationField_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating an index on a relation field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Devices: [Device] @index
+					}
+
+					type Device {
+						Model: String
+						Owner: Users
+					}
+				`,
+				ExpectedError: "indexing fields of the given kind is not supported",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users", "Device"}, test)
+}
+
+func TestIndexCreate_WithInvalidDirection_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating an index with an invalid direction",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String @index(direction: UP)
+					}
+				`,
+				ExpectedError: "invalid @index direction, expected ASC or DESC",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestIndexCreate_WithSchemaPatch_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test adding an index via a schema patch",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Indexes", "value": [{"Fields": [{"Name": "Name"}]}] }
+					]
+				`,
+				ExpectedError: "modifying indexes via schema patch is not supported",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
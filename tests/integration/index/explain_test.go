@@ -0,0 +1,173 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestExplainQueryWithIndex_WithEqualFilter_ShouldUseIndex(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Explain (simple) a request filtering on an indexed field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String @index(name: "UsersByName")
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query @explain {
+					Users(filter: {Name: {_eq: "John"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"explain": map[string]any{
+							"selectTopNode": map[string]any{
+								"selectNode": map[string]any{
+									"filter": nil,
+									"scanNode": map[string]any{
+										"filter": map[string]any{
+											"Name": map[string]any{
+												"_eq": "John",
+											},
+										},
+										"collectionID":   "1",
+										"collectionName": "Users",
+										"index":          "UsersByName",
+										"spans":          []map[string]any{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestExplainQueryWithIndex_WithFilterOnNonIndexedField_ShouldNotUseIndex(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Explain (simple) a request filtering on a field that is not indexed",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String @index
+						Age: Int
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query @explain {
+					Users(filter: {Age: {_eq: 21}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"explain": map[string]any{
+							"selectTopNode": map[string]any{
+								"selectNode": map[string]any{
+									"filter": nil,
+									"scanNode": map[string]any{
+										"filter": map[string]any{
+											"Age": map[string]any{
+												"_eq": int(21),
+											},
+										},
+										"collectionID":   "1",
+										"collectionName": "Users",
+										"spans": []map[string]any{
+											{
+												"start": "/1",
+												"end":   "/2",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestExplainQueryWithIndex_WithOrderOnIndexedFieldAndAfterCursor_ShouldUseIndex(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Explain (simple) a request paginating past a cursor, ordered by an indexed field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int @index(name: "UsersByAge")
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query @explain {
+					Users(order: {Age: ASC}, after: "ogGBGCACeChiYWUtOGQ4ZGE0NDAtZDU2MC01MTU1LWI4NjctNDNjMzYwNDI2NmNl") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"explain": map[string]any{
+							"selectTopNode": map[string]any{
+								"pageNode": map[string]any{
+									"first":  nil,
+									"last":   nil,
+									"after":  "ogGBGCACeChiYWUtOGQ4ZGE0NDAtZDU2MC01MTU1LWI4NjctNDNjMzYwNDI2NmNl",
+									"before": nil,
+									"orderNode": map[string]any{
+										"orderings": []map[string]any{
+											{
+												"direction": "ASC",
+												"fields": []string{
+													"Age",
+												},
+											},
+										},
+										"selectNode": map[string]any{
+											"filter": nil,
+											"scanNode": map[string]any{
+												"filter":         nil,
+												"collectionID":   "1",
+												"collectionName": "Users",
+												"index":          "UsersByAge",
+												"spans":          []map[string]any{},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,386 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithEqualFilter_ShouldFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on an indexed field with _eq",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String @index
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Islam",
+					"Age": 32
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Name: {_eq: "Islam"}}) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Islam",
+						"Age":  uint64(32),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_WithInFilter_ShouldFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on an indexed field with _in",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Islam",
+					"Age": 32
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Fred",
+					"Age": 44
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Age: {_in: [21, 44]}}, order: {Age: ASC}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+					{
+						"Name": "Fred",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_WithRangeFilter_ShouldFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on an indexed field with _gt and _le",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Islam",
+					"Age": 32
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Fred",
+					"Age": 44
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Andy"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {_and: [{Age: {_gt: 21}}, {Age: {_le: 44}}]}, order: {Age: ASC}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Islam",
+					},
+					{
+						"Name": "Fred",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_WithDescendingIndexAndRangeFilter_ShouldFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on a descending indexed field with _lt",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int @index(direction: DESC)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Islam",
+					"Age": 32
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Age: {_lt: 30}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_WithCompositeIndex_ShouldFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on the fields of a composite index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users @index(fields: ["Name", "Age"]) {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 32
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Name: {_eq: "John"}, Age: {_eq: 32}}) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(32),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_WithOrderAndAfterCursor_ShouldFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test paginating past a cursor, ordered by an indexed field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Islam",
+					"Age": 32
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Fred",
+					"Age": 44
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Andy"
+				}`,
+			},
+			testUtils.Request{
+				// The cursor of Islam (bae-8d8da440-d560-5155-b867-43c3604266ce) when ordered by Age.
+				Request: `query {
+					Users(order: {Age: ASC}, after: "ogGBGCACeChiYWUtOGQ4ZGE0NDAtZDU2MC01MTU1LWI4NjctNDNjMzYwNDI2NmNl") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Fred",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_WithDescendingOrderAndBeforeCursor_ShouldFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test paginating up to a cursor, ordered by an indexed field in descending order",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Islam",
+					"Age": 32
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Fred",
+					"Age": 44
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Andy"
+				}`,
+			},
+			testUtils.Request{
+				// The cursor of John (bae-52b9170d-b77a-5887-b877-cbdbb99b009f) when ordered by Age.
+				Request: `query {
+					Users(order: {Age: DESC}, before: "ogGBFQJ4KGJhZS01MmI5MTcwZC1iNzdhLTU4ODctYjg3Ny1jYmRiYjk5YjAwOWY") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Fred",
+					},
+					{
+						"Name": "Islam",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_AfterUpdate_ShouldFetchByNewValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on an indexed field after the field has been updated",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"Name": "Shahzad"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Name: {_eq: "John"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Name: {_eq: "Shahzad"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Shahzad",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_AfterUpdateWithMutation_ShouldFetchByNewValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on an indexed field after the field has been updated by a mutation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"Name\": \"Keenan\"}") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Keenan",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Name: {_eq: "Keenan"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Keenan",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithIndex_AfterDelete_ShouldNotFetch(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test filtering on an indexed field after the document has been deleted",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				CollectionID: 0,
+				DocID:        0,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Name: {_eq: "John"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package create
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+	simpleTests "github.com/sourcenetwork/defradb/tests/integration/mutation/simple"
+)
+
+func TestMutationCreateSimpleWithDocList(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create mutation with a list of documents",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					create_user(data: "[{\"name\": \"John\", \"age\": 27}, {\"name\": \"Bob\", \"age\": 27}]") {
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"age": uint64(27),
+					},
+					{
+						"age": uint64(27),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					user(order: {name: ASC}) {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Bob",
+						"age":  uint64(27),
+					},
+					{
+						"name": "John",
+						"age":  uint64(27),
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationCreateSimpleWithDocListOutOfKeyOrder(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create mutation with a list of documents not sorted by dockey",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					create_user(data: "[{\"name\": \"Bob\", \"age\": 27}, {\"name\": \"John\", \"age\": 27}, {\"name\": \"Fred\", \"age\": 27}]") {
+						_key
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-155d685e-f535-51e1-92f0-5b9f489f6f98",
+						"name": "Fred",
+					},
+					{
+						"_key": "bae-2947fe23-94c8-5c6c-aaab-95b98181f22b",
+						"name": "Bob",
+					},
+					{
+						"_key": "bae-88b63198-7d38-5714-a9ff-21ba46374fd1",
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationCreateSimpleWithEmptyDocList(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create mutation with an empty list of documents",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					create_user(data: "[]") {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationCreateSimpleWithDocListContainingExistingDocCreatesNothing(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create mutation with a list of documents, one of which already exists",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_user(data: "[{\"name\": \"Bob\", \"age\": 30}, {\"name\": \"John\", \"age\": 27}]") {
+						name
+					}
+				}`,
+				ExpectedError: "a document with the given dockey already exists",
+			},
+			testUtils.Request{
+				Request: `query {
+					user {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationCreateSimpleWithDocListContainingDuplicatesCreatesNothing(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create mutation with a list of documents containing the same document twice",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					create_user(data: "[{\"name\": \"Bob\", \"age\": 30}, {\"name\": \"Bob\", \"age\": 30}]") {
+						name
+					}
+				}`,
+				ExpectedError: "a document with the given dockey already exists",
+			},
+			testUtils.Request{
+				Request: `query {
+					user {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationCreateSimpleWithDocListContainingInvalidDocCreatesNothing(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create mutation with a list of documents, one of which is invalid",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					create_user(data: "[{\"name\": \"Bob\", \"age\": 30}, \"John\"]") {
+						name
+					}
+				}`,
+				ExpectedError: "json: cannot unmarshal string into Go value of type map[string]interface {}",
+			},
+			testUtils.Request{
+				Request: `query {
+					user {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package revert
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+	simpleTests "github.com/sourcenetwork/defradb/tests/integration/mutation/simple"
+)
+
+func TestMutationRevertSimpleWithoutCidReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation, without cid",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					revert_user(dockey: "bae-88b63198-7d38-5714-a9ff-21ba46374fd1") {
+						name
+					}
+				}`,
+				ExpectedError: `argument "cid" of type "String!" is required but not provided`,
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationRevertSimpleWithVersionOfOtherDocReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation, with version not in the history of the document",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					revert_user(
+						dockey: "bae-88b63198-7d38-5714-a9ff-21ba46374fd1",
+						cid: "bafybeihxvx3f7eejvco6zbxsidoeuph6ywpbo33lrqm3picna2aj7pdeiu"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "the version is not part of the history of the document",
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationRevertSimpleWithNonExistentDocReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation, with non-existent document",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					revert_user(
+						dockey: "bae-88b63198-7d38-5714-a9ff-21ba46374fd1",
+						cid: "bafybeihxvx3f7eejvco6zbxsidoeuph6ywpbo33lrqm3picna2aj7pdeiu"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "no document for the given key exists",
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
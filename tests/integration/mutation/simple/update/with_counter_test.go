@@ -0,0 +1,262 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package update
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var counterSchema = `
+	type Users {
+		name: String
+		likes: Int @crdt(type: "pncounter")
+		points: Float @crdt(type: "pncounter")
+		age: Int
+	}
+`
+
+func TestMutationUpdateCounterWithIncrement(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, incrementing an Int counter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: counterSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"likes": 10
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"likes\": {\"_inc\": 5}}") {
+						name
+						likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"likes": uint64(15),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"likes": uint64(15),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestMutationUpdateCounterWithDecrement(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, decrementing an Int counter below zero",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: counterSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"likes": 10
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"likes\": {\"_dec\": 3}}") {
+						likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"likes": uint64(7),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"likes\": {\"_dec\": 10}}") {
+						likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"likes": int64(-3),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestMutationUpdateCounterWithFloatIncrement(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, incrementing a Float counter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: counterSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 1.5
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"points\": {\"_inc\": 0.25}}") {
+						points
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"points": float64(1.75),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestMutationUpdateCounterWithIncrementOfUnsetValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, incrementing a counter that has not been set",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: counterSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"likes\": {\"_inc\": 2}}") {
+						likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"likes": uint64(2),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestMutationUpdateCounterWithValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, setting a counter directly",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: counterSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"likes": 10
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"likes\": 4}") {
+						likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"likes": uint64(4),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestMutationUpdateCounterWithIncrementOnNonCounterField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, incrementing a field that is not a counter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: counterSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"age\": {\"_inc\": 1}}") {
+						age
+					}
+				}`,
+				ExpectedError: "the type of value in the merge patch doesn't match the schema",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestMutationUpdateCounterWithUnknownOperation(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, applying an unknown operation to a counter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: counterSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"likes": 10
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"likes\": {\"_mul\": 2}}") {
+						likes
+					}
+				}`,
+				ExpectedError: "counter operations must contain exactly one of _inc or _dec",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package update
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var embeddedSchema = `
+	type Users {
+		name: String
+		address: Address @embedded
+	}
+
+	type Address {
+		city: String
+		street: String
+	}
+`
+
+func TestMutationUpdateEmbeddedObjectKeepsOtherValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, updating a single value within an embedded object",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: embeddedSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"address": {
+						"city": "Toronto",
+						"street": "Yonge Street"
+					}
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"address\": {\"city\": \"Montreal\"}}") {
+						name
+						address {
+							city
+							street
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"address": map[string]any{
+							"city":   "Montreal",
+							"street": "Yonge Street",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users", "Address"}, test)
+}
+
+func TestMutationUpdateEmbeddedObjectWithNonObjectValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, embedded object with a non-object value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: embeddedSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"address\": \"Toronto\"}") {
+						name
+					}
+				}`,
+				ExpectedError: "embedded object values must be objects",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users", "Address"}, test)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package update
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationUpdateEnumWithInvalidValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update mutation, enum field with a value that is not of the enum type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						status: Status
+					}
+
+					enum Status {
+						ACTIVE
+						ARCHIVED
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"status": "ACTIVE"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"status\": \"DELETED\"}") {
+						name
+					}
+				}`,
+				ExpectedError: "the given value is not a value of the field's enum type. Field: status, Value: DELETED",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upsert
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+	simpleTests "github.com/sourcenetwork/defradb/tests/integration/mutation/simple"
+)
+
+func TestMutationUpsertSimpleWithNoMatchCreatesDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation, no documents matching the filter",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_user(
+						filter: {name: {_eq: "Bob"}},
+						create: "{\"name\": \"Bob\", \"age\": 30}",
+						update: "{\"age\": 31}"
+					) {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Bob",
+						"age":  uint64(30),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					user(order: {name: ASC}) {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Bob",
+						"age":  uint64(30),
+					},
+					{
+						"name": "John",
+						"age":  uint64(27),
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationUpsertSimpleWithMatchUpdatesDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation, one document matching the filter",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Bob",
+					"age": 30
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_user(
+						filter: {name: {_eq: "Bob"}},
+						create: "{\"name\": \"Bob\", \"age\": 30}",
+						update: "{\"age\": 31}"
+					) {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Bob",
+						"age":  uint64(31),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					user {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Bob",
+						"age":  uint64(31),
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationUpsertSimpleWithMultipleMatchesUpdatesAllDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation, multiple documents matching the filter",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Bob",
+					"age": 30,
+					"verified": false
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27,
+					"verified": false
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_user(
+						filter: {verified: {_eq: false}},
+						create: "{\"name\": \"Fred\", \"verified\": true}",
+						update: "{\"verified\": true}"
+					) {
+						verified
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"verified": true,
+					},
+					{
+						"verified": true,
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					user(order: {name: ASC}) {
+						name
+						verified
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":     "Bob",
+						"verified": true,
+					},
+					{
+						"name":     "John",
+						"verified": true,
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestMutationUpsertSimpleWithoutFilterReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation, without filter",
+		Actions: []any{
+			testUtils.Request{
+				Request: `mutation {
+					upsert_user(
+						create: "{\"name\": \"Bob\", \"age\": 30}",
+						update: "{\"age\": 31}"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: `argument "filter" of type "userFilterArg!" is required but not provided`,
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
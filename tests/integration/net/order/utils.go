@@ -303,7 +303,7 @@ func executeTestCase(t *testing.T, test P2PTestCase) {
 						fmt.Sprintf("%s/p2p/%s", test.NodeConfig[r].Net.P2PAddress, nodes[r].PeerID()),
 					)
 					require.NoError(t, err)
-					_, err = n.Peer.SetReplicator(ctx, addr)
+					_, err = n.Peer.SetReplicator(ctx, addr, nil)
 					require.NoError(t, err)
 				}
 			}
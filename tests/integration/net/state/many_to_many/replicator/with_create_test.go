@@ -0,0 +1,161 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replicator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+const authorBookSchema = `
+	type Author {
+		Name: String
+		Books: [Book]
+	}
+	type Book {
+		Name: String
+		Authors: [Author]
+	}
+`
+
+// The links between authors and books are stored in the implicit author_book collection.
+var collectionNames = []string{"Author", "Book", "author_book"}
+
+func TestP2PManyToManyReplicator(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: authorBookSchema,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				// Create Saadi on the first node
+				NodeID:       immutable.Some(0),
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Saadi"
+				}`,
+			},
+			testUtils.CreateDoc{
+				// Create Gulistan on the first node
+				NodeID:       immutable.Some(0),
+				CollectionID: 1,
+				Doc: `{
+					"Name": "Gulistan"
+				}`,
+			},
+			testUtils.CreateDoc{
+				// Link Saadi and Gulistan on the first node
+				NodeID:       immutable.Some(0),
+				CollectionID: 2,
+				Doc: `{
+					"author_id": "bae-cf278a29-5680-565d-9c7f-4c46d3700cf0",
+					"book_id": "bae-0d41bf19-26b4-5483-8618-c5965f01a3c8"
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				// Saadi, Gulistan, and the link between them should be synced to all nodes
+				Request: `query {
+					Book {
+						Name
+						Authors {
+							Name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Gulistan",
+						"Authors": []map[string]any{
+							{
+								"Name": "Saadi",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, collectionNames, test)
+}
+
+func TestP2PManyToManyReplicatorWithLinkDeleted(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: authorBookSchema,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID:       immutable.Some(0),
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Saadi"
+				}`,
+			},
+			testUtils.CreateDoc{
+				NodeID:       immutable.Some(0),
+				CollectionID: 1,
+				Doc: `{
+					"Name": "Gulistan"
+				}`,
+			},
+			testUtils.CreateDoc{
+				NodeID:       immutable.Some(0),
+				CollectionID: 2,
+				Doc: `{
+					"author_id": "bae-cf278a29-5680-565d-9c7f-4c46d3700cf0",
+					"book_id": "bae-0d41bf19-26b4-5483-8618-c5965f01a3c8"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				// Unlink Saadi and Gulistan on the first node only, and allow the change to sync
+				NodeID:       immutable.Some(0),
+				CollectionID: 2,
+				DocID:        0,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						Name
+						Books {
+							Name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name":  "Saadi",
+						"Books": []map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, collectionNames, test)
+}
@@ -350,11 +350,15 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 			},
 			testUtils.UpdateDoc{
 				// Update John's Age on the second node only
+				//
+				// The second node has caught up on the deletion of John on connect, so the
+				// update is not applied and there is nothing to sync.
 				NodeID: immutable.Some(1),
 				DocID:  0,
 				Doc: `{
 					"Age": 66
 				}`,
+				DontSync: true,
 			},
 			testUtils.WaitForSync{},
 			testUtils.Request{
@@ -379,8 +383,7 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 					},
 				},
 			},
-			// The target node currently won't receive the pre-connection updates from the source.
-			// We should look into adding a head exchange mechanic on connect.
+			// The target node catches up on the pre-connection updates from the source on connect.
 			testUtils.Request{
 				NodeID: immutable.Some(1),
 				Request: `query {
@@ -397,9 +400,9 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 						"Age":      uint64(74),
 					},
 					{
-						"_deleted": false,
+						"_deleted": true,
 						"Name":     "John",
-						"Age":      uint64(66),
+						"Age":      uint64(62),
 					},
 				},
 			},
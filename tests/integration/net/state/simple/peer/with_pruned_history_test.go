@@ -0,0 +1,130 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package peer_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/logging"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2PWithPrunedHistory_PeerBehindPrunedHeight_RefusesSync(t *testing.T) {
+	// Send the network logs to a temp file so that the refusal can be inspected.
+	logFile := path.Join(t.TempDir(), "pruned_history_test.log")
+	logging.SetConfig(logging.Config{
+		OverridesByLoggerName: map[string]logging.Config{
+			"defra.net": {OutputPaths: []string{logFile}},
+		},
+	})
+	defer logging.SetConfig(logging.Config{
+		OverridesByLoggerName: map[string]logging.Config{
+			"defra.net": {},
+		},
+	})
+
+	test := testUtils.TestCase{
+		Description: "Test syncing a document from a peer that pruned the history the node is missing",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Update John on the first node only, whilst the nodes are not connected.
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Age": 22
+				}`,
+				DontSync: true,
+			},
+			testUtils.UpdateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Age": 23
+				}`,
+				DontSync: true,
+			},
+			testUtils.SetHistoryPolicy{
+				NodeID: immutable.Some(0),
+				HistoryPolicy: client.HistoryPolicy{
+					CollectionName: "Users",
+					KeepHeights:    1,
+				},
+			},
+			testUtils.PruneHistory{
+				NodeID: immutable.Some(0),
+			},
+			testUtils.Request{
+				// The latest state of the document is kept by the pruning node.
+				NodeID: immutable.Some(0),
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(23),
+					},
+				},
+			},
+			testUtils.ConnectPeers{
+				// Connecting triggers a sync pass, the second node holds John at height 1 and
+				// can no longer sync the commits pruned by the first node.
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(21),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+
+	logs, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	require.Contains(t, string(logs), "required to sync it has been pruned by the peer")
+}
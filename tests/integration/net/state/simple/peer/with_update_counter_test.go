@@ -0,0 +1,187 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package peer_test
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2PWithSingleDocumentConcurrentCounterIncrements(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Likes: Int @crdt(type: "pncounter")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Likes": 10
+				}`,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.UpdateDoc{
+				NodeID:    immutable.Some(0),
+				Doc:       `{"Likes": {"_inc": 1}}`,
+				AsUpdater: true,
+			},
+			testUtils.UpdateDoc{
+				NodeID:    immutable.Some(1),
+				Doc:       `{"Likes": {"_inc": 5}}`,
+				AsUpdater: true,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				// Both increments must be applied on both nodes, regardless of the order
+				// in which they were received.
+				Request: `query {
+					Users {
+						Likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Likes": uint64(16),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestP2PWithSingleDocumentConcurrentCounterIncrementAndDecrement(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Likes: Int @crdt(type: "pncounter")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Likes": 10
+				}`,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.UpdateDoc{
+				NodeID:    immutable.Some(0),
+				Doc:       `{"Likes": {"_inc": 3}}`,
+				AsUpdater: true,
+			},
+			testUtils.UpdateDoc{
+				NodeID:    immutable.Some(1),
+				Doc:       `{"Likes": {"_dec": 2}}`,
+				AsUpdater: true,
+			},
+			testUtils.UpdateDoc{
+				NodeID:    immutable.Some(1),
+				Doc:       `{"Likes": {"_inc": 3}}`,
+				AsUpdater: true,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Likes": uint64(14),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestP2PWithSingleDocumentCounterIncrementsBeforeConnect(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Likes: Int @crdt(type: "pncounter")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Likes": 10
+				}`,
+			},
+			testUtils.UpdateDoc{
+				NodeID:    immutable.Some(0),
+				Doc:       `{"Likes": {"_inc": 2}}`,
+				AsUpdater: true,
+				DontSync:  true,
+			},
+			testUtils.UpdateDoc{
+				NodeID:    immutable.Some(1),
+				Doc:       `{"Likes": {"_dec": 7}}`,
+				AsUpdater: true,
+				DontSync:  true,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.Request{
+				// The increments made whilst disconnected must be exchanged once the nodes connect.
+				Request: `query {
+					Users {
+						Likes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Likes": uint64(5),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
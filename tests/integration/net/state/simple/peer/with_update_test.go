@@ -180,6 +180,128 @@ func TestP2PWithSingleDocumentUpdatePerNode(t *testing.T) {
 	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
 }
 
+// TestP2PWithSingleDocumentSingleUpdateBeforeConnect tests that updates made whilst the nodes
+// were disconnected are synced once they connect.
+func TestP2PWithSingleDocumentSingleUpdateBeforeConnect(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Update John's Age on the first node only
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Age": 60
+				}`,
+				DontSync: true,
+			},
+			testUtils.UpdateDoc{
+				// Update John's Age on the first node only
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Age": 62
+				}`,
+				DontSync: true,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 1,
+				TargetNodeID: 0,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Age": uint64(62),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+// TestP2PWithSingleDocumentUpdatePerNodeBeforeConnect tests that concurrent updates made whilst the
+// nodes were disconnected are synced both ways once they connect.
+func TestP2PWithSingleDocumentUpdatePerNodeBeforeConnect(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Update John's Age on the first node to 60
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Age": 60
+				}`,
+				DontSync: true,
+			},
+			testUtils.UpdateDoc{
+				// Update John's Name on the second node
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Name": "Johnny"
+				}`,
+				DontSync: true,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Johnny",
+						"Age":  uint64(60),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
 func TestP2PWithSingleDocumentSingleUpdateDoesNotSyncToNonPeerNode(t *testing.T) {
 	test := testUtils.TestCase{
 		Actions: []any{
@@ -0,0 +1,242 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replicator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2POneToOneReplicatorWithAllowFieldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+				FieldFilters: map[string]client.FieldFilter{
+					"Users": {Allow: []string{"Name"}},
+				},
+			},
+			testUtils.CreateDoc{
+				// Create John on the first node only, and allow the permitted fields to sync
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID: immutable.Some(0),
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(21),
+					},
+				},
+			},
+			testUtils.Request{
+				// The partial document exists on the second node, without the fields
+				// that are not allowed.
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  nil,
+					},
+				},
+			},
+			testUtils.Request{
+				// Only the block of the allowed field is replicated, the composite block
+				// holding the values of all the fields is not.
+				NodeID: immutable.Some(1),
+				Request: `query {
+					commits {
+						height
+						links {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"height": int64(1),
+						"links":  []map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestP2POneToOneReplicatorWithDenyFieldFilterUpdatesDocCreatedBeforeReplicatorConfig(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// This document is created in first node before the replicator is set up.
+				// The fields that are not denied should be synced across nodes.
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+				FieldFilters: map[string]client.FieldFilter{
+					"Users": {Deny: []string{"Age"}},
+				},
+			},
+			testUtils.UpdateDoc{
+				// Update John on the first node only, only the Name should sync
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "Johnny",
+					"Age": 60
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID: immutable.Some(0),
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Johnny",
+						"Age":  uint64(60),
+					},
+				},
+			},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Johnny",
+						"Age":  nil,
+					},
+				},
+			},
+			testUtils.Request{
+				// Neither the blocks of the denied field nor the composite blocks are replicated.
+				NodeID: immutable.Some(1),
+				Request: `query {
+					commits(order: {height: DESC}) {
+						height
+						links {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"height": int64(2),
+						"links": []map[string]any{
+							{
+								"name": "_head",
+							},
+						},
+					},
+					{
+						"height": int64(1),
+						"links":  []map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestP2POneToOneReplicatorWithFieldFilterAndSubscribedTarget_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.SubscribeToCollection{
+				// The second node receives all the fields of the collection through pubsub.
+				NodeID:        1,
+				CollectionIDs: []int{0},
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+				FieldFilters: map[string]client.FieldFilter{
+					"Users": {Deny: []string{"Age"}},
+				},
+				ExpectedError: "can't filter the fields of Users replicated to",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -12,6 +12,7 @@ package tests
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -59,6 +60,18 @@ type ConfigureReplicator struct {
 
 	// TargetNodeID is the node ID (index) of the node to which data should be replicated.
 	TargetNodeID int
+
+	// FieldFilters may restrict the fields replicated to the target node, keyed by
+	// collection name.
+	//
+	// Documents of filtered collections are replicated as partial documents.
+	FieldFilters map[string]client.FieldFilter
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
 }
 
 // NonExistantCollectionID can be used to represent a non-existant collection ID, it will be substituted
@@ -123,6 +136,20 @@ type GetAllP2PCollections struct {
 // node 1 to see if it has been replicated.
 type WaitForSync struct{}
 
+// IndexConflictSubscription subscribes to the unique index conflicts raised on the given node
+// by the documents it receives from its peers.
+//
+// The subscription will remain active until shortly after all actions have been processed.
+// The conflicts received will then be asserted upon.
+type IndexConflictSubscription struct {
+	// NodeID is the node ID (index) of the node on which to subscribe.
+	NodeID int
+
+	// ExpectedIndexNames are the names of the unique indexes expected to be violated, in the
+	// order in which the conflicts are raised.
+	ExpectedIndexNames []string
+}
+
 // AnyOf may be used as `Results` field where the value may
 // be one of several values, yet the value of that field must be the same
 // across all nodes due to strong eventual consistancy.
@@ -152,6 +179,10 @@ func connectPeers(
 	log.Info(ctx, "Bootstrapping with peers", logging.NewKV("Addresses", addrs))
 	sourceNode.Boostrap(addrs)
 
+	// Connecting triggers a sync pass on both nodes, this must complete before any further changes are made
+	// or the changes may be synced by it instead of by the events we wait on below.
+	waitForPeerSync(t, sourceNode, targetNode)
+
 	// Boostrap triggers a bunch of async stuff for which we have no good way of waiting on.  It must be
 	// allowed to complete before documentation begins or it will not even try and sync it. So for now, we
 	// sleep a little.
@@ -284,6 +315,7 @@ func configureReplicator(
 	cfg ConfigureReplicator,
 	nodes []*node.Node,
 	addresses []string,
+	collectionNames []string,
 ) chan struct{} {
 	sourceNode := nodes[cfg.SourceNodeID]
 	targetNode := nodes[cfg.TargetNodeID]
@@ -292,8 +324,22 @@ func configureReplicator(
 	addr, err := ma.NewMultiaddr(targetAddress)
 	require.NoError(t, err)
 
-	_, err = sourceNode.Peer.SetReplicator(ctx, addr)
+	_, err = sourceNode.Peer.SetReplicator(ctx, addr, cfg.FieldFilters)
+	expectedErrorRaised := AssertError(t, testCase.Description, err, cfg.ExpectedError)
+	assertExpectedErrorRaised(t, testCase.Description, cfg.ExpectedError, expectedErrorRaised)
+	if err != nil {
+		// Nothing will be replicated, so there is nothing to wait for.
+		nodeSynced := make(chan struct{})
+		close(nodeSynced)
+		return nodeSynced
+	}
+
+	// The replicator is only dialed on demand, so we connect to it here in order to control
+	// when the sync pass triggered by the connection takes place.
+	addrs, err := netutils.ParsePeers([]string{targetAddress})
 	require.NoError(t, err)
+	sourceNode.Boostrap(addrs)
+	waitForPeerSync(t, sourceNode, targetNode)
 
 	sourceToTargetEvents := []int{0}
 	targetToSourceEvents := []int{0}
@@ -308,7 +354,8 @@ func configureReplicator(
 			}
 
 			if action.NodeID.HasValue() && action.NodeID.Value() == cfg.SourceNodeID {
-				sourceToTargetEvents[waitIndex] += 1
+				filter := cfg.FieldFilters[collectionNames[action.CollectionID]]
+				sourceToTargetEvents[waitIndex] += getReplicatedLogCount(t, filter, action.Doc)
 			}
 
 			currentdocID++
@@ -330,7 +377,8 @@ func configureReplicator(
 			}
 
 			if action.NodeID.HasValue() && action.NodeID.Value() == cfg.SourceNodeID {
-				sourceToTargetEvents[waitIndex] += 1
+				filter := cfg.FieldFilters[collectionNames[action.CollectionID]]
+				sourceToTargetEvents[waitIndex] += getReplicatedLogCount(t, filter, action.Doc)
 			}
 
 		case WaitForSync:
@@ -358,6 +406,38 @@ func configureReplicator(
 	return nodeSynced
 }
 
+// getReplicatedLogCount returns the number of logs pushed to a replicator with the given
+// field filter when the fields of the given JSON document are written.
+//
+// The blocks of each of the permitted fields are pushed as separate logs to partial replicas.
+func getReplicatedLogCount(t *testing.T, filter client.FieldFilter, docJSON string) int {
+	if filter.IsEmpty() {
+		return 1
+	}
+
+	fields := map[string]any{}
+	err := json.Unmarshal([]byte(docJSON), &fields)
+	require.NoError(t, err)
+
+	count := 0
+	for fieldName := range fields {
+		if filter.Permits(fieldName) {
+			count++
+		}
+	}
+	return count
+}
+
+// waitForPeerSync waits for the given, connected, nodes to have caught up with each other.
+//
+// Any errors generated whilst waiting will result in a test failure.
+func waitForPeerSync(t *testing.T, sourceNode *node.Node, targetNode *node.Node) {
+	err := sourceNode.WaitForPeerSync(targetNode.PeerID())
+	require.NoError(t, err)
+	err = targetNode.WaitForPeerSync(sourceNode.PeerID())
+	require.NoError(t, err)
+}
+
 // subscribeToCollection sets up a collection subscription on the given node/collection.
 //
 // Any errors generated during this process will result in a test failure.
@@ -478,6 +558,45 @@ func waitForSync(
 	}
 }
 
+// subscribeToIndexConflicts subscribes to the unique index conflicts of the given node, returning
+// a channel that will receive the assertion of the conflicts once all actions have been processed.
+func subscribeToIndexConflicts(
+	t *testing.T,
+	allActionsDone chan struct{},
+	action IndexConflictSubscription,
+	nodes []*node.Node,
+) chan func() {
+	conflictsAssert := make(chan func())
+
+	conflicts, err := nodes[action.NodeID].DB.Events().IndexConflicts.Value().Subscribe()
+	require.NoError(t, err)
+
+	go func() {
+		indexNames := []string{}
+		allActionsAreDone := false
+		for {
+			select {
+			case conflict := <-conflicts:
+				indexNames = append(indexNames, conflict.IndexName)
+
+			case <-allActionsDone:
+				allActionsAreDone = true
+			}
+
+			if allActionsAreDone && len(indexNames) >= len(action.ExpectedIndexNames) {
+				conflictsAssert <- func() {
+					// This assert should be executed from the main test routine
+					// so that failures will be properly handled.
+					assert.Equal(t, action.ExpectedIndexNames, indexNames)
+				}
+				return
+			}
+		}
+	}()
+
+	return conflictsAssert
+}
+
 const randomMultiaddr = "/ip4/0.0.0.0/tcp/0"
 
 func RandomNetworkingConfig() ConfigureNode {
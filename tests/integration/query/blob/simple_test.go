@@ -0,0 +1,111 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package blob
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryBlob(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with a blob value",
+		Request: `query {
+					users {
+						Name
+						Avatar
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Avatar": "00ff0a"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":   "John",
+				"Avatar": "00ff0a",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryBlobWithEqualFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an equality filter on a blob value",
+		Request: `query {
+					users(filter: {Avatar: {_eq: "00ff0a"}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Avatar": "00ff0a"
+				}`,
+				`{
+					"Name": "Bob",
+					"Avatar": "0b"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryBlobWithInvalidFilterValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with a filter on a blob value that is not hex encoded",
+		Request: `query {
+					users(filter: {Avatar: {_eq: "not hex"}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Avatar": "00ff0a"
+				}`,
+			},
+		},
+		ExpectedError: "Argument \"filter\" has invalid value {Avatar: {_eq: \"not hex\"}}.",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryBlobWithCreateOfInvalidValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Create mutation with a blob value that is not hex encoded",
+		Request: `mutation {
+					create_users(data: "{\"Name\": \"John\", \"Avatar\": \"not hex\"}") {
+						Name
+					}
+				}`,
+		ExpectedError: "blob values must be hex encoded strings",
+	}
+
+	executeTestCase(t, test)
+}
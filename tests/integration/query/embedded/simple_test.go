@@ -0,0 +1,198 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package embedded
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryEmbeddedObject(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, embedded object",
+		Request: `query {
+					users {
+						Name
+						Address {
+							City
+							Number
+						}
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Address": {
+						"City": "Toronto",
+						"Street": "Yonge Street",
+						"Number": 12
+					}
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"Address": map[string]any{
+					"City":   "Toronto",
+					"Number": uint64(12),
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithAliasAndTypeName(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, embedded object with aliased fields and type name",
+		Request: `query {
+					users {
+						Name
+						home: Address {
+							__typename
+							town: City
+						}
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Address": {
+						"City": "Toronto"
+					}
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"home": map[string]any{
+					"__typename": "address",
+					"town":       "Toronto",
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithPartialValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, embedded object with only some values set",
+		Request: `query {
+					users {
+						Name
+						Address {
+							City
+							Street
+						}
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Address": {
+						"Street": "Yonge Street"
+					}
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"Address": map[string]any{
+					"City":   nil,
+					"Street": "Yonge Street",
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithoutValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, embedded object without a value",
+		Request: `query {
+					users {
+						Name
+						Address {
+							City
+						}
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":    "John",
+				"Address": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithUnknownField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create, embedded object with a value for a field it does not have",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Address": {
+						"Country": "Canada"
+					}
+				}`,
+				ExpectedError: "The given field does not exist. Name: Address.Country",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users", "address"}, test)
+}
+
+func TestQueryEmbeddedObjectWithNonObjectValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create, embedded object with a non-object value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Address": "Toronto"
+				}`,
+				ExpectedError: "embedded object values must be objects",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users", "address"}, test)
+}
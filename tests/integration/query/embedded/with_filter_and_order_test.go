@@ -0,0 +1,165 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package embedded
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var embeddedTestDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Address": {
+				"City": "Toronto",
+				"Number": 12
+			}
+		}`,
+		`{
+			"Name": "Bob",
+			"Address": {
+				"City": "Montreal",
+				"Number": 3
+			}
+		}`,
+		`{
+			"Name": "Shahzad"
+		}`,
+	},
+}
+
+func TestQueryEmbeddedObjectWithEqualFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an equality filter on a field within an embedded object",
+		Request: `query {
+					users(filter: {Address: {City: {_eq: "Montreal"}}}) {
+						Name
+					}
+				}`,
+		Docs: embeddedTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithGreaterThanFilterAndSelection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with a comparison filter on a field within a selected embedded object",
+		Request: `query {
+					users(filter: {Address: {Number: {_gt: 5}}}) {
+						Name
+						Address {
+							City
+						}
+					}
+				}`,
+		Docs: embeddedTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"Address": map[string]any{
+					"City": "Toronto",
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithNotFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with a _not filter on a field within an embedded object",
+		Request: `query {
+					users(filter: {_not: {Address: {City: {_eq: "Toronto"}}}}, order: {Name: ASC}) {
+						Name
+					}
+				}`,
+		Docs: embeddedTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+			},
+			{
+				"Name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithOrderAscending(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query ordered by a field within an embedded object",
+		Request: `query {
+					users(order: {Address: {Number: ASC}}) {
+						Name
+						Address {
+							Number
+						}
+					}
+				}`,
+		Docs: embeddedTestDocs,
+		Results: []map[string]any{
+			{
+				"Name":    "Shahzad",
+				"Address": nil,
+			},
+			{
+				"Name": "Bob",
+				"Address": map[string]any{
+					"Number": uint64(3),
+				},
+			},
+			{
+				"Name": "John",
+				"Address": map[string]any{
+					"Number": uint64(12),
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEmbeddedObjectWithOrderDescendingWithoutSelection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query ordered by a field within an embedded object that is not selected",
+		Request: `query {
+					users(order: {Address: {City: DESC}}) {
+						Name
+					}
+				}`,
+		Docs: embeddedTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+			{
+				"Name": "Bob",
+			},
+			{
+				"Name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package enum
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryEnum(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, enum field",
+		Request: `query {
+					users {
+						Name
+						Status
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Status": "ACTIVE"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":   "John",
+				"Status": "ACTIVE",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEnumWithoutValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, enum field without a value",
+		Request: `query {
+					users {
+						Name
+						Status
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":   "John",
+				"Status": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEnumWithInvalidValueOnCreate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create, enum field with a value that is not of the enum type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Status": "DELETED"
+				}`,
+				ExpectedError: "the given value is not a value of the field's enum type. Field: Status, Value: DELETED",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQueryEnumWithNonStringValueOnCreate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple create, enum field with a non-string value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Status": 1
+				}`,
+				ExpectedError: "the given value is not a value of the field's enum type. Field: Status, Value: 1",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package enum
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var enumTestDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Status": "ACTIVE"
+		}`,
+		`{
+			"Name": "Bob",
+			"Status": "ARCHIVED"
+		}`,
+		`{
+			"Name": "Fred",
+			"Status": "PENDING"
+		}`,
+	},
+}
+
+func TestQueryEnumWithEqualFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an equality filter on an enum field",
+		Request: `query {
+					users(filter: {Status: {_eq: ARCHIVED}}) {
+						Name
+					}
+				}`,
+		Docs: enumTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEnumWithInFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an in filter on an enum field",
+		Request: `query {
+					users(filter: {Status: {_in: [ACTIVE, PENDING]}}, order: {Name: ASC}) {
+						Name
+					}
+				}`,
+		Docs: enumTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Fred",
+			},
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEnumWithFilterOfValueNotOfEnum(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an equality filter on an enum field with a value not of the enum",
+		Request: `query {
+					users(filter: {Status: {_eq: DELETED}}) {
+						Name
+					}
+				}`,
+		Docs:          enumTestDocs,
+		ExpectedError: "Argument \"filter\" has invalid value {Status: {_eq: DELETED}}.",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryEnumWithOrder(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query ordered by an enum field",
+		Request: `query {
+					users(order: {Status: DESC}) {
+						Name
+						Status
+					}
+				}`,
+		Docs: enumTestDocs,
+		Results: []map[string]any{
+			{
+				"Name":   "Fred",
+				"Status": "PENDING",
+			},
+			{
+				"Name":   "Bob",
+				"Status": "ARCHIVED",
+			},
+			{
+				"Name":   "John",
+				"Status": "ACTIVE",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
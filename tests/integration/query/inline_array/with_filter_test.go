@@ -0,0 +1,167 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineStringArrayWithAnyFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by any item",
+		Request: `query {
+					users(filter: {PreferredStrings: {_any: {_eq: "urgent"}}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"PreferredStrings": ["bug", "urgent"]
+				}`,
+				`{
+					"Name": "Shahzad",
+					"PreferredStrings": ["feature"]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithAllFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by all items",
+		Request: `query {
+					users(filter: {FavouriteIntegers: {_all: {_gt: 0}}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"FavouriteIntegers": [1, 2, 3]
+				}`,
+				`{
+					"Name": "Shahzad",
+					"FavouriteIntegers": [-1, 2, 3]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableFloatArrayWithNoneFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by no items",
+		Request: `query {
+					users(filter: {PageRatings: {_none: {_eq: null}}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"PageRatings": [4.5, 2.5]
+				}`,
+				`{
+					"Name": "Shahzad",
+					"PageRatings": [4.5, null]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineStringArrayWithContainsFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by containing all values",
+		Request: `query {
+					users(filter: {PreferredStrings: {_contains: ["bug", "urgent"]}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"PreferredStrings": ["urgent", "feature", "bug"]
+				}`,
+				`{
+					"Name": "Shahzad",
+					"PreferredStrings": ["urgent", "feature"]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableIntegerArrayWithOverlapsFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by containing any value",
+		Request: `query {
+					users(filter: {TestScores: {_overlaps: [100, 90]}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"TestScores": [50, null, 90]
+				}`,
+				`{
+					"Name": "Shahzad",
+					"TestScores": [50, 80]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineIntegerArrayWithMinAndMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, min and max of integer array",
+		Request: `query {
+					users {
+						Name
+						_min(FavouriteIntegers: {})
+						_max(FavouriteIntegers: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Shahzad",
+					"FavouriteIntegers": [-1, 2, -1, 1, 0]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Shahzad",
+				"_min": int64(-1),
+				"_max": int64(2),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineEmptyIntegerArrayWithMin(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, min of empty integer array",
+		Request: `query {
+					users {
+						Name
+						_min(FavouriteIntegers: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Shahzad",
+					"FavouriteIntegers": []
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Shahzad",
+				"_min": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableIntegerArrayWithMinSkipsNil(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, min of nillable integer array ignores nil items",
+		Request: `query {
+					users {
+						Name
+						_min(TestScores: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Shahzad",
+					"TestScores": [3, null, 1, 4]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Shahzad",
+				"_min": int64(1),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineFloatArrayWithMaxWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered max of float array",
+		Request: `query {
+					users {
+						Name
+						_max(FavouriteFloats: {filter: {_lt: 3}})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Shahzad",
+					"FavouriteFloats": [3.1425, 0.00000000001, 2.71828, 10]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Shahzad",
+				"_max": 2.71828,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineStringArrayWithMaxWithLimitOffsetOrder(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, max of string array with limit, offset and order",
+		Request: `query {
+					users {
+						Name
+						_max(PreferredStrings: {offset: 1, limit: 2, order: ASC})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Shahzad",
+					"PreferredStrings": ["c", "a", "d", "b"]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Shahzad",
+				"_max": "c",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryJSONWithObjectValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with a JSON object value",
+		Request: `query {
+					users {
+						Name
+						Custom
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Custom": {
+						"tree": "maple",
+						"age": 250,
+						"height": 12.5,
+						"leaves": ["red", "orange"],
+						"location": {
+							"city": "Toronto"
+						}
+					}
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"Custom": map[string]any{
+					"tree":   "maple",
+					"age":    uint64(250),
+					"height": 12.5,
+					"leaves": []any{"red", "orange"},
+					"location": map[string]any{
+						"city": "Toronto",
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryJSONWithScalarValues(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with JSON scalar and array values",
+		Request: `query {
+					users(order: {Name: ASC}) {
+						Name
+						Custom
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Bob",
+					"Custom": "some text"
+				}`,
+				`{
+					"Name": "John",
+					"Custom": [1, "two", true]
+				}`,
+				`{
+					"Name": "Shahzad"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":   "Bob",
+				"Custom": "some text",
+			},
+			{
+				"Name":   "John",
+				"Custom": []any{uint64(1), "two", true},
+			},
+			{
+				"Name":   "Shahzad",
+				"Custom": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var jsonFilterTestDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Custom": {
+				"tree": "maple",
+				"age": 250,
+				"location": {
+					"city": "Toronto"
+				}
+			}
+		}`,
+		`{
+			"Name": "Bob",
+			"Custom": {
+				"tree": "oak",
+				"age": 120,
+				"location": {
+					"city": "Montreal"
+				}
+			}
+		}`,
+		`{
+			"Name": "Shahzad",
+			"Custom": "maple"
+		}`,
+	},
+}
+
+func TestQueryJSONWithEqualFilterOnPath(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an equality filter on a path within a JSON value",
+		Request: `query {
+					users(filter: {Custom: {tree: {_eq: "maple"}}}) {
+						Name
+					}
+				}`,
+		Docs: jsonFilterTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryJSONWithEqualFilterOnNestedPath(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an equality filter on a nested path within a JSON value",
+		Request: `query {
+					users(filter: {Custom: {location: {city: {_eq: "Montreal"}}}}) {
+						Name
+					}
+				}`,
+		Docs: jsonFilterTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryJSONWithNumericFilterOnPath(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with a greater than filter on a numeric path within a JSON value",
+		Request: `query {
+					users(filter: {Custom: {age: {_gt: 200}}}) {
+						Name
+					}
+				}`,
+		Docs: jsonFilterTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryJSONWithCompoundFilterOnPaths(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an _or filter on paths within a JSON value",
+		Request: `query {
+					users(
+						filter: {Custom: {_or: [{tree: {_eq: "oak"}}, {location: {city: {_eq: "Toronto"}}}]}},
+						order: {Name: ASC}
+					) {
+						Name
+					}
+				}`,
+		Docs: jsonFilterTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+			},
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryJSONWithEqualFilterOnValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with an equality filter on a JSON scalar value",
+		Request: `query {
+					users(filter: {Custom: {_eq: "maple"}}) {
+						Name
+					}
+				}`,
+		Docs: jsonFilterTestDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,568 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package many_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryManyToManyFromFirstSide(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query from the author side",
+		Actions: withAuthorsAndBooks(
+			testUtils.Request{
+				Request: `query {
+					Author(order: {name: ASC}) {
+						name
+						books(order: {name: ASC}) {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Cornelia",
+						"books": []map[string]any{
+							{
+								"name": "A Time for Mercy",
+							},
+							{
+								"name": "Theif Lord",
+							},
+						},
+					},
+					{
+						"name": "John",
+						"books": []map[string]any{
+							{
+								"name": "A Time for Mercy",
+							},
+							{
+								"name": "Painted House",
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyFromSecondSide(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query from the book side",
+		Actions: withAuthorsAndBooks(
+			testUtils.Request{
+				Request: `query {
+					Book(order: {name: ASC}) {
+						name
+						authors(order: {name: ASC}) {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "A Time for Mercy",
+						"authors": []map[string]any{
+							{
+								"name": "Cornelia",
+							},
+							{
+								"name": "John",
+							},
+						},
+					},
+					{
+						"name": "Painted House",
+						"authors": []map[string]any{
+							{
+								"name": "John",
+							},
+						},
+					},
+					{
+						"name": "Theif Lord",
+						"authors": []map[string]any{
+							{
+								"name": "Cornelia",
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyWithNoLinks(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query, document without links",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: authorBookGQLSchema,
+			},
+			testUtils.CreateDoc{
+				CollectionID: authorCollectionID,
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						name
+						books {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"books": []map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyWithFilterOnSubType(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query with filter on the related type",
+		Actions: withAuthorsAndBooks(
+			testUtils.Request{
+				Request: `query {
+					Author(filter: {books: {name: {_eq: "Theif Lord"}}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Cornelia",
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyWithFilterWithinSubType(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query with filter within the related selection",
+		Actions: withAuthorsAndBooks(
+			testUtils.Request{
+				Request: `query {
+					Book(filter: {name: {_eq: "A Time for Mercy"}}) {
+						name
+						authors(filter: {name: {_eq: "John"}}) {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "A Time for Mercy",
+						"authors": []map[string]any{
+							{
+								"name": "John",
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyWithCount(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query with count of related documents",
+		Actions: withAuthorsAndBooks(
+			testUtils.Request{
+				Request: `query {
+					Book(order: {name: ASC}) {
+						name
+						_count(authors: {})
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "A Time for Mercy",
+						"_count": 2,
+					},
+					{
+						"name":   "Painted House",
+						"_count": 1,
+					},
+					{
+						"name":   "Theif Lord",
+						"_count": 1,
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyAfterLinkRemoved(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query after a link has been removed",
+		Actions: withAuthorsAndBooks(
+			testUtils.UpdateDoc{
+				CollectionID: authorCollectionID,
+				// John - Painted House only, unlinking A Time for Mercy
+				DocID: 0,
+				Doc: `{
+					"books_id": [
+						"bae-3d236f89-6a31-5add-a36a-27971a2eac76"
+					]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Book(filter: {name: {_eq: "A Time for Mercy"}}) {
+						name
+						authors {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "A Time for Mercy",
+						"authors": []map[string]any{
+							{
+								"name": "Cornelia",
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyAfterLinkRemovedAndRestored(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query after a link has been removed and restored",
+		Actions: withAuthorsAndBooks(
+			testUtils.UpdateDoc{
+				CollectionID: authorCollectionID,
+				// John - Painted House only, unlinking A Time for Mercy
+				DocID: 0,
+				Doc: `{
+					"books_id": [
+						"bae-3d236f89-6a31-5add-a36a-27971a2eac76"
+					]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: authorCollectionID,
+				// John - Painted House, A Time for Mercy
+				DocID: 0,
+				Doc: `{
+					"books_id": [
+						"bae-3d236f89-6a31-5add-a36a-27971a2eac76",
+						"bae-b79e1ebe-d819-5abf-9fd1-9009a532eb03"
+					]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Book(filter: {name: {_eq: "A Time for Mercy"}}) {
+						name
+						authors(order: {name: ASC}) {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "A Time for Mercy",
+						"authors": []map[string]any{
+							{
+								"name": "Cornelia",
+							},
+							{
+								"name": "John",
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyAfterLinkedDocDeleted(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query after a linked document has been deleted",
+		Actions: withAuthorsAndBooks(
+			testUtils.DeleteDoc{
+				CollectionID: authorCollectionID,
+				// John
+				DocID: 0,
+			},
+			testUtils.Request{
+				Request: `query {
+					Book(order: {name: ASC}) {
+						name
+						authors {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "A Time for Mercy",
+						"authors": []map[string]any{
+							{
+								"name": "Cornelia",
+							},
+						},
+					},
+					{
+						"name":    "Painted House",
+						"authors": []map[string]any{},
+					},
+					{
+						"name": "Theif Lord",
+						"authors": []map[string]any{
+							{
+								"name": "Cornelia",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				// The links of the deleted document must have been deleted along with it.
+				Request: `query {
+					author_book(order: {book_id: ASC}) {
+						book_id
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"book_id": "bae-b79e1ebe-d819-5abf-9fd1-9009a532eb03",
+					},
+					{
+						"book_id": "bae-c2f3f08b-53f2-5b53-9a9f-da1eee096321",
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyWithLinksCreatedByMutation(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query with links set by the create mutation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: authorBookGQLSchema,
+			},
+			testUtils.CreateDoc{
+				CollectionID: bookCollectionID,
+				// bae-3d236f89-6a31-5add-a36a-27971a2eac76
+				Doc: `{
+					"name": "Painted House"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: bookCollectionID,
+				// bae-b79e1ebe-d819-5abf-9fd1-9009a532eb03
+				Doc: `{
+					"name": "A Time for Mercy"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation($data: String) {
+					create_Author(data: $data) {
+						name
+						books(order: {name: ASC}) {
+							name
+						}
+					}
+				}`,
+				Variables: map[string]any{
+					"data": `{
+						"name": "John",
+						"books_id": [
+							"bae-3d236f89-6a31-5add-a36a-27971a2eac76",
+							"bae-b79e1ebe-d819-5abf-9fd1-9009a532eb03"
+						]
+					}`,
+				},
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"books": []map[string]any{
+							{
+								"name": "A Time for Mercy",
+							},
+							{
+								"name": "Painted House",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Book(order: {name: ASC}) {
+						name
+						authors {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "A Time for Mercy",
+						"authors": []map[string]any{
+							{
+								"name": "John",
+							},
+						},
+					},
+					{
+						"name": "Painted House",
+						"authors": []map[string]any{
+							{
+								"name": "John",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyWithLinksSetByUpdateMutationFromSecondSide(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation query with links set by the update mutation of the book side",
+		Actions: withAuthorsAndBooks(
+			testUtils.Request{
+				Request: `mutation($data: String) {
+					update_Book(filter: {name: {_eq: "Painted House"}}, data: $data) {
+						name
+						authors(order: {name: ASC}) {
+							name
+						}
+					}
+				}`,
+				Variables: map[string]any{
+					// Cornelia, replacing John
+					"data": `{
+						"authors_id": [
+							"bae-2163becf-66ca-57e9-9440-d5113261b621"
+						]
+					}`,
+				},
+				Results: []map[string]any{
+					{
+						"name": "Painted House",
+						"authors": []map[string]any{
+							{
+								"name": "Cornelia",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Author(filter: {name: {_eq: "John"}}) {
+						name
+						books {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"books": []map[string]any{
+							{
+								"name": "A Time for Mercy",
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryManyToManyWithLinkToNonExistingDoc_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Many-to-many relation link to a document that does not exist",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: authorBookGQLSchema,
+			},
+			testUtils.Request{
+				Request: `mutation($data: String) {
+					create_Author(data: $data) {
+						name
+					}
+				}`,
+				Variables: map[string]any{
+					"data": `{
+						"name": "John",
+						"books_id": [
+							"bae-3d236f89-6a31-5add-a36a-27971a2eac76"
+						]
+					}`,
+				},
+				ExpectedError: "the document to link to does not exist",
+			},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
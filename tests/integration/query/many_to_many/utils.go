@@ -0,0 +1,111 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package many_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var authorBookGQLSchema = (`
+	type Author {
+		name: String
+		books: [Book]
+	}
+
+	type Book {
+		name: String
+		authors: [Author]
+	}
+`)
+
+// The links between authors and books are stored in the implicit author_book collection.
+var collectionNames = []string{"Author", "Book", "author_book"}
+
+const (
+	authorCollectionID = 0
+	bookCollectionID   = 1
+)
+
+// withAuthorsAndBooks returns the given actions, preceded by the actions creating the schema,
+// two authors, and three books, one of which is co-authored by both authors.
+//
+// The books are linked to their authors through the `books_id` field of the authors.
+func withAuthorsAndBooks(actions ...any) []any {
+	setup := []any{
+		testUtils.SchemaUpdate{
+			Schema: authorBookGQLSchema,
+		},
+		testUtils.CreateDoc{
+			CollectionID: authorCollectionID,
+			// bae-decf6467-4c7c-50d7-b09d-0a7097ef6bad
+			Doc: `{
+				"name": "John"
+			}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: authorCollectionID,
+			// bae-2163becf-66ca-57e9-9440-d5113261b621
+			Doc: `{
+				"name": "Cornelia"
+			}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: bookCollectionID,
+			// bae-3d236f89-6a31-5add-a36a-27971a2eac76
+			Doc: `{
+				"name": "Painted House"
+			}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: bookCollectionID,
+			// bae-c2f3f08b-53f2-5b53-9a9f-da1eee096321
+			Doc: `{
+				"name": "Theif Lord"
+			}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: bookCollectionID,
+			// bae-b79e1ebe-d819-5abf-9fd1-9009a532eb03
+			Doc: `{
+				"name": "A Time for Mercy"
+			}`,
+		},
+		testUtils.UpdateDoc{
+			CollectionID: authorCollectionID,
+			// John - Painted House, A Time for Mercy
+			DocID: 0,
+			Doc: `{
+				"books_id": [
+					"bae-3d236f89-6a31-5add-a36a-27971a2eac76",
+					"bae-b79e1ebe-d819-5abf-9fd1-9009a532eb03"
+				]
+			}`,
+		},
+		testUtils.UpdateDoc{
+			CollectionID: authorCollectionID,
+			// Cornelia - Theif Lord, A Time for Mercy
+			DocID: 1,
+			Doc: `{
+				"books_id": [
+					"bae-c2f3f08b-53f2-5b53-9a9f-da1eee096321",
+					"bae-b79e1ebe-d819-5abf-9fd1-9009a532eb03"
+				]
+			}`,
+		},
+	}
+	return append(setup, actions...)
+}
+
+func executeTestCase(t *testing.T, test testUtils.TestCase) {
+	testUtils.ExecuteTestCase(t, collectionNames, test)
+}
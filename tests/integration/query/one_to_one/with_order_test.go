@@ -263,3 +263,133 @@ func TestQueryOneToOneWithChildIntOrderAscendingWithNoSubTypeFieldsSelected(t *t
 
 	executeTestCase(t, test)
 }
+
+func TestQueryOneToOneWithChildIntOrderAscendingAndNullsLast(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-one relation query with ascending order by sub type, nulls last",
+		Request: `query {
+			book(order: {author: {age: ASC}, _nulls: LAST}) {
+				name
+				author {
+					age
+				}
+			}
+		}`,
+		Docs: map[int][]string{
+			//books
+			0: {
+				// bae-fd541c25-229e-5280-b44b-e5c2af3e374d
+				`{
+					"name": "Painted House",
+					"rating": 4.9
+				}`,
+				// bae-d432bdfb-787d-5a1c-ac29-dc025ab80095
+				`{
+					"name": "Theif Lord",
+					"rating": 4.8
+				}`,
+				`{
+					"name": "A Time for Mercy",
+					"rating": 4.5
+				}`,
+			},
+			//authors
+			1: {
+				// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+				`{
+					"name": "John Grisham",
+					"age": 65,
+					"verified": true,
+					"published_id": "bae-fd541c25-229e-5280-b44b-e5c2af3e374d"
+				}`,
+				// bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04
+				`{
+					"name": "Cornelia Funke",
+					"age": 62,
+					"verified": false,
+					"published_id": "bae-d432bdfb-787d-5a1c-ac29-dc025ab80095"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Theif Lord",
+				"author": map[string]any{
+					"age": uint64(62),
+				},
+			},
+			{
+				"name": "Painted House",
+				"author": map[string]any{
+					"age": uint64(65),
+				},
+			},
+			{
+				"name":   "A Time for Mercy",
+				"author": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToOneWithChildIntOrderDescendingAndChildNullsFirst(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-one relation query with descending order by sub type, sub type nulls first",
+		Request: `query {
+			book(order: {author: {age: DESC, _nulls: FIRST}}) {
+				name
+			}
+		}`,
+		Docs: map[int][]string{
+			//books
+			0: {
+				// bae-fd541c25-229e-5280-b44b-e5c2af3e374d
+				`{
+					"name": "Painted House",
+					"rating": 4.9
+				}`,
+				// bae-d432bdfb-787d-5a1c-ac29-dc025ab80095
+				`{
+					"name": "Theif Lord",
+					"rating": 4.8
+				}`,
+				`{
+					"name": "A Time for Mercy",
+					"rating": 4.5
+				}`,
+			},
+			//authors
+			1: {
+				// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+				`{
+					"name": "John Grisham",
+					"age": 65,
+					"verified": true,
+					"published_id": "bae-fd541c25-229e-5280-b44b-e5c2af3e374d"
+				}`,
+				// bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04
+				`{
+					"name": "Cornelia Funke",
+					"age": 62,
+					"verified": false,
+					"published_id": "bae-d432bdfb-787d-5a1c-ac29-dc025ab80095"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "A Time for Mercy",
+			},
+			{
+				"name": "Painted House",
+			},
+			{
+				"name": "Theif Lord",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
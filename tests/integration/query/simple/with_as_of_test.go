@@ -0,0 +1,394 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithAsOfHeight(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOfHeight, returning the document as it was created",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOfHeight: 1) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(21),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOfHeight: 2) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(22),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfHeightAndFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOfHeight and filter, filtering on past values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Fred",
+					"Age": 30
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"Age": 31
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOfHeight: 1, filter: {Age: {_lt: 25}}) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(21),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Age: {_lt: 25}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfHeightAndGroupByCount(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOfHeight, group by and count, aggregating past values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Fred",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOfHeight: 1, groupBy: [Age]) {
+						Age
+						_count(_group: {})
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Age":    uint64(21),
+						"_count": 2,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfHeightReturnsDeletedDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOfHeight, returning a document that has since been deleted",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				CollectionID: 0,
+				DocID:        0,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOfHeight: 1) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOfHeight: 2) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfBeforeCreation(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf, before the document was created",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2000-01-01T00:00:00Z") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfAfterUpdate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf, after the latest update",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2100-01-01T00:00:00Z") {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(22),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfAndAsOfHeightReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with both asOf and asOfHeight",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2100-01-01T00:00:00Z", asOfHeight: 1) {
+						Name
+					}
+				}`,
+				ExpectedError: "asOf cannot be combined with asOfHeight",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfHeightAndCidReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with both asOfHeight and cid",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(
+						asOfHeight: 1,
+						dockey: "bae-52b9170d-b77a-5887-b877-cbdbb99b009f",
+						cid: "bafybeicloiyf5zl5k54cjuhfg6rpsj7rmhnaoxn5lwtqlqdeafsxfg2dum"
+					) {
+						Name
+					}
+				}`,
+				ExpectedError: "asOf and asOfHeight cannot be combined with cid",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfWithInvalidTimeReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf that is not a valid time",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "yesterday") {
+						Name
+					}
+				}`,
+				ExpectedError: "Argument \"asOf\" has invalid value \"yesterday\".",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,330 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// The docs below are yielded in key order when unordered:
+//
+//	Jim:  bae-1378ab62-e064-5af4-9ea6-49941c8d8f94
+//	John: bae-52b9170d-b77a-5887-b877-cbdbb99b009f
+//	Fred: bae-9b2e1434-9d61-5eb1-b3b9-82e8e40729a7
+var cursorPaginationDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Age": 21
+		}`,
+		`{
+			"Name": "Jim",
+			"Age": 27
+		}`,
+		`{
+			"Name": "Fred",
+			"Age": 21
+		}`,
+	},
+}
+
+const (
+	jimCursor  = "ogGAAngoYmFlLTEzNzhhYjYyLWUwNjQtNWFmNC05ZWE2LTQ5OTQxYzhkOGY5NA"
+	johnCursor = "ogGAAngoYmFlLTUyYjkxNzBkLWI3N2EtNTg4Ny1iODc3LWNiZGJiOTliMDA5Zg"
+	fredCursor = "ogGAAngoYmFlLTliMmUxNDM0LTlkNjEtNWViMS1iM2I5LTgyZThlNDA3MjlhNw"
+
+	// The cursor of Jim when ordered by Age.
+	jimAgeCursor = "ogGBGBsCeChiYWUtMTM3OGFiNjItZTA2NC01YWY0LTllYTYtNDk5NDFjOGQ4Zjk0"
+)
+
+func TestQuerySimpleWithCursor(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with cursor",
+		Request: `query {
+					users {
+						Name
+						_cursor
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name":    "Jim",
+				"_cursor": jimCursor,
+			},
+			{
+				"Name":    "John",
+				"_cursor": johnCursor,
+			},
+			{
+				"Name":    "Fred",
+				"_cursor": fredCursor,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with first",
+		Request: `query {
+					users(first: 2) {
+						Name
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Jim",
+			},
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirstAndAfter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with first and after",
+		Request: `query {
+					users(first: 1, after: "` + jimCursor + `") {
+						Name
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithLast(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with last",
+		Request: `query {
+					users(last: 2) {
+						Name
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+			{
+				"Name": "Fred",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithLastAndBefore(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with last and before",
+		Request: `query {
+					users(last: 1, before: "` + fredCursor + `") {
+						Name
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithAfterAndBefore(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with after and before",
+		Request: `query {
+					users(after: "` + jimCursor + `", before: "` + fredCursor + `") {
+						Name
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirstAndPageInfo(t *testing.T) {
+	pageInfo := map[string]any{
+		"hasNextPage":     true,
+		"hasPreviousPage": false,
+		"startCursor":     jimCursor,
+		"endCursor":       johnCursor,
+	}
+
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with first and page info",
+		Request: `query {
+					users(first: 2) {
+						Name
+						_pageInfo {
+							hasNextPage
+							hasPreviousPage
+							startCursor
+							endCursor
+						}
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name":      "Jim",
+				"_pageInfo": pageInfo,
+			},
+			{
+				"Name":      "John",
+				"_pageInfo": pageInfo,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithLastAndPageInfo(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with last and page info",
+		Request: `query {
+					users(last: 1) {
+						Name
+						_pageInfo {
+							hasNextPage
+							hasPreviousPage
+						}
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Fred",
+				"_pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": true,
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithOrderAndFirstAndAfter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with order, first and after",
+		Request: `query {
+					users(order: {Age: DESC}, first: 2, after: "` + jimAgeCursor + `") {
+						Name
+						Age
+					}
+				}`,
+		Docs: cursorPaginationDocs,
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"Age":  uint64(21),
+			},
+			{
+				"Name": "Fred",
+				"Age":  uint64(21),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithOrderAndCursorOfOtherOrderReturnsError(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with order, and a cursor of an unordered query",
+		Request: `query {
+					users(order: {Age: DESC}, after: "` + jimCursor + `") {
+						Name
+					}
+				}`,
+		Docs:          cursorPaginationDocs,
+		ExpectedError: "invalid cursor",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithInvalidCursorReturnsError(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with invalid cursor",
+		Request: `query {
+					users(after: "not a cursor") {
+						Name
+					}
+				}`,
+		Docs:          cursorPaginationDocs,
+		ExpectedError: "invalid cursor",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirstAndLimitReturnsError(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with first and limit",
+		Request: `query {
+					users(first: 1, limit: 1) {
+						Name
+					}
+				}`,
+		Docs:          cursorPaginationDocs,
+		ExpectedError: "cursor pagination cannot be combined with limit or offset",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByAndFirstWithinGroupReturnsError(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by, and first within the group",
+		Request: `query {
+					users(groupBy: [Age]) {
+						Age
+						_group(first: 1) {
+							Name
+						}
+					}
+				}`,
+		Docs:          cursorPaginationDocs,
+		ExpectedError: "cursor pagination may not be used within _group",
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithRegexStringFilterBlock(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic regex-string filter",
+		Request: `query {
+					users(filter: {Name: {_regex: "^Viserys [IVX]+ "}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Daenerys Stormborn of House Targaryen, the First of Her Name",
+					"HeightM": 1.65
+				}`,
+				`{
+					"Name": "Viserys I Targaryen, King of the Andals",
+					"HeightM": 1.82
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Viserys I Targaryen, King of the Andals",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithInvalidRegexStringFilterBlock(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with invalid regex-string filter",
+		Request: `query {
+					users(filter: {Name: {_regex: "(Viserys"}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Viserys I Targaryen, King of the Andals",
+					"HeightM": 1.82
+				}`,
+			},
+		},
+		ExpectedError: "invalid regular expression",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithInvalidRegexStringFilterBlockOnEmptyCollection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with invalid regex-string filter, no documents",
+		Request: `query {
+					users(filter: {Name: {_regex: "(Viserys"}}) {
+						Name
+					}
+				}`,
+		ExpectedError: "invalid regular expression",
+	}
+
+	executeTestCase(t, test)
+}
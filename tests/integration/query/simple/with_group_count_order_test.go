@@ -0,0 +1,164 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithGroupByStringWithCountOrderDescending(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, ordered by count descending",
+		Request: `query {
+					users(groupBy: [Name], order: {_count: DESC}) {
+						Name
+						_count(_group: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+				`{
+					"Name": "John",
+					"Age": 25
+				}`,
+				`{
+					"Name": "John",
+					"Age": 32
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 55
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":   "John",
+				"_count": 2,
+			},
+			{
+				"Name":   "Alice",
+				"_count": 1,
+			},
+			{
+				"Name":   "Carlo",
+				"_count": 1,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithAliasedCountOrderAscending(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, ordered by aliased count ascending",
+		Request: `query {
+					users(groupBy: [Name], order: {_count: ASC}) {
+						Name
+						count: _count(_group: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 25
+				}`,
+				`{
+					"Name": "John",
+					"Age": 32
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 55
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":  "Carlo",
+				"count": 1,
+			},
+			{
+				"Name":  "John",
+				"count": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithUnselectedCountOrderDescending(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, ordered by count without selecting it",
+		Request: `query {
+					users(groupBy: [Name], order: {_count: DESC}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+				`{
+					"Name": "John",
+					"Age": 25
+				}`,
+				`{
+					"Name": "John",
+					"Age": 32
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+			{
+				"Name": "Alice",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithUnselectedCountOrderReturnsError(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query ordered by count without selecting it and without group by",
+		Request: `query {
+					users(order: {_count: DESC}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 25
+				}`,
+			},
+		},
+		ExpectedError: "aggregates must be selected in order to order by them",
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithMaxOnEmptyCollection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, max on empty",
+		Request: `query {
+					_max(users: {field: Age})
+				}`,
+		Results: []map[string]any{
+			{
+				"_max": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, max",
+		Request: `query {
+					_max(users: {field: HeightM})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"HeightM": 1.82
+				}`,
+				`{
+					"Name": "Bob",
+					"HeightM": 1.91
+				}`,
+				`{
+					"Name": "Alice"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_max": 1.91,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMaxOnDateTimeField(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, max of datetime field",
+		Request: `query {
+					_max(users: {field: CreatedAt})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"CreatedAt": "2019-07-23T03:46:56.647Z"
+				}`,
+				`{
+					"Name": "Bob",
+					"CreatedAt": "2019-07-23T04:46:56.647+02:00"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_max": "2019-07-23T03:46:56.647Z",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByWithMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, group by with max of child group",
+		Request: `query {
+					users(groupBy: [Name], order: {Name: ASC}) {
+						Name
+						_max(_group: {field: Age})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 32
+				}`,
+				`{
+					"Name": "John",
+					"Age": 25
+				}`,
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Alice",
+				"_max": int64(19),
+			},
+			{
+				"Name": "John",
+				"_max": int64(32),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMaxOnStringFieldHoldingDateTimes(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, max of string field holding date times compares the strings",
+		Request: `query {
+					_max(users: {field: Name})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "2019-07-23T05:46:56Z"
+				}`,
+				`{
+					"Name": "2019-07-23T06:46:56+02:00"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_max": "2019-07-23T06:46:56+02:00",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
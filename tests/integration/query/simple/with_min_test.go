@@ -0,0 +1,137 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithMinOnUndefinedField(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min on undefined field",
+		Request: `query {
+					_min(users: {})
+				}`,
+		ExpectedError: "Argument \"users\" has invalid value {}.\nIn field \"field\": Expected \"usersComparableFieldsArg!\", found null.",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinOnEmptyCollection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min on empty",
+		Request: `query {
+					_min(users: {field: Age})
+				}`,
+		Results: []map[string]any{
+			{
+				"_min": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMin(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min",
+		Request: `query {
+					_min(users: {field: Age})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 30
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Alice"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_min": int64(21),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinOnStringField(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min of string field",
+		Request: `query {
+					_min(users: {field: Name})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John"
+				}`,
+				`{
+					"Name": "Bob"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_min": "Bob",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinOnDateTimeFieldWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min of datetime field with filter",
+		Request: `query {
+					_min(users: {field: CreatedAt, filter: {Age: {_gt: 20}}})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21,
+					"CreatedAt": "2018-07-23T03:46:56.647Z"
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 32,
+					"CreatedAt": "2019-07-23T05:46:56.647+02:00"
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 19,
+					"CreatedAt": "2017-07-23T03:46:56.647Z"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_min": "2018-07-23T03:46:56.647Z",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithNumericOrderAscendingAndNullsLast(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic order ASC, nulls last",
+		Request: `query {
+					users(order: {Age: ASC, _nulls: LAST}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob"
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 55
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"Age":  uint64(21),
+			},
+			{
+				"Name": "Carlo",
+				"Age":  uint64(55),
+			},
+			{
+				"Name": "Bob",
+				"Age":  nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithNumericOrderDescendingAndNullsFirst(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic order DESC, nulls first",
+		Request: `query {
+					users(order: {Age: DESC, _nulls: FIRST}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob"
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 55
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+				"Age":  nil,
+			},
+			{
+				"Name": "Carlo",
+				"Age":  uint64(55),
+			},
+			{
+				"Name": "John",
+				"Age":  uint64(21),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithNumericOrderDescendingAndDefaultNulls(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic order DESC, nulls placed last by default",
+		Request: `query {
+					users(order: {Age: DESC}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob"
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 55
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Carlo",
+				"Age":  uint64(55),
+			},
+			{
+				"Name": "John",
+				"Age":  uint64(21),
+			},
+			{
+				"Name": "Bob",
+				"Age":  nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithCompoundOrderAndNullsLast(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with compound order, nulls last applying to all fields",
+		Request: `query {
+					users(order: {Age: ASC, Name: ASC, _nulls: LAST}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob"
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 21
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Carlo",
+				"Age":  uint64(21),
+			},
+			{
+				"Name": nil,
+				"Age":  uint64(21),
+			},
+			{
+				"Name": "Bob",
+				"Age":  nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
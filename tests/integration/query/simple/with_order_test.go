@@ -292,3 +292,50 @@ func TestQuerySimpleWithNumericOrderDescendingAndBooleanOrderAscending(t *testin
 
 	executeTestCase(t, test)
 }
+
+func TestQuerySimpleWithNumericOrderAscendingAndStringOrderAscending(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with compound order, ties broken by the second field",
+		Request: `query {
+					users(order: {Age: ASC, Name: ASC}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				// bae-52b9170d-b77a-5887-b877-cbdbb99b009f
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				// bae-1378ab62-e064-5af4-9ea6-49941c8d8f94
+				`{
+					"Name": "Jim",
+					"Age": 27
+				}`,
+				// bae-9b2e1434-9d61-5eb1-b3b9-82e8e40729a7
+				`{
+					"Name": "Fred",
+					"Age": 21
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Fred",
+				"Age":  uint64(21),
+			},
+			{
+				"Name": "John",
+				"Age":  uint64(21),
+			},
+			{
+				"Name": "Jim",
+				"Age":  uint64(27),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
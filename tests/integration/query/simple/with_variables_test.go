@@ -0,0 +1,311 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithVariableInFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with variable within filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+			},
+			testUtils.Request{
+				Request: `query($age: Int) {
+					users(filter: {Age: {_gt: $age}}) {
+						Name
+					}
+				}`,
+				Variables: map[string]any{
+					"age": 25,
+				},
+				Results: []map[string]any{
+					{
+						"Name": "Bob",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithFilterVariable(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with whole filter as a variable",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+			},
+			testUtils.Request{
+				Request: `query($filter: usersFilterArg) {
+					users(filter: $filter) {
+						Name
+					}
+				}`,
+				Variables: map[string]any{
+					"filter": map[string]any{
+						"Name": map[string]any{
+							"_eq": "John",
+						},
+					},
+				},
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithDockeyAndLimitVariables(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with dockey and limit variables",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query($dockey: String, $limit: Int) {
+					users(dockey: $dockey, limit: $limit) {
+						Name
+					}
+				}`,
+				Variables: map[string]any{
+					"dockey": "bae-52b9170d-b77a-5887-b877-cbdbb99b009f",
+					// JSON numbers are unmarshalled as floats, these must be accepted by Int variables.
+					"limit": float64(1),
+				},
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithVariableDefaultValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with variable default value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+			},
+			testUtils.Request{
+				Request: `query($name: String = "Bob") {
+					users(filter: {Name: {_eq: $name}}) {
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Age": uint64(32),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithUnsetVariable(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with unset nullable variable, argument ignored",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query($limit: Int) {
+					users(limit: $limit) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithMissingNonNullVariable(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with missing non-null variable",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.Request{
+				Request: `query($name: String!) {
+					users(filter: {Name: {_eq: $name}}) {
+						Name
+					}
+				}`,
+				ExpectedError: "missing value for non-null variable",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithInvalidVariableValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with variable value of the wrong type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.Request{
+				Request: `query($age: Int) {
+					users(filter: {Age: {_eq: $age}}) {
+						Name
+					}
+				}`,
+				Variables: map[string]any{
+					"age": "twenty one",
+				},
+				ExpectedError: "invalid variable value",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithOperationName(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with operation name selecting one of many operations",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `
+					query UserNames {
+						users {
+							Name
+						}
+					}
+					query UserAges {
+						users {
+							Age
+						}
+					}
+				`,
+				OperationName: "UserAges",
+				Results: []map[string]any{
+					{
+						"Age": uint64(21),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
+
+func TestQuerySimpleWithUnknownOperationName(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with unknown operation name",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.Request{
+				Request: `query UserNames {
+					users {
+						Name
+					}
+				}`,
+				OperationName: "UserAges",
+				ExpectedError: "no operation with the given name exists",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
@@ -384,59 +384,69 @@ func TestSchemaAggregateInlineArrayCreatesUsersAverage(t *testing.T) {
 	testUtils.ExecuteTestCase(t, []string{"users"}, test)
 }
 
-var aggregateGroupArg = map[string]any{
-	"name": "_group",
-	"type": map[string]any{
-		"name": "users__CountSelector",
-		"inputFields": []any{
-			map[string]any{
-				"name": "filter",
-				"type": map[string]any{
-					"name": "usersFilterArg",
-					"inputFields": []any{
-						map[string]any{
-							"name": "_and",
-							"type": map[string]any{
-								"name": nil,
+// aggregateGroupArg returns the expected `_group` aggregate argument of a `users` type
+// hosting a `Favourites` inline array filtered using the given operator block.
+func aggregateGroupArg(favouritesOperatorBlock string) map[string]any {
+	return map[string]any{
+		"name": "_group",
+		"type": map[string]any{
+			"name": "users__CountSelector",
+			"inputFields": []any{
+				map[string]any{
+					"name": "filter",
+					"type": map[string]any{
+						"name": "usersFilterArg",
+						"inputFields": []any{
+							map[string]any{
+								"name": "Favourites",
+								"type": map[string]any{
+									"name": favouritesOperatorBlock,
+								},
 							},
-						},
-						map[string]any{
-							"name": "_key",
-							"type": map[string]any{
-								"name": "IDOperatorBlock",
+							map[string]any{
+								"name": "_and",
+								"type": map[string]any{
+									"name": nil,
+								},
 							},
-						},
-						map[string]any{
-							"name": "_not",
-							"type": map[string]any{
-								"name": "usersFilterArg",
+							map[string]any{
+								"name": "_key",
+								"type": map[string]any{
+									"name": "IDOperatorBlock",
+								},
 							},
-						},
-						map[string]any{
-							"name": "_or",
-							"type": map[string]any{
-								"name": nil,
+							map[string]any{
+								"name": "_not",
+								"type": map[string]any{
+									"name": "usersFilterArg",
+								},
+							},
+							map[string]any{
+								"name": "_or",
+								"type": map[string]any{
+									"name": nil,
+								},
 							},
 						},
 					},
 				},
-			},
-			map[string]any{
-				"name": "limit",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "limit",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
 				},
-			},
-			map[string]any{
-				"name": "offset",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "offset",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
 				},
 			},
 		},
-	},
+	}
 }
 
 var aggregateVersionArg = map[string]any{
@@ -548,6 +558,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *tes
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "BooleanFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -574,7 +590,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("BooleanListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -674,6 +690,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersBooleanCountFilter(t *testing.T)
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullBooleanFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -700,7 +722,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersBooleanCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullBooleanListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -824,6 +846,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableIntegerCountFilter(t *tes
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "IntFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -850,7 +878,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableIntegerCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("IntListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -974,6 +1002,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersIntegerCountFilter(t *testing.T)
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullIntFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -1000,7 +1034,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersIntegerCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullIntListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1124,6 +1158,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableFloatCountFilter(t *testi
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "FloatFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -1150,7 +1190,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableFloatCountFilter(t *testi
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("FloatListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1274,6 +1314,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersFloatCountFilter(t *testing.T) {
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullFloatFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -1300,7 +1346,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersFloatCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullFloatListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1382,6 +1428,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1400,6 +1452,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1412,12 +1470,24 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "StringFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_regex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -1438,7 +1508,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("StringListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1520,6 +1590,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1538,6 +1614,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1550,12 +1632,24 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullStringFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_regex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -1576,7 +1670,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullStringListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -305,3 +305,59 @@ func TestSchemaAggregateTopLevelCreatesAverageGivenSchema(t *testing.T) {
 
 	testUtils.ExecuteTestCase(t, []string{"users"}, test)
 }
+
+func TestSchemaAggregateTopLevelCreatesMinAndMaxOfComparableType(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type users {
+						name: String
+						born: DateTime
+					}
+				`,
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__schema {
+							queryType {
+								fields {
+									name
+									type {
+										name
+										kind
+									}
+								}
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__schema": map[string]any{
+						"queryType": map[string]any{
+							"fields": []any{
+								map[string]any{
+									"name": "_max",
+									"type": map[string]any{
+										"name": "Comparable",
+										"kind": "SCALAR",
+									},
+								},
+								map[string]any{
+									"name": "_min",
+									"type": map[string]any{
+										"name": "Comparable",
+										"kind": "SCALAR",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"users"}, test)
+}
@@ -62,6 +62,8 @@ var DefaultFields = concat(
 		versionField,
 		groupField,
 		deletedField,
+		cursorField,
+		pageInfoField,
 	},
 	aggregateFields,
 )
@@ -82,6 +84,22 @@ var deletedField = Field{
 	},
 }
 
+var cursorField = Field{
+	"name": "_cursor",
+	"type": map[string]any{
+		"kind": "SCALAR",
+		"name": "String",
+	},
+}
+
+var pageInfoField = Field{
+	"name": "_pageInfo",
+	"type": map[string]any{
+		"kind": "OBJECT",
+		"name": "PageInfo",
+	},
+}
+
 var versionField = Field{
 	"name": "_version",
 	"type": map[string]any{
@@ -113,6 +131,20 @@ var aggregateFields = fields{
 			"name": "Int",
 		},
 	},
+	map[string]any{
+		"name": "_max",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Comparable",
+		},
+	},
+	map[string]any{
+		"name": "_min",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Comparable",
+		},
+	},
 	map[string]any{
 		"name": "_sum",
 		"type": map[string]any{
@@ -129,6 +161,20 @@ var cidArg = Field{
 		"inputFields": nil,
 	},
 }
+var asOfArg = Field{
+	"name": "asOf",
+	"type": map[string]any{
+		"name":        "DateTime",
+		"inputFields": nil,
+	},
+}
+var asOfHeightArg = Field{
+	"name": "asOfHeight",
+	"type": map[string]any{
+		"name":        "Int",
+		"inputFields": nil,
+	},
+}
 var dockeyArg = Field{
 	"name": "dockey",
 	"type": map[string]any{
@@ -185,6 +231,42 @@ var offsetArg = Field{
 	},
 }
 
+var firstArg = Field{
+	"name": "first",
+	"type": map[string]any{
+		"name":        "Int",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
+var lastArg = Field{
+	"name": "last",
+	"type": map[string]any{
+		"name":        "Int",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
+var afterArg = Field{
+	"name": "after",
+	"type": map[string]any{
+		"name":        "String",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
+var beforeArg = Field{
+	"name": "before",
+	"type": map[string]any{
+		"name":        "String",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
 type argDef struct {
 	fieldName string
 	typeName  string
@@ -192,7 +274,13 @@ type argDef struct {
 
 func buildOrderArg(objectName string, fields []argDef) Field {
 	inputFields := []any{
+		makeInputObject("_avg", "Ordering", nil),
+		makeInputObject("_count", "Ordering", nil),
 		makeInputObject("_key", "Ordering", nil),
+		makeInputObject("_max", "Ordering", nil),
+		makeInputObject("_min", "Ordering", nil),
+		makeInputObject("_nulls", "NullsOrdering", nil),
+		makeInputObject("_sum", "Ordering", nil),
 	}
 
 	for _, field := range fields {
@@ -132,12 +132,18 @@ var testFilterForSimpleSchemaArgProps = map[string]any{
 var defaultUserArgsWithoutFilter = trimFields(
 	fields{
 		cidArg,
+		asOfArg,
+		asOfHeightArg,
 		dockeyArg,
 		dockeysArg,
 		showDeletedArg,
 		groupByArg,
 		limitArg,
 		offsetArg,
+		firstArg,
+		lastArg,
+		afterArg,
+		beforeArg,
 		buildOrderArg("users", []argDef{
 			{
 				fieldName: "name",
@@ -284,12 +290,18 @@ var testFilterForOneToOneSchemaArgProps = map[string]any{
 var defaultBookArgsWithoutFilter = trimFields(
 	fields{
 		cidArg,
+		asOfArg,
+		asOfHeightArg,
 		dockeyArg,
 		dockeysArg,
 		showDeletedArg,
 		groupByArg,
 		limitArg,
 		offsetArg,
+		firstArg,
+		lastArg,
+		afterArg,
+		beforeArg,
 		buildOrderArg("book", []argDef{
 			{
 				fieldName: "author",
@@ -83,6 +83,20 @@ func TestInputTypeOfOrderFieldWhereSchemaHasRelationType(t *testing.T) {
 											"name":   "authorOrderArg",
 											"ofType": nil,
 											"inputFields": []any{
+												map[string]any{
+													"name": "_avg",
+													"type": map[string]any{
+														"name":   "Ordering",
+														"ofType": nil,
+													},
+												},
+												map[string]any{
+													"name": "_count",
+													"type": map[string]any{
+														"name":   "Ordering",
+														"ofType": nil,
+													},
+												},
 												map[string]any{
 													"name": "_key",
 													"type": map[string]any{
@@ -90,6 +104,34 @@ func TestInputTypeOfOrderFieldWhereSchemaHasRelationType(t *testing.T) {
 														"ofType": nil,
 													},
 												},
+												map[string]any{
+													"name": "_max",
+													"type": map[string]any{
+														"name":   "Ordering",
+														"ofType": nil,
+													},
+												},
+												map[string]any{
+													"name": "_min",
+													"type": map[string]any{
+														"name":   "Ordering",
+														"ofType": nil,
+													},
+												},
+												map[string]any{
+													"name": "_nulls",
+													"type": map[string]any{
+														"name":   "NullsOrdering",
+														"ofType": nil,
+													},
+												},
+												map[string]any{
+													"name": "_sum",
+													"type": map[string]any{
+														"name":   "Ordering",
+														"ofType": nil,
+													},
+												},
 												map[string]any{
 													"name": "age",
 													"type": map[string]any{
@@ -182,6 +224,10 @@ var defaultGroupArgsWithoutOrder = trimFields(
 		groupByArg,
 		limitArg,
 		offsetArg,
+		firstArg,
+		lastArg,
+		afterArg,
+		beforeArg,
 	},
 	testInputTypeOfOrderFieldWhereSchemaHasRelationTypeArgProps,
 )
@@ -0,0 +1,270 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+const (
+	initialSchemaVersionId = "bafkreicg3xcpjlt3ecguykpcjrdx5ogi4n7cq2fultyr6vippqdxnrny3u"
+	updatedSchemaVersionId = "bafkreicquhkxvwfzmjnoptu4cf5ib4tameu6wmq5wzwg3ooc32zqbvtif4"
+)
+
+func TestSchemaMigrationQueryWithDefaultValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, default value for new field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Email", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      initialSchemaVersionId,
+					DestinationSchemaVersionID: updatedSchemaVersionId,
+					Lens: []client.LensOperation{
+						{
+							Op:    client.LensOpDefault,
+							Field: "Email",
+							Value: "unknown",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+						Email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name":  "John",
+						"Email": "unknown",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaMigrationQueryWithCopiedValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, copy value to new field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Email", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      initialSchemaVersionId,
+					DestinationSchemaVersionID: updatedSchemaVersionId,
+					Lens: []client.LensOperation{
+						{
+							Op:    client.LensOpCopy,
+							Field: "Email",
+							From:  "Name",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+						Email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name":  "John",
+						"Email": "John",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaMigrationQueryDoesNotMigrateDocsAtCurrentVersion(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, docs created after schema update are not migrated",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Email", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      initialSchemaVersionId,
+					DestinationSchemaVersionID: updatedSchemaVersionId,
+					Lens: []client.LensOperation{
+						{
+							Op:    client.LensOpSet,
+							Field: "Email",
+							Value: "unknown",
+						},
+					},
+				},
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+						Email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name":  "John",
+						"Email": nil,
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaMigrationQueryAfterUpdateKeepsMigratedValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, migrated values are kept when the doc is updated",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Email", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      initialSchemaVersionId,
+					DestinationSchemaVersionID: updatedSchemaVersionId,
+					Lens: []client.LensOperation{
+						{
+							Op:    client.LensOpDefault,
+							Field: "Email",
+							Value: "unknown",
+						},
+					},
+				},
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"Name": "Fred"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+						Email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name":  "Fred",
+						"Email": "unknown",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaMigrationWithSameSourceAndDestinationErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, same source and destination version",
+		Actions: []any{
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      initialSchemaVersionId,
+					DestinationSchemaVersionID: initialSchemaVersionId,
+				},
+				ExpectedError: "a migration must have different source and destination versions",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{}, test)
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package migrations
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/logging"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaMigrationP2PWithDocFromOlderVersion_MigratesDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, doc received from a peer at an older schema version",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				// Only the second node is patched to the new schema version.
+				NodeID: immutable.Some(1),
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Email", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				NodeID: immutable.Some(1),
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      initialSchemaVersionId,
+					DestinationSchemaVersionID: updatedSchemaVersionId,
+					Lens: []client.LensOperation{
+						{
+							Op:    client.LensOpDefault,
+							Field: "Email",
+							Value: "unknown",
+						},
+					},
+				},
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users {
+						Name
+						Email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name":  "John",
+						"Email": "unknown",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaMigrationP2PWithDocFromNewerVersion_RefusesDoc(t *testing.T) {
+	// Send the network logs to a temp file so that the refusal can be inspected.
+	logFile := path.Join(t.TempDir(), "schema_migration_p2p_test.log")
+	logging.SetConfig(logging.Config{
+		OverridesByLoggerName: map[string]logging.Config{
+			"defra.net": {OutputPaths: []string{logFile}},
+		},
+	})
+	defer logging.SetConfig(logging.Config{
+		OverridesByLoggerName: map[string]logging.Config{
+			"defra.net": {},
+		},
+	})
+
+	test := testUtils.TestCase{
+		Description: "Test schema migration, doc received from a peer at a newer schema version",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				// Only the first node is patched to the new schema version.
+				NodeID: immutable.Some(0),
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Email", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.Request{
+				// The second node does not know the schema version of the document, and
+				// refuses it until its schema has been patched.
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+
+	logs, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	require.Contains(t, string(logs), "block was written at a schema version unknown to this node")
+}
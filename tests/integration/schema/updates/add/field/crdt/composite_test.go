@@ -33,7 +33,7 @@ func TestSchemaUpdatesAddFieldCRDTCompositeErrors(t *testing.T) {
 						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Foo", "Kind": 2, "Typ":3} }
 					]
 				`,
-				ExpectedError: "only default or LWW (last writer wins) CRDT types are supported. Name: Foo, CRDTType: 3",
+				ExpectedError: "only default, LWW or PN counter CRDT types are supported. Name: Foo, CRDTType: 3",
 			},
 		},
 	}
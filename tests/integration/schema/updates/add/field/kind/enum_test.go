@@ -0,0 +1,196 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kind
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaUpdatesAddFieldKindEnumWithCreate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, add field with kind enum (22) with create",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Enums", "value": [{"Name": "Status", "Values": ["ACTIVE"]}] },
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Foo", "Kind": 22, "Schema": "Status"} }
+					]
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Foo": "ACTIVE"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Foo: {_eq: ACTIVE}}) {
+						Name
+						Foo
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Foo":  "ACTIVE",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaUpdatesAddFieldKindEnumWithUnknownEnum(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, add field with kind enum (22) of unknown enum",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Fields/-", "value": {"Name": "Foo", "Kind": 22, "Schema": "Status"} }
+					]
+				`,
+				ExpectedError: "no enum type with the given name exists. Name: Status",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaUpdatesAddEnumValueWithCreate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, add enum value with create",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Status: Status
+					}
+
+					enum Status {
+						ACTIVE
+						ARCHIVED
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Enums/0/Values/-", "value": "DELETED" }
+					]
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Status": "DELETED"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Status: {_eq: DELETED}}) {
+						Name
+						Status
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name":   "John",
+						"Status": "DELETED",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaUpdatesRemoveEnumValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, remove enum value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Status: Status
+					}
+
+					enum Status {
+						ACTIVE
+						ARCHIVED
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "remove", "path": "/Users/Schema/Enums/0/Values/1" }
+					]
+				`,
+				ExpectedError: "enum values may not be removed or reordered. Enum: Status, Value: ARCHIVED",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaUpdatesAddDuplicateEnumValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, add duplicate enum value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Status: Status
+					}
+
+					enum Status {
+						ACTIVE
+						ARCHIVED
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/Enums/0/Values/-", "value": "ACTIVE" }
+					]
+				`,
+				ExpectedError: "duplicate enum value. Enum: Status, Value: ACTIVE",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -13,6 +13,7 @@ package tests
 import (
 	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/config"
 )
 
@@ -73,6 +74,41 @@ type SchemaPatch struct {
 	ExpectedError string
 }
 
+// ConfigureMigration will attempt to register the given schema migration.
+type ConfigureMigration struct {
+	// NodeID may hold the ID (index) of a node to apply this migration to.
+	//
+	// If a value is not provided the migration will be applied to all nodes.
+	NodeID immutable.Option[int]
+
+	client.LensConfig
+
+	ExpectedError string
+}
+
+// SetHistoryPolicy will attempt to set the given history policy.
+type SetHistoryPolicy struct {
+	// NodeID may hold the ID (index) of a node to set this policy on.
+	//
+	// If a value is not provided the policy will be set on all nodes.
+	NodeID immutable.Option[int]
+
+	client.HistoryPolicy
+
+	ExpectedError string
+}
+
+// PruneHistory will attempt to prune the history of the collections that have a
+// history policy.
+type PruneHistory struct {
+	// NodeID may hold the ID (index) of a node to prune the history of.
+	//
+	// If a value is not provided the history of all nodes will be pruned.
+	NodeID immutable.Option[int]
+
+	ExpectedError string
+}
+
 // CreateDoc will attempt to create the given document in the given collection
 // using the collection api.
 type CreateDoc struct {
@@ -140,6 +176,13 @@ type UpdateDoc struct {
 	// provided.
 	Doc string
 
+	// If true, Doc will be applied as an updater via `UpdateWithKey` instead of being
+	// set on the document and saved.  This allows the use of updater operations such
+	// as `{"Likes": {"_inc": 1}}`.
+	//
+	// The locally held document will not reflect the changes made.
+	AsUpdater bool
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
@@ -161,6 +204,12 @@ type Request struct {
 	// The request to execute.
 	Request string
 
+	// The values of any variables referenced by the request. Optional.
+	Variables map[string]any
+
+	// The name of the operation within the request to execute. Optional.
+	OperationName string
+
 	// The expected (data) results of the issued request.
 	Results []map[string]any
 
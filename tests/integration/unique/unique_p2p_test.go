@@ -0,0 +1,81 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package unique
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestUniqueField_WithDuplicateCreateFromPeer_PublishesIndexConflict(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test receiving a document with a duplicate unique field value from a peer",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Email: String @unique
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.IndexConflictSubscription{
+				NodeID:             1,
+				ExpectedIndexNames: []string{"Users_Email_UNIQUE"},
+			},
+			testUtils.CreateDoc{
+				// John is created on the target node only.
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Name": "John",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "Johnny",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				// Changes received from peers cannot be rejected, both documents must be
+				// found through the index on the target node.
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users(filter: {Email: {_eq: "john@example.com"}}, order: {Name: ASC}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+					{
+						"Name": "Johnny",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
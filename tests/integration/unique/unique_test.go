@@ -0,0 +1,250 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package unique
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestUniqueField_WithDuplicateCreate_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating a document with a duplicate unique field value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Email: String @unique
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Johnny",
+					"Email": "john@example.com"
+				}`,
+				ExpectedError: "a document with the given unique field values already exists",
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestUniqueField_WithDuplicateCreateMutation_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating a document with a duplicate unique field value via a mutation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Email: String @unique
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_Users(data: "{\"Name\": \"Johnny\", \"Email\": \"john@example.com\"}") {
+						Name
+					}
+				}`,
+				ExpectedError: "a document with the given unique field values already exists",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestUniqueField_WithDuplicateUpdate_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test updating a document to a duplicate unique field value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Email: String @unique
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Fred",
+					"Email": "fred@example.com"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        1,
+				Doc: `{
+					"Email": "john@example.com"
+				}`,
+				ExpectedError: "a document with the given unique field values already exists",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestUniqueField_WithValueOfDeletedDoc_Succeeds(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating a document with the unique field value of a deleted document",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Email: String @unique
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "John",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				CollectionID: 0,
+				DocID:        0,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"Name": "Johnny",
+					"Email": "john@example.com"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Johnny",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestUniqueComposite_WithPartiallyDuplicateValues_Succeeds(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test composite unique constraint allows documents sharing some of the values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users @unique(fields: ["FirstName", "LastName"]) {
+						FirstName: String
+						LastName: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"FirstName": "John",
+					"LastName": "Smith"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"FirstName": "John",
+					"LastName": "Doe"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"FirstName": "John",
+					"LastName": "Doe",
+					"Age": 30
+				}`,
+				ExpectedError: "a document with the given unique field values already exists",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestUniqueConstraint_WithSchemaPatch_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test adding a unique constraint via a schema patch",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Schema/UniqueConstraints", "value": [{"Fields": ["Name"]}] }
+					]
+				`,
+				ExpectedError: "modifying unique constraints is not supported",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -342,7 +342,15 @@ func executeTestCase(
 			syncChans = append(syncChans, connectPeers(ctx, t, testCase, action, nodes, nodeAddresses))
 
 		case ConfigureReplicator:
-			syncChans = append(syncChans, configureReplicator(ctx, t, testCase, action, nodes, nodeAddresses))
+			syncChans = append(syncChans, configureReplicator(
+				ctx,
+				t,
+				testCase,
+				action,
+				nodes,
+				nodeAddresses,
+				collectionNames,
+			))
 
 		case SubscribeToCollection:
 			subscribeToCollection(ctx, t, testCase, action, nodes, collections)
@@ -363,6 +371,15 @@ func executeTestCase(
 			// If the schema was updated we need to refresh the collection definitions.
 			collections = getCollections(ctx, t, nodes, collectionNames)
 
+		case ConfigureMigration:
+			configureMigration(ctx, t, nodes, testCase, action)
+
+		case SetHistoryPolicy:
+			setHistoryPolicy(ctx, t, nodes, testCase, action)
+
+		case PruneHistory:
+			pruneHistory(ctx, t, nodes, testCase, action)
+
 		case CreateDoc:
 			documents = createDoc(ctx, t, testCase, nodes, collections, documents, action)
 
@@ -395,6 +412,9 @@ func executeTestCase(
 		case WaitForSync:
 			waitForSync(t, testCase, action, syncChans)
 
+		case IndexConflictSubscription:
+			resultsChans = append(resultsChans, subscribeToIndexConflicts(t, allActionsDone, action, nodes))
+
 		case SetupComplete:
 			// no-op, just continue.
 
@@ -746,6 +766,51 @@ func patchSchema(
 	}
 }
 
+func configureMigration(
+	ctx context.Context,
+	t *testing.T,
+	nodes []*node.Node,
+	testCase TestCase,
+	action ConfigureMigration,
+) {
+	for _, node := range getNodes(action.NodeID, nodes) {
+		err := node.DB.SetMigration(ctx, action.LensConfig)
+		expectedErrorRaised := AssertError(t, testCase.Description, err, action.ExpectedError)
+
+		assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
+	}
+}
+
+func setHistoryPolicy(
+	ctx context.Context,
+	t *testing.T,
+	nodes []*node.Node,
+	testCase TestCase,
+	action SetHistoryPolicy,
+) {
+	for _, node := range getNodes(action.NodeID, nodes) {
+		err := node.DB.SetHistoryPolicy(ctx, action.HistoryPolicy)
+		expectedErrorRaised := AssertError(t, testCase.Description, err, action.ExpectedError)
+
+		assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
+	}
+}
+
+func pruneHistory(
+	ctx context.Context,
+	t *testing.T,
+	nodes []*node.Node,
+	testCase TestCase,
+	action PruneHistory,
+) {
+	for _, node := range getNodes(action.NodeID, nodes) {
+		err := node.DB.PruneHistory(ctx)
+		expectedErrorRaised := AssertError(t, testCase.Description, err, action.ExpectedError)
+
+		assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
+	}
+}
+
 // createDoc creates a document using the collection api and caches it in the
 // given documents slice.
 func createDoc(
@@ -831,9 +896,11 @@ func updateDoc(
 ) {
 	doc := documents[action.CollectionID][action.DocID]
 
-	err := doc.SetWithJSON([]byte(action.Doc))
-	if AssertError(t, testCase.Description, err, action.ExpectedError) {
-		return
+	if !action.AsUpdater {
+		err := doc.SetWithJSON([]byte(action.Doc))
+		if AssertError(t, testCase.Description, err, action.ExpectedError) {
+			return
+		}
 	}
 
 	var expectedErrorRaised bool
@@ -842,7 +909,13 @@ func updateDoc(
 		err := withRetry(
 			actionNodes,
 			nodeID,
-			func() error { return collections[action.CollectionID].Save(ctx, doc) },
+			func() error {
+				if action.AsUpdater {
+					_, err := collections[action.CollectionID].UpdateWithKey(ctx, doc.Key(), action.Doc)
+					return err
+				}
+				return collections[action.CollectionID].Save(ctx, doc)
+			},
 		)
 		expectedErrorRaised = AssertError(t, testCase.Description, err, action.ExpectedError)
 	}
@@ -958,7 +1031,12 @@ func executeRequest(
 ) {
 	var expectedErrorRaised bool
 	for nodeID, node := range getNodes(action.NodeID, nodes) {
-		result := node.DB.ExecRequest(ctx, action.Request)
+		result := node.DB.ExecRequest(
+			ctx,
+			action.Request,
+			client.WithVariables(action.Variables),
+			client.WithOperationName(action.OperationName),
+		)
 
 		anyOfByFieldKey := map[docFieldKey][]any{}
 		expectedErrorRaised = assertRequestResults(